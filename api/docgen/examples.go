@@ -21,6 +21,9 @@ var ExampleValues = map[reflect.Type]interface{}{
 	reflect.TypeOf(true):              true,
 	reflect.TypeOf([]byte{}):          []byte("byte array"),
 	reflect.TypeOf(fraud.BadEncoding): fraud.BadEncoding,
+	reflect.TypeOf(map[string]string{}): map[string]string{
+		"string value": "string value",
+	},
 }
 
 func ExampleValue(t, parent reflect.Type) (interface{}, error) {
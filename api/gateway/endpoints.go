@@ -3,51 +3,238 @@ package gateway
 import (
 	"fmt"
 	"net/http"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/state"
 )
 
-func (h *Handler) RegisterEndpoints(rpc *Server) {
+// endpoint defines an endpoint of the gateway, both to serve it and to document it in the OpenAPI
+// spec.
+type endpoint struct {
+	method  string
+	path    string
+	handler func(*Handler, http.ResponseWriter, *http.Request)
+
+	operation string
+	tag       string
+	summary   string
+	query     []param
+	// request and response are the values of the types of the JSON bodies, if any
+	request  interface{}
+	response interface{}
+	// stream marks the endpoints streaming the responses as Server-Sent Events
+	stream bool
+	// das marks the endpoints served only by the nodes running the DASer
+	das bool
+}
+
+// param is a path or query parameter of an endpoint.
+type param struct {
+	name        string
+	schema      string
+	description string
+}
+
+// pathParams are the parameters of the paths of the endpoints, by their keys.
+var pathParams = map[string]param{
+	heightKey: {name: heightKey, schema: "integer", description: "Height of the block."},
+	addrKey:   {name: addrKey, schema: "string", description: "Bech32 address of an account or a validator."},
+	nIDKey:    {name: nIDKey, schema: "string", description: "Hex-encoded namespace ID."},
+	txHashKey: {name: txHashKey, schema: "string", description: "Hex-encoded hash of the transaction."},
+}
+
+var endpoints = []endpoint{
 	// state endpoints
-	rpc.RegisterHandlerFunc(balanceEndpoint, h.handleBalanceRequest, http.MethodGet)
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey), h.handleBalanceRequest,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(submitTxEndpoint, h.handleSubmitTx, http.MethodPost)
-	rpc.RegisterHandlerFunc(submitPFDEndpoint, h.handleSubmitPFD, http.MethodPost)
-	rpc.RegisterHandlerFunc(transferEndpoint, h.handleTransfer, http.MethodPost)
-	rpc.RegisterHandlerFunc(delegationEndpoint, h.handleDelegation, http.MethodPost)
-	rpc.RegisterHandlerFunc(undelegationEndpoint, h.handleUndelegation, http.MethodPost)
-	rpc.RegisterHandlerFunc(cancelUnbondingEndpoint, h.handleCancelUnbonding, http.MethodPost)
-	rpc.RegisterHandlerFunc(beginRedelegationEndpoint, h.handleRedelegation, http.MethodPost)
+	{
+		method: http.MethodGet, path: balanceEndpoint, handler: (*Handler).handleBalanceRequest,
+		operation: "getBalance", tag: "state",
+		summary:  "Returns the balance of the node's account.",
+		response: state.Balance{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", balanceEndpoint, addrKey),
+		handler:   (*Handler).handleBalanceRequest,
+		operation: "getBalanceForAddress", tag: "state",
+		summary:  "Returns the balance of the given account, verified against the state of the chain.",
+		response: state.Balance{},
+	},
+	{
+		method: http.MethodPost, path: submitTxEndpoint, handler: (*Handler).handleSubmitTx,
+		operation: "submitTx", tag: "state",
+		summary: "Submits the given hex-encoded raw transaction and waits for its inclusion.",
+		request: submitTxRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: submitPFDEndpoint, handler: (*Handler).handleSubmitPFD,
+		operation: "submitPayForData", tag: "state",
+		summary: "Submits a PayForData transaction with the given hex-encoded data.",
+		request: submitPFDRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: submitPFBEndpoint, handler: (*Handler).handleSubmitPFB,
+		operation: "submitPayForBlob", tag: "state",
+		summary: "Submits a PayForData transaction carrying the given hex-encoded blob.",
+		request: submitPFBRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: transferEndpoint, handler: (*Handler).handleTransfer,
+		operation: "transfer", tag: "state",
+		summary: "Sends the given amount of coins from the node's account to the given one.",
+		request: transferRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: delegationEndpoint, handler: (*Handler).handleDelegation,
+		operation: "delegate", tag: "state",
+		summary: "Delegates the given amount of coins of the node's account to the given validator.",
+		request: delegationRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: undelegationEndpoint, handler: (*Handler).handleUndelegation,
+		operation: "undelegate", tag: "state",
+		summary: "Undelegates the given amount of coins of the node's account from the given validator.",
+		request: unbondRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: cancelUnbondingEndpoint, handler: (*Handler).handleCancelUnbonding,
+		operation: "cancelUnbondingDelegation", tag: "state",
+		summary: "Cancels the pending undelegation of the node's account from the given validator.",
+		request: cancelUnbondRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodPost, path: beginRedelegationEndpoint, handler: (*Handler).handleRedelegation,
+		operation: "beginRedelegate", tag: "state",
+		summary: "Redelegates the given amount of coins of the node's account between the given validators.",
+		request: redelegationRequest{}, response: state.TxResponse{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", txStatusEndpoint, txHashKey),
+		handler:   (*Handler).handleTxStatus,
+		operation: "getTxStatus", tag: "state",
+		summary:  "Returns the latest known stage of the transaction submitted through the node.",
+		response: state.TxStatus{},
+	},
 
 	// staking queries
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey), h.handleQueryDelegation,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey), h.handleQueryUnbonding,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(queryRedelegationsEndpoint, h.handleQueryRedelegations,
-		http.MethodPost)
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", queryDelegationEndpoint, addrKey),
+		handler:   (*Handler).handleQueryDelegation,
+		operation: "queryDelegation", tag: "state",
+		summary:  "Returns the delegation of the node's account to the given validator.",
+		response: stakingtypes.QueryDelegationResponse{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", queryUnbondingEndpoint, addrKey),
+		handler:   (*Handler).handleQueryUnbonding,
+		operation: "queryUnbonding", tag: "state",
+		summary:  "Returns the undelegation of the node's account from the given validator.",
+		response: stakingtypes.QueryUnbondingDelegationResponse{},
+	},
+	{
+		method: http.MethodPost, path: queryRedelegationsEndpoint, handler: (*Handler).handleQueryRedelegations,
+		operation: "queryRedelegations", tag: "state",
+		summary: "Returns the redelegations of the node's account between the given validators.",
+		request: queryRedelegationsRequest{}, response: stakingtypes.QueryRedelegationsResponse{},
+	},
 
 	// share endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, nIDKey, heightKey),
-		h.handleSharesByNamespaceRequest, http.MethodGet)
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", namespacedSharesEndpoint, nIDKey),
-		h.handleSharesByNamespaceRequest, http.MethodGet)
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}/height/{%s}", namespacedDataEndpoint, nIDKey, heightKey),
-		h.handleDataByNamespaceRequest, http.MethodGet)
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", namespacedDataEndpoint, nIDKey),
-		h.handleDataByNamespaceRequest, http.MethodGet)
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}/height/{%s}", namespacedSharesEndpoint, nIDKey, heightKey),
+		handler:   (*Handler).handleSharesByNamespaceRequest,
+		operation: "getSharesByNamespaceAtHeight", tag: "share",
+		summary:  "Returns the shares of the given namespace of the block at the given height.",
+		response: NamespacedSharesResponse{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", namespacedSharesEndpoint, nIDKey),
+		handler:   (*Handler).handleSharesByNamespaceRequest,
+		operation: "getSharesByNamespace", tag: "share",
+		summary:  "Returns the shares of the given namespace of the block at the local chain head.",
+		response: NamespacedSharesResponse{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}/height/{%s}", namespacedDataEndpoint, nIDKey, heightKey),
+		handler:   (*Handler).handleDataByNamespaceRequest,
+		operation: "getDataByNamespaceAtHeight", tag: "share",
+		summary:  "Returns the data of the given namespace of the block at the given height.",
+		response: NamespacedDataResponse{},
+	},
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", namespacedDataEndpoint, nIDKey),
+		handler:   (*Handler).handleDataByNamespaceRequest,
+		operation: "getDataByNamespace", tag: "share",
+		summary:  "Returns the data of the given namespace of the block at the local chain head.",
+		response: NamespacedDataResponse{},
+	},
 
 	// DAS endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", heightAvailabilityEndpoint, heightKey),
-		h.handleHeightAvailabilityRequest, http.MethodGet)
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", heightAvailabilityEndpoint, heightKey),
+		handler:   (*Handler).handleHeightAvailabilityRequest,
+		operation: "getDataAvailable", tag: "share",
+		summary:  "Reports whether the data of the block at the given height is available.",
+		response: AvailabilityResponse{},
+	},
 
 	// header endpoints
-	rpc.RegisterHandlerFunc(fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey), h.handleHeaderRequest,
-		http.MethodGet)
-	rpc.RegisterHandlerFunc(headEndpoint, h.handleHeadRequest, http.MethodGet)
+	{
+		method: http.MethodGet, path: fmt.Sprintf("%s/{%s}", headerByHeightEndpoint, heightKey),
+		handler:   (*Handler).handleHeaderRequest,
+		operation: "getHeader", tag: "header",
+		summary:  "Returns the header at the given height, waiting for it to be synced.",
+		response: header.ExtendedHeader{},
+	},
+	{
+		method: http.MethodGet, path: headEndpoint, handler: (*Handler).handleHeadRequest,
+		operation: "getHead", tag: "header",
+		summary: "Returns the header of the local chain head.",
+		query: []param{{
+			name: networkKey, schema: "boolean",
+			description: "Returns the header of the network chain head instead.",
+		}},
+		response: header.ExtendedHeader{},
+	},
+	{
+		method: http.MethodGet, path: headWatchEndpoint, handler: (*Handler).handleHeadWatch,
+		operation: "watchHead", tag: "header",
+		summary:  "Streams the newly verified headers as Server-Sent Events.",
+		response: header.ExtendedHeader{},
+		stream:   true,
+	},
 
 	// DASer endpoints
-	// only register if DASer service is available
-	if h.das != nil {
-		rpc.RegisterHandlerFunc(dasStateEndpoint, h.handleDASStateRequest, http.MethodGet)
+	{
+		method: http.MethodGet, path: dasStateEndpoint, handler: (*Handler).handleDASStateRequest,
+		operation: "getSamplingStats", tag: "das",
+		summary:  "Returns the progress of the data availability sampling. Served by light and full nodes only.",
+		response: das.SamplingStats{},
+		das:      true,
+	},
+}
+
+func (h *Handler) RegisterEndpoints(rpc *Server) {
+	for _, e := range endpoints {
+		// only register if DASer service is available
+		if e.das && h.das == nil {
+			continue
+		}
+		handler := e.handler
+		rpc.RegisterHandlerFunc(e.path, func(w http.ResponseWriter, r *http.Request) {
+			handler(h, w, r)
+		}, e.method)
+	}
+
+	spec, err := OpenAPI()
+	if err != nil {
+		log.Errorw("generating OpenAPI spec", "err", err)
+		return
 	}
+	rpc.RegisterHandlerFunc(openAPIEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(spec)
+		if err != nil {
+			log.Errorw("serving request", "endpoint", openAPIEndpoint, "err", err)
+		}
+	}, http.MethodGet)
 }
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// ErrorCode is the machine-readable code of an Error, which the clients branch on instead of the
+// message. The codes are kept stable across releases.
+type ErrorCode string
+
+const (
+	// CodeInvalidRequest is returned for malformed requests, e.g. with an invalid height or address.
+	CodeInvalidRequest ErrorCode = "invalid_request"
+	// CodeNotFound is returned when the requested data is not known to the node.
+	CodeNotFound ErrorCode = "not_found"
+	// CodeNotAllowed is returned for the requests the node does not serve, e.g. the transactions
+	// once the state module is stopped.
+	CodeNotAllowed ErrorCode = "not_allowed"
+	// CodeDataUnavailable is returned when the data of the block could not be retrieved from the
+	// network.
+	CodeDataUnavailable ErrorCode = "data_unavailable"
+	// CodeDataPoisoned is returned for the data of the blocks proven to be incorrectly encoded.
+	CodeDataPoisoned ErrorCode = "data_poisoned"
+	// CodeTimeout is returned when the request was not served in time.
+	CodeTimeout ErrorCode = "timeout"
+	// CodeInternal is returned for all the other failures.
+	CodeInternal ErrorCode = "internal"
+)
+
+// Error is the body of the error responses of the gateway.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// newError makes the Error of the given error, along with the status code to respond with. The
+// code of a known error takes precedence over the given status code.
+func newError(statusCode int, err error) (int, *Error) {
+	code := CodeInternal
+	switch statusCode {
+	case http.StatusBadRequest:
+		code = CodeInvalidRequest
+	case http.StatusNotFound:
+		code = CodeNotFound
+	case http.StatusMethodNotAllowed:
+		code = CodeNotAllowed
+	}
+
+	switch {
+	case errors.Is(err, header.ErrNotFound), errors.Is(err, share.ErrNotFound):
+		statusCode, code = http.StatusNotFound, CodeNotFound
+	case errors.Is(err, share.ErrNotAvailable):
+		statusCode, code = http.StatusServiceUnavailable, CodeDataUnavailable
+	case errors.Is(err, share.ErrPoisoned):
+		statusCode, code = http.StatusUnprocessableEntity, CodeDataPoisoned
+	case errors.Is(err, context.DeadlineExceeded):
+		statusCode, code = http.StatusGatewayTimeout, CodeTimeout
+	}
+	return statusCode, &Error{Code: code, Message: err.Error()}
+}
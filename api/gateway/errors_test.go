@@ -0,0 +1,43 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		status     int
+		err        error
+		wantStatus int
+		wantCode   ErrorCode
+	}{
+		{http.StatusBadRequest, errors.New("invalid height"), http.StatusBadRequest, CodeInvalidRequest},
+		{http.StatusInternalServerError, errors.New("failed"), http.StatusInternalServerError, CodeInternal},
+		{http.StatusMethodNotAllowed, errors.New("stopped"), http.StatusMethodNotAllowed, CodeNotAllowed},
+		// the known errors take precedence over the given status
+		{http.StatusInternalServerError, fmt.Errorf("getting: %w", header.ErrNotFound), http.StatusNotFound, CodeNotFound},
+		{http.StatusInternalServerError, share.ErrNotAvailable, http.StatusServiceUnavailable, CodeDataUnavailable},
+		{http.StatusInternalServerError, share.ErrPoisoned, http.StatusUnprocessableEntity, CodeDataPoisoned},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		writeError(rec, tt.status, "/test", tt.err)
+		assert.Equal(t, tt.wantStatus, rec.Code)
+
+		var body Error
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, tt.wantCode, body.Code)
+		assert.Equal(t, tt.err.Error(), body.Message)
+	}
+}
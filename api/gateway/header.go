@@ -1,7 +1,10 @@
 package gateway
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -12,15 +15,37 @@ import (
 
 const (
 	headEndpoint           = "/head"
+	headWatchEndpoint      = "/head/watch"
 	headerByHeightEndpoint = "/header"
 )
 
 var (
 	heightKey = "height"
+	// networkKey is the query parameter of the headEndpoint, which selects the network head, rather
+	// than the local one.
+	networkKey = "network"
+	// headWatchBufferSize is the amount of headers buffered for a watcher of the headWatchEndpoint.
+	// Watchers falling behind by more than that are disconnected, so they can reconnect and
+	// catch up through the headerByHeightEndpoint instead of silently missing headers.
+	headWatchBufferSize = 16
 )
 
 func (h *Handler) handleHeadRequest(w http.ResponseWriter, r *http.Request) {
-	head, err := h.header.Head(r.Context())
+	var network bool
+	if param := r.URL.Query().Get(networkKey); param != "" {
+		var err error
+		network, err = strconv.ParseBool(param)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, headEndpoint, err)
+			return
+		}
+	}
+
+	headFn := h.header.Head
+	if network {
+		headFn = h.header.NetworkHead
+	}
+	head, err := headFn(r.Context())
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, headEndpoint, err)
 		return
@@ -37,6 +62,58 @@ func (h *Handler) handleHeadRequest(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleHeadWatch streams newly verified headers to the client as Server-Sent Events.
+func (h *Handler) handleHeadWatch(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, headWatchEndpoint, errors.New("streaming is not supported"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	headers, err := h.header.SubscribeHeaders(ctx)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, headWatchEndpoint, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	buf := make(chan *header.ExtendedHeader, headWatchBufferSize)
+	go func() {
+		defer close(buf)
+		for h := range headers {
+			select {
+			case buf <- h:
+			default:
+				log.Warnw("watcher is too slow, disconnecting", "endpoint", headWatchEndpoint,
+					"remote", r.RemoteAddr, "height", h.Height)
+				cancel()
+				return
+			}
+		}
+	}()
+
+	for h := range buf {
+		data, err := json.Marshal(h)
+		if err != nil {
+			log.Errorw("serializing header", "endpoint", headWatchEndpoint, "height", h.Height, "err", err)
+			return
+		}
+		_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+		if err != nil {
+			log.Debugw("writing response", "endpoint", headWatchEndpoint, "err", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
 func (h *Handler) handleHeaderRequest(w http.ResponseWriter, r *http.Request) {
 	header, err := h.performGetHeaderRequest(w, r, headerByHeightEndpoint)
 	if err != nil {
@@ -0,0 +1,86 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+)
+
+func TestHandleHeadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHeader := headerMock.NewMockModule(ctrl)
+	h := NewHandler(nil, nil, mockHeader, nil)
+
+	local, network := header.RandExtendedHeader(t), header.RandExtendedHeader(t)
+	mockHeader.EXPECT().Head(gomock.Any()).Return(local, nil).Times(2)
+	mockHeader.EXPECT().NetworkHead(gomock.Any()).Return(network, nil)
+
+	var tests = []struct {
+		query    string
+		code     int
+		expected *header.ExtendedHeader
+	}{
+		{query: "", code: http.StatusOK, expected: local},
+		{query: "?network=false", code: http.StatusOK, expected: local},
+		{query: "?network=true", code: http.StatusOK, expected: network},
+		{query: "?network=maybe", code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		h.handleHeadRequest(rec, httptest.NewRequest(http.MethodGet, headEndpoint+tt.query, nil))
+		require.Equal(t, tt.code, rec.Code, tt.query)
+		if tt.expected == nil {
+			continue
+		}
+		eh := new(header.ExtendedHeader)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), eh))
+		assert.Equal(t, tt.expected.Hash(), eh.Hash(), tt.query)
+	}
+}
+
+func TestHandleHeadWatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHeader := headerMock.NewMockModule(ctrl)
+	h := NewHandler(nil, nil, mockHeader, nil)
+
+	expected := []*header.ExtendedHeader{header.RandExtendedHeader(t), header.RandExtendedHeader(t)}
+	headers := make(chan *header.ExtendedHeader, len(expected))
+	for _, eh := range expected {
+		headers <- eh
+	}
+	close(headers)
+	mockHeader.EXPECT().SubscribeHeaders(gomock.Any()).Return((<-chan *header.ExtendedHeader)(headers), nil)
+
+	rec := httptest.NewRecorder()
+	h.handleHeadWatch(rec, httptest.NewRequest(http.MethodGet, headWatchEndpoint, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+
+	var got []*header.ExtendedHeader
+	scanner := bufio.NewScanner(rec.Body)
+	scanner.Buffer(make([]byte, 0, 1<<16), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		eh := new(header.ExtendedHeader)
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), eh))
+		got = append(got, eh)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, got, len(expected))
+	for i := range expected {
+		assert.Equal(t, expected[i].Hash(), got[i].Hash())
+	}
+}
@@ -33,7 +33,7 @@ func checkPostDisabled(state state.Module) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// check if state service was halted and deny the transaction
-			if r.Method == http.MethodPost && state.IsStopped() {
+			if r.Method == http.MethodPost && state.IsStopped(r.Context()) {
 				writeError(w, http.StatusMethodNotAllowed, r.URL.Path, errors.New("not possible to submit data"))
 				return
 			}
@@ -43,9 +43,14 @@ func checkPostDisabled(state state.Module) mux.MiddlewareFunc {
 }
 
 // wrapRequestContext ensures we implement a deadline on serving requests
-// via the gateway server-side to prevent context leaks.
+// via the gateway server-side to prevent context leaks. Streaming requests
+// are exempt, as they are bound to the lifetime of the connection instead.
 func wrapRequestContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == headWatchEndpoint {
+			next.ServeHTTP(w, r)
+			return
+		}
 		ctx, cancel := context.WithTimeout(r.Context(), timeout)
 		defer cancel()
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"cosmossdk.io/math"
+	"github.com/alecthomas/jsonschema"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const openAPIEndpoint = "/openapi.json"
+
+const (
+	openAPIVersion = "3.0.3"
+	openAPITitle   = "Celestia Node Gateway"
+	// APIVersion is the version of the gateway API documented in the OpenAPI spec.
+	APIVersion = "v0.1.0"
+)
+
+var pathParamRegexp = regexp.MustCompile(`{(\w+)}`)
+
+// OpenAPI generates the OpenAPI spec of the gateway endpoints from their definitions, with the
+// schemas of the request and response bodies reflected from the Go types, so that the client SDKs
+// in other languages can be generated from it. The errors are described by the Error schema.
+func OpenAPI() ([]byte, error) {
+	schemas := newSchemas()
+	paths := make(map[string]map[string]interface{})
+	for _, e := range endpoints {
+		op := map[string]interface{}{
+			"operationId": e.operation,
+			"tags":        []string{e.tag},
+			"summary":     e.summary,
+		}
+
+		var params []interface{}
+		for _, m := range pathParamRegexp.FindAllStringSubmatch(e.path, -1) {
+			params = append(params, paramSpec(pathParams[m[1]], "path"))
+		}
+		for _, p := range e.query {
+			params = append(params, paramSpec(p, "query"))
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if e.request != nil {
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.add(e.request)},
+				},
+			}
+		}
+
+		contentType, description := "application/json", "Success."
+		if e.stream {
+			contentType, description = "text/event-stream", "Stream of the events carrying the schema as data."
+		}
+		op["responses"] = map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": description,
+				"content": map[string]interface{}{
+					contentType: map[string]interface{}{"schema": schemas.add(e.response)},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "Error.",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemas.add(Error{})},
+				},
+			},
+		}
+
+		if paths[e.path] == nil {
+			paths[e.path] = make(map[string]interface{})
+		}
+		paths[e.path][strings.ToLower(e.method)] = op
+	}
+
+	errSchema := schemas.defs["Error"].(map[string]interface{})
+	errSchema["properties"].(map[string]interface{})["code"].(map[string]interface{})["enum"] = []ErrorCode{
+		CodeInvalidRequest,
+		CodeNotFound,
+		CodeNotAllowed,
+		CodeDataUnavailable,
+		CodeDataPoisoned,
+		CodeTimeout,
+		CodeInternal,
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":   openAPITitle,
+			"version": APIVersion,
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas.defs},
+	}, "", "  ")
+}
+
+func paramSpec(p param, in string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        p.name,
+		"in":          in,
+		"required":    in == "path",
+		"description": p.description,
+		"schema":      map[string]interface{}{"type": p.schema},
+	}
+}
+
+// schemas collects the schemas of the types of the bodies, converted from the JSON Schema
+// reflected from the Go types to the dialect of the OpenAPI.
+type schemas struct {
+	reflector *jsonschema.Reflector
+	defs      map[string]interface{}
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		reflector: &jsonschema.Reflector{
+			AllowAdditionalProperties: true,
+			TypeMapper:                schemaType,
+		},
+		defs: make(map[string]interface{}),
+	}
+}
+
+// add adds the schema of the type of the given value along with the schemas it refers to and
+// returns the reference to it.
+func (s *schemas) add(v interface{}) interface{} {
+	schema := s.reflector.Reflect(v)
+	for name, def := range schema.Definitions {
+		s.defs[name] = toOpenAPI(def)
+	}
+	return toOpenAPI(schema.Type)
+}
+
+// schemaType maps the types with custom JSON encoding to their schemas.
+func schemaType(t reflect.Type) *jsonschema.Type {
+	switch {
+	case t == reflect.TypeOf(math.Int{}), t == reflect.TypeOf(sdk.Dec{}):
+		return &jsonschema.Type{Type: "string", Description: "Decimal number."}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && t.Elem().PkgPath() == "" &&
+		t.PkgPath() != "":
+		// the named byte slices, e.g. the hashes, are encoded as strings, either hex or base64
+		return &jsonschema.Type{Type: "string"}
+	}
+	return nil
+}
+
+// toOpenAPI converts the JSON Schema type to the OpenAPI schema.
+func toOpenAPI(t *jsonschema.Type) interface{} {
+	raw, err := json.Marshal(t)
+	if err != nil {
+		// the schemas reflected from the types always marshal
+		panic(err)
+	}
+	var schema interface{}
+	err = json.Unmarshal([]byte(strings.ReplaceAll(string(raw), "#/definitions/", "#/components/schemas/")), &schema)
+	if err != nil {
+		panic(err)
+	}
+	return convert(schema)
+}
+
+// convert replaces the keywords of the JSON Schema unknown to the OpenAPI with their equivalents.
+func convert(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		delete(v, "$schema")
+		if patterns, ok := v["patternProperties"].(map[string]interface{}); ok {
+			delete(v, "patternProperties")
+			v["additionalProperties"] = patterns[".*"]
+		}
+		if media, ok := v["media"].(map[string]interface{}); ok {
+			delete(v, "media")
+			if media["binaryEncoding"] == "base64" {
+				v["format"] = "byte"
+			}
+		}
+		if ref, ok := v["$ref"]; ok {
+			// the siblings of the references are ignored by the OpenAPI
+			return map[string]interface{}{"$ref": ref}
+		}
+		for key, val := range v {
+			// the properties are named by the user, rather than being the keywords
+			if key == "properties" {
+				for name, prop := range val.(map[string]interface{}) {
+					val.(map[string]interface{})[name] = convert(prop)
+				}
+				continue
+			}
+			v[key] = convert(val)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = convert(v[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	raw, err := OpenAPI()
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &spec))
+
+	// every endpoint is documented under a unique operation
+	operations := make(map[string]bool)
+	for _, e := range endpoints {
+		op, ok := spec.Paths[e.path][strings.ToLower(e.method)]
+		require.True(t, ok, "%s %s", e.method, e.path)
+		assert.Equal(t, e.operation, op["operationId"])
+		assert.False(t, operations[e.operation], "duplicate operation %s", e.operation)
+		operations[e.operation] = true
+	}
+
+	// all the references resolve
+	refs := regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(compact(t, raw), -1)
+	require.NotEmpty(t, refs)
+	for _, ref := range refs {
+		assert.Contains(t, spec.Components.Schemas, ref[1])
+	}
+	assert.NotContains(t, string(raw), "#/definitions/")
+	assert.NotContains(t, string(raw), "patternProperties")
+}
+
+func compact(t *testing.T, raw []byte) string {
+	var v interface{}
+	require.NoError(t, json.Unmarshal(raw, &v))
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(out)
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+
+	"github.com/celestiaorg/celestia-node/libs/httpsrv"
 )
 
 // Server represents a gateway server on the Node.
@@ -15,6 +17,9 @@ type Server struct {
 	srv      *http.Server
 	srvMux   *mux.Router // http request multiplexer
 	listener net.Listener
+	// cancel terminates the requests still being served on Stop, e.g. long-lived streams,
+	// which would otherwise block the graceful shutdown.
+	cancel context.CancelFunc
 
 	started atomic.Bool
 }
@@ -36,6 +41,12 @@ func NewServer(address, port string) *Server {
 	return server
 }
 
+// Configure applies the given HTTP config to the Server. It must be called before Start.
+// Note that the WriteTimeout, if any, also cuts the streams of new headers.
+func (s *Server) Configure(cfg httpsrv.Config) error {
+	return cfg.Apply(s.srv)
+}
+
 // Start starts the gateway Server, listening on the given address.
 func (s *Server) Start(context.Context) error {
 	couldStart := s.started.CompareAndSwap(false, true)
@@ -48,6 +59,12 @@ func (s *Server) Start(context.Context) error {
 		return err
 	}
 	s.listener = listener
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.srv.BaseContext = func(net.Listener) context.Context {
+		return ctx
+	}
+	s.cancel = cancel
 	log.Infow("server started", "listening on", s.srv.Addr)
 	//nolint:errcheck
 	go s.srv.Serve(listener)
@@ -61,6 +78,7 @@ func (s *Server) Stop(ctx context.Context) error {
 		log.Warn("cannot stop server: already stopped")
 		return nil
 	}
+	s.cancel()
 	err := s.srv.Shutdown(ctx)
 	if err != nil {
 		return err
@@ -16,6 +16,7 @@ const (
 	balanceEndpoint            = "/balance"
 	submitTxEndpoint           = "/submit_tx"
 	submitPFDEndpoint          = "/submit_pfd"
+	submitPFBEndpoint          = "/submit_pfb"
 	transferEndpoint           = "/transfer"
 	delegationEndpoint         = "/delegate"
 	undelegationEndpoint       = "/begin_unbonding"
@@ -24,9 +25,13 @@ const (
 	queryDelegationEndpoint    = "/query_delegation"
 	queryUnbondingEndpoint     = "/query_unbonding"
 	queryRedelegationsEndpoint = "/query_redelegations"
+	txStatusEndpoint           = "/tx_status"
 )
 
-const addrKey = "address"
+const (
+	addrKey   = "address"
+	txHashKey = "hash"
+)
 
 var (
 	ErrInvalidAddressFormat = errors.New("address must be a valid account or validator address")
@@ -46,6 +51,14 @@ type submitPFDRequest struct {
 	GasLimit    uint64 `json:"gas_limit"`
 }
 
+// submitPFBRequest represents a request to submit a PayForData
+// transaction carrying a blob.
+type submitPFBRequest struct {
+	NamespaceID string `json:"namespace_id"`
+	Blob        string `json:"blob"`
+	GasLimit    uint64 `json:"gas_limit"`
+}
+
 type transferRequest struct {
 	To       string `json:"to"`
 	Amount   int64  `json:"amount"`
@@ -194,6 +207,41 @@ func (h *Handler) handleSubmitPFD(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) handleSubmitPFB(w http.ResponseWriter, r *http.Request) {
+	// decode request
+	var req submitPFBRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, submitPFBEndpoint, err)
+		return
+	}
+	nID, err := hex.DecodeString(req.NamespaceID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, submitPFBEndpoint, err)
+		return
+	}
+	blob, err := hex.DecodeString(req.Blob)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, submitPFBEndpoint, err)
+		return
+	}
+	// perform request
+	txResp, err := h.state.SubmitPayForData(r.Context(), nID, blob, req.GasLimit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, submitPFBEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(txResp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, submitPFBEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", submitPFBEndpoint, "err", err)
+	}
+}
+
 func (h *Handler) handleTransfer(w http.ResponseWriter, r *http.Request) {
 	var req transferRequest
 	err := json.NewDecoder(r.Body).Decode(&req)
@@ -448,3 +496,29 @@ func (h *Handler) handleQueryRedelegations(w http.ResponseWriter, r *http.Reques
 		log.Errorw("writing response", "endpoint", queryRedelegationsEndpoint, "err", err)
 	}
 }
+
+func (h *Handler) handleTxStatus(w http.ResponseWriter, r *http.Request) {
+	hash := mux.Vars(r)[txHashKey]
+	if _, err := hex.DecodeString(hash); err != nil {
+		writeError(w, http.StatusBadRequest, txStatusEndpoint, err)
+		return
+	}
+	status, err := h.state.TxStatus(r.Context(), hash)
+	if err != nil {
+		if errors.Is(err, state.ErrTxNotTracked) {
+			writeError(w, http.StatusNotFound, txStatusEndpoint, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, txStatusEndpoint, err)
+		return
+	}
+	resp, err := json.Marshal(status)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, txStatusEndpoint, err)
+		return
+	}
+	_, err = w.Write(resp)
+	if err != nil {
+		log.Errorw("writing response", "endpoint", txStatusEndpoint, "err", err)
+	}
+}
@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+func TestHandleSubmitPFB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockState := stateMock.NewMockModule(ctrl)
+	h := NewHandler(mockState, nil, nil, nil)
+
+	nID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	blob := []byte("blob")
+	mockState.EXPECT().
+		SubmitPayForData(gomock.Any(), nID, blob, uint64(2000)).
+		Return(&state.TxResponse{TxHash: "DEADBEEF"}, nil)
+
+	body, err := json.Marshal(submitPFBRequest{
+		NamespaceID: "0102030405060708",
+		Blob:        "626c6f62",
+		GasLimit:    2000,
+	})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	h.handleSubmitPFB(rec, httptest.NewRequest(http.MethodPost, submitPFBEndpoint, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp state.TxResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "DEADBEEF", resp.TxHash)
+
+	// malformed blobs are rejected before reaching the state module
+	body, err = json.Marshal(submitPFBRequest{NamespaceID: "0102030405060708", Blob: "not hex"})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	h.handleSubmitPFB(rec, httptest.NewRequest(http.MethodPost, submitPFBEndpoint, bytes.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -8,8 +8,9 @@ import (
 func writeError(w http.ResponseWriter, statusCode int, endpoint string, err error) {
 	log.Errorw("serving request", "endpoint", endpoint, "err", err)
 
+	statusCode, body := newError(statusCode, err)
 	w.WriteHeader(statusCode)
-	errBody, jerr := json.Marshal(err.Error())
+	errBody, jerr := json.Marshal(body)
 	if jerr != nil {
 		log.Errorw("serializing error", "endpoint", endpoint, "err", jerr)
 		return
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	blobServ "github.com/celestiaorg/celestia-node/nodebuilder/blob"
+)
+
+type blobService struct {
+	blob blobServ.Module
+}
+
+func (s *blobService) GetAll(ctx context.Context, req *pb.GetAllRequest) (*pb.GetAllResponse, error) {
+	nIDs := make([]namespace.ID, len(req.Namespaces))
+	for i, nID := range req.Namespaces {
+		nIDs[i] = nID
+	}
+	blobs, err := s.blob.GetAll(ctx, req.Height, nIDs)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.GetAllResponse{Blobs: make([]*pb.Blob, len(blobs))}
+	for i, b := range blobs {
+		resp.Blobs[i] = &pb.Blob{
+			Namespace:  b.Namespace,
+			Data:       b.Data,
+			Commitment: b.Commitment,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/header"
+	blobServ "github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	stateServ "github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// Handler serves the modules of the node as the gRPC services defined in the pb package.
+type Handler struct {
+	state  stateServ.Module
+	share  shareServ.Module
+	header headerServ.Module
+	blob   blobServ.Module
+}
+
+func NewHandler(
+	state stateServ.Module,
+	share shareServ.Module,
+	header headerServ.Module,
+	blob blobServ.Module,
+) *Handler {
+	return &Handler{
+		state:  state,
+		share:  share,
+		header: header,
+		blob:   blob,
+	}
+}
+
+// RegisterServices registers the services onto the given Server. It must be called before Start.
+func (h *Handler) RegisterServices(s *Server) {
+	pb.RegisterHeaderServiceServer(s.srv, &headerService{header: h.header})
+	pb.RegisterShareServiceServer(s.srv, &shareService{share: h.share, header: h.header})
+	pb.RegisterBlobServiceServer(s.srv, &blobService{blob: h.blob})
+	pb.RegisterStateServiceServer(s.srv, &stateService{state: h.state})
+}
+
+// toStatus converts the errors of the modules into the gRPC statuses, so that the clients tell
+// them apart by the code.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, header.ErrNotFound), errors.Is(err, share.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, share.ErrNotAvailable):
+		code = codes.Unavailable
+	case errors.Is(err, share.ErrPoisoned):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	}
+	return status.Error(code, err.Error())
+}
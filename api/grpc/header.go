@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/header"
+	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
+)
+
+type headerService struct {
+	header headerServ.Module
+}
+
+func (s *headerService) Head(ctx context.Context, _ *pb.HeadRequest) (*pb.ExtendedHeader, error) {
+	eh, err := s.header.Head(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return headerToPb(eh)
+}
+
+func (s *headerService) GetByHeight(ctx context.Context, req *pb.GetByHeightRequest) (*pb.ExtendedHeader, error) {
+	eh, err := s.header.GetByHeight(ctx, req.Height)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return headerToPb(eh)
+}
+
+func (s *headerService) SubscribeHeaders(
+	_ *pb.SubscribeHeadersRequest,
+	stream pb.HeaderService_SubscribeHeadersServer,
+) error {
+	headers, err := s.header.SubscribeHeaders(stream.Context())
+	if err != nil {
+		return toStatus(err)
+	}
+	for eh := range headers {
+		msg, err := headerToPb(eh)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(msg)
+		if err != nil {
+			return err
+		}
+	}
+	// the subscription is only closed before the stream is done when the node stops
+	if err = stream.Context().Err(); err != nil {
+		return toStatus(err)
+	}
+	return status.Error(codes.Unavailable, "header subscription closed")
+}
+
+func headerToPb(eh *header.ExtendedHeader) (*pb.ExtendedHeader, error) {
+	raw, err := eh.MarshalBinary()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshaling header: %s", err)
+	}
+	return &pb.ExtendedHeader{
+		Height:   uint64(eh.Height),
+		Hash:     eh.Hash(),
+		DataHash: eh.DataHash,
+		Time:     eh.Time.UnixNano(),
+		Raw:      raw,
+	}, nil
+}
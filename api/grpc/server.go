@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/gbrlsnchs/jwt/v3"
+	logging "github.com/ipfs/go-log/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+)
+
+var log = logging.Logger("grpc")
+
+// writeMethods are the methods requiring the write permission, while all the others require the
+// read one, as with the `perm` tags of the API structs served over the JSON-RPC.
+var writeMethods = map[string]bool{
+	"/celestia.node.StateService/Transfer":         true,
+	"/celestia.node.StateService/SubmitPayForBlob": true,
+}
+
+// Server serves the node's services over the gRPC, next to the JSON-RPC, e.g. for the clients in
+// other languages and the indexers streaming the data. The requests are authenticated with the
+// same tokens as the JSON-RPC ones, passed in the "authorization" metadata as "Bearer <token>".
+// The tokens restricted with authtoken.Restrictions are not accepted, as those are only enforced
+// by the JSON-RPC.
+type Server struct {
+	srv      *grpc.Server
+	addr     string
+	listener net.Listener
+	signer   jwt.Algorithm
+
+	started atomic.Bool
+}
+
+// NewServer creates a new gRPC Server that authenticates requests with tokens signed by the
+// given signer.
+func NewServer(address, port string, signer jwt.Algorithm) *Server {
+	s := &Server{
+		addr:   address + ":" + port,
+		signer: signer,
+	}
+	s.srv = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryAuth),
+		grpc.StreamInterceptor(s.streamAuth),
+	)
+	return s
+}
+
+// Start starts the gRPC Server.
+func (s *Server) Start(context.Context) error {
+	couldStart := s.started.CompareAndSwap(false, true)
+	if !couldStart {
+		log.Warn("cannot start server: already started")
+		return nil
+	}
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	log.Infow("server started", "listening on", s.addr)
+	//nolint:errcheck
+	go s.srv.Serve(listener)
+	return nil
+}
+
+// Stop stops the gRPC Server. It stops accepting new connections and waits for the in-flight
+// requests to be served until the given context is done, closing the remaining connections after.
+func (s *Server) Stop(ctx context.Context) error {
+	couldStop := s.started.CompareAndSwap(true, false)
+	if !couldStop {
+		log.Warn("cannot stop server: already stopped")
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Warnw("stopped before in-flight requests were served", "err", ctx.Err())
+		s.srv.Stop()
+	}
+	s.listener = nil
+	log.Info("server stopped")
+	return nil
+}
+
+// ListenAddr returns the listen address of the server.
+func (s *Server) ListenAddr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) unaryAuth(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuth(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := s.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authorize checks the token of the request grants the permission the method requires. A request
+// with no token is granted perms.DefaultPerms.
+func (s *Server) authorize(ctx context.Context, method string) error {
+	allowed := perms.DefaultPerms
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		token := md.Get("authorization")[0]
+		if !strings.HasPrefix(token, "Bearer ") {
+			return status.Error(codes.Unauthenticated, "missing Bearer prefix in authorization")
+		}
+		payload, err := authtoken.ExtractSignedPayload(s.signer, strings.TrimPrefix(token, "Bearer "))
+		if err != nil {
+			log.Warnw("JWT verification failed", "method", method, "err", err)
+			return status.Error(codes.Unauthenticated, "invalid token")
+		}
+		if payload.Restricted() {
+			return status.Error(codes.PermissionDenied, "restricted tokens are only accepted over the JSON-RPC")
+		}
+		allowed = payload.Allow
+	}
+
+	required := auth.Permission("read")
+	if writeMethods[method] {
+		required = "write"
+	}
+	for _, perm := range allowed {
+		if perm == required {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "missing permission to call %s (needs '%s')", method, required)
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
+	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
+	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	ctrl := gomock.NewController(t)
+	headerMod, stateMod := headerMock.NewMockModule(ctrl), stateMock.NewMockModule(ctrl)
+	signer := jwt.NewHS256([]byte("secret"))
+	server := NewServer("localhost", "0", signer)
+	NewHandler(stateMod, shareMock.NewMockModule(ctrl), headerMod, blobMock.NewMockModule(ctrl)).RegisterServices(server)
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(ctx))
+	})
+
+	conn, err := grpc.DialContext(ctx, server.ListenAddr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	headers, states := pb.NewHeaderServiceClient(conn), pb.NewStateServiceClient(conn)
+
+	t.Run("read without token", func(t *testing.T) {
+		eh := header.RandExtendedHeader(t)
+		headerMod.EXPECT().Head(gomock.Any()).Return(eh, nil)
+		resp, err := headers.Head(ctx, &pb.HeadRequest{})
+		require.NoError(t, err)
+		assert.EqualValues(t, eh.Height, resp.Height)
+		assert.EqualValues(t, eh.Hash(), resp.Hash)
+
+		got := new(header.ExtendedHeader)
+		require.NoError(t, got.UnmarshalBinary(resp.Raw))
+		assert.EqualValues(t, eh.Hash(), got.Hash())
+
+		headerMod.EXPECT().GetByHeight(gomock.Any(), uint64(10)).Return(nil, header.ErrNotFound)
+		_, err = headers.GetByHeight(ctx, &pb.GetByHeightRequest{Height: 10})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("stream headers", func(t *testing.T) {
+		ch := make(chan *header.ExtendedHeader, 2)
+		ch <- header.RandExtendedHeader(t)
+		ch <- header.RandExtendedHeader(t)
+		close(ch)
+		headerMod.EXPECT().SubscribeHeaders(gomock.Any()).Return((<-chan *header.ExtendedHeader)(ch), nil)
+
+		sub, err := headers.SubscribeHeaders(ctx, &pb.SubscribeHeadersRequest{})
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = sub.Recv()
+			require.NoError(t, err)
+		}
+		_, err = sub.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("write requires permission", func(t *testing.T) {
+		req := &pb.TransferRequest{To: state.AccAddress([]byte("receiver")).String(), Amount: "10"}
+		_, err := states.Transfer(ctx, req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		readToken, err := authtoken.NewSignedJWT(signer, perms.ReadPerms)
+		require.NoError(t, err)
+		_, err = states.Transfer(withToken(ctx, readToken), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = states.Transfer(withToken(ctx, "invalid"), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		restricted, err := authtoken.NewRestrictedJWT(signer, perms.ReadWritePerms, authtoken.Restrictions{
+			Methods: []string{"state.*"},
+		})
+		require.NoError(t, err)
+		_, err = states.Transfer(withToken(ctx, restricted), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		writeToken, err := authtoken.NewSignedJWT(signer, perms.ReadWritePerms)
+		require.NoError(t, err)
+		stateMod.EXPECT().Transfer(gomock.Any(), gomock.Any(), math.NewInt(10), uint64(0)).
+			Return(&state.TxResponse{Height: 5, TxHash: "hash"}, nil)
+		resp, err := states.Transfer(withToken(ctx, writeToken), req)
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, resp.Height)
+		assert.Equal(t, "hash", resp.TxHash)
+	})
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
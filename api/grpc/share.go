@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	headerServ "github.com/celestiaorg/celestia-node/nodebuilder/header"
+	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+type shareService struct {
+	share  shareServ.Module
+	header headerServ.Module
+}
+
+func (s *shareService) GetShare(ctx context.Context, req *pb.GetShareRequest) (*pb.Share, error) {
+	eh, err := s.header.GetByHeight(ctx, req.Height)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sh, err := s.share.GetShare(ctx, eh.DAH, int(req.Row), int(req.Col))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.Share{Data: sh}, nil
+}
+
+func (s *shareService) GetSharesByNamespace(
+	req *pb.GetSharesByNamespaceRequest,
+	stream pb.ShareService_GetSharesByNamespaceServer,
+) error {
+	if len(req.Namespace) != share.NamespaceSize {
+		return status.Errorf(codes.InvalidArgument, "namespace must be %d bytes long", share.NamespaceSize)
+	}
+	ctx := stream.Context()
+	eh, err := s.header.GetByHeight(ctx, req.Height)
+	if err != nil {
+		return toStatus(err)
+	}
+	rows, err := s.share.GetSharesByNamespaceWithProof(ctx, eh.DAH, req.Namespace)
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, row := range rows {
+		err = stream.Send(&pb.Row{Index: uint32(row.Index), Shares: row.Shares})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *shareService) GetEDS(req *pb.GetEDSRequest, stream pb.ShareService_GetEDSServer) error {
+	rows, err := s.share.GetEDS(stream.Context(), req.Height)
+	if err != nil {
+		return toStatus(err)
+	}
+	for i, row := range rows {
+		err = stream.Send(&pb.Row{Index: uint32(i), Shares: row})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
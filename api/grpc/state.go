@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+
+	"cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/celestiaorg/celestia-node/api/grpc/pb"
+	stateServ "github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+type stateService struct {
+	state stateServ.Module
+}
+
+func (s *stateService) Balance(ctx context.Context, req *pb.BalanceRequest) (*pb.Balance, error) {
+	var (
+		bal *state.Balance
+		err error
+	)
+	if req.Address == "" {
+		bal, err = s.state.Balance(ctx)
+	} else {
+		var addr state.AccAddress
+		addr, err = parseAddress(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		bal, err = s.state.BalanceForAddress(ctx, addr)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.Balance{Denom: bal.Denom, Amount: bal.Amount.String()}, nil
+}
+
+func (s *stateService) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.TxResponse, error) {
+	to, err := parseAddress(req.To)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := math.NewIntFromString(req.Amount)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount: %s", req.Amount)
+	}
+	resp, err := s.state.Transfer(ctx, to, amount, req.GasLimit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return txToPb(resp), nil
+}
+
+func (s *stateService) SubmitPayForBlob(ctx context.Context, req *pb.SubmitPayForBlobRequest) (*pb.TxResponse, error) {
+	resp, err := s.state.SubmitPayForData(ctx, req.Namespace, req.Data, req.GasLimit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return txToPb(resp), nil
+}
+
+// parseAddress parses the given bech32 address of an account or a validator.
+func parseAddress(addr string) (state.AccAddress, error) {
+	accAddr, err := types.AccAddressFromBech32(addr)
+	if err == nil {
+		return accAddr, nil
+	}
+	valAddr, err := types.ValAddressFromBech32(addr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %s", addr)
+	}
+	return valAddr.Bytes(), nil
+}
+
+func txToPb(resp *state.TxResponse) *pb.TxResponse {
+	return &pb.TxResponse{
+		Height:    resp.Height,
+		TxHash:    resp.TxHash,
+		Code:      resp.Code,
+		Codespace: resp.Codespace,
+		RawLog:    resp.RawLog,
+		GasWanted: resp.GasWanted,
+		GasUsed:   resp.GasUsed,
+	}
+}
@@ -2,12 +2,16 @@ package client
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/filecoin-project/go-jsonrpc"
 
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/keystore"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
@@ -18,17 +22,23 @@ type API interface {
 	header.Module
 	state.Module
 	share.Module
+	blob.Module
 	das.Module
 	p2p.Module
+	keystore.Module
+	node.Module
 }
 
 type Client struct {
-	Fraud  fraud.API
-	Header header.API
-	State  state.API
-	Share  share.API
-	DAS    das.API
-	P2P    p2p.API
+	Fraud    fraud.API
+	Header   header.API
+	State    state.API
+	Share    share.API
+	Blob     blob.API
+	DAS      das.API
+	P2P      p2p.API
+	Keystore keystore.API
+	Node     node.API
 
 	closer multiClientCloser
 }
@@ -56,26 +66,39 @@ func (c *Client) Close() {
 }
 
 // NewClient creates a new Client with one connection per namespace.
-func NewClient(ctx context.Context, addr string) (*Client, error) {
+// The address scheme selects the transport: "http://" for plain requests and "ws://" for
+// a WebSocket connection, which is required for subscriptions. The given token, if any,
+// is sent with every request as the bearer of the Authorization header.
+func NewClient(ctx context.Context, addr, token string) (*Client, error) {
 	var client Client
 	var multiCloser multiClientCloser
 
+	var requestHeader http.Header
+	if token != "" {
+		requestHeader = http.Header{"Authorization": []string{"Bearer " + token}}
+	}
+
 	// TODO: this duplication of strings many times across the codebase can be avoided with issue #1176
 	var modules = map[string]interface{}{
-		"share":  &client.Share,
-		"state":  &client.State,
-		"header": &client.Header,
-		"fraud":  &client.Fraud,
-		"das":    &client.DAS,
-		"p2p":    &client.P2P,
+		"share":    &client.Share.Internal,
+		"blob":     &client.Blob.Internal,
+		"state":    &client.State.Internal,
+		"header":   &client.Header.Internal,
+		"fraud":    &client.Fraud.Internal,
+		"das":      &client.DAS.Internal,
+		"p2p":      &client.P2P.Internal,
+		"keystore": &client.Keystore.Internal,
+		"node":     &client.Node.Internal,
 	}
 	for name, module := range modules {
-		closer, err := jsonrpc.NewClient(ctx, addr, name, module, nil)
+		closer, err := jsonrpc.NewClient(ctx, addr, name, module, requestHeader)
 		if err != nil {
+			multiCloser.closeAll()
 			return nil, err
 		}
 		multiCloser.register(closer)
 	}
 
+	client.closer = multiCloser
 	return &client, nil
 }
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// NamespaceData holds the shares of a namespace found in the block at Height.
+type NamespaceData struct {
+	Height uint64
+	Shares []share.Share
+}
+
+// SubscribeNamespace subscribes to the shares of the given namespace.ID published in new blocks.
+// Blocks holding no shares of the namespace are skipped. The returned channel is closed once the
+// given context is canceled, the header subscription ends, or the shares of a block cannot be
+// fetched.
+//
+// Subscriptions require the Client to be connected over WebSocket.
+func (c *Client) SubscribeNamespace(ctx context.Context, nID namespace.ID) (<-chan *NamespaceData, error) {
+	headers, err := c.Header.SubscribeHeaders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(chan *NamespaceData)
+	go func() {
+		defer close(data)
+		for h := range headers {
+			shares, err := c.Share.GetSharesByNamespace(ctx, h.DAH, nID)
+			if err != nil {
+				return
+			}
+			if len(shares) == 0 {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case data <- &NamespaceData{Height: uint64(h.Height), Shares: shares}:
+			}
+		}
+	}()
+	return data, nil
+}
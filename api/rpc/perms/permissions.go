@@ -0,0 +1,30 @@
+package perms
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+)
+
+var (
+	// DefaultPerms are granted to requests that carry no token.
+	DefaultPerms   = []auth.Permission{"read"}
+	ReadPerms      = []auth.Permission{"read"}
+	ReadWritePerms = []auth.Permission{"read", "write"}
+	AllPerms       = []auth.Permission{"read", "write", "admin"}
+)
+
+// With returns the set of permissions granted by the given permission tier,
+// as each tier includes all the tiers below it.
+func With(perm auth.Permission) ([]auth.Permission, error) {
+	switch perm {
+	case "read":
+		return ReadPerms, nil
+	case "write":
+		return ReadWritePerms, nil
+	case "admin":
+		return AllPerms, nil
+	default:
+		return nil, fmt.Errorf("perms: unknown permission tier %q, expected one of %v", perm, AllPerms)
+	}
+}
@@ -4,40 +4,168 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/gbrlsnchs/jwt/v3"
 	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/httpsrv"
 )
 
 var log = logging.Logger("rpc")
 
+// CallObserver is notified about every call of a method of the registered services.
+type CallObserver func(namespace, method string)
+
 type Server struct {
 	srv      *http.Server
 	rpc      *jsonrpc.RPCServer
 	listener net.Listener
+	signer   jwt.Algorithm
 
-	started atomic.Bool
+	started  atomic.Bool
+	observer atomic.Pointer[CallObserver]
+	tenants  *tenants
 }
 
-func NewServer(address, port string) *Server {
+// NewServer creates a new RPC Server that authenticates requests with tokens signed by the
+// given signer.
+func NewServer(address, port string, signer jwt.Algorithm) *Server {
 	rpc := jsonrpc.NewServer()
-	return &Server{
-		rpc: rpc,
-		srv: &http.Server{
-			Addr:    address + ":" + port,
-			Handler: rpc,
-			// the amount of time allowed to read request headers. set to the default 2 seconds
-			ReadHeaderTimeout: 2 * time.Second,
-		},
+	srv := &Server{
+		rpc:     rpc,
+		signer:  signer,
+		tenants: newTenants(),
 	}
+	srv.srv = &http.Server{
+		Addr:    address + ":" + port,
+		Handler: srv.newHandlerStack(rpc),
+		// the amount of time allowed to read request headers. set to the default 2 seconds
+		ReadHeaderTimeout: 2 * time.Second,
+	}
+	return srv
+}
+
+// Configure applies the given HTTP config to the Server. It must be called before Start.
+func (s *Server) Configure(cfg httpsrv.Config) error {
+	return cfg.Apply(s.srv)
+}
+
+// verifyAuth is the RPC server's auth middleware. A request with no token is granted
+// perms.DefaultPerms.
+func (s *Server) verifyAuth(_ context.Context, token string) (*authtoken.JWTPayload, error) {
+	return authtoken.ExtractSignedPayload(s.signer, token)
+}
+
+// newHandlerStack returns wrapped rpc related handlers.
+func (s *Server) newHandlerStack(core http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// the token is taken in the same way as by auth.Handler
+		token := r.Header.Get("Authorization")
+		if token == "" {
+			token = r.FormValue("token")
+			if token != "" {
+				token = "Bearer " + token
+			}
+		}
+
+		if token != "" {
+			if !strings.HasPrefix(token, "Bearer ") {
+				log.Warn("missing Bearer prefix in auth header")
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			token = strings.TrimPrefix(token, "Bearer ")
+
+			payload, err := s.verifyAuth(ctx, token)
+			if err != nil {
+				log.Warnw("JWT verification failed", "from", r.RemoteAddr, "err", err)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			ctx = auth.WithPerm(ctx, payload.Allow)
+			if payload.Restricted() {
+				ctx = withTenant(ctx, s.tenants.get(token, payload.Restrictions))
+			}
+		}
+
+		core.ServeHTTP(w, r.WithContext(ctx))
+	})
 }
 
 // RegisterService registers a service onto the RPC server. All methods on the service will then be
-// exposed over the RPC.
-func (s *Server) RegisterService(namespace string, service interface{}) {
-	s.rpc.Register(namespace, service)
+// exposed over the RPC. The given out must be a pointer to the API struct of the service, whose
+// `perm` tags define the permission each method requires.
+func (s *Server) RegisterService(namespace string, service interface{}, out interface{}) {
+	internal := getInternalStruct(out)
+	auth.PermissionedProxy(perms.AllPerms, perms.DefaultPerms, service, internal)
+	s.observeCalls(namespace, internal)
+	s.rpc.Register(namespace, out)
+}
+
+// SetCallObserver sets the CallObserver notified about the calls of all the registered services.
+func (s *Server) SetCallObserver(observer CallObserver) {
+	s.observer.Store(&observer)
+}
+
+// observeCalls wraps all the methods of the given Internal struct of an API, so that every call
+// is checked against the restrictions of the token it is made with and reported to the
+// CallObserver, if any.
+func (s *Server) observeCalls(namespace string, internal interface{}) {
+	v := reflect.ValueOf(internal).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field, method := v.Field(i), v.Type().Field(i).Name
+		call := reflect.ValueOf(field.Interface())
+		field.Set(reflect.MakeFunc(field.Type(), func(args []reflect.Value) []reflect.Value {
+			// all the methods take the context first
+			if len(args) > 0 {
+				if ctx, ok := args[0].Interface().(context.Context); ok {
+					if err := checkTenant(ctx, namespace, method); err != nil {
+						return errorResult(call.Type(), err)
+					}
+				}
+			}
+			if observer := s.observer.Load(); observer != nil {
+				(*observer)(namespace, method)
+			}
+			if call.Type().IsVariadic() {
+				return call.CallSlice(args)
+			}
+			return call.Call(args)
+		}))
+	}
+}
+
+// errorResult makes the results of a method of the given type returning the given error.
+// The methods not returning an error, e.g. state.IsStopped, panic with it instead, which the
+// JSON-RPC handler recovers from and responds to with the error.
+func errorResult(tp reflect.Type, err error) []reflect.Value {
+	if tp.NumOut() == 0 || tp.Out(tp.NumOut()-1) != errorType {
+		panic(err)
+	}
+
+	out := make([]reflect.Value, tp.NumOut())
+	for i := range out {
+		out[i] = reflect.Zero(tp.Out(i))
+	}
+	out[len(out)-1] = reflect.ValueOf(&err).Elem()
+	return out
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func getInternalStruct(api interface{}) interface{} {
+	return reflect.ValueOf(api).Elem().FieldByName("Internal").Addr().Interface()
 }
 
 // Start starts the RPC Server.
@@ -58,7 +186,8 @@ func (s *Server) Start(context.Context) error {
 	return nil
 }
 
-// Stop stops the RPC Server.
+// Stop stops the RPC Server. It stops accepting new connections and waits for the in-flight
+// requests to be served until the given context is done, closing the remaining connections after.
 func (s *Server) Stop(ctx context.Context) error {
 	couldStop := s.started.CompareAndSwap(true, false)
 	if !couldStop {
@@ -67,7 +196,14 @@ func (s *Server) Stop(ctx context.Context) error {
 	}
 	err := s.srv.Shutdown(ctx)
 	if err != nil {
-		return err
+		if ctx.Err() == nil {
+			return err
+		}
+		log.Warnw("stopped before in-flight requests were served", "err", err)
+		err = s.srv.Close()
+		if err != nil {
+			return err
+		}
 	}
 	s.listener = nil
 	log.Info("server stopped")
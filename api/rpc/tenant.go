@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+)
+
+var (
+	// ErrMethodNotAllowed is returned for the calls of the methods the token is not allowed to call.
+	ErrMethodNotAllowed = errors.New("rpc: method not allowed for the token")
+	// ErrRateLimited is returned for the calls exceeding the rate limit of the token.
+	ErrRateLimited = errors.New("rpc: rate limit of the token exceeded")
+)
+
+type tenantKey struct{}
+
+// tenant is the bearer of a token with authtoken.Restrictions, sharing the node with others.
+type tenant struct {
+	restrictions authtoken.Restrictions
+
+	lk sync.Mutex
+	// tokens and last are the state of the token bucket limiting the rate of the calls
+	tokens float64
+	last   time.Time
+}
+
+func newTenant(r authtoken.Restrictions) *tenant {
+	t := &tenant{restrictions: r}
+	t.tokens = t.burst()
+	return t
+}
+
+// call checks whether the tenant is allowed to call the method at the given time, consuming its
+// rate limit.
+func (t *tenant) call(now time.Time, namespace, method string) error {
+	if !t.restrictions.Allows(namespace, method) {
+		return fmt.Errorf("%w: %s.%s", ErrMethodNotAllowed, namespace, method)
+	}
+	if t.restrictions.RateLimit <= 0 {
+		return nil
+	}
+
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if !t.last.IsZero() {
+		refill := now.Sub(t.last).Seconds() * t.restrictions.RateLimit
+		t.tokens = math.Min(t.burst(), t.tokens+refill)
+	}
+	t.last = now
+	if t.tokens < 1 {
+		return ErrRateLimited
+	}
+	t.tokens--
+	return nil
+}
+
+func (t *tenant) burst() float64 {
+	if t.restrictions.Burst > 0 {
+		return float64(t.restrictions.Burst)
+	}
+	return math.Max(1, math.Ceil(t.restrictions.RateLimit))
+}
+
+// tenants keeps the tenants of the tokens used, so that the rate limits are kept across the
+// requests made with the same token.
+type tenants struct {
+	lk      sync.Mutex
+	tenants map[[sha256.Size]byte]*tenant
+}
+
+func newTenants() *tenants {
+	return &tenants{tenants: make(map[[sha256.Size]byte]*tenant)}
+}
+
+func (ts *tenants) get(token string, r authtoken.Restrictions) *tenant {
+	key := sha256.Sum256([]byte(token))
+	ts.lk.Lock()
+	defer ts.lk.Unlock()
+	t, ok := ts.tenants[key]
+	if !ok {
+		t = newTenant(r)
+		ts.tenants[key] = t
+	}
+	return t
+}
+
+func withTenant(ctx context.Context, t *tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// checkTenant checks whether the tenant making the call, if any, is allowed to call the method.
+func checkTenant(ctx context.Context, namespace, method string) error {
+	t, ok := ctx.Value(tenantKey{}).(*tenant)
+	if !ok {
+		return nil
+	}
+	return t.call(time.Now(), namespace, method)
+}
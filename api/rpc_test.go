@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,15 +14,28 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/nmt/namespace"
+
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/api/rpc/client"
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
+	blobmod "github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	blobMock "github.com/celestiaorg/celestia-node/nodebuilder/blob/mocks"
+	dasmod "github.com/celestiaorg/celestia-node/nodebuilder/das"
 	dasMock "github.com/celestiaorg/celestia-node/nodebuilder/das/mocks"
+	fraudmod "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	fraudMock "github.com/celestiaorg/celestia-node/nodebuilder/fraud/mocks"
+	headermod "github.com/celestiaorg/celestia-node/nodebuilder/header"
 	headerMock "github.com/celestiaorg/celestia-node/nodebuilder/header/mocks"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	sharemod "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	shareMock "github.com/celestiaorg/celestia-node/nodebuilder/share/mocks"
+	statemod "github.com/celestiaorg/celestia-node/nodebuilder/state"
 	stateMock "github.com/celestiaorg/celestia-node/nodebuilder/state/mocks"
+	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -28,22 +43,7 @@ func TestRPCCallsUnderlyingNode(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
 	nd, server := setupNodeWithModifiedRPC(t)
-	url := nd.RPCServer.ListenAddr()
-	// we need to run this a few times to prevent the race where the server is not yet started
-	var (
-		rpcClient *client.Client
-		err       error
-	)
-	for i := 0; i < 3; i++ {
-		time.Sleep(time.Second * 1)
-		rpcClient, err = client.NewClient(ctx, "http://"+url)
-		if err == nil {
-			t.Cleanup(rpcClient.Close)
-			break
-		}
-	}
-	require.NotNil(t, rpcClient)
-	require.NoError(t, err)
+	rpcClient := newTestClient(ctx, t, "http://"+nd.RPCServer.ListenAddr())
 
 	expectedBalance := &state.Balance{
 		Amount: sdk.NewInt(100),
@@ -57,18 +57,203 @@ func TestRPCCallsUnderlyingNode(t *testing.T) {
 	require.Equal(t, expectedBalance, balance)
 }
 
+func TestRPCTrustedHeader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	rpcClient := newTestClient(ctx, t, "http://"+nd.RPCServer.ListenAddr())
+
+	expected := &headermod.TrustedHeader{Height: 10, Hash: "AB"}
+	server.Header.EXPECT().TrustedHeader(gomock.Any()).Return(expected, nil).Times(1)
+
+	trusted, err := rpcClient.Header.TrustedHeader(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, trusted)
+}
+
+func TestRPCSubscriptionOverWebSocket(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	rpcClient := newTestClient(ctx, t, "ws://"+nd.RPCServer.ListenAddr())
+
+	expected := header.RandExtendedHeader(t)
+	headers := make(chan *header.ExtendedHeader, 1)
+	headers <- expected
+	close(headers)
+	server.Header.EXPECT().SubscribeHeaders(gomock.Any()).Return((<-chan *header.ExtendedHeader)(headers), nil)
+
+	sub, err := rpcClient.Header.SubscribeHeaders(ctx)
+	require.NoError(t, err)
+	select {
+	case h := <-sub:
+		require.NotNil(t, h)
+		require.Equal(t, expected.Height, h.Height)
+		require.Equal(t, expected.Hash(), h.Hash())
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for the subscribed header")
+	}
+}
+
+func TestRPCSubscribeNamespace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	rpcClient := newTestClient(ctx, t, "ws://"+nd.RPCServer.ListenAddr())
+
+	empty, expected := header.RandExtendedHeader(t), header.RandExtendedHeader(t)
+	headers := make(chan *header.ExtendedHeader, 2)
+	headers <- empty
+	headers <- expected
+	close(headers)
+	server.Header.EXPECT().SubscribeHeaders(gomock.Any()).Return((<-chan *header.ExtendedHeader)(headers), nil)
+
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	shares := []share.Share{append(nID, []byte("data")...)}
+	server.Share.EXPECT().GetSharesByNamespace(gomock.Any(), gomock.Any(), nID).Return(nil, nil)
+	server.Share.EXPECT().GetSharesByNamespace(gomock.Any(), gomock.Any(), nID).Return(shares, nil)
+
+	sub, err := rpcClient.SubscribeNamespace(ctx, nID)
+	require.NoError(t, err)
+	select {
+	case data := <-sub:
+		require.NotNil(t, data)
+		require.EqualValues(t, expected.Height, data.Height)
+		require.Equal(t, shares, data.Shares)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for the namespace data")
+	}
+}
+
+func TestAuthedRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	addr := "http://" + nd.RPCServer.ListenAddr()
+
+	tx := state.Tx("tx")
+	server.State.EXPECT().SubmitTx(gomock.Any(), tx).Return(&state.TxResponse{}, nil).Times(1)
+
+	// requests without a token can only read
+	rpcClient := newTestClient(ctx, t, addr)
+	_, err := rpcClient.State.SubmitTx(ctx, tx)
+	require.ErrorContains(t, err, "missing permission")
+
+	readToken, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.ReadPerms)
+	require.NoError(t, err)
+	rpcClient = newTestClientWithToken(ctx, t, addr, readToken)
+	_, err = rpcClient.State.SubmitTx(ctx, tx)
+	require.ErrorContains(t, err, "missing permission")
+
+	writeToken, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.ReadWritePerms)
+	require.NoError(t, err)
+	rpcClient = newTestClientWithToken(ctx, t, addr, writeToken)
+	_, err = rpcClient.State.SubmitTx(ctx, tx)
+	require.NoError(t, err)
+
+	// tokens not signed by the node are rejected
+	forged, err := client.NewClient(ctx, addr, writeToken+"invalid")
+	require.NoError(t, err)
+	t.Cleanup(forged.Close)
+	_, err = forged.State.SubmitTx(ctx, tx)
+	require.Error(t, err)
+}
+
+func TestRestrictedRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	addr := "http://" + nd.RPCServer.ListenAddr()
+
+	server.State.EXPECT().Balance(gomock.Any()).Return(&state.Balance{}, nil).Times(2)
+	server.Header.EXPECT().Head(gomock.Any()).Return(header.RandExtendedHeader(t), nil).Times(1)
+
+	token, err := authtoken.NewRestrictedJWT(nd.AdminSigner, perms.ReadPerms, authtoken.Restrictions{
+		Methods:   []string{"state.Balance", "header.*"},
+		RateLimit: 0.001,
+		Burst:     2,
+	})
+	require.NoError(t, err)
+	rpcClient := newTestClientWithToken(ctx, t, addr, token)
+
+	// only the listed methods can be called
+	err = rpcClient.Share.SharesAvailable(ctx, nil)
+	require.ErrorContains(t, err, rpc.ErrMethodNotAllowed.Error())
+
+	// the limit is shared by all the clients using the token
+	_, err = rpcClient.State.Balance(ctx)
+	require.NoError(t, err)
+	_, err = newTestClientWithToken(ctx, t, addr, token).Header.Head(ctx)
+	require.NoError(t, err)
+	_, err = rpcClient.State.Balance(ctx)
+	require.ErrorContains(t, err, rpc.ErrRateLimited.Error())
+
+	// other tokens are not limited
+	unrestricted, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.ReadPerms)
+	require.NoError(t, err)
+	_, err = newTestClientWithToken(ctx, t, addr, unrestricted).State.Balance(ctx)
+	require.NoError(t, err)
+
+	_, err = authtoken.NewRestrictedJWT(nd.AdminSigner, perms.ReadPerms, authtoken.Restrictions{
+		Methods: []string{"Balance"},
+	})
+	require.Error(t, err)
+}
+
+func TestRestrictedRPC_NoErrorResult(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	nd, server := setupNodeWithModifiedRPC(t)
+	addr := "http://" + nd.RPCServer.ListenAddr()
+
+	server.Header.EXPECT().IsSyncing(gomock.Any()).Return(true).Times(1)
+
+	token, err := authtoken.NewRestrictedJWT(nd.AdminSigner, perms.ReadPerms, authtoken.Restrictions{
+		Methods: []string{"header.IsSyncing"},
+	})
+	require.NoError(t, err)
+	rpcClient := newTestClientWithToken(ctx, t, addr, token)
+
+	// the methods without an error result are served as usual when allowed...
+	require.True(t, rpcClient.Header.IsSyncing(ctx))
+	// ...and are denied without reaching the node otherwise, which the client only sees as the
+	// zero value, while the response carries the error
+	require.False(t, rpcClient.State.IsStopped(ctx))
+
+	body := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"state.IsStopped","params":[]}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, body)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var res struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.NotNil(t, res.Error)
+	require.Contains(t, res.Error.Message, rpc.ErrMethodNotAllowed.Error())
+}
+
 func TestModulesImplementFullAPI(t *testing.T) {
 	api := reflect.TypeOf(new(client.API)).Elem()
 	client := reflect.TypeOf(new(client.Client)).Elem()
 	for i := 0; i < client.NumField(); i++ {
 		module := client.Field(i)
-		for j := 0; j < module.Type.NumField(); j++ {
-			impl := module.Type.Field(j)
-			method, _ := api.MethodByName(impl.Name)
-			// closers is the only thing on the Client struct that doesn't exist in the API
-			if impl.Name != "closers" {
-				require.Equal(t, method.Type, impl.Type, "method %s does not match", impl.Name)
-			}
+		// closer is the only thing on the Client struct that doesn't exist in the API
+		if module.Name == "closer" {
+			continue
+		}
+		internal, ok := module.Type.FieldByName("Internal")
+		require.True(t, ok, "module %s has no Internal struct", module.Name)
+		for j := 0; j < internal.Type.NumField(); j++ {
+			impl := internal.Type.Field(j)
+			method, ok := api.MethodByName(impl.Name)
+			require.True(t, ok, "method %s is not part of the API", impl.Name)
+			require.Equal(t, method.Type, impl.Type, "method %s does not match", impl.Name)
+			require.NotEmpty(t, impl.Tag.Get("perm"), "method %s has no required permission", impl.Name)
 		}
 	}
 }
@@ -140,16 +325,18 @@ func setupNodeWithModifiedRPC(t *testing.T) (*nodebuilder.Node, *mockAPI) {
 		fraudMock.NewMockModule(ctrl),
 		headerMock.NewMockModule(ctrl),
 		dasMock.NewMockModule(ctrl),
+		blobMock.NewMockModule(ctrl),
 	}
 
 	// given the behavior of fx.Invoke, this invoke will be called last as it is added at the root
 	// level module. For further information, check the documentation on fx.Invoke.
 	invokeRPC := fx.Invoke(func(srv *rpc.Server) {
-		srv.RegisterService("state", mockAPI.State)
-		srv.RegisterService("share", mockAPI.Share)
-		srv.RegisterService("fraud", mockAPI.Fraud)
-		srv.RegisterService("header", mockAPI.Header)
-		srv.RegisterService("das", mockAPI.Das)
+		srv.RegisterService("state", mockAPI.State, &statemod.API{})
+		srv.RegisterService("share", mockAPI.Share, &sharemod.API{})
+		srv.RegisterService("fraud", mockAPI.Fraud, &fraudmod.API{})
+		srv.RegisterService("header", mockAPI.Header, &headermod.API{})
+		srv.RegisterService("das", mockAPI.Das, &dasmod.API{})
+		srv.RegisterService("blob", mockAPI.Blob, &blobmod.API{})
 	})
 	nd := nodebuilder.TestNode(t, node.Full, invokeRPC)
 	// start node
@@ -162,10 +349,134 @@ func setupNodeWithModifiedRPC(t *testing.T) (*nodebuilder.Node, *mockAPI) {
 	return nd, mockAPI
 }
 
+// newTestClient dials the RPC server at the given address, retrying a few times to prevent
+// the race where the server is not yet started.
+func newTestClient(ctx context.Context, t *testing.T, addr string) *client.Client {
+	return newTestClientWithToken(ctx, t, addr, "")
+}
+
+func newTestClientWithToken(ctx context.Context, t *testing.T, addr, token string) *client.Client {
+	var (
+		rpcClient *client.Client
+		err       error
+	)
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second * 1)
+		rpcClient, err = client.NewClient(ctx, addr, token)
+		if err == nil {
+			t.Cleanup(rpcClient.Close)
+			break
+		}
+	}
+	require.NoError(t, err)
+	require.NotNil(t, rpcClient)
+	return rpcClient
+}
+
 type mockAPI struct {
 	State  *stateMock.MockModule
 	Share  *shareMock.MockModule
 	Fraud  *fraudMock.MockModule
 	Header *headerMock.MockModule
 	Das    *dasMock.MockModule
+	Blob   *blobMock.MockModule
+}
+
+func TestAdminRPC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	nd := nodebuilder.TestNode(t, node.Full)
+	err := nd.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = nd.Stop(ctx)
+		require.NoError(t, err)
+	})
+	addr := "http://" + nd.RPCServer.ListenAddr()
+
+	rpcClient := newTestClient(ctx, t, addr)
+	_, err = rpcClient.Node.ReloadableSettings(ctx)
+	require.ErrorContains(t, err, "missing permission")
+	// the keys are only exposed to admin clients
+	_, err = rpcClient.Keystore.ListKeys(ctx)
+	require.ErrorContains(t, err, "missing permission")
+	_, err = rpcClient.Keystore.ExportKeyArmor(ctx, "my_celes_key", "passphrase")
+	require.ErrorContains(t, err, "missing permission")
+	writeToken, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.ReadWritePerms)
+	require.NoError(t, err)
+	writeClient := newTestClientWithToken(ctx, t, addr, writeToken)
+	_, err = writeClient.Keystore.ExportKeyArmor(ctx, "my_celes_key", "passphrase")
+	require.ErrorContains(t, err, "missing permission")
+	// health is available to read-only clients
+	status, err := rpcClient.Node.Health(ctx)
+	require.NoError(t, err)
+	require.Contains(t, status.Services, "daser")
+	require.Contains(t, status.Services, "syncer")
+	require.Contains(t, status.Services, "state")
+
+	adminToken, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.AllPerms)
+	require.NoError(t, err)
+	rpcClient = newTestClientWithToken(ctx, t, addr, adminToken)
+	keys, err := rpcClient.Keystore.ListKeys(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, keys)
+	settings, err := rpcClient.Node.ReloadableSettings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"DASer.ConcurrencyLimit",
+		"Log",
+		"P2P.MutualPeers",
+		"Share.ServeBanDuration",
+		"Share.ServeBytesPerHour",
+		"Share.ServeRequestsPerMinute",
+	}, settings)
+
+	err = rpcClient.Node.ReloadConfig(ctx)
+	require.NoError(t, err)
+	err = rpcClient.Node.LogLevelSet(ctx, "node", "info")
+	require.NoError(t, err)
+	// diagnostics are only served when enabled
+	_, err = rpcClient.Node.GCStats(ctx)
+	require.ErrorContains(t, err, node.ErrDiagnosticsDisabled.Error())
+	err = rpcClient.Node.DatastoreGC(ctx)
+	require.NoError(t, err)
+	err = rpcClient.Node.DatastoreCompact(ctx)
+	require.NoError(t, err)
+}
+
+func TestAdminRPC_Diagnostics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg := nodebuilder.DefaultConfig(node.Full)
+	cfg.RPC.EnableDiagnostics = true
+	nd := nodebuilder.TestNodeWithConfig(t, node.Full, cfg)
+	err := nd.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = nd.Stop(ctx)
+		require.NoError(t, err)
+	})
+	addr := "http://" + nd.RPCServer.ListenAddr()
+
+	rpcClient := newTestClient(ctx, t, addr)
+	_, err = rpcClient.Node.GCStats(ctx)
+	require.ErrorContains(t, err, "missing permission")
+
+	adminToken, err := authtoken.NewSignedJWT(nd.AdminSigner, perms.AllPerms)
+	require.NoError(t, err)
+	rpcClient = newTestClientWithToken(ctx, t, addr, adminToken)
+	stats, err := rpcClient.Node.GCStats(ctx)
+	require.NoError(t, err)
+	require.NotZero(t, stats.NumGoroutine)
+	require.NotZero(t, stats.HeapAlloc)
+
+	dump, err := rpcClient.Node.Profile(ctx, "goroutine", 2)
+	require.NoError(t, err)
+	require.Contains(t, string(dump), "goroutine")
+	_, err = rpcClient.Node.Profile(ctx, "unknown", 0)
+	require.Error(t, err)
+
+	profile, err := rpcClient.Node.CPUProfile(ctx, time.Millisecond*100)
+	require.NoError(t, err)
+	require.NotEmpty(t, profile)
 }
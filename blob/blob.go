@@ -0,0 +1,14 @@
+package blob
+
+import (
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// Blob is the data submitted under a namespace along with the commitment it is included into the
+// block with.
+type Blob struct {
+	Namespace namespace.ID `json:"namespace"`
+	Data      []byte       `json:"data"`
+	// Commitment is the commitment over the Data the PayForData transaction includes the Blob with.
+	Commitment []byte `json:"commitment"`
+}
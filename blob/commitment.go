@@ -0,0 +1,104 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/minio/sha256-simd"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	appshares "github.com/celestiaorg/celestia-app/pkg/shares"
+	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// ErrEmptyBlob is returned on attempt to commit to a Blob without data.
+var ErrEmptyBlob = errors.New("blob: empty data")
+
+// Commitment is the commitment to the data of a Blob included into a block with the given square
+// size, i.e. the width of the original data square.
+type Commitment struct {
+	SquareSize uint64 `json:"square_size"`
+	Commitment []byte `json:"commitment"`
+}
+
+// CreateCommitment computes the commitments to the data under the namespace for all the square sizes
+// it fits in. As the square size of the block including the data is unknown until it is produced,
+// the PayForData transaction has to commit to each of them, and the block commits to the one
+// matching its square size.
+func CreateCommitment(nID namespace.ID, data []byte) ([]*Commitment, error) {
+	if err := apptypes.ValidateMessageNamespaceID(nID); err != nil {
+		return nil, fmt.Errorf("blob: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyBlob
+	}
+
+	squareSizes := apptypes.AllSquareSizes(len(data))
+	commitments := make([]*Commitment, len(squareSizes))
+	for i, squareSize := range squareSizes {
+		commitment, err := createCommitment(squareSize, nID, data)
+		if err != nil {
+			return nil, err
+		}
+		commitments[i] = &Commitment{
+			SquareSize: squareSize,
+			Commitment: commitment,
+		}
+	}
+	return commitments, nil
+}
+
+// createCommitment computes the commitment to the data under the namespace for the given square
+// size, following the rules of celestia-app:
+//   - the data is split into the shares of a single message, each prefixed with the namespace;
+//   - the shares are divided into the subtrees of a merkle mountain range, none wider than the
+//     square size, as they are laid out in the square according to the non-interactive defaults;
+//   - the commitment is the merkle root over the NMT roots of the subtrees.
+func createCommitment(squareSize uint64, nID namespace.ID, data []byte) ([]byte, error) {
+	msgs := []coretypes.Message{{NamespaceID: nID, Data: data}}
+	shares, err := appshares.SplitMessages(0, nil, msgs, false)
+	if err != nil {
+		return nil, err
+	}
+	// the message must start at the second row at the earliest, as at least one share of the first
+	// one is taken by the transaction paying for it
+	if maxShares := squareSize * (squareSize - 1); uint64(len(shares)) > maxShares {
+		return nil, fmt.Errorf("blob: data of %d shares exceeds max of %d for square size %d",
+			len(shares), maxShares, squareSize)
+	}
+
+	var (
+		subtreeRoots [][]byte
+		cursor       uint64
+	)
+	for _, size := range subtreeSizes(uint64(len(shares)), squareSize) {
+		tree := nmt.New(sha256.New())
+		for _, shr := range shares[cursor : cursor+size] {
+			leaf := append(append(make([]byte, 0, len(nID)+len(shr)), nID...), shr...)
+			if err := tree.Push(leaf); err != nil {
+				return nil, err
+			}
+		}
+		subtreeRoots = append(subtreeRoots, tree.Root())
+		cursor += size
+	}
+	return merkle.HashFromByteSlices(subtreeRoots), nil
+}
+
+// subtreeSizes returns the sizes of the subtrees of the merkle mountain range over the given amount
+// of shares, none exceeding maxSize.
+func subtreeSizes(total, maxSize uint64) []uint64 {
+	var sizes []uint64
+	for total != 0 {
+		size := maxSize
+		if total < maxSize {
+			size = appshares.RoundDownPowerOfTwo(total)
+		}
+		sizes = append(sizes, size)
+		total -= size
+	}
+	return sizes
+}
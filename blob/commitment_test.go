@@ -0,0 +1,63 @@
+package blob
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// TestCreateCommitment ensures the commitments match the ones celestia-app computes for all the
+// square sizes, and for data spanning from a single share to multiple rows.
+func TestCreateCommitment(t *testing.T) {
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	sizes := []int{
+		1,
+		appconsts.SparseShareContentSize,
+		appconsts.SparseShareContentSize + 1,
+		appconsts.SparseShareContentSize * 7,
+		appconsts.SparseShareContentSize * 100,
+	}
+	for _, size := range sizes {
+		data := bytes.Repeat([]byte{0xf}, size)
+		commitments, err := CreateCommitment(nID, data)
+		require.NoError(t, err)
+		require.Len(t, commitments, len(apptypes.AllSquareSizes(size)))
+
+		for _, c := range commitments {
+			expected, err := apptypes.CreateCommitment(c.SquareSize, nID, data)
+			require.NoError(t, err)
+			assert.Equal(t, expected, c.Commitment, "size %d, square size %d", size, c.SquareSize)
+		}
+	}
+}
+
+// TestCreateCommitment_Vector pins a commitment, so that changes of the commitment rules across
+// versions of celestia-app are noticed.
+func TestCreateCommitment_Vector(t *testing.T) {
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	data := bytes.Repeat([]byte{0xf}, appconsts.SparseShareContentSize*3)
+
+	commitment, err := createCommitment(4, nID, data)
+	require.NoError(t, err)
+	assert.Equal(t, "955509e3a6a6623b53c19ce9111d3ac084672cf6dc850d0a7f836f53a064ca14", hex.EncodeToString(commitment))
+}
+
+func TestCreateCommitment_Invalid(t *testing.T) {
+	_, err := CreateCommitment(namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}, nil)
+	assert.ErrorIs(t, err, ErrEmptyBlob)
+	_, err = CreateCommitment(appconsts.TxNamespaceID, []byte("data"))
+	assert.Error(t, err)
+	_, err = CreateCommitment(namespace.ID{1, 2, 3}, []byte("data"))
+	assert.Error(t, err)
+
+	// the data does not fit into the square
+	_, err = createCommitment(2, namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}, make([]byte, appconsts.ShareSize*4))
+	assert.Error(t, err)
+}
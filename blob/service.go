@@ -0,0 +1,94 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+	"golang.org/x/sync/errgroup"
+
+	appshares "github.com/celestiaorg/celestia-app/pkg/shares"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var log = logging.Logger("blob")
+
+// Service retrieves the Blobs included into the blocks.
+type Service struct {
+	// shares gets the shares of the namespaces the Blobs are parsed from.
+	shares share.Getter
+	// headers resolves the heights of the requested blocks to their Roots.
+	headers header.Getter
+}
+
+// NewService creates a new Service.
+func NewService(shares share.Getter, headers header.Getter) *Service {
+	return &Service{
+		shares:  shares,
+		headers: headers,
+	}
+}
+
+// GetAll returns all the Blobs under the given namespaces included into the block at the given
+// height. The Blobs are ordered as the namespaces are, and within a namespace as they are in the
+// block.
+func (s *Service) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*Blob, error) {
+	h, err := s.headers.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([][]*Blob, len(nIDs))
+	errGroup, ctx := errgroup.WithContext(ctx)
+	for i, nID := range nIDs {
+		i, nID := i, nID
+		errGroup.Go(func() error {
+			var err error
+			blobs[i], err = s.getBlobs(ctx, h.DAH, nID)
+			if err != nil {
+				log.Debugw("getting blobs", "height", height, "namespace", nID.String(), "err", err)
+			}
+			return err
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	var all []*Blob
+	for _, b := range blobs {
+		all = append(all, b...)
+	}
+	return all, nil
+}
+
+// getBlobs gets the shares of the namespace and parses them back into the Blobs, skipping the
+// padding between them.
+func (s *Service) getBlobs(ctx context.Context, root *share.Root, nID namespace.ID) ([]*Blob, error) {
+	shares, err := s.shares.GetSharesByNamespace(ctx, root, nID)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := appshares.ParseMsgs(shares)
+	if err != nil {
+		return nil, fmt.Errorf("blob: parsing shares of namespace %s: %w", nID, err)
+	}
+
+	squareSize := uint64(len(root.RowsRoots) / 2)
+	blobs := make([]*Blob, len(msgs.MessagesList))
+	for i, msg := range msgs.MessagesList {
+		commitment, err := createCommitment(squareSize, msg.NamespaceID, msg.Data)
+		if err != nil {
+			return nil, fmt.Errorf("blob: creating commitment in namespace %s: %w", nID, err)
+		}
+		blobs[i] = &Blob{
+			Namespace:  msg.NamespaceID,
+			Data:       msg.Data,
+			Commitment: commitment,
+		}
+	}
+	return blobs, nil
+}
@@ -0,0 +1,80 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	appshares "github.com/celestiaorg/celestia-app/pkg/shares"
+	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/getters"
+)
+
+func TestService_GetAll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nIDs := []namespace.ID{
+		{1, 1, 1, 1, 1, 1, 1, 1},
+		{2, 2, 2, 2, 2, 2, 2, 2},
+		{3, 3, 3, 3, 3, 3, 3, 3},
+	}
+	msgs := []coretypes.Message{
+		// spans multiple shares
+		{NamespaceID: nIDs[0], Data: bytes.Repeat([]byte{0xa}, appconsts.ShareSize*2)},
+		{NamespaceID: nIDs[0], Data: []byte("second blob of the namespace")},
+		{NamespaceID: nIDs[1], Data: []byte("blob")},
+	}
+	// the second message of the first namespace is padded to start at the next row
+	msgShares, err := appshares.SplitMessages(0, []uint32{0, 4, 5}, msgs, true)
+	require.NoError(t, err)
+	shares := appshares.ToBytes(msgShares)
+	shares = append(shares, appshares.ToBytes(appshares.TailPaddingShares(16-len(shares)))...)
+
+	bServ := mdutils.Bserv()
+	square, err := share.AddShares(ctx, shares, bServ)
+	require.NoError(t, err)
+	root := da.NewDataAvailabilityHeader(square)
+
+	eh := header.RandExtendedHeader(t)
+	eh.DAH = &root
+	serv := NewService(getters.NewIPLDGetter(bServ), &headerGetter{eh: eh})
+
+	blobs, err := serv.GetAll(ctx, uint64(eh.Height), nIDs)
+	require.NoError(t, err)
+	require.Len(t, blobs, len(msgs))
+	for i, msg := range msgs {
+		assert.Equal(t, msg.NamespaceID, blobs[i].Namespace)
+		assert.Equal(t, msg.Data, blobs[i].Data)
+
+		commitment, err := apptypes.CreateCommitment(4, msg.NamespaceID, msg.Data)
+		require.NoError(t, err)
+		assert.Equal(t, commitment, blobs[i].Commitment)
+	}
+
+	// namespaces without blobs add none
+	blobs, err = serv.GetAll(ctx, uint64(eh.Height), nIDs[2:])
+	require.NoError(t, err)
+	assert.Empty(t, blobs)
+}
+
+// headerGetter serves the single ExtendedHeader by height.
+type headerGetter struct {
+	header.Getter
+	eh *header.ExtendedHeader
+}
+
+func (g *headerGetter) GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error) {
+	return g.eh, nil
+}
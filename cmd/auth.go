@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
+)
+
+// AuthCmd constructs a CLI command to mint an RPC auth token of the given permission
+// tier, signed with the secret of the Celestia Node under the store path. The token can be
+// restricted to some methods and rate limited, e.g. for one of many tenants of the node.
+func AuthCmd(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "auth [permission-level (e.g. read || write || admin)]",
+		Short:        "Signs and returns an RPC auth token with the given permission level.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if !nodebuilder.IsInit(StorePath(ctx)) {
+				return fmt.Errorf("cmd: node store at '%s' is not initialized", StorePath(ctx))
+			}
+
+			// the keystore is opened directly, as the store may be locked by the running node
+			ks, err := nodebuilder.OpenKeystore(StorePath(ctx))
+			if err != nil {
+				return err
+			}
+
+			restrictions, err := parseRestrictions(cmd)
+			if err != nil {
+				return err
+			}
+			token, err := rpc.NewRestrictedToken(ks, auth.Permission(args[0]), restrictions)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), token)
+			return nil
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	cmd.Flags().StringSlice(
+		authMethodsFlag,
+		nil,
+		"Restricts the token to the given methods, as <namespace>.<Method> (e.g. share.GetShare) or <namespace>.*",
+	)
+	cmd.Flags().Float64(
+		authRateLimitFlag,
+		0,
+		"Limits the calls made with the token per second. Unlimited if 0",
+	)
+	cmd.Flags().Int(
+		authBurstFlag,
+		0,
+		"Sets the amount of calls the token can make at once. Defaults to the rate limit",
+	)
+	return cmd
+}
+
+const (
+	authMethodsFlag   = "methods"
+	authRateLimitFlag = "rate-limit"
+	authBurstFlag     = "burst"
+)
+
+// parseRestrictions parses the restrictions of the token from the flags of the auth command.
+func parseRestrictions(cmd *cobra.Command) (authtoken.Restrictions, error) {
+	var (
+		r   authtoken.Restrictions
+		err error
+	)
+	r.Methods, err = cmd.Flags().GetStringSlice(authMethodsFlag)
+	if err != nil {
+		return r, err
+	}
+	r.RateLimit, err = cmd.Flags().GetFloat64(authRateLimitFlag)
+	if err != nil {
+		return r, err
+	}
+	r.Burst, err = cmd.Flags().GetInt(authBurstFlag)
+	if err != nil {
+		return r, err
+	}
+	return r, r.Validate()
+}
@@ -6,6 +6,7 @@ import (
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/grpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
@@ -24,6 +25,17 @@ func init() {
 			cmdnode.MiscFlags(),
 			rpc.Flags(),
 			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.AuthCmd(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			core.Flags(),
+			cmdnode.MiscFlags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
 			state.Flags(),
 		),
 		cmdnode.Start(
@@ -33,6 +45,37 @@ func init() {
 			cmdnode.MiscFlags(),
 			rpc.Flags(),
 			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.ResetStore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			core.Flags(),
+			cmdnode.MiscFlags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.PurgeBlockstore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			core.Flags(),
+			cmdnode.MiscFlags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.RestoreDatastore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			core.Flags(),
+			cmdnode.MiscFlags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
 			state.Flags(),
 		),
 	)
@@ -80,6 +123,7 @@ var bridgeCmd = &cobra.Command{
 
 		rpc.ParseFlags(cmd, &cfg.RPC)
 		gateway.ParseFlags(cmd, &cfg.Gateway)
+		grpc.ParseFlags(cmd, &cfg.GRPC)
 		state.ParseFlags(cmd, &cfg.State)
 
 		// set config
@@ -4,9 +4,15 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 )
 
 func TestLight(t *testing.T) {
@@ -75,6 +81,30 @@ func TestBridge(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("purge-blockstore", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		rootCmd.SetOut(output)
+		rootCmd.SetArgs([]string{
+			"bridge",
+			"--node.store", ".celestia-bridge",
+			"purge-blockstore",
+		})
+		err := rootCmd.ExecuteContext(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("unsafe-reset-store", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		rootCmd.SetOut(output)
+		rootCmd.SetArgs([]string{
+			"bridge",
+			"--node.store", ".celestia-bridge",
+			"unsafe-reset-store",
+		})
+		err := rootCmd.ExecuteContext(context.Background())
+		require.NoError(t, err)
+	})
+
 	t.Cleanup(func() {
 		if err := os.Chdir(testDir); err != nil {
 			t.Error("error resetting:", err)
@@ -100,3 +130,50 @@ func TestBridge(t *testing.T) {
 			})
 	*/
 }
+
+// TestAuth tests that the tokens minted with the auth command authenticate against the running
+// node, which holds the lock of the store, with the store encrypted.
+func TestAuth(t *testing.T) {
+	t.Setenv(nodebuilder.EnvStorePassphrase, "passphrase")
+	path := filepath.Join(t.TempDir(), ".celestia-bridge")
+
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{
+		"bridge",
+		"--node.store", path,
+		"init",
+	})
+	err := rootCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(path, "config.toml")
+	cfg, err := nodebuilder.LoadConfig(cfgPath)
+	require.NoError(t, err)
+	cfg.Node.EncryptStore = true
+	err = nodebuilder.SaveConfig(cfgPath, cfg)
+	require.NoError(t, err)
+
+	// get the secret the same way the node does on start
+	store, err := nodebuilder.OpenStore(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	ks, err := store.Keystore()
+	require.NoError(t, err)
+	signer, err := rpc.Secret(ks)
+	require.NoError(t, err)
+
+	output := &bytes.Buffer{}
+	rootCmd.SetOut(output)
+	rootCmd.SetArgs([]string{
+		"bridge",
+		"--node.store", path,
+		"auth", "admin",
+	})
+	err = rootCmd.ExecuteContext(context.Background())
+	require.NoError(t, err)
+
+	_, err = authtoken.ExtractSignedPayload(signer, strings.TrimSpace(output.String()))
+	require.NoError(t, err)
+}
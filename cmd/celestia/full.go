@@ -7,6 +7,7 @@ import (
 	cmdnode "github.com/celestiaorg/celestia-node/cmd"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/grpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
@@ -29,6 +30,20 @@ func init() {
 			core.Flags(),
 			rpc.Flags(),
 			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.AuthCmd(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			header.Flags(),
+			cmdnode.MiscFlags(),
+			// NOTE: for now, state-related queries can only be accessed
+			// over an RPC connection with a celestia-core node.
+			core.Flags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
 			state.Flags(),
 		),
 		cmdnode.Start(
@@ -41,6 +56,46 @@ func init() {
 			core.Flags(),
 			rpc.Flags(),
 			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.ResetStore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			header.Flags(),
+			cmdnode.MiscFlags(),
+			// NOTE: for now, state-related queries can only be accessed
+			// over an RPC connection with a celestia-core node.
+			core.Flags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.PurgeBlockstore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			header.Flags(),
+			cmdnode.MiscFlags(),
+			// NOTE: for now, state-related queries can only be accessed
+			// over an RPC connection with a celestia-core node.
+			core.Flags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
+			state.Flags(),
+		),
+		cmdnode.RestoreDatastore(
+			cmdnode.NodeFlags(),
+			p2p.Flags(),
+			header.Flags(),
+			cmdnode.MiscFlags(),
+			// NOTE: for now, state-related queries can only be accessed
+			// over an RPC connection with a celestia-core node.
+			core.Flags(),
+			rpc.Flags(),
+			gateway.Flags(),
+			grpc.Flags(),
 			state.Flags(),
 		),
 	)
@@ -93,6 +148,7 @@ var fullCmd = &cobra.Command{
 
 		rpc.ParseFlags(cmd, &cfg.RPC)
 		gateway.ParseFlags(cmd, &cfg.Gateway)
+		grpc.ParseFlags(cmd, &cfg.GRPC)
 		state.ParseFlags(cmd, &cfg.State)
 
 		// set config
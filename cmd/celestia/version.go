@@ -2,15 +2,10 @@ package main
 
 import (
 	"fmt"
-	"runtime"
 
 	"github.com/spf13/cobra"
-)
 
-var (
-	buildTime       string
-	lastCommit      string
-	semanticVersion string
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
 var versionCmd = &cobra.Command{
@@ -21,9 +16,10 @@ var versionCmd = &cobra.Command{
 }
 
 func printBuildInfo(_ *cobra.Command, _ []string) {
-	fmt.Printf("Semantic version: %s\n", semanticVersion)
-	fmt.Printf("Commit: %s\n", lastCommit)
-	fmt.Printf("Build Date: %s\n", buildTime)
-	fmt.Printf("System version: %s/%s\n", runtime.GOARCH, runtime.GOOS)
-	fmt.Printf("Golang version: %s\n", runtime.Version())
+	info := node.GetBuildInfo()
+	fmt.Printf("Semantic version: %s\n", info.SemanticVersion)
+	fmt.Printf("Commit: %s\n", info.LastCommit)
+	fmt.Printf("Build Date: %s\n", info.BuildTime)
+	fmt.Printf("System version: %s\n", info.SystemVersion)
+	fmt.Printf("Golang version: %s\n", info.GolangVersion)
 }
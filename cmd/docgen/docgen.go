@@ -9,12 +9,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/celestiaorg/celestia-node/api/docgen"
+	"github.com/celestiaorg/celestia-node/api/gateway"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "docgen [packages]",
 	Short: "docgen generates the openrpc documentation for Celestia Node packages",
+	// the packages are not subcommands
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, moduleNames []string) error {
 		// 1. Open the respective nodebuilder/X/service.go files for AST parsing
 		nodeComments := docgen.ParseCommentsFromNodebuilderModules(moduleNames...)
@@ -47,6 +50,24 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "openapi generates the OpenAPI spec of the gateway",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		spec, err := gateway.OpenAPI()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(spec)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openAPICmd)
+}
+
 func main() {
 	err := run()
 	if err != nil {
@@ -7,7 +7,6 @@ import (
 	"net/http/pprof"
 	"strings"
 
-	logging "github.com/ipfs/go-log/v2"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 	"go.opentelemetry.io/otel"
@@ -19,11 +18,13 @@ import (
 
 	"github.com/celestiaorg/celestia-node/logs"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/metrics"
 )
 
 var (
 	logLevelFlag        = "log.level"
 	logLevelModuleFlag  = "log.level.module"
+	logFormatFlag       = "log.format"
 	pprofFlag           = "pprof"
 	tracingFlag         = "tracing"
 	tracingEndpointFlag = "tracing.endpoint"
@@ -31,6 +32,8 @@ var (
 	metricsFlag         = "metrics"
 	metricsEndpointFlag = "metrics.endpoint"
 	metricsTlS          = "metrics.tls"
+	metricsAddressFlag  = "metrics.address"
+	replayRecordFlag    = "replay.record"
 )
 
 // MiscFlags gives a set of hardcoded miscellaneous flags.
@@ -39,15 +42,22 @@ func MiscFlags() *flag.FlagSet {
 
 	flags.String(
 		logLevelFlag,
-		"INFO",
+		"",
 		`DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL
-and their lower-case forms`,
+and their lower-case forms. Overrides Log.Level of the config (default: INFO)`,
 	)
 
 	flags.StringSlice(
 		logLevelModuleFlag,
 		nil,
-		"<module>:<level>, e.g. pubsub:debug",
+		"<module>:<level>, e.g. pubsub:debug. Adds to Log.Modules of the config",
+	)
+
+	flags.String(
+		logFormatFlag,
+		"",
+		fmt.Sprintf("Sets the format of the log output: %s, %s or %s. Overrides Log.Format of the config",
+			logs.ColorizedFormat, logs.PlaintextFormat, logs.JSONFormat),
 	)
 
 	flags.Bool(
@@ -77,7 +87,7 @@ and their lower-case forms`,
 	flags.Bool(
 		metricsFlag,
 		false,
-		"Enables OTLP metrics with HTTP exporter",
+		"Enables OTLP metrics with HTTP exporter and the Prometheus metrics endpoint",
 	)
 
 	flags.String(
@@ -92,35 +102,56 @@ and their lower-case forms`,
 		"Enable TLS connection to OTLP metric backend",
 	)
 
+	flags.String(
+		metricsAddressFlag,
+		metrics.DefaultAddress,
+		"Sets the listen address of the Prometheus metrics endpoint. Depends on '--metrics'",
+	)
+
+	flags.String(
+		replayRecordFlag,
+		"",
+		"Records the headers and the blocks of shares got from the network to the given trace file, "+
+			"so that they can be replayed offline",
+	)
+
 	return flags
 }
 
 // ParseMiscFlags parses miscellaneous flags from the given cmd and applies values to Env.
 func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, error) {
+	// the flags override the logging config only on start and are not persisted
+	logCfg := NodeConfig(ctx).Log
 	logLevel := cmd.Flag(logLevelFlag).Value.String()
 	if logLevel != "" {
-		level, err := logging.LevelFromString(logLevel)
-		if err != nil {
-			return ctx, fmt.Errorf("cmd: while parsing '%s': %w", logLevelFlag, err)
-		}
-
-		logs.SetAllLoggers(level)
+		logCfg.Level = logLevel
 	}
 
 	logModules, err := cmd.Flags().GetStringSlice(logLevelModuleFlag)
 	if err != nil {
 		panic(err)
 	}
+	modules := make(map[string]string, len(logCfg.Modules)+len(logModules))
+	for module, level := range logCfg.Modules {
+		modules[module] = level
+	}
 	for _, ll := range logModules {
 		params := strings.Split(ll, ":")
 		if len(params) != 2 {
 			return ctx, fmt.Errorf("cmd: %s arg must be in form <module>:<level>, e.g. pubsub:debug", logLevelModuleFlag)
 		}
+		modules[params[0]] = params[1]
+	}
+	logCfg.Modules = modules
 
-		err := logging.SetLogLevel(params[0], params[1])
-		if err != nil {
-			return ctx, err
-		}
+	logFormat := cmd.Flag(logFormatFlag).Value.String()
+	if logFormat != "" {
+		logCfg.Format = logFormat
+	}
+
+	err = logs.Apply(logCfg)
+	if err != nil {
+		return ctx, fmt.Errorf("cmd: while applying the logging config: %w", err)
 	}
 
 	ok, err := cmd.Flags().GetBool(pprofFlag)
@@ -198,7 +229,14 @@ func ParseMiscFlags(ctx context.Context, cmd *cobra.Command) (context.Context, e
 			opts = append(opts, otlpmetrichttp.WithInsecure())
 		}
 
-		ctx = WithNodeOptions(ctx, nodebuilder.WithMetrics(opts, NodeType(ctx)))
+		ctx = WithNodeOptions(ctx,
+			nodebuilder.WithMetrics(opts, NodeType(ctx)),
+			nodebuilder.WithPrometheusMetrics(cmd.Flag(metricsAddressFlag).Value.String(), NodeType(ctx)),
+		)
+	}
+
+	if path := cmd.Flag(replayRecordFlag).Value.String(); path != "" {
+		ctx = WithNodeOptions(ctx, nodebuilder.WithTraceRecording(path))
 	}
 
 	return ctx, err
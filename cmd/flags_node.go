@@ -10,13 +10,17 @@ import (
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 var (
-	nodeStoreFlag  = "node.store"
-	nodeConfigFlag = "node.config"
+	nodeStoreFlag    = "node.store"
+	nodeConfigFlag   = "node.config"
+	nodeReadOnlyFlag = "node.read-only"
+	// nodeSkipPreflightFlag is only read on start
+	nodeSkipPreflightFlag = "node.skip-preflight"
 )
 
 // NodeFlags gives a set of hardcoded Node package flags.
@@ -33,6 +37,18 @@ func NodeFlags() *flag.FlagSet {
 		"",
 		"Path to a customized node config TOML file",
 	)
+	flags.Bool(
+		nodeReadOnlyFlag,
+		false,
+		"Run the node purely from its existing store without any networking, e.g. to analyze a snapshot "+
+			"of the store offline",
+	)
+	flags.Bool(
+		nodeSkipPreflightFlag,
+		false,
+		"Skip the checks of the environment before starting the node, e.g. of the clock skew, the ports "+
+			"and the Core endpoint reachability",
+	)
 
 	return flags
 }
@@ -62,10 +78,28 @@ func ParseNodeFlags(ctx context.Context, cmd *cobra.Command, network p2p.Network
 		if err != nil {
 			return ctx, err
 		}
-		cfg, err := nodebuilder.LoadConfig(filepath.Join(expanded, "config.toml"))
+		cfgPath := filepath.Join(expanded, "config.toml")
+		if utils.Exists(cfgPath) {
+			// upgrade the config stored by a previous version of the node, if needed
+			_, err = nodebuilder.MigrateConfig(cfgPath, NodeType(ctx))
+			if err != nil {
+				return ctx, err
+			}
+		}
+		cfg, err := nodebuilder.LoadConfig(cfgPath)
 		if err == nil {
 			ctx = WithNodeConfig(ctx, cfg)
 		}
 	}
+
+	if cmd.Flag(nodeReadOnlyFlag).Changed {
+		readOnly, err := cmd.Flags().GetBool(nodeReadOnlyFlag)
+		if err != nil {
+			return ctx, err
+		}
+		cfg := NodeConfig(ctx)
+		cfg.Node.ReadOnly = readOnly
+		ctx = WithNodeConfig(ctx, &cfg)
+	}
 	return ctx, nil
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+)
+
+// ResetStore constructs a CLI command to erase all the data of Celestia Node of any type, while
+// keeping its config and keys.
+func ResetStore(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unsafe-reset-store",
+		Short: "Erases all the data of Celestia Node, keeping its config and keys.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return nodebuilder.Reset(StorePath(cmd.Context()))
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
+
+// PurgeBlockstore constructs a CLI command to delete all the blocks stored by Celestia Node of
+// any type, while keeping the rest of its data.
+func PurgeBlockstore(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge-blockstore",
+		Short: "Deletes all the blocks stored by Celestia Node, keeping the rest of its data.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			return nodebuilder.PurgeBlockstore(ctx, StorePath(ctx))
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
+
+// RestoreDatastore constructs a CLI command to replace the data of Celestia Node of any type with
+// a backup of its datastore, while the Node is stopped.
+func RestoreDatastore(fsets ...*flag.FlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-datastore [backup]",
+		Short: "Replaces the data of stopped Celestia Node with the backup, relative to Node.BackupDir.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			return nodebuilder.RestoreDatastore(ctx, StorePath(ctx), args[0])
+		},
+	}
+	for _, set := range fsets {
+		cmd.Flags().AddFlagSet(set)
+	}
+	return cmd
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
@@ -15,7 +19,8 @@ func Start(fsets ...*flag.FlagSet) *cobra.Command {
 	cmd := &cobra.Command{
 		Use: "start",
 		Short: `Starts Node daemon. First stopping signal gracefully stops the Node and second terminates it.
-Options passed on start override configuration options only on start and are not persisted in config.`,
+Options passed on start override configuration options only on start and are not persisted in config.
+Sending SIGHUP reloads the settings of the stored config which can be changed without restarting the Node.`,
 		Aliases:      []string{"run", "daemon"},
 		Args:         cobra.NoArgs,
 		SilenceUsage: true,
@@ -29,6 +34,13 @@ Options passed on start override configuration options only on start and are not
 			// override config with all modifiers passed on start
 			cfg := NodeConfig(ctx)
 
+			if skip := cmd.Flag(nodeSkipPreflightFlag); skip == nil || skip.Value.String() != "true" {
+				err = preflight(cmd, store, &cfg)
+				if err != nil {
+					return err
+				}
+			}
+
 			nd, err := nodebuilder.NewWithConfig(NodeType(ctx), Network(ctx), store, &cfg, NodeOptions(ctx)...)
 			if err != nil {
 				return err
@@ -41,6 +53,35 @@ Options passed on start override configuration options only on start and are not
 				return err
 			}
 
+			addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(nd.Host))
+			if err != nil {
+				log.Errorw("Retrieving multiaddress information", "err", err)
+				return err
+			}
+			fmt.Println("The p2p host is listening on:")
+			for _, addr := range addrs {
+				fmt.Println("* ", addr.String())
+			}
+			fmt.Println()
+
+			// reload the reloadable settings of the config on SIGHUP
+			reloadCh := make(chan os.Signal, 1)
+			signal.Notify(reloadCh, syscall.SIGHUP)
+			defer signal.Stop(reloadCh)
+			go func() {
+				for {
+					select {
+					case <-reloadCh:
+						err := nd.Reloader.Reload(ctx)
+						if err != nil {
+							log.Errorw("reloading config", "err", err)
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+
 			<-ctx.Done()
 			cancel() // ensure we stop reading more signals for start context
 
@@ -59,3 +100,23 @@ Options passed on start override configuration options only on start and are not
 	}
 	return cmd
 }
+
+// preflight checks the environment of the node, printing the issues found, and fails if any of them
+// would leave the node half-broken.
+func preflight(cmd *cobra.Command, store nodebuilder.Store, cfg *nodebuilder.Config) error {
+	ctx := cmd.Context()
+	var fatal int
+	for _, issue := range nodebuilder.Preflight(ctx, NodeType(ctx), cfg, store) {
+		if issue.Fatal {
+			fatal++
+			fmt.Fprintln(cmd.ErrOrStderr(), "ERROR", issue)
+			continue
+		}
+		fmt.Fprintln(cmd.ErrOrStderr(), "WARNING", issue)
+	}
+	if fatal > 0 {
+		return fmt.Errorf("cmd: %d preflight check(s) failed, fix the issues above or pass --%s to start anyway",
+			fatal, nodeSkipPreflightFlag)
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package core
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
+	nethttp "net/http"
 
 	retryhttp "github.com/hashicorp/go-retryablehttp"
 
@@ -12,16 +15,102 @@ import (
 // Client is an alias to Core Client.
 type Client = client.Client
 
-// NewRemote creates a new Client that communicates with a remote Core endpoint over HTTP.
-func NewRemote(ip, port string) (Client, error) {
+// Endpoint is the address of the RPC and gRPC endpoints of a Core node.
+type Endpoint struct {
+	IP       string
+	RPCPort  string
+	GRPCPort string
+}
+
+// RemoteOption configures the Client created by NewRemote.
+type RemoteOption func(*remoteOptions)
+
+type remoteOptions struct {
+	tls   *tls.Config
+	token string
+}
+
+// WithTLS secures the connection to the Core endpoint with TLS of the given configuration.
+func WithTLS(cfg *tls.Config) RemoteOption {
+	return func(o *remoteOptions) {
+		o.tls = cfg
+	}
+}
+
+// WithAuthToken authorizes every request to the Core endpoint with the bearer token. The token
+// requires TLS to be enabled, so that it is never sent in plain text.
+func WithAuthToken(token string) RemoteOption {
+	return func(o *remoteOptions) {
+		o.token = token
+	}
+}
+
+// NewRemote creates a new Client that communicates with a remote Core endpoint over HTTP, or over
+// HTTPS if TLS is enabled.
+func NewRemote(ip, port string, options ...RemoteOption) (Client, error) {
+	var opts remoteOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.tls == nil && opts.token != "" {
+		return nil, errors.New("core: auth token requires TLS")
+	}
+
 	httpClient := retryhttp.NewClient()
 	httpClient.RetryMax = 2
 	// suppress logging
 	httpClient.Logger = nil
 
-	return http.NewWithClient(
-		fmt.Sprintf("tcp://%s:%s", ip, port),
+	if opts.tls == nil {
+		return http.NewWithClient(
+			fmt.Sprintf("tcp://%s:%s", ip, port),
+			"/websocket",
+			httpClient.StandardClient(),
+		)
+	}
+
+	var transport nethttp.RoundTripper = &nethttp.Transport{
+		TLSClientConfig:    opts.tls.Clone(),
+		DisableCompression: true,
+	}
+	if opts.token != "" {
+		transport = &tokenTransport{token: opts.token, base: transport}
+	}
+	httpClient.HTTPClient.Transport = transport
+	return newTLSClient(
+		fmt.Sprintf("https://%s:%s", ip, port),
 		"/websocket",
 		httpClient.StandardClient(),
+		opts.tls,
 	)
 }
+
+// NewRemoteWithFailover creates a new Client to the first given endpoint, which fails over to the
+// next healthy one in the given order whenever the endpoint in use becomes unhealthy.
+// If only one endpoint is given, it is equivalent to NewRemote.
+func NewRemoteWithFailover(endpoints []Endpoint, options ...RemoteOption) (Client, error) {
+	clients := make([]Client, len(endpoints))
+	for i, endpoint := range endpoints {
+		cl, err := NewRemote(endpoint.IP, endpoint.RPCPort, options...)
+		if err != nil {
+			return nil, err
+		}
+		clients[i] = cl
+	}
+	if len(clients) == 1 {
+		return clients[0], nil
+	}
+	return NewFailoverClient(clients...)
+}
+
+// tokenTransport authorizes every request with the bearer token.
+type tokenTransport struct {
+	token string
+	base  nethttp.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,428 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	// healthCheckInterval is the interval at which the health of the active endpoint is checked.
+	healthCheckInterval = time.Second * 5
+	// healthCheckTimeout is the time an endpoint has to report its health.
+	healthCheckTimeout = time.Second * 5
+)
+
+// failoverClient is a Client over multiple Core endpoints. It forwards all the requests to the
+// active endpoint, which is health checked periodically. Once the active endpoint becomes
+// unhealthy, the failoverClient switches over to the next healthy one, moving all the event
+// subscriptions along.
+type failoverClient struct {
+	service.BaseService
+
+	clients []Client
+	active  int32
+
+	subsLk sync.Mutex
+	subs   map[subscriptionKey]*subscription
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type subscriptionKey struct {
+	subscriber, query string
+}
+
+// subscription pipes events from the active endpoint into the channel handed out to the
+// subscriber, so that it stays valid across failovers.
+type subscription struct {
+	out    chan ctypes.ResultEvent
+	cancel context.CancelFunc
+	// client is the Client of the endpoint the subscription is currently made on.
+	client Client
+}
+
+// NewFailoverClient creates a new Client switching over between the given Clients in the given
+// order whenever the active one becomes unhealthy.
+func NewFailoverClient(clients ...Client) (Client, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("core: no clients to fail over between")
+	}
+
+	f := &failoverClient{
+		clients: clients,
+		subs:    make(map[subscriptionKey]*subscription),
+	}
+	f.BaseService = *service.NewBaseService(nil, "FailoverClient", f)
+	return f, nil
+}
+
+func (f *failoverClient) OnStart() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	// pick the first healthy endpoint to start with
+	active := -1
+	for i, cl := range f.clients {
+		if f.ready(ctx, cl) {
+			active = i
+			break
+		}
+	}
+	if active == -1 {
+		cancel()
+		return fmt.Errorf("core: no healthy endpoint")
+	}
+
+	atomic.StoreInt32(&f.active, int32(active))
+	f.cancel, f.done = cancel, make(chan struct{})
+	go f.healthCheck(ctx)
+	return nil
+}
+
+func (f *failoverClient) OnStop() {
+	f.cancel()
+	<-f.done
+
+	f.subsLk.Lock()
+	for key, sub := range f.subs {
+		f.unpipe(context.Background(), key, sub)
+		delete(f.subs, key)
+	}
+	f.subsLk.Unlock()
+
+	for _, cl := range f.clients {
+		if !cl.IsRunning() {
+			continue
+		}
+		err := cl.Stop()
+		if err != nil {
+			log.Errorw("stopping core client", "err", err)
+		}
+	}
+}
+
+// client returns the Client of the active endpoint.
+func (f *failoverClient) client() Client {
+	return f.clients[atomic.LoadInt32(&f.active)]
+}
+
+// healthCheck periodically checks the health of the active endpoint and fails over to the next
+// healthy one if it is not.
+func (f *failoverClient) healthCheck(ctx context.Context) {
+	defer close(f.done)
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			active := atomic.LoadInt32(&f.active)
+			if f.healthy(ctx, f.clients[active]) {
+				continue
+			}
+			log.Warnw("core endpoint is unhealthy", "endpoint", active)
+			f.failover(ctx, active)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ready ensures the given Client is started and its endpoint is healthy.
+// Clients of endpoints that were unreachable on start are started lazily.
+func (f *failoverClient) ready(ctx context.Context, cl Client) bool {
+	if !cl.IsRunning() {
+		err := cl.Start()
+		if err != nil {
+			log.Debugw("starting core client", "err", err)
+			return false
+		}
+	}
+	return f.healthy(ctx, cl)
+}
+
+func (f *failoverClient) healthy(ctx context.Context, cl Client) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	_, err := cl.Health(ctx)
+	return err == nil
+}
+
+// failover switches over to the first healthy endpoint following the given unhealthy one.
+func (f *failoverClient) failover(ctx context.Context, unhealthy int32) {
+	for i := 1; i < len(f.clients); i++ {
+		next := (int(unhealthy) + i) % len(f.clients)
+		if !f.ready(ctx, f.clients[next]) {
+			continue
+		}
+
+		f.subsLk.Lock()
+		atomic.StoreInt32(&f.active, int32(next))
+		for key, sub := range f.subs {
+			f.unpipe(ctx, key, sub)
+			err := f.pipe(ctx, key, sub)
+			if err != nil {
+				log.Errorw("moving subscription to new core endpoint", "subscriber", key.subscriber,
+					"query", key.query, "err", err)
+			}
+		}
+		f.subsLk.Unlock()
+
+		log.Infow("failed over to new core endpoint", "endpoint", next)
+		return
+	}
+	log.Error("no healthy core endpoint to fail over to")
+}
+
+// pipe subscribes to the events of the active endpoint and forwards them to the subscription.
+// NOTE: subsLk must be held.
+func (f *failoverClient) pipe(ctx context.Context, key subscriptionKey, sub *subscription) error {
+	cl := f.client()
+	in, err := cl.Subscribe(ctx, key.subscriber, key.query, cap(sub.out))
+	if err != nil {
+		return err
+	}
+
+	sub.client = cl
+	ctx, sub.cancel = context.WithCancel(context.Background())
+	go func() {
+		for {
+			select {
+			case ev, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case sub.out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// unpipe stops forwarding the events to the subscription and cancels it on the endpoint it was
+// made on, so that the endpoint stops sending them.
+// NOTE: subsLk must be held.
+func (f *failoverClient) unpipe(ctx context.Context, key subscriptionKey, sub *subscription) {
+	sub.cancel()
+	if sub.client == nil || !sub.client.IsRunning() {
+		return
+	}
+	// the endpoint is likely unhealthy, so don't wait on it for long
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	err := sub.client.Unsubscribe(ctx, key.subscriber, key.query)
+	if err != nil {
+		log.Debugw("unsubscribing from core endpoint", "subscriber", key.subscriber, "query", key.query,
+			"err", err)
+	}
+}
+
+func (f *failoverClient) Subscribe(
+	ctx context.Context,
+	subscriber, query string,
+	outCapacity ...int,
+) (<-chan ctypes.ResultEvent, error) {
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+
+	f.subsLk.Lock()
+	defer f.subsLk.Unlock()
+	key := subscriptionKey{subscriber: subscriber, query: query}
+	if _, ok := f.subs[key]; ok {
+		return nil, fmt.Errorf("core: already subscribed to %s as %s", query, subscriber)
+	}
+
+	sub := &subscription{out: make(chan ctypes.ResultEvent, outCap)}
+	err := f.pipe(ctx, key, sub)
+	if err != nil {
+		return nil, err
+	}
+	f.subs[key] = sub
+	return sub.out, nil
+}
+
+func (f *failoverClient) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	f.subsLk.Lock()
+	defer f.subsLk.Unlock()
+	key := subscriptionKey{subscriber: subscriber, query: query}
+	sub, ok := f.subs[key]
+	if !ok {
+		return f.client().Unsubscribe(ctx, subscriber, query)
+	}
+	sub.cancel()
+	delete(f.subs, key)
+	return sub.client.Unsubscribe(ctx, subscriber, query)
+}
+
+func (f *failoverClient) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	f.subsLk.Lock()
+	defer f.subsLk.Unlock()
+	for key, sub := range f.subs {
+		if key.subscriber == subscriber {
+			f.unpipe(ctx, key, sub)
+			delete(f.subs, key)
+		}
+	}
+	return f.client().UnsubscribeAll(ctx, subscriber)
+}
+
+func (f *failoverClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
+	return f.client().ABCIInfo(ctx)
+}
+
+func (f *failoverClient) ABCIQuery(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+) (*ctypes.ResultABCIQuery, error) {
+	return f.client().ABCIQuery(ctx, path, data)
+}
+
+func (f *failoverClient) ABCIQueryWithOptions(
+	ctx context.Context,
+	path string,
+	data bytes.HexBytes,
+	opts client.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return f.client().ABCIQueryWithOptions(ctx, path, data, opts)
+}
+
+func (f *failoverClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return f.client().BroadcastTxCommit(ctx, tx)
+}
+
+func (f *failoverClient) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return f.client().BroadcastTxAsync(ctx, tx)
+}
+
+func (f *failoverClient) BroadcastTxSync(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return f.client().BroadcastTxSync(ctx, tx)
+}
+
+func (f *failoverClient) Genesis(ctx context.Context) (*ctypes.ResultGenesis, error) {
+	return f.client().Genesis(ctx)
+}
+
+func (f *failoverClient) GenesisChunked(ctx context.Context, id uint) (*ctypes.ResultGenesisChunk, error) {
+	return f.client().GenesisChunked(ctx, id)
+}
+
+func (f *failoverClient) BlockchainInfo(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+) (*ctypes.ResultBlockchainInfo, error) {
+	return f.client().BlockchainInfo(ctx, minHeight, maxHeight)
+}
+
+func (f *failoverClient) NetInfo(ctx context.Context) (*ctypes.ResultNetInfo, error) {
+	return f.client().NetInfo(ctx)
+}
+
+func (f *failoverClient) DumpConsensusState(ctx context.Context) (*ctypes.ResultDumpConsensusState, error) {
+	return f.client().DumpConsensusState(ctx)
+}
+
+func (f *failoverClient) ConsensusState(ctx context.Context) (*ctypes.ResultConsensusState, error) {
+	return f.client().ConsensusState(ctx)
+}
+
+func (f *failoverClient) ConsensusParams(ctx context.Context, height *int64) (*ctypes.ResultConsensusParams, error) {
+	return f.client().ConsensusParams(ctx, height)
+}
+
+func (f *failoverClient) Health(ctx context.Context) (*ctypes.ResultHealth, error) {
+	return f.client().Health(ctx)
+}
+
+func (f *failoverClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	return f.client().Block(ctx, height)
+}
+
+func (f *failoverClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
+	return f.client().BlockByHash(ctx, hash)
+}
+
+func (f *failoverClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	return f.client().BlockResults(ctx, height)
+}
+
+func (f *failoverClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	return f.client().Commit(ctx, height)
+}
+
+func (f *failoverClient) DataCommitment(
+	ctx context.Context,
+	beginBlock, endBlock uint64,
+) (*ctypes.ResultDataCommitment, error) {
+	return f.client().DataCommitment(ctx, beginBlock, endBlock)
+}
+
+func (f *failoverClient) Validators(
+	ctx context.Context,
+	height *int64,
+	page, perPage *int,
+) (*ctypes.ResultValidators, error) {
+	return f.client().Validators(ctx, height, page, perPage)
+}
+
+func (f *failoverClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	return f.client().Tx(ctx, hash, prove)
+}
+
+func (f *failoverClient) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultTxSearch, error) {
+	return f.client().TxSearch(ctx, query, prove, page, perPage, orderBy)
+}
+
+func (f *failoverClient) BlockSearch(
+	ctx context.Context,
+	query string,
+	page, perPage *int,
+	orderBy string,
+) (*ctypes.ResultBlockSearch, error) {
+	return f.client().BlockSearch(ctx, query, page, perPage, orderBy)
+}
+
+func (f *failoverClient) Status(ctx context.Context) (*ctypes.ResultStatus, error) {
+	return f.client().Status(ctx)
+}
+
+func (f *failoverClient) BroadcastEvidence(
+	ctx context.Context,
+	ev types.Evidence,
+) (*ctypes.ResultBroadcastEvidence, error) {
+	return f.client().BroadcastEvidence(ctx, ev)
+}
+
+func (f *failoverClient) UnconfirmedTxs(ctx context.Context, limit *int) (*ctypes.ResultUnconfirmedTxs, error) {
+	return f.client().UnconfirmedTxs(ctx, limit)
+}
+
+func (f *failoverClient) NumUnconfirmedTxs(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error) {
+	return f.client().NumUnconfirmedTxs(ctx)
+}
+
+func (f *failoverClient) CheckTx(ctx context.Context, tx types.Tx) (*ctypes.ResultCheckTx, error) {
+	return f.client().CheckTx(ctx, tx)
+}
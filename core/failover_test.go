@@ -0,0 +1,129 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+func TestFailoverClient(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	interval := healthCheckInterval
+	healthCheckInterval = time.Millisecond * 10
+	t.Cleanup(func() { healthCheckInterval = interval })
+
+	primary, fallback := newFakeClient("primary"), newFakeClient("fallback")
+	cl, err := NewFailoverClient(primary, fallback)
+	require.NoError(t, err)
+	require.NoError(t, cl.Start())
+	t.Cleanup(func() {
+		require.NoError(t, cl.Stop())
+	})
+
+	status, err := cl.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "primary", status.NodeInfo.Moniker)
+	// the fallback is not started until it is needed
+	assert.False(t, fallback.IsRunning())
+
+	events, err := cl.Subscribe(ctx, "test", "query")
+	require.NoError(t, err)
+	primary.events <- ctypes.ResultEvent{Query: "primary"}
+	assert.Equal(t, "primary", (<-events).Query)
+
+	primary.unhealthy.Store(true)
+	require.Eventually(t, func() bool {
+		status, err := cl.Status(ctx)
+		return err == nil && status.NodeInfo.Moniker == "fallback"
+	}, time.Second, healthCheckInterval)
+	assert.True(t, fallback.IsRunning())
+	// the subscription to the old endpoint is cancelled
+	assert.Eventually(t, func() bool {
+		return primary.unsubscribed.Load() == 1
+	}, time.Second, healthCheckInterval)
+
+	// the subscription keeps receiving events from the new endpoint
+	fallback.events <- ctypes.ResultEvent{Query: "fallback"}
+	select {
+	case ev := <-events:
+		assert.Equal(t, "fallback", ev.Query)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestFailoverClient_NoHealthyEndpoint(t *testing.T) {
+	primary, fallback := newFakeClient("primary"), newFakeClient("fallback")
+	primary.unhealthy.Store(true)
+	fallback.unhealthy.Store(true)
+
+	cl, err := NewFailoverClient(primary, fallback)
+	require.NoError(t, err)
+	require.Error(t, cl.Start())
+
+	_, err = NewFailoverClient()
+	require.Error(t, err)
+}
+
+// fakeClient is a Client over a fake Core endpoint, implementing only the methods used in tests.
+type fakeClient struct {
+	Client
+
+	name      string
+	running   atomic.Bool
+	unhealthy atomic.Bool
+	events    chan ctypes.ResultEvent
+	// unsubscribed counts the cancelled subscriptions
+	unsubscribed atomic.Int32
+}
+
+func newFakeClient(name string) *fakeClient {
+	return &fakeClient{
+		name:   name,
+		events: make(chan ctypes.ResultEvent),
+	}
+}
+
+func (c *fakeClient) Start() error {
+	c.running.Store(true)
+	return nil
+}
+
+func (c *fakeClient) Stop() error {
+	c.running.Store(false)
+	return nil
+}
+
+func (c *fakeClient) IsRunning() bool {
+	return c.running.Load()
+}
+
+func (c *fakeClient) Health(context.Context) (*ctypes.ResultHealth, error) {
+	if c.unhealthy.Load() {
+		return nil, errors.New("unhealthy")
+	}
+	return &ctypes.ResultHealth{}, nil
+}
+
+func (c *fakeClient) Status(context.Context) (*ctypes.ResultStatus, error) {
+	status := &ctypes.ResultStatus{}
+	status.NodeInfo.Moniker = c.name
+	return status, nil
+}
+
+func (c *fakeClient) Subscribe(context.Context, string, string, ...int) (<-chan ctypes.ResultEvent, error) {
+	return c.events, nil
+}
+
+func (c *fakeClient) Unsubscribe(context.Context, string, string) error {
+	c.unsubscribed.Add(1)
+	return nil
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCDialOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	cert, caPath := newTestCert(t)
+	addr := newTestGRPCServer(t, cert, "secret")
+
+	call := func(opts []grpc.DialOption) error {
+		conn, err := grpc.DialContext(ctx, addr, opts...)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			conn.Close()
+		})
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	opts, err := GRPCDialOptions(true, caPath, "secret")
+	require.NoError(t, err)
+	require.NoError(t, call(opts))
+
+	// the token is checked by the server
+	opts, err = GRPCDialOptions(true, caPath, "wrong")
+	require.NoError(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(call(opts)))
+
+	// the endpoint is not trusted by the system certificates
+	opts, err = GRPCDialOptions(true, "", "secret")
+	require.NoError(t, err)
+	assert.Error(t, call(opts))
+
+	// tokens are never sent in plain text
+	_, err = GRPCDialOptions(false, "", "secret")
+	assert.Error(t, err)
+	_, err = GRPCDialOptions(true, filepath.Join(t.TempDir(), "missing.pem"), "")
+	assert.Error(t, err)
+}
+
+// newTestCert creates a self-signed certificate for the localhost and stores it under the returned
+// path.
+func newTestCert(t *testing.T) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, path
+}
+
+// newTestGRPCServer serves the health service over TLS to the calls authorized with the token.
+func newTestGRPCServer(t *testing.T, cert tls.Certificate, token string) string {
+	auth := func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer "+token {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(ctx, req)
+	}
+	srv := grpc.NewServer(
+		grpc.Creds(credentials.NewServerTLSFromCert(&cert)),
+		grpc.UnaryInterceptor(auth),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
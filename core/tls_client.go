@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	"github.com/tendermint/tendermint/libs/service"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+var errNotRunning = errors.New("core: client is not running")
+
+// tlsClient is a Client to a Core endpoint served over TLS. The requests are made by the regular
+// HTTP client, while the event subscriptions are made over the WebSocket dialed with TLS, which
+// the regular client does not support.
+//
+// NOTE: the WebSocket handshake carries no auth token, so the endpoint has to accept
+// subscriptions secured by TLS only.
+type tlsClient struct {
+	service.BaseService
+	*rpchttp.HTTP
+
+	ws *jsonrpcclient.WSClient
+
+	subsLk sync.RWMutex
+	subs   map[string]chan ctypes.ResultEvent // query -> events
+}
+
+// newTLSClient creates a new tlsClient to the remote, which has to be an https URL, making the
+// requests with the given HTTP client and the subscriptions with the given TLS configuration.
+func newTLSClient(remote, wsEndpoint string, httpClient *http.Client, tlsCfg *tls.Config) (Client, error) {
+	rpc, err := rpchttp.NewWithClient(remote, wsEndpoint, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &tlsClient{
+		HTTP: rpc,
+		subs: make(map[string]chan ctypes.ResultEvent),
+	}
+	c.BaseService = *service.NewBaseService(nil, "TLSClient", c)
+	c.ws, err = jsonrpcclient.NewWS(remote, wsEndpoint, jsonrpcclient.OnReconnect(func() {
+		c.resubscribe()
+	}))
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg = tlsCfg.Clone()
+	c.ws.Dialer = func(network, addr string) (net.Conn, error) {
+		return tls.Dial(network, addr, tlsCfg)
+	}
+	return c, nil
+}
+
+func (c *tlsClient) OnStart() error {
+	if err := c.ws.Start(); err != nil {
+		return err
+	}
+	go c.listen()
+	return nil
+}
+
+func (c *tlsClient) OnStop() {
+	if err := c.ws.Stop(); err != nil {
+		log.Errorw("stopping websocket client", "err", err)
+	}
+}
+
+// SetLogger sets the logger of both the service and the underlying clients.
+func (c *tlsClient) SetLogger(l tmlog.Logger) {
+	c.BaseService.SetLogger(l)
+	c.HTTP.SetLogger(l)
+	c.ws.SetLogger(l)
+}
+
+// Subscribe subscribes to the events matching the query. The subscriber is ignored, as Core
+// identifies subscribers by the remote address anyway.
+func (c *tlsClient) Subscribe(
+	ctx context.Context,
+	_, query string,
+	outCapacity ...int,
+) (<-chan ctypes.ResultEvent, error) {
+	if !c.IsRunning() {
+		return nil, errNotRunning
+	}
+	if err := c.ws.Subscribe(ctx, query); err != nil {
+		return nil, err
+	}
+
+	outCap := 1
+	if len(outCapacity) > 0 {
+		outCap = outCapacity[0]
+	}
+	out := make(chan ctypes.ResultEvent, outCap)
+	c.subsLk.Lock()
+	c.subs[query] = out
+	c.subsLk.Unlock()
+	return out, nil
+}
+
+func (c *tlsClient) Unsubscribe(ctx context.Context, _, query string) error {
+	if !c.IsRunning() {
+		return errNotRunning
+	}
+	if err := c.ws.Unsubscribe(ctx, query); err != nil {
+		return err
+	}
+
+	c.subsLk.Lock()
+	delete(c.subs, query)
+	c.subsLk.Unlock()
+	return nil
+}
+
+func (c *tlsClient) UnsubscribeAll(ctx context.Context, _ string) error {
+	if !c.IsRunning() {
+		return errNotRunning
+	}
+	if err := c.ws.UnsubscribeAll(ctx); err != nil {
+		return err
+	}
+
+	c.subsLk.Lock()
+	c.subs = make(map[string]chan ctypes.ResultEvent)
+	c.subsLk.Unlock()
+	return nil
+}
+
+// resubscribe makes all the subscriptions again, as they are lost once the WebSocket reconnects.
+func (c *tlsClient) resubscribe() {
+	c.subsLk.RLock()
+	defer c.subsLk.RUnlock()
+	for query := range c.subs {
+		err := c.ws.Subscribe(context.Background(), query)
+		if err != nil {
+			log.Errorw("resubscribing to core events", "query", query, "err", err)
+		}
+	}
+}
+
+// listen routes the received events to the subscriptions of their queries.
+func (c *tlsClient) listen() {
+	for {
+		select {
+		case resp, ok := <-c.ws.ResponsesCh:
+			if !ok {
+				return
+			}
+			if resp.Error != nil {
+				log.Errorw("core events", "err", resp.Error)
+				// give Core time to recover before subscribing again, unless the subscription
+				// is already there
+				if !strings.Contains(resp.Error.Error(), tmpubsub.ErrAlreadySubscribed.Error()) {
+					time.Sleep(time.Second)
+					c.resubscribe()
+				}
+				continue
+			}
+
+			ev := new(ctypes.ResultEvent)
+			err := tmjson.Unmarshal(resp.Result, ev)
+			if err != nil {
+				log.Errorw("unmarshalling core event", "err", err)
+				continue
+			}
+
+			c.subsLk.RLock()
+			if out, ok := c.subs[ev.Query]; ok && cap(out) == 0 {
+				out <- *ev
+			} else if ok {
+				select {
+				case out <- *ev:
+				default:
+					log.Errorw("dropping core event, subscription is full", "query", ev.Query)
+				}
+			}
+			c.subsLk.RUnlock()
+		case <-c.Quit():
+			return
+		}
+	}
+}
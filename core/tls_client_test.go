@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRemoteClient_TLS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	_, _, cfg := StartTestKVApp(ctx, t)
+	endpoint, err := GetEndpoint(cfg)
+	require.NoError(t, err)
+
+	// terminate TLS in front of the Core endpoint, requiring the token from the requests
+	cert, caPath := newTestCert(t)
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: endpoint})
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/websocket" && r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	ip, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	tlsCfg, err := NewTLSConfig(caPath)
+	require.NoError(t, err)
+	client, err := NewRemote(ip, port, WithTLS(tlsCfg), WithAuthToken("secret"))
+	require.NoError(t, err)
+	require.NoError(t, client.Start())
+	t.Cleanup(func() {
+		require.NoError(t, client.Stop())
+	})
+
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+	events, err := client.Subscribe(ctx, newBlockSubscriber, newBlockEventQuery)
+	require.NoError(t, err)
+	select {
+	case ev := <-events:
+		assert.NotZero(t, ev.Data.(types.EventDataNewBlock).Block.Height)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	require.NoError(t, client.Unsubscribe(ctx, newBlockSubscriber, newBlockEventQuery))
+
+	// the token is checked by the endpoint
+	unauthorized, err := NewRemote(ip, port, WithTLS(tlsCfg), WithAuthToken("wrong"))
+	require.NoError(t, err)
+	_, err = unauthorized.Status(ctx)
+	assert.Error(t, err)
+
+	// tokens are never sent in plain text
+	_, err = NewRemote(ip, port, WithAuthToken("secret"))
+	assert.Error(t, err)
+}
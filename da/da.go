@@ -0,0 +1,51 @@
+// Package da implements the generic data availability interface of the rollup frameworks over the
+// blob, state and header services of the node, so that a framework plugs the node in as its DA
+// layer with a single adapter: the blobs are submitted with Submit, retrieved with Get by the IDs
+// Submit returns, committed to in advance with Commit and checked for inclusion with Validate.
+package da
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// ErrInvalidID is returned for the IDs not produced by Submit.
+	ErrInvalidID = errors.New("da: invalid blob ID")
+	// ErrBlobNotFound is returned by Get when the blob is not included into the block of its ID.
+	ErrBlobNotFound = errors.New("da: blob not found")
+)
+
+// heightSize is the size of the height prefixing the ID.
+const heightSize = 8
+
+// ID identifies a blob included into the chain by the height of the block including it and the
+// commitment to the blob of that block.
+type ID []byte
+
+// NewID creates the ID of the blob included into the block at the given height with the given
+// commitment.
+func NewID(height uint64, commitment []byte) ID {
+	id := make(ID, heightSize+len(commitment))
+	binary.BigEndian.PutUint64(id, height)
+	copy(id[heightSize:], commitment)
+	return id
+}
+
+// Height returns the height of the block including the blob.
+func (id ID) Height() uint64 {
+	return binary.BigEndian.Uint64(id[:heightSize])
+}
+
+// Commitment returns the commitment to the blob of the block including it.
+func (id ID) Commitment() []byte {
+	return id[heightSize:]
+}
+
+// Validate checks the ID is well-formed.
+func (id ID) Validate() error {
+	if len(id) <= heightSize || id.Height() == 0 {
+		return ErrInvalidID
+	}
+	return nil
+}
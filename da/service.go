@@ -0,0 +1,150 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+var log = logging.Logger("da")
+
+// blobGetter gets the blobs included into the blocks, e.g. blob.Service.
+type blobGetter interface {
+	GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error)
+}
+
+// submitter submits the PayForData transactions carrying the blobs, e.g. state.CoreAccessor.
+type submitter interface {
+	SubmitPayForData(ctx context.Context, nID namespace.ID, data []byte, gasLim uint64) (*state.TxResponse, error)
+}
+
+// Service implements the data availability interface of the rollup frameworks.
+type Service struct {
+	blobs     blobGetter
+	submitter submitter
+	// headers resolves the heights of the blocks including the submitted blobs to their square sizes.
+	headers header.Getter
+}
+
+// NewService creates a new Service.
+func NewService(blobs blobGetter, submitter submitter, headers header.Getter) *Service {
+	return &Service{
+		blobs:     blobs,
+		submitter: submitter,
+		headers:   headers,
+	}
+}
+
+// Submit submits the blobs under the given namespace, each with its own PayForData transaction,
+// and returns their IDs once they are included into the chain. The gas of the transactions is
+// estimated. On failure, the IDs of the blobs submitted before are returned along with the error.
+func (s *Service) Submit(ctx context.Context, blobs [][]byte, nID namespace.ID) ([]ID, error) {
+	ids := make([]ID, 0, len(blobs))
+	for i, data := range blobs {
+		resp, err := s.submitter.SubmitPayForData(ctx, nID, data, 0)
+		if err != nil {
+			return ids, fmt.Errorf("da: submitting blob %d: %w", i, err)
+		}
+		if resp.Code != 0 {
+			return ids, fmt.Errorf("da: submitting blob %d: tx failed with code %d: %s", i, resp.Code, resp.RawLog)
+		}
+
+		// the commitment to the blob depends on the square size of the block including it
+		h, err := s.headers.GetByHeight(ctx, uint64(resp.Height))
+		if err != nil {
+			return ids, fmt.Errorf("da: getting header %d including blob %d: %w", resp.Height, i, err)
+		}
+		commitment, err := commit(nID, data, uint64(len(h.DAH.RowsRoots)/2))
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, NewID(uint64(resp.Height), commitment))
+		log.Debugw("submitted blob", "height", resp.Height, "namespace", nID.String(), "tx", resp.TxHash)
+	}
+	return ids, nil
+}
+
+// Get returns the blobs with the given IDs under the given namespace, in the order of the IDs.
+func (s *Service) Get(ctx context.Context, ids []ID, nID namespace.ID) ([][]byte, error) {
+	blobs := make([][]byte, len(ids))
+	for i, id := range ids {
+		b, err := s.get(ctx, id, nID)
+		if err != nil {
+			return nil, err
+		}
+		blobs[i] = b.Data
+	}
+	return blobs, nil
+}
+
+// Commit returns the commitments to the blobs under the given namespace for all the square sizes
+// the blobs fit in, as the square size of the block including a blob is unknown before it is
+// produced.
+func (s *Service) Commit(_ context.Context, blobs [][]byte, nID namespace.ID) ([][]*blob.Commitment, error) {
+	commitments := make([][]*blob.Commitment, len(blobs))
+	for i, data := range blobs {
+		var err error
+		commitments[i], err = blob.CreateCommitment(nID, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commitments, nil
+}
+
+// Validate reports whether the blobs with the given IDs are included into the chain under the
+// given namespace. The blobs are retrieved from the blocks of the IDs, so that their inclusion is
+// proven against the verified headers.
+func (s *Service) Validate(ctx context.Context, ids []ID, nID namespace.ID) ([]bool, error) {
+	included := make([]bool, len(ids))
+	for i, id := range ids {
+		_, err := s.get(ctx, id, nID)
+		switch err {
+		case nil:
+			included[i] = true
+		case ErrBlobNotFound, ErrInvalidID:
+		default:
+			return nil, err
+		}
+	}
+	return included, nil
+}
+
+// get gets the blob with the given ID from the block of its height.
+func (s *Service) get(ctx context.Context, id ID, nID namespace.ID) (*blob.Blob, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	blobs, err := s.blobs.GetAll(ctx, id.Height(), []namespace.ID{nID})
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blobs {
+		if bytes.Equal(b.Commitment, id.Commitment()) {
+			return b, nil
+		}
+	}
+	return nil, ErrBlobNotFound
+}
+
+// commit returns the commitment to the blob for the given square size.
+func commit(nID namespace.ID, data []byte, squareSize uint64) ([]byte, error) {
+	commitments, err := blob.CreateCommitment(nID, data)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commitments {
+		if c.SquareSize == squareSize {
+			return c.Commitment, nil
+		}
+	}
+	return nil, fmt.Errorf("da: blob does not fit square size %d", squareSize)
+}
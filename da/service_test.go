@@ -0,0 +1,95 @@
+package da
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appda "github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nID := namespace.ID{1, 2, 3, 4, 5, 6, 7, 8}
+	eh := header.RandExtendedHeader(t)
+	root := appda.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	eh.DAH = &root
+	chain := &fakeChain{eh: eh, blobs: make(map[uint64][]*blob.Blob)}
+	serv := NewService(chain, chain, chain)
+
+	data := [][]byte{[]byte("first blob"), []byte("second blob")}
+	ids, err := serv.Submit(ctx, data, nID)
+	require.NoError(t, err)
+	require.Len(t, ids, len(data))
+	for _, id := range ids {
+		assert.Equal(t, uint64(eh.Height), id.Height())
+	}
+
+	got, err := serv.Get(ctx, ids, nID)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	// the IDs are committed to in advance
+	commitments, err := serv.Commit(ctx, data, nID)
+	require.NoError(t, err)
+	require.Len(t, commitments, len(data))
+	assert.Contains(t, commitments[0], &blob.Commitment{SquareSize: 4, Commitment: ids[0].Commitment()})
+
+	unknown := NewID(uint64(eh.Height), []byte("unknown"))
+	included, err := serv.Validate(ctx, append(ids, unknown, ID{1}), nID)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, false, false}, included)
+
+	_, err = serv.Get(ctx, []ID{unknown}, nID)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	// failed transactions are reported along with the IDs of the blobs submitted before
+	chain.code = 11
+	ids, err = serv.Submit(ctx, data, nID)
+	assert.Error(t, err)
+	assert.Empty(t, ids)
+}
+
+// fakeChain includes the submitted blobs into the single ExtendedHeader.
+type fakeChain struct {
+	header.Getter
+	eh    *header.ExtendedHeader
+	blobs map[uint64][]*blob.Blob
+	code  uint32
+}
+
+func (c *fakeChain) SubmitPayForData(
+	_ context.Context,
+	nID namespace.ID,
+	data []byte,
+	_ uint64,
+) (*state.TxResponse, error) {
+	if c.code != 0 {
+		return &state.TxResponse{Code: c.code}, nil
+	}
+	commitment, err := commit(nID, data, uint64(len(c.eh.DAH.RowsRoots)/2))
+	if err != nil {
+		return nil, err
+	}
+	height := uint64(c.eh.Height)
+	c.blobs[height] = append(c.blobs[height], &blob.Blob{Namespace: nID, Data: data, Commitment: commitment})
+	return &state.TxResponse{Height: c.eh.Height}, nil
+}
+
+func (c *fakeChain) GetAll(_ context.Context, height uint64, _ []namespace.ID) ([]*blob.Blob, error) {
+	return c.blobs[height], nil
+}
+
+func (c *fakeChain) GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error) {
+	return c.eh, nil
+}
@@ -11,6 +11,8 @@ type checkpoint struct {
 	Failed map[uint64]int `json:"failed,omitempty"`
 	// Workers will resume on restart from previous state
 	Workers []workerCheckpoint `json:"workers,omitempty"`
+	// OfflineGap is the offline gap not yet caught up on, extended by the next one on restart
+	OfflineGap *OfflineGap `json:"offline_gap,omitempty"`
 }
 
 // workerCheckpoint will be used to resume worker on restart
@@ -27,12 +29,16 @@ func newCheckpoint(stats SamplingStats) checkpoint {
 			To:   w.To,
 		})
 	}
-	return checkpoint{
+	cp := checkpoint{
 		SampleFrom:  stats.CatchupHead + 1,
 		NetworkHead: stats.NetworkHead,
 		Failed:      stats.Failed,
 		Workers:     workers,
 	}
+	if stats.OfflineGap != nil && !stats.OfflineGap.Done {
+		cp.OfflineGap = stats.OfflineGap
+	}
+	return cp
 }
 
 func (c checkpoint) String() string {
@@ -42,6 +48,10 @@ func (c checkpoint) String() string {
 		str += fmt.Sprintf(", Workers: %v", len(c.Workers))
 	}
 
+	if c.OfflineGap != nil {
+		str += fmt.Sprintf(", OfflineGap: %v-%v", c.OfflineGap.From, c.OfflineGap.To)
+	}
+
 	if len(c.Failed) > 0 {
 		str += fmt.Sprintf("\nFailed: %v", c.Failed)
 	}
@@ -2,6 +2,7 @@ package das
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -38,3 +39,33 @@ func TestCheckpointStore(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, cp, got)
 }
+
+// TestCheckpointStore_Paused verifies that the background storing skips the checkpoints while paused.
+func TestCheckpointStore_Paused(t *testing.T) {
+	ds := newCheckpointStore(sync.MutexWrap(datastore.NewMapDatastore()))
+	var paused int32 = 1
+	ds.paused = func() bool {
+		return atomic.LoadInt32(&paused) == 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+	storeCtx, stop := context.WithCancel(ctx)
+	cp := checkpoint{SampleFrom: 5, NetworkHead: 10}
+	go ds.runBackgroundStore(storeCtx, time.Millisecond*10, func(context.Context) (checkpoint, error) {
+		return cp, nil
+	})
+
+	time.Sleep(time.Millisecond * 100)
+	_, err := ds.load(ctx)
+	require.ErrorIs(t, err, datastore.ErrNotFound)
+
+	atomic.StoreInt32(&paused, 0)
+	assert.Eventually(t, func() bool {
+		got, err := ds.load(ctx)
+		return err == nil && got.SampleFrom == cp.SampleFrom
+	}, time.Second, time.Millisecond*10)
+
+	stop()
+	require.NoError(t, ds.wait(ctx))
+}
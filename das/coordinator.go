@@ -4,12 +4,17 @@ import (
 	"context"
 	"sync"
 
+	"go.uber.org/multierr"
+
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 // samplingCoordinator runs and coordinates sampling workers and updates current sampling state
 type samplingCoordinator struct {
 	concurrencyLimit int
+	// batchGet indicates whether the workers get the headers of their jobs at once
+	batchGet bool
 
 	getter   header.Getter
 	sampleFn sampleFn
@@ -20,11 +25,14 @@ type samplingCoordinator struct {
 	resultCh chan result
 	// updHeadCh signals to update network head header height
 	updHeadCh chan uint64
+	// updLimitCh signals to update the concurrency limit
+	updLimitCh chan int
 	// waitCh signals to block coordinator for external access to state
 	waitCh chan *sync.WaitGroup
 
 	workersWg sync.WaitGroup
 	metrics   *metrics
+	events    *events.Bus
 	done
 }
 
@@ -42,11 +50,13 @@ func newSamplingCoordinator(
 ) *samplingCoordinator {
 	return &samplingCoordinator{
 		concurrencyLimit: params.ConcurrencyLimit,
+		batchGet:         params.Intermittent,
 		getter:           getter,
 		sampleFn:         sample,
 		state:            newCoordinatorState(params),
 		resultCh:         make(chan result),
 		updHeadCh:        make(chan uint64),
+		updLimitCh:       make(chan int),
 		waitCh:           make(chan *sync.WaitGroup),
 		done:             newDone("sampling coordinator"),
 	}
@@ -73,8 +83,11 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 			if sc.state.updateHead(head) {
 				sc.metrics.observeNewHead(ctx)
 			}
+		case limit := <-sc.updLimitCh:
+			sc.concurrencyLimit = limit
 		case res := <-sc.resultCh:
 			sc.state.handleResult(res)
+			sc.publishFailures(res)
 		case wg := <-sc.waitCh:
 			wg.Wait()
 		case <-ctx.Done():
@@ -88,6 +101,7 @@ func (sc *samplingCoordinator) run(ctx context.Context, cp checkpoint) {
 // runWorker runs job in separate worker go-routine
 func (sc *samplingCoordinator) runWorker(ctx context.Context, j job) {
 	w := newWorker(j)
+	w.batch = sc.batchGet
 	sc.state.putInProgress(j.id, w.getState)
 
 	// launch worker go-routine
@@ -98,6 +112,19 @@ func (sc *samplingCoordinator) runWorker(ctx context.Context, j job) {
 	}()
 }
 
+// publishFailures publishes the heights the worker failed to sample, along with their errors.
+func (sc *samplingCoordinator) publishFailures(res result) {
+	// the worker collects the errors in the same order as the failed heights
+	errs := multierr.Errors(res.err)
+	for i, height := range res.failed {
+		e := events.Event{Type: events.DASFailure, Height: height}
+		if i < len(errs) {
+			e.Message = errs[i].Error()
+		}
+		sc.events.Publish(e)
+	}
+}
+
 // listen notifies the coordinator about a new network head received via subscription.
 func (sc *samplingCoordinator) listen(ctx context.Context, height uint64) {
 	select {
@@ -106,6 +133,18 @@ func (sc *samplingCoordinator) listen(ctx context.Context, height uint64) {
 	}
 }
 
+// setConcurrencyLimit updates the maximum amount of workers running in parallel.
+// Workers above the new limit are not stopped, but no new ones are started until the amount of
+// running workers drops below it.
+func (sc *samplingCoordinator) setConcurrencyLimit(ctx context.Context, limit int) error {
+	select {
+	case sc.updLimitCh <- limit:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // stats pauses the coordinator to get stats in a concurrently safe manner
 func (sc *samplingCoordinator) stats(ctx context.Context) (SamplingStats, error) {
 	var wg sync.WaitGroup
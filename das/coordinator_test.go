@@ -68,6 +68,30 @@ func TestCoordinator(t *testing.T) {
 		assert.Equal(t, sampler.finalState(), newCheckpoint(coordinator.state.unsafeStats()))
 	})
 
+	t.Run("concurrency limit update", func(t *testing.T) {
+		testParams := defaultTestParams()
+		testParams.dasParams.ConcurrencyLimit = 1
+
+		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		sampler := newMockSampler(testParams.sampleFrom, testParams.networkHead)
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, sampler.sample)
+		go coordinator.run(ctx, sampler.checkpoint)
+
+		assert.NoError(t, coordinator.setConcurrencyLimit(ctx, 10))
+		stats, err := coordinator.stats(ctx)
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, stats.Concurrency, 10)
+		assert.Equal(t, 10, coordinator.concurrencyLimit)
+
+		// check if all jobs were sampled successfully
+		assert.NoError(t, sampler.finished(ctx), "not all headers were sampled")
+
+		cancel()
+		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		defer cancel()
+		assert.NoError(t, coordinator.wait(stopCtx))
+	})
+
 	t.Run("prioritize newly discovered over known", func(t *testing.T) {
 
 		testParams := defaultTestParams()
@@ -178,6 +202,41 @@ func TestCoordinator(t *testing.T) {
 		assert.Equal(t, sampler.finalState(), newCheckpoint(coordinator.state.unsafeStats()))
 	})
 
+	t.Run("catch up on offline gap", func(t *testing.T) {
+		testParams := defaultTestParams()
+		testParams.dasParams.Intermittent = true
+
+		ctx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		// everything was sampled before going offline
+		sampler := newMockSampler(testParams.networkHead+1, testParams.networkHead)
+		coordinator := newSamplingCoordinator(testParams.dasParams, getterStub{}, sampler.sample)
+		go coordinator.run(ctx, sampler.checkpoint)
+
+		// the first head received once back online
+		discovered := testParams.networkHead * 2
+		sampler.discover(ctx, discovered, coordinator.listen)
+
+		// check if all jobs were sampled successfully
+		assert.NoError(t, sampler.finished(ctx), "not all headers were sampled")
+		assert.NoError(t, coordinator.state.waitCatchUp(ctx))
+
+		stats, err := coordinator.stats(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, &OfflineGap{
+			From:    testParams.networkHead + 1,
+			To:      discovered - 1,
+			Sampled: discovered - testParams.networkHead - 1,
+			Done:    true,
+		}, stats.OfflineGap)
+
+		cancel()
+		stopCtx, cancel := context.WithTimeout(context.Background(), testParams.timeoutDelay)
+		defer cancel()
+		assert.NoError(t, coordinator.wait(stopCtx))
+		// the gap caught up on is not persisted
+		assert.Equal(t, sampler.finalState(), newCheckpoint(coordinator.state.unsafeStats()))
+	})
+
 	t.Run("failed should be stored", func(t *testing.T) {
 		testParams := defaultTestParams()
 		testParams.sampleFrom = 1
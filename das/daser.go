@@ -4,31 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync/atomic"
 
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 )
 
-var log = logging.Logger("das")
+var (
+	log    = logging.Logger("das")
+	tracer = otel.Tracer("das")
+)
 
 // DASer continuously validates availability of data committed to headers.
 type DASer struct {
+	// guarantee holds the bits of the lowest probability of availability achieved for a sampled
+	// header. It goes first to be 64-bit aligned for atomic operations.
+	guarantee uint64
+
 	params Parameters
 
 	da     share.Availability
 	bcast  fraud.Broadcaster
 	hsub   header.Subscriber // listens for new headers in the network
 	getter header.Getter     // retrieves past headers
+	events *events.Bus       // publishes sampling failures
+	// poisoned keeps the heights proven fraudulent, which are not sampled
+	poisoned *share.PoisonList
 
 	sampler    *samplingCoordinator
 	store      checkpointStore
 	subscriber subscriber
+	// prefetcher prefetches over the headers announced ahead, if the Availability supports it
+	prefetcher *prefetcher
 
 	cancel         context.CancelFunc
 	subscriberDone chan struct{}
@@ -68,6 +85,10 @@ func NewDASer(
 	}
 
 	d.sampler = newSamplingCoordinator(d.params, getter, d.sample)
+	d.sampler.events = d.events
+	if pf, ok := da.(share.Prefetcher); ok {
+		d.prefetcher = newPrefetcher(pf, d.poisoned, d.params.ConcurrencyLimit)
+	}
 	return d, nil
 }
 
@@ -106,6 +127,9 @@ func (d *DASer) Start(ctx context.Context) error {
 	go d.sampler.run(runCtx, cp)
 	go d.subscriber.run(runCtx, sub, d.sampler.listen)
 	go d.store.runBackgroundStore(runCtx, d.params.BackgroundStoreInterval, d.sampler.getCheckpoint)
+	if d.prefetcher != nil {
+		d.prefetcher.start()
+	}
 
 	return nil
 }
@@ -127,6 +151,9 @@ func (d *DASer) Stop(ctx context.Context) error {
 	}
 
 	d.cancel()
+	if d.prefetcher != nil {
+		d.prefetcher.stop()
+	}
 	if err = d.sampler.wait(ctx); err != nil {
 		return fmt.Errorf("DASer force quit: %w", err)
 	}
@@ -142,9 +169,33 @@ func (d *DASer) Stop(ctx context.Context) error {
 	return d.subscriber.wait(ctx)
 }
 
+// Prefetch implements sync.Prefetcher, fetching the shares to be sampled over the header announced
+// by the Syncer ahead of its sampling. It never blocks and does nothing, unless the DASer is running
+// and its Availability supports prefetching.
+func (d *DASer) Prefetch(h *header.ExtendedHeader) {
+	if d.prefetcher != nil {
+		d.prefetcher.prefetch(h)
+	}
+}
+
 func (d *DASer) sample(ctx context.Context, h *header.ExtendedHeader) error {
+	ctx, span := tracer.Start(ctx, "sample")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int64("height", h.Height),
+		attribute.Int("square_width", len(h.DAH.RowsRoots)),
+	)
+
+	if d.poisoned != nil && d.poisoned.IsHeightPoisoned(uint64(h.Height)) {
+		log.Warnw("skipping sampling of height proven fraudulent", "height", h.Height)
+		span.SetAttributes(attribute.Bool("poisoned", true))
+		return nil
+	}
+
 	err := d.da.SharesAvailable(ctx, h.DAH)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		if err == context.Canceled {
 			return err
 		}
@@ -162,12 +213,54 @@ func (d *DASer) sample(ctx context.Context, h *header.ExtendedHeader) error {
 		return err
 	}
 
+	guarantee := d.da.ProbabilityOfAvailabilityFor(ctx, h.DAH)
+	span.SetAttributes(attribute.Float64("guarantee", guarantee))
+	log.Debugw("sampled header", "height", h.Height, "square width", len(h.DAH.RowsRoots),
+		"guarantee", guarantee)
+	d.recordGuarantee(guarantee)
 	return nil
 }
 
+// recordGuarantee keeps the given probability of availability, if it is the lowest one achieved.
+func (d *DASer) recordGuarantee(guarantee float64) {
+	for {
+		old := atomic.LoadUint64(&d.guarantee)
+		if old != 0 && math.Float64frombits(old) <= guarantee {
+			return
+		}
+		if atomic.CompareAndSwapUint64(&d.guarantee, old, math.Float64bits(guarantee)) {
+			return
+		}
+	}
+}
+
 // SamplingStats returns the current statistics over the DA sampling process.
 func (d *DASer) SamplingStats(ctx context.Context) (SamplingStats, error) {
-	return d.sampler.stats(ctx)
+	stats, err := d.sampler.stats(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Guarantee = math.Float64frombits(atomic.LoadUint64(&d.guarantee))
+	return stats, nil
+}
+
+// SetConcurrencyLimit updates the maximum amount of sampling workers running in parallel.
+func (d *DASer) SetConcurrencyLimit(ctx context.Context, limit int) error {
+	if limit <= 0 {
+		return fmt.Errorf("das: invalid concurrency limit: %d", limit)
+	}
+	if atomic.LoadInt32(&d.running) == 0 {
+		return fmt.Errorf("das: DASer is not running")
+	}
+	return d.sampler.setConcurrencyLimit(ctx, limit)
+}
+
+// Healthy reports whether the DASer is sampling. It is not once stopped, e.g. on a fraud proof.
+func (d *DASer) Healthy() error {
+	if atomic.LoadInt32(&d.running) == 0 {
+		return fmt.Errorf("das: DASer is not running")
+	}
+	return nil
 }
 
 // WaitCatchUp waits for DASer to indicate catchup is done
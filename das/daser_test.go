@@ -23,6 +23,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 var timeout = time.Second * 15
@@ -67,6 +68,12 @@ func TestDASerLifecycle(t *testing.T) {
 
 	// give catch-up routine a second to finish up sampling last header
 	assert.NoError(t, daser.sampler.state.waitCatchUp(ctx))
+
+	// the guarantee achieved for sampled headers is reported
+	stats, err := daser.SamplingStats(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, stats.Guarantee, 0.0)
+	assert.LessOrEqual(t, stats.Guarantee, 1.0)
 }
 
 func TestDASer_Restart(t *testing.T) {
@@ -179,6 +186,62 @@ func TestDASer_stopsAfter_BEFP(t *testing.T) {
 	require.True(t, daser.running == 0)
 }
 
+func TestDASer_skipsPoisoned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	bServ := mdutils.Bserv()
+	mockGet, sub, mockService := createDASerSubcomponents(t, bServ, 1, 0)
+	fraudulent := header.CreateFraudExtHeader(t, mockGet.headers[1], bServ)
+
+	poisoned := share.NewPoisonList()
+	daser, err := NewDASer(full.TestAvailability(bServ), sub, mockGet, ds, mockService, WithPoisonList(poisoned))
+	require.NoError(t, err)
+
+	// the incorrectly encoded data fails sampling until it is proven fraudulent
+	require.Error(t, daser.sample(ctx, fraudulent))
+	poisoned.Poison(uint64(fraudulent.Height), fraudulent.DAH)
+	require.NoError(t, daser.sample(ctx, fraudulent))
+}
+
+func TestDASer_Prefetch(t *testing.T) {
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	bServ := mdutils.Bserv()
+	mockGet, sub, mockService := createDASerSubcomponents(t, bServ, 2, 0)
+
+	poisoned := share.NewPoisonList()
+	avail := &prefetchingAvailability{
+		Availability: light.TestAvailability(bServ),
+		prefetched:   make(chan *share.Root, 2),
+	}
+	daser, err := NewDASer(avail, sub, mockGet, ds, mockService, WithPoisonList(poisoned))
+	require.NoError(t, err)
+
+	// nothing is prefetched, unless running
+	daser.Prefetch(mockGet.headers[1])
+	daser.prefetcher.start()
+	// the heights proven fraudulent are not prefetched
+	poisoned.Poison(1, mockGet.headers[1].DAH)
+	daser.Prefetch(mockGet.headers[1])
+	daser.Prefetch(mockGet.headers[2])
+	daser.prefetcher.stop()
+	daser.Prefetch(mockGet.headers[2])
+
+	require.Len(t, avail.prefetched, 1)
+	assert.Equal(t, mockGet.headers[2].DAH, <-avail.prefetched)
+}
+
+// prefetchingAvailability records the Roots prefetched over.
+type prefetchingAvailability struct {
+	share.Availability
+	prefetched chan *share.Root
+}
+
+func (pa *prefetchingAvailability) Prefetch(_ context.Context, root *share.Root) {
+	pa.prefetched <- root
+}
+
 // createDASerSubcomponents takes numGetter (number of headers
 // to store in mockGetter) and numSub (number of headers to store
 // in the mock header.Subscriber), returning a newly instantiated
@@ -320,7 +383,15 @@ func (m getterStub) GetByHeight(_ context.Context, height uint64) (*header.Exten
 }
 
 func (m getterStub) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
-	return nil, nil
+	hs := make([]*header.ExtendedHeader, 0, to-from)
+	for height := from; height < to; height++ {
+		h, err := m.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		hs = append(hs, h)
+	}
+	return hs, nil
 }
 
 func (m getterStub) Get(context.Context, tmbytes.HexBytes) (*header.ExtendedHeader, error) {
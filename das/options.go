@@ -3,6 +3,9 @@ package das
 import (
 	"fmt"
 	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 // ErrInvalidOption is an error that is returned by Parameters.Validate
@@ -37,6 +40,11 @@ type Parameters struct {
 
 	// SampleFrom is the height sampling will start from
 	SampleFrom uint64
+
+	// Intermittent adapts sampling to the nodes which are often offline, e.g. mobile and IoT ones.
+	// The headers produced while the node was offline are tracked as the offline gap, the most
+	// recent of them are sampled first, and the workers get the headers of their jobs in batches.
+	Intermittent bool
 }
 
 // DefaultParameters returns the default configuration values for the daser parameters
@@ -141,3 +149,36 @@ func WithSampleFrom(sampleFrom uint64) Option {
 		d.params.SampleFrom = sampleFrom
 	}
 }
+
+// WithIntermittent is a functional option to configure the daser's `Intermittent` parameter
+// Refer to WithSamplingRange documentation to see an example of how to use this
+func WithIntermittent(intermittent bool) Option {
+	return func(d *DASer) {
+		d.params.Intermittent = intermittent
+	}
+}
+
+// WithEvents is a functional option to configure the Bus the daser publishes the heights it failed
+// to sample to. Refer to WithSamplingRange documentation to see an example of how to use this
+func WithEvents(bus *events.Bus) Option {
+	return func(d *DASer) {
+		d.events = bus
+	}
+}
+
+// WithPoisonList makes the DASer skip sampling the heights proven fraudulent, as kept in the given
+// share.PoisonList.
+func WithPoisonList(poisoned *share.PoisonList) Option {
+	return func(d *DASer) {
+		d.poisoned = poisoned
+	}
+}
+
+// WithStorePaused makes the DASer skip storing the checkpoints in the background while the given
+// function reports true, e.g. while the node is low on disk space. The checkpoint is still stored
+// once the DASer stops.
+func WithStorePaused(paused func() bool) Option {
+	return func(d *DASer) {
+		d.store.paused = paused
+	}
+}
@@ -0,0 +1,77 @@
+package das
+
+import (
+	"context"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+// prefetcher fetches the shares to be sampled over the headers announced ahead of their sampling,
+// cutting the latency between a header arrives and its sampling completes. It runs at most the
+// sampling concurrency limit of prefetches in parallel and drops the rest, as they are an
+// optimization only.
+type prefetcher struct {
+	da       share.Prefetcher
+	poisoned *share.PoisonList
+	sem      chan struct{}
+
+	lk     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newPrefetcher(da share.Prefetcher, poisoned *share.PoisonList, limit int) *prefetcher {
+	return &prefetcher{
+		da:       da,
+		poisoned: poisoned,
+		sem:      make(chan struct{}, limit),
+	}
+}
+
+func (p *prefetcher) start() {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+}
+
+// stop cancels the running prefetches and waits for them to return.
+func (p *prefetcher) stop() {
+	p.lk.Lock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.ctx, p.cancel = nil, nil
+	p.lk.Unlock()
+	p.wg.Wait()
+}
+
+// prefetch prefetches over the given header in the background, unless stopped or at the limit.
+func (p *prefetcher) prefetch(h *header.ExtendedHeader) {
+	if p.poisoned != nil && p.poisoned.IsHeightPoisoned(uint64(h.Height)) {
+		return
+	}
+
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if p.ctx == nil {
+		return
+	}
+	select {
+	case p.sem <- struct{}{}:
+	default:
+		log.Debugw("prefetching at the limit, skipping", "height", h.Height)
+		return
+	}
+
+	p.wg.Add(1)
+	go func(ctx context.Context) {
+		defer func() {
+			<-p.sem
+			p.wg.Done()
+		}()
+		p.da.Prefetch(ctx, h.DAH)
+	}(p.ctx)
+}
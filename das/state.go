@@ -20,6 +20,10 @@ type coordinatorState struct {
 
 	catchUpDone   bool          // indicates if all headers are sampled
 	catchUpDoneCh chan struct{} // blocks until all headers are sampled
+
+	intermittent bool        // tracks the offline gap and prioritizes the most recent headers
+	gap          *OfflineGap // keeps the heights missed while offline, if any
+	awaitHead    bool        // indicates if the state is resumed and awaits the first network head
 }
 
 // newCoordinatorState initiates state for samplingCoordinator
@@ -36,6 +40,7 @@ func newCoordinatorState(params Parameters) coordinatorState {
 		networkHead:       params.SampleFrom,
 		catchUpDone:       false,
 		catchUpDoneCh:     make(chan struct{}),
+		intermittent:      params.Intermittent,
 	}
 }
 
@@ -47,6 +52,10 @@ func (s *coordinatorState) resumeFromCheckpoint(c checkpoint) {
 		s.failed[h] = count
 		s.priority = append(s.priority, s.newJob(h, h))
 	}
+	if s.intermittent {
+		s.gap = c.OfflineGap
+		s.awaitHead = true
+	}
 }
 
 func (s *coordinatorState) handleResult(res result) {
@@ -87,8 +96,19 @@ func (s *coordinatorState) updateHead(last uint64) bool {
 		return true
 	}
 
+	if s.awaitHead {
+		s.updateGap(last)
+	}
+
 	// add most recent headers into priority queue
 	from := s.networkHead + 1
+	if s.intermittent && len(s.priority) < s.priorityQueueSize {
+		// only the most recent headers fit the queue, while the older ones are left to the catch-up
+		window := uint64(s.priorityQueueSize-len(s.priority)) * s.samplingRange
+		if last-from >= window {
+			from = last - window + 1
+		}
+	}
 	for from <= last && len(s.priority) < s.priorityQueueSize {
 		s.priority = append(s.priority, s.newJob(from, last))
 		from += s.samplingRange
@@ -100,6 +120,21 @@ func (s *coordinatorState) updateHead(last uint64) bool {
 	return true
 }
 
+// updateGap extends the offline gap with the heights missed before the first network head received
+// after resuming. The unfinished gap of the previous run, if any, is caught up on as part of it.
+func (s *coordinatorState) updateGap(head uint64) {
+	s.awaitHead = false
+	if head-s.networkHead <= 1 {
+		return
+	}
+
+	if s.gap == nil {
+		s.gap = &OfflineGap{From: s.networkHead + 1}
+	}
+	s.gap.To = head - 1
+	log.Infow("catching up on headers missed while offline", "from", s.gap.From, "to", s.gap.To)
+}
+
 // nextJob will return header height to be processed and done flag if there is none
 func (s *coordinatorState) nextJob() (next job, found bool) {
 	// all headers were sent to workers.
@@ -203,7 +238,25 @@ func (s *coordinatorState) unsafeStats() SamplingStats {
 		Concurrency:      len(workers),
 		CatchUpDone:      s.catchUpDone,
 		IsRunning:        len(workers) > 0 || s.catchUpDone,
+		OfflineGap:       s.offlineGap(lowestFailedOrInProgress - 1),
+	}
+}
+
+// offlineGap reports the progress of catching up on the offline gap given the head of the sampled
+// chain.
+func (s *coordinatorState) offlineGap(sampledHead uint64) *OfflineGap {
+	if s.gap == nil {
+		return nil
+	}
+
+	gap := OfflineGap{From: s.gap.From, To: s.gap.To}
+	if sampledHead >= gap.To {
+		sampledHead, gap.Done = gap.To, true
+	}
+	if sampledHead >= gap.From {
+		gap.Sampled = sampledHead - gap.From + 1
 	}
+	return &gap
 }
 
 func (s *coordinatorState) checkDone() {
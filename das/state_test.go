@@ -83,3 +83,33 @@ func Test_coordinatorStats(t *testing.T) {
 		})
 	}
 }
+
+func Test_coordinatorState_offlineGap(t *testing.T) {
+	params := DefaultParameters()
+	params.Intermittent = true
+	params.SamplingRange = 10
+	params.PriorityQueueSize = 2
+
+	state := newCoordinatorState(params)
+	// the gap of the previous run was not caught up on
+	state.resumeFromCheckpoint(checkpoint{
+		SampleFrom:  15,
+		NetworkHead: 30,
+		OfflineGap:  &OfflineGap{From: 10, To: 20},
+	})
+	assert.Equal(t, &OfflineGap{From: 10, To: 20, Sampled: 5}, state.unsafeStats().OfflineGap)
+
+	// the gap is extended with the heights missed this time
+	assert.True(t, state.updateHead(100))
+	assert.Equal(t, &OfflineGap{From: 10, To: 99, Sampled: 5}, state.unsafeStats().OfflineGap)
+
+	// the most recent headers are prioritized
+	assert.Equal(t, []job{{id: 1, From: 81, To: 90}, {id: 2, From: 91, To: 100}}, state.priority)
+
+	// the subsequent heads do not extend the gap
+	assert.True(t, state.updateHead(150))
+	assert.Equal(t, uint64(99), state.unsafeStats().OfflineGap.To)
+
+	state.next = 51
+	assert.Equal(t, &OfflineGap{From: 10, To: 99, Sampled: 41}, state.unsafeStats().OfflineGap)
+}
@@ -15,12 +15,28 @@ type SamplingStats struct {
 	Failed map[uint64]int `json:"failed,omitempty"`
 	// Workers has information about each currently running worker stats
 	Workers []WorkerStats `json:"workers,omitempty"`
+	// Guarantee is the lowest probability of availability achieved for a header sampled since start
+	Guarantee float64 `json:"availability_guarantee"`
 	// Concurrency currently running parallel workers
 	Concurrency int `json:"concurrency"`
 	// CatchUpDone indicates whether all known headers are sampled
 	CatchUpDone bool `json:"catch_up_done"`
 	// IsRunning tracks whether the DASer service is running
 	IsRunning bool `json:"is_running"`
+	// OfflineGap is the gap of headers missed while offline, if any is being caught up on
+	OfflineGap *OfflineGap `json:"offline_gap,omitempty"`
+}
+
+// OfflineGap describes the headers the network produced while the DASer was not running, which it
+// catches up on after the restart. It is only tracked in the intermittent mode.
+type OfflineGap struct {
+	// From and To are the first and the last heights of the gap.
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// Sampled is the amount of headers of the gap sampled in a row from its beginning.
+	Sampled uint64 `json:"sampled"`
+	// Done indicates whether all the headers of the gap are sampled.
+	Done bool `json:"done"`
 }
 
 type WorkerStats struct {
@@ -21,13 +21,17 @@ var (
 type checkpointStore struct {
 	datastore.Datastore
 	done
+
+	// paused reports whether the background storing is paused, if set
+	paused func() bool
 }
 
 // newCheckpointStore wraps the given datastore.Datastore with the `das` prefix.
 func newCheckpointStore(ds datastore.Datastore) checkpointStore {
 	return checkpointStore{
-		namespace.Wrap(ds, storePrefix),
-		newDone("checkpoint store")}
+		Datastore: namespace.Wrap(ds, storePrefix),
+		done:      newDone("checkpoint store"),
+	}
 }
 
 // load loads the DAS checkpoint from disk and returns it.
@@ -86,6 +90,11 @@ func (s *checkpointStore) runBackgroundStore(
 			return
 		}
 
+		if s.paused != nil && s.paused() {
+			log.Debug("DASer background checkpointStore is paused")
+			continue
+		}
+
 		cp, err := getCheckpoint(ctx)
 		if err != nil {
 			log.Debug("DASer coordinator checkpoint is unavailable")
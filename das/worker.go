@@ -15,6 +15,8 @@ import (
 type worker struct {
 	lock  sync.Mutex
 	state workerState
+	// batch indicates whether the headers of the job are got at once
+	batch bool
 }
 
 // workerState contains important information about the state of a
@@ -43,9 +45,21 @@ func (w *worker) run(
 	jobStart := time.Now()
 	log.Debugw("start sampling worker", "from", w.state.From, "to", w.state.To)
 
+	var batch []*header.ExtendedHeader
+	if w.batch {
+		batch = w.getBatch(ctx, getter, metrics)
+	}
+
 	for curr := w.state.From; curr <= w.state.To; curr++ {
+		if batch != nil {
+			err := w.sampleHeader(ctx, batch[curr-w.state.From], sample, metrics)
+			if errors.Is(err, context.Canceled) {
+				break
+			}
+			continue
+		}
+
 		startGet := time.Now()
-		// TODO: get headers in batches
 		h, err := getter.GetByHeight(ctx, curr)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -62,21 +76,11 @@ func (w *worker) run(
 		log.Debugw("got header from header store", "height", h.Height, "hash", h.Hash(),
 			"square width", len(h.DAH.RowsRoots), "data root", h.DAH.Hash(), "finished (s)", time.Since(startGet))
 
-		startSample := time.Now()
-		err = sample(ctx, h)
+		err = w.sampleHeader(ctx, h, sample, metrics)
 		if errors.Is(err, context.Canceled) {
 			// sampling worker will resume upon restart
 			break
 		}
-		w.setResult(curr, err)
-		metrics.observeSample(ctx, h, time.Since(startSample), err)
-		if err != nil {
-			log.Debugw("failed to sampled header", "height", h.Height, "hash", h.Hash(),
-				"square width", len(h.DAH.RowsRoots), "data root", h.DAH.Hash(), "err", err)
-		} else {
-			log.Debugw("sampled header", "height", h.Height, "hash", h.Hash(),
-				"square width", len(h.DAH.RowsRoots), "data root", h.DAH.Hash(), "finished (s)", time.Since(startSample))
-		}
 	}
 
 	if w.state.Curr > w.state.From {
@@ -95,6 +99,41 @@ func (w *worker) run(
 	}
 }
 
+// getBatch gets all the headers of the job at once. It returns nothing on failure, so that the
+// headers are got one by one instead.
+func (w *worker) getBatch(ctx context.Context, getter header.Getter, metrics *metrics) []*header.ExtendedHeader {
+	startGet := time.Now()
+	hs, err := getter.GetRangeByHeight(ctx, w.state.From, w.state.To+1)
+	if err != nil || uint64(len(hs)) != w.state.To-w.state.From+1 {
+		log.Debugw("failed to get headers in batch", "from", w.state.From, "to", w.state.To, "err", err)
+		return nil
+	}
+
+	metrics.observeGetHeader(ctx, time.Since(startGet))
+	log.Debugw("got headers from header store", "from", w.state.From, "to", w.state.To,
+		"finished (s)", time.Since(startGet))
+	return hs
+}
+
+// sampleHeader samples the header and records the result, unless the sampling is canceled.
+func (w *worker) sampleHeader(ctx context.Context, h *header.ExtendedHeader, sample sampleFn, metrics *metrics) error {
+	startSample := time.Now()
+	err := sample(ctx, h)
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	w.setResult(uint64(h.Height), err)
+	metrics.observeSample(ctx, h, time.Since(startSample), err)
+	if err != nil {
+		log.Debugw("failed to sampled header", "height", h.Height, "hash", h.Hash(),
+			"square width", len(h.DAH.RowsRoots), "data root", h.DAH.Hash(), "err", err)
+	} else {
+		log.Debugw("sampled header", "height", h.Height, "hash", h.Hash(),
+			"square width", len(h.DAH.RowsRoots), "data root", h.DAH.Hash(), "finished (s)", time.Since(startSample))
+	}
+	return err
+}
+
 func newWorker(j job) worker {
 	return worker{
 		state: workerState{
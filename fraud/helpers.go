@@ -2,19 +2,32 @@ package fraud
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 )
 
-func join(p *pubsub.PubSub, proofType ProofType,
+// PubSubTopicID formats the name of the gossipsub topic of the given ProofType for the given network.
+func PubSubTopicID(p ProofType, networkID string) string {
+	return fmt.Sprintf("/%s/fraud-sub/%s/v0.0.1", networkID, p)
+}
+
+// ProtocolID formats the ID of the protocol fraud proofs are requested over for the given network.
+func ProtocolID(networkID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/fraud/v0.0.1", networkID))
+}
+
+func join(p *pubsub.PubSub, proofType ProofType, networkID string,
 	validate func(context.Context, ProofType, peer.ID, *pubsub.Message) pubsub.ValidationResult) (*pubsub.Topic, error) {
-	t, err := p.Join(string(proofType))
+	topic := PubSubTopicID(proofType, networkID)
+	t, err := p.Join(topic)
 	if err != nil {
 		return nil, err
 	}
 	err = p.RegisterTopicValidator(
-		string(proofType),
+		topic,
 		func(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 			return validate(ctx, proofType, from, msg)
 		},
@@ -11,7 +11,7 @@ import (
 
 // WithMetrics enables metrics to monitor fraud proofs.
 func WithMetrics(store Getter) {
-	proofTypes := registeredProofTypes()
+	proofTypes := RegisteredProofTypes()
 	for _, proofType := range proofTypes {
 		counter, _ := meter.AsyncInt64().Gauge(string(proofType),
 			instrument.WithUnit(unit.Dimensionless),
@@ -28,8 +28,8 @@ func Register(p Proof) {
 	}
 }
 
-// registeredProofTypes returns all available proofTypes.
-func registeredProofTypes() []ProofType {
+// RegisteredProofTypes returns all available proofTypes.
+func RegisteredProofTypes() []ProofType {
 	unmarshalersLk.Lock()
 	defer unmarshalersLk.Unlock()
 	proofs := make([]ProofType, 0, len(defaultUnmarshalers))
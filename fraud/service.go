@@ -16,6 +16,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 // fraudRequests is the amount of external requests that will be tried to get fraud proofs from
@@ -25,6 +27,7 @@ const fraudRequests = 5
 // ProofService is responsible for validating and propagating Fraud Proofs.
 // It implements the Service interface.
 type ProofService struct {
+	networkID  string
 	protocolID protocol.ID
 
 	ctx    context.Context
@@ -33,41 +36,59 @@ type ProofService struct {
 	topicsLk sync.RWMutex
 	topics   map[ProofType]*pubsub.Topic
 
-	storesLk sync.RWMutex
+	storesLk sync.Mutex
 	stores   map[ProofType]datastore.Datastore
 
 	pubsub *pubsub.PubSub
 	host   host.Host
 	getter headerFetcher
 	ds     datastore.Datastore
+	events *events.Bus
 
 	syncerEnabled bool
 }
 
+// Option is the functional option that is applied to the ProofService.
+type Option func(*ProofService)
+
+// WithEvents configures the Bus the ProofService publishes the valid fraud proofs it receives and
+// the peers it blacklists to.
+func WithEvents(bus *events.Bus) Option {
+	return func(f *ProofService) {
+		f.events = bus
+	}
+}
+
 func NewProofService(
 	p *pubsub.PubSub,
 	host host.Host,
 	getter headerFetcher,
 	ds datastore.Datastore,
 	syncerEnabled bool,
-	protocolSuffix string,
+	networkID string,
+	opts ...Option,
 ) *ProofService {
-	return &ProofService{
+	f := &ProofService{
 		pubsub:        p,
 		host:          host,
 		getter:        getter,
 		topics:        make(map[ProofType]*pubsub.Topic),
 		stores:        make(map[ProofType]datastore.Datastore),
 		ds:            ds,
-		protocolID:    protocol.ID(fmt.Sprintf("/fraud/v0.0.1/%s", protocolSuffix)),
+		networkID:     networkID,
+		protocolID:    ProtocolID(networkID),
 		syncerEnabled: syncerEnabled,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // registerProofTopics registers proofTypes as pubsub topics to be joined.
 func (f *ProofService) registerProofTopics(proofTypes ...ProofType) error {
 	for _, proofType := range proofTypes {
-		t, err := join(f.pubsub, proofType, f.processIncoming)
+		t, err := join(f.pubsub, proofType, f.networkID, f.processIncoming)
 		if err != nil {
 			return err
 		}
@@ -82,7 +103,7 @@ func (f *ProofService) registerProofTopics(proofTypes ...ProofType) error {
 // if syncer is enabled.
 func (f *ProofService) Start(context.Context) error {
 	f.ctx, f.cancel = context.WithCancel(context.Background())
-	if err := f.registerProofTopics(registeredProofTypes()...); err != nil {
+	if err := f.registerProofTopics(RegisteredProofTypes()...); err != nil {
 		return err
 	}
 	f.host.SetStreamHandler(f.protocolID, f.handleFraudMessageRequest)
@@ -133,7 +154,15 @@ func (f *ProofService) processIncoming(
 	proofType ProofType,
 	from peer.ID,
 	msg *pubsub.Message,
-) pubsub.ValidationResult {
+) (res pubsub.ValidationResult) {
+	defer func() {
+		// malformed proofs are rejected, rather than panic the node
+		if r := recover(); r != nil {
+			log.Errorw("panic while processing proof", "from", from, "err", r)
+			f.blacklist(from)
+			res = pubsub.ValidationReject
+		}
+	}()
 	ctx, span := tracer.Start(ctx, "process_proof", trace.WithAttributes(
 		attribute.String("proof_type", string(proofType)),
 	))
@@ -145,7 +174,7 @@ func (f *ProofService) processIncoming(
 	if err != nil {
 		log.Errorw("unmarshalling failed", "err", err)
 		if !errors.Is(err, &errNoUnmarshaler{}) {
-			f.pubsub.BlacklistPeer(from)
+			f.blacklist(from)
 		}
 		span.RecordError(err)
 		return pubsub.ValidationReject
@@ -176,7 +205,7 @@ func (f *ProofService) processIncoming(
 	if err != nil {
 		log.Errorw("proof validation err: ",
 			"err", err, "proofType", proof.Type(), "height", proof.Height())
-		f.pubsub.BlacklistPeer(from)
+		f.blacklist(from)
 		span.RecordError(err)
 		return pubsub.ValidationReject
 	}
@@ -195,44 +224,34 @@ func (f *ProofService) processIncoming(
 		span.RecordError(err)
 	}
 
+	f.events.Publish(events.Event{
+		Type:    events.FraudProof,
+		Height:  proof.Height(),
+		Peer:    from,
+		Message: string(proof.Type()),
+	})
 	span.SetStatus(codes.Ok, "")
 	return pubsub.ValidationAccept
 }
 
-func (f *ProofService) Get(ctx context.Context, proofType ProofType) ([]Proof, error) {
-	f.storesLk.Lock()
-	store, ok := f.stores[proofType]
-	if !ok {
-		store = initStore(proofType, f.ds)
-		f.stores[proofType] = store
-	}
-	f.storesLk.Unlock()
+// blacklist ignores all the messages of the peer from now on.
+func (f *ProofService) blacklist(p peer.ID) {
+	f.pubsub.BlacklistPeer(p)
+	f.events.Publish(events.Event{Type: events.PeerBanned, Peer: p, Message: "sent invalid fraud proof"})
+}
 
-	return getAll(ctx, store, proofType)
+func (f *ProofService) Get(ctx context.Context, proofType ProofType) ([]Proof, error) {
+	return getAll(ctx, f.store(proofType), proofType)
 }
 
 // put adds a fraud proof to the local storage.
 func (f *ProofService) put(ctx context.Context, proofType ProofType, hash string, data []byte) error {
-	f.storesLk.Lock()
-	store, ok := f.stores[proofType]
-	if !ok {
-		store = initStore(proofType, f.ds)
-		f.stores[proofType] = store
-	}
-	f.storesLk.Unlock()
-	return put(ctx, store, hash, data)
+	return put(ctx, f.store(proofType), hash, data)
 }
 
 // verifyLocal checks if a fraud proof has been stored locally.
 func (f *ProofService) verifyLocal(ctx context.Context, proofType ProofType, hash string, data []byte) bool {
-	f.storesLk.RLock()
-	storage, ok := f.stores[proofType]
-	f.storesLk.RUnlock()
-	if !ok {
-		return false
-	}
-
-	proof, err := getByHash(ctx, storage, hash)
+	proof, err := getByHash(ctx, f.store(proofType), hash)
 	if err != nil {
 		if !errors.Is(err, datastore.ErrNotFound) {
 			log.Error(err)
@@ -242,3 +261,16 @@ func (f *ProofService) verifyLocal(ctx context.Context, proofType ProofType, has
 
 	return bytes.Equal(proof, data)
 }
+
+// store returns the datastore keeping fraud proofs of the given type.
+// Stores are initialized lazily, so that proofs persisted before a restart are found.
+func (f *ProofService) store(proofType ProofType) datastore.Datastore {
+	f.storesLk.Lock()
+	defer f.storesLk.Unlock()
+	store, ok := f.stores[proofType]
+	if !ok {
+		store = initStore(proofType, f.ds)
+		f.stores[proofType] = store
+	}
+	return store
+}
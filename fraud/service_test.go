@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 func TestService_Subscribe(t *testing.T) {
@@ -70,16 +71,19 @@ func TestService_processIncoming(t *testing.T) {
 		precondition     func()
 		proof            *mockProof
 		validationResult pubsub.ValidationResult
+		event            events.Type
 	}{
 		{
 			nil,
 			newValidProof(),
 			pubsub.ValidationAccept,
+			events.FraudProof,
 		},
 		{
 			nil,
 			newInvalidProof(),
 			pubsub.ValidationReject,
+			events.PeerBanned,
 		},
 		{
 			func() {
@@ -87,6 +91,7 @@ func TestService_processIncoming(t *testing.T) {
 			},
 			newValidProof(),
 			pubsub.ValidationReject,
+			events.PeerBanned,
 		},
 	}
 	for _, test := range tests {
@@ -94,6 +99,8 @@ func TestService_processIncoming(t *testing.T) {
 		require.NoError(t, err)
 		// create first fraud service that will broadcast incorrect Fraud Proof
 		service, _ := createTestServiceWithHost(ctx, t, net.Hosts()[0], false)
+		service.events = events.NewBus()
+		sub := service.events.Subscribe()
 		msg := &pubsub.Message{
 			Message: &pubsubpb.Message{
 				Data: bin,
@@ -106,6 +113,9 @@ func TestService_processIncoming(t *testing.T) {
 		require.NoError(t, service.Start(ctx))
 		res := service.processIncoming(ctx, test.proof.Type(), net.Hosts()[1].ID(), msg)
 		require.True(t, res == test.validationResult)
+		e := <-sub.Out()
+		require.Equal(t, test.event, e.Type)
+		require.Equal(t, net.Hosts()[1].ID(), e.Peer)
 	}
 }
 
@@ -211,6 +221,41 @@ func TestService_Get(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_ProofPersistence(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+
+	proof := newValidProof()
+	bin, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	msg := &pubsub.Message{
+		Message:      &pubsubpb.Message{Data: bin},
+		ReceivedFrom: net.Hosts()[1].ID(),
+	}
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	newService := func() *ProofService {
+		ps, err := pubsub.NewGossipSub(ctx, net.Hosts()[0], pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign))
+		require.NoError(t, err)
+		store := createStore(t, 10)
+		return NewProofService(ps, net.Hosts()[0], store.GetByHeight, ds, false, "private")
+	}
+
+	service := newService()
+	res := service.processIncoming(ctx, proof.Type(), net.Hosts()[1].ID(), msg)
+	require.Equal(t, pubsub.ValidationAccept, res)
+
+	// a restarted service finds the verified proof on disk
+	service = newService()
+	proofs, err := service.Get(ctx, proof.Type())
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	res = service.processIncoming(ctx, proof.Type(), net.Hosts()[1].ID(), msg)
+	require.Equal(t, pubsub.ValidationIgnore, res)
+}
+
 func TestService_Sync(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	t.Cleanup(cancel)
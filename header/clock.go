@@ -0,0 +1,61 @@
+package header
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultClockDrift is the default of how much a new header's time can drift into the future
+	// relative to the now time during verification.
+	DefaultClockDrift = 10 * time.Second
+	// MaxClockOffset caps how much the estimated offset of the local clock widens the tolerated
+	// drift, as the offset is estimated from unauthenticated sources.
+	MaxClockOffset = 5 * time.Second
+)
+
+// Clock defines how much a new header's time can drift into the future relative to the now time
+// during verification, so that the nodes with slightly wrong clocks don't reject valid new
+// headers. The drift is widened by the estimated offset of the local clock while it's behind, up
+// to MaxClockOffset.
+// A nil Clock tolerates DefaultClockDrift.
+type Clock struct {
+	drift  time.Duration
+	offset atomic.Int64
+}
+
+// NewClock creates a new Clock tolerating the given drift.
+func NewClock(drift time.Duration) *Clock {
+	return &Clock{drift: drift}
+}
+
+// SetOffset sets the estimated offset of the local clock, e.g. measured over NTP, which is
+// positive while the local clock is behind.
+func (c *Clock) SetOffset(offset time.Duration) {
+	c.offset.Store(int64(offset))
+}
+
+// Offset returns the estimated offset of the local clock.
+func (c *Clock) Offset() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return time.Duration(c.offset.Load())
+}
+
+// Drift returns how much a new header's time can drift into the future during verification.
+func (c *Clock) Drift() time.Duration {
+	if c == nil {
+		return DefaultClockDrift
+	}
+
+	offset := c.Offset()
+	switch {
+	case offset < 0:
+		// the local clock being ahead never narrows the drift
+		offset = 0
+	case offset > MaxClockOffset:
+		offset = MaxClockOffset
+	}
+	return c.drift + offset
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/eds"
 )
 
 // Listener is responsible for listening to Core for
@@ -23,21 +24,30 @@ type Listener struct {
 	bcast     header.Broadcaster
 	fetcher   *core.BlockFetcher
 	bServ     blockservice.BlockService
+	store     *eds.Store
 	construct header.ConstructFn
 	cancel    context.CancelFunc
+
+	// queue of the new headers, the squares of which are to be kept in the store
+	squares chan *header.ExtendedHeader
 }
 
+// NewListener creates a new Listener.
+// The squares of the new blocks are kept in the given eds.Store, unless it is nil.
 func NewListener(
 	bcast header.Broadcaster,
 	fetcher *core.BlockFetcher,
 	bServ blockservice.BlockService,
+	store *eds.Store,
 	construct header.ConstructFn,
 ) *Listener {
 	return &Listener{
 		bcast:     bcast,
 		fetcher:   fetcher,
 		bServ:     bServ,
+		store:     store,
 		construct: construct,
+		squares:   make(chan *header.ExtendedHeader, 16),
 	}
 }
 
@@ -54,6 +64,9 @@ func (cl *Listener) Start(ctx context.Context) error {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go cl.listen(ctx, sub)
+	if cl.store != nil {
+		go cl.storeLoop(ctx)
+	}
 	cl.cancel = cancel
 	return nil
 }
@@ -77,22 +90,24 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan *types.Block) {
 				return
 			}
 
+			// a failure to process a single block must not stop the listener, as the header is
+			// requested again from Core by the syncer once a subsequent one is received
 			syncing, err := cl.fetcher.IsSyncing(ctx)
 			if err != nil {
-				log.Errorw("listener: getting sync state", "err", err)
-				return
+				log.Errorw("listener: getting sync state", "height", b.Height, "err", err)
+				continue
 			}
 
 			comm, vals, err := cl.fetcher.GetBlockInfo(ctx, &b.Height)
 			if err != nil {
-				log.Errorw("listener: getting block info", "err", err)
-				return
+				log.Errorw("listener: getting block info", "height", b.Height, "err", err)
+				continue
 			}
 
 			eh, err := cl.construct(ctx, b, comm, vals, cl.bServ)
 			if err != nil {
-				log.Errorw("listener: making extended header", "err", err)
-				return
+				log.Errorw("listener: making extended header", "height", b.Height, "err", err)
+				continue
 			}
 
 			// broadcast new ExtendedHeader, but if core is still syncing, notify only local subscribers
@@ -101,8 +116,43 @@ func (cl *Listener) listen(ctx context.Context, sub <-chan *types.Block) {
 				log.Errorw("listener: broadcasting next header", "height", eh.Height,
 					"err", err)
 			}
+
+			if cl.store != nil {
+				// the square is stored in the background, so that storing never delays the
+				// headers, unless it falls behind by more than the queue holds
+				select {
+				case cl.squares <- eh:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// storeLoop keeps the squares of the queued headers in the eds.Store.
+func (cl *Listener) storeLoop(ctx context.Context) {
+	for {
+		select {
+		case eh := <-cl.squares:
+			err := cl.storeSquare(ctx, eh)
+			if err != nil {
+				log.Errorw("listener: storing square", "height", eh.Height, "err", err)
+			}
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// storeSquare keeps the square of the given ExtendedHeader in the eds.Store. The square is read
+// back from the blockstore the ConstructFn has put its shares into.
+func (cl *Listener) storeSquare(ctx context.Context, eh *header.ExtendedHeader) error {
+	square, err := eds.NewRetriever(cl.bServ).Retrieve(ctx, eh.DAH)
+	if err != nil {
+		return err
+	}
+	return cl.store.Put(ctx, *eh.DAH, square)
+}
@@ -2,19 +2,26 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
 	mdutils "github.com/ipfs/go-merkledag/test"
 	"github.com/libp2p/go-libp2p-core/event"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/types"
 
 	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/header/p2p"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
 )
 
 // TestListener tests the lifecycle of the core listener.
@@ -25,7 +32,7 @@ func TestListener(t *testing.T) {
 	// create mocknet with two pubsub endpoints
 	ps0, ps1 := createMocknetWithTwoPubsubEndpoints(ctx, t)
 	// create second subscription endpoint to listen for Listener's pubsub messages
-	topic, err := ps1.Join(p2p.PubSubTopic)
+	topic, err := ps1.Join(p2p.PubSubTopicID("private"))
 	require.NoError(t, err)
 	sub, err := topic.Subscribe()
 	require.NoError(t, err)
@@ -53,6 +60,106 @@ func TestListener(t *testing.T) {
 	require.Nil(t, cl.cancel)
 }
 
+// TestListener_SkipsFailedBlocks tests that the core listener keeps listening
+// after failing to process a block.
+func TestListener_SkipsFailedBlocks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	ps0, ps1 := createMocknetWithTwoPubsubEndpoints(ctx, t)
+	topic, err := ps1.Join(p2p.PubSubTopicID("private"))
+	require.NoError(t, err)
+	sub, err := topic.Subscribe()
+	require.NoError(t, err)
+
+	p2pSub := p2p.NewSubscriber(ps0, "private")
+	err = p2pSub.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := p2pSub.Stop(ctx)
+		require.NoError(t, err)
+	})
+
+	var failed bool
+	construct := func(
+		ctx context.Context,
+		b *coretypes.Block,
+		comm *coretypes.Commit,
+		vals *coretypes.ValidatorSet,
+		bServ blockservice.BlockService,
+	) (*header.ExtendedHeader, error) {
+		if !failed {
+			failed = true
+			return nil, errors.New("failed")
+		}
+		return header.MakeExtendedHeader(ctx, b, comm, vals, bServ)
+	}
+	cl := NewListener(p2pSub, createCoreFetcher(t), mdutils.Bserv(), nil, construct)
+	err = cl.Start(ctx)
+	require.NoError(t, err)
+
+	// ensure headers following the failed one are still broadcasted
+	msg, err := sub.Next(ctx)
+	require.NoError(t, err)
+	var resp header.ExtendedHeader
+	err = resp.UnmarshalBinary(msg.Data)
+	require.NoError(t, err)
+	assert.True(t, failed)
+
+	err = cl.Stop(ctx)
+	require.NoError(t, err)
+}
+
+// TestListener_StoresSquares tests that the core listener keeps the squares of the new blocks in
+// the eds.Store.
+func TestListener_StoresSquares(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+
+	ps0, ps1 := createMocknetWithTwoPubsubEndpoints(ctx, t)
+	topic, err := ps1.Join(p2p.PubSubTopicID("private"))
+	require.NoError(t, err)
+	sub, err := topic.Subscribe()
+	require.NoError(t, err)
+
+	p2pSub := p2p.NewSubscriber(ps0, "private")
+	err = p2pSub.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := p2pSub.Stop(ctx)
+		require.NoError(t, err)
+	})
+
+	bServ := mdutils.Bserv()
+	err = share.EnsureEmptySquareExists(ctx, bServ)
+	require.NoError(t, err)
+	store, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	err = store.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	cl := NewListener(p2pSub, createCoreFetcher(t), bServ, store, header.MakeExtendedHeader)
+	err = cl.Start(ctx)
+	require.NoError(t, err)
+
+	msg, err := sub.Next(ctx)
+	require.NoError(t, err)
+	var eh header.ExtendedHeader
+	err = eh.UnmarshalBinary(msg.Data)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		has, _ := store.Has(ctx, *eh.DAH)
+		return has
+	}, time.Second*10, time.Millisecond*50)
+
+	err = cl.Stop(ctx)
+	require.NoError(t, err)
+}
+
 func createMocknetWithTwoPubsubEndpoints(ctx context.Context, t *testing.T) (*pubsub.PubSub, *pubsub.PubSub) {
 	net, err := mocknet.FullMeshLinked(2)
 	require.NoError(t, err)
@@ -94,7 +201,7 @@ func createListener(
 	fetcher *core.BlockFetcher,
 	ps *pubsub.PubSub,
 ) *Listener {
-	p2pSub := p2p.NewSubscriber(ps)
+	p2pSub := p2p.NewSubscriber(ps, "private")
 	err := p2pSub.Start(ctx)
 	require.NoError(t, err)
 	t.Cleanup(func() {
@@ -102,5 +209,5 @@ func createListener(
 		require.NoError(t, err)
 	})
 
-	return NewListener(p2pSub, fetcher, mdutils.Bserv(), header.MakeExtendedHeader)
+	return NewListener(p2pSub, fetcher, mdutils.Bserv(), nil, header.MakeExtendedHeader)
 }
@@ -0,0 +1,19 @@
+package header
+
+import (
+	"testing"
+)
+
+func FuzzUnmarshalExtendedHeader(f *testing.F) {
+	bin, err := RandExtendedHeader(&testing.T{}).MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(bin)
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// malformed headers must be rejected with an error, rather than panic
+		UnmarshalExtendedHeader(data) //nolint:errcheck
+	})
+}
@@ -0,0 +1,190 @@
+// Package gateway implements the header.Exchange requesting headers over HTTPS from the gateways
+// of trusted nodes, for the networks where libp2p connectivity is blocked.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	logging "github.com/ipfs/go-log/v2"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var log = logging.Logger("header/gateway")
+
+const (
+	headEndpoint           = "/head"
+	headerByHeightEndpoint = "/header"
+)
+
+var (
+	// ErrNoEndpoints is returned when the Exchange has no gateways to request headers from.
+	ErrNoEndpoints = errors.New("header/gateway: no endpoints")
+	// errUnsupported is returned for the requests the gateways do not serve.
+	errUnsupported = errors.New("header/gateway: requesting headers by hash is not supported")
+)
+
+// maxResponseSize limits the size of a header read from a gateway.
+const maxResponseSize = 1 << 22
+
+// rangeConcurrency is the amount of headers of a range requested in parallel.
+var rangeConcurrency = 8
+
+// Exchange requests headers from the gateways at the given endpoints. The responses pass the same
+// validation as the ones of the header/p2p Exchange, so they must be verified thereafter as well.
+type Exchange struct {
+	endpoints []string
+	client    *http.Client
+}
+
+// NewExchange creates a new Exchange requesting headers from the given HTTPS endpoints with the
+// given client.
+func NewExchange(endpoints []string, client *http.Client) (*Exchange, error) {
+	for _, endpoint := range endpoints {
+		if err := ValidateEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return &Exchange{
+		endpoints: endpoints,
+		client:    client,
+	}, nil
+}
+
+// ValidateEndpoint ensures the endpoint is an HTTPS URL.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("header/gateway: invalid endpoint %s: %w", endpoint, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("header/gateway: endpoint %s must be an HTTPS URL", endpoint)
+	}
+	return nil
+}
+
+// Head requests the latest header from all the gateways and returns the highest one.
+func (ex *Exchange) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	if len(ex.endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	heads := make([]*header.ExtendedHeader, len(ex.endpoints))
+	var wg errgroup.Group
+	for i, endpoint := range ex.endpoints {
+		i, endpoint := i, endpoint
+		wg.Go(func() error {
+			h, err := ex.request(ctx, endpoint, headEndpoint)
+			if err != nil {
+				log.Debugw("requesting head", "endpoint", endpoint, "err", err)
+				return nil
+			}
+			heads[i] = h
+			return nil
+		})
+	}
+	wg.Wait() //nolint:errcheck
+
+	var head *header.ExtendedHeader
+	for _, h := range heads {
+		if h != nil && (head == nil || h.Height > head.Height) {
+			head = h
+		}
+	}
+	if head == nil {
+		return nil, fmt.Errorf("header/gateway: no endpoint served the head")
+	}
+	return head, nil
+}
+
+// GetByHeight requests the header at the given height from the gateways in turn, until one serves
+// it.
+func (ex *Exchange) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	if height == 0 {
+		return nil, fmt.Errorf("specified request height must be greater than 0")
+	}
+	if len(ex.endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	var err error
+	for _, endpoint := range ex.endpoints {
+		var h *header.ExtendedHeader
+		h, err = ex.request(ctx, endpoint, fmt.Sprintf("%s/%d", headerByHeightEndpoint, height))
+		if err != nil {
+			log.Debugw("requesting header", "endpoint", endpoint, "height", height, "err", err)
+			continue
+		}
+		if uint64(h.Height) != height {
+			err = fmt.Errorf("header/gateway: %s served header at height %d instead of %d", endpoint, h.Height, height)
+			continue
+		}
+		return h, nil
+	}
+	return nil, err
+}
+
+// GetRangeByHeight requests the given amount of headers starting from the given height.
+func (ex *Exchange) GetRangeByHeight(ctx context.Context, from, amount uint64) ([]*header.ExtendedHeader, error) {
+	headers := make([]*header.ExtendedHeader, amount)
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.SetLimit(rangeConcurrency)
+	for i := range headers {
+		i := i
+		errg.Go(func() (err error) {
+			headers[i], err = ex.GetByHeight(ctx, from+uint64(i))
+			return err
+		})
+	}
+	if err := errg.Wait(); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+// Get is not supported by the gateways, which only serve headers by height.
+func (ex *Exchange) Get(context.Context, tmbytes.HexBytes) (*header.ExtendedHeader, error) {
+	return nil, errUnsupported
+}
+
+// request gets the header from the path of the endpoint and validates it.
+func (ex *Exchange) request(ctx context.Context, endpoint, path string) (_ *header.ExtendedHeader, err error) {
+	defer func() {
+		// malformed responses fail the request, rather than panic the node
+		if r := recover(); r != nil {
+			err = fmt.Errorf("header/gateway: panic while validating header: %v", r)
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ex.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("header/gateway: status %d: %s", resp.StatusCode, body)
+	}
+
+	h := new(header.ExtendedHeader)
+	if err = json.Unmarshal(body, h); err != nil {
+		return nil, err
+	}
+	return h, h.ValidateBasic()
+}
@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+func TestExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	srv := newTestGateway(t, headers)
+	ex, err := NewExchange([]string{srv.URL}, srv.Client())
+	require.NoError(t, err)
+
+	head, err := ex.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, headers[len(headers)-1].Hash(), head.Hash())
+
+	h, err := ex.GetByHeight(ctx, uint64(headers[2].Height))
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), h.Hash())
+
+	rng, err := ex.GetRangeByHeight(ctx, uint64(headers[3].Height), 5)
+	require.NoError(t, err)
+	require.Len(t, rng, 5)
+	for i, h := range rng {
+		assert.Equal(t, headers[3+i].Hash(), h.Hash())
+	}
+
+	_, err = ex.GetByHeight(ctx, uint64(headers[len(headers)-1].Height+1))
+	assert.Error(t, err)
+	_, err = ex.Get(ctx, headers[0].Hash())
+	assert.Error(t, err)
+}
+
+// TestExchange_InvalidResponses ensures the headers not passing validation or served for another
+// height are rejected.
+func TestExchange_InvalidResponses(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(2)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		invalid := *headers[0]
+		invalid.Commit = headers[1].Commit
+		data, err := json.Marshal(&invalid)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(data) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	ex, err := NewExchange([]string{srv.URL}, srv.Client())
+	require.NoError(t, err)
+	_, err = ex.Head(ctx)
+	assert.Error(t, err)
+	_, err = ex.GetByHeight(ctx, uint64(headers[1].Height))
+	assert.Error(t, err)
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	assert.NoError(t, ValidateEndpoint("https://gateway.example.com:26659"))
+	assert.Error(t, ValidateEndpoint("http://gateway.example.com:26659"))
+	assert.Error(t, ValidateEndpoint("gateway.example.com"))
+	_, err := NewExchange([]string{"http://gateway.example.com"}, http.DefaultClient)
+	assert.Error(t, err)
+}
+
+// newTestGateway serves the given headers the way the node's gateway does.
+func newTestGateway(t *testing.T, headers []*header.ExtendedHeader) *httptest.Server {
+	byHeight := make(map[int64]*header.ExtendedHeader, len(headers))
+	for _, h := range headers {
+		byHeight[h.Height] = h
+	}
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := headers[len(headers)-1]
+		if path := strings.TrimPrefix(r.URL.Path, headerByHeightEndpoint+"/"); path != r.URL.Path {
+			height, err := strconv.ParseInt(path, 10, 64)
+			if err != nil || byHeight[height] == nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			h = byHeight[height]
+		}
+		data, err := json.Marshal(h)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write(data) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
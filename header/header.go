@@ -110,16 +110,22 @@ func (eh *ExtendedHeader) ValidateBasic() error {
 		return err
 	}
 
-	err = eh.ValidatorSet.ValidateBasic()
-	if err != nil {
-		return err
-	}
+	// reuse the validated validator set of the previous headers, if it's the same
+	if vals, ok := validatorSets.get(eh.ValidatorsHash); ok {
+		eh.ValidatorSet = vals
+	} else {
+		err = eh.ValidatorSet.ValidateBasic()
+		if err != nil {
+			return err
+		}
 
-	// make sure the validator set is consistent with the header
-	if valSetHash := eh.ValidatorSet.Hash(); !bytes.Equal(eh.ValidatorsHash, valSetHash) {
-		return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
-			eh.ValidatorsHash, valSetHash,
-		)
+		// make sure the validator set is consistent with the header
+		if valSetHash := eh.ValidatorSet.Hash(); !bytes.Equal(eh.ValidatorsHash, valSetHash) {
+			return fmt.Errorf("expected validator hash of header to match validator set hash (%X != %X)",
+				eh.ValidatorsHash, valSetHash,
+			)
+		}
+		validatorSets.add(eh.ValidatorsHash, eh.ValidatorSet)
 	}
 
 	if err := eh.ValidatorSet.VerifyCommitLight(eh.ChainID, eh.Commit.BlockID, eh.Height, eh.Commit); err != nil {
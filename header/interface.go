@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ipfs/go-blockservice"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -104,6 +105,9 @@ type Store interface {
 	// Has checks whether ExtendedHeader is already stored.
 	Has(context.Context, tmbytes.HexBytes) (bool, error)
 
+	// GetByTime returns the ExtendedHeader of the latest block produced at or before the given time.
+	GetByTime(context.Context, time.Time) (*ExtendedHeader, error)
+
 	// Append stores and verifies the given ExtendedHeader(s).
 	// It requires them to be adjacent and in ascending order,
 	// as it applies them contiguously on top of the current head height.
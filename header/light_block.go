@@ -0,0 +1,58 @@
+package header
+
+import (
+	"errors"
+
+	amino "github.com/tendermint/tendermint/libs/json"
+	core "github.com/tendermint/tendermint/types"
+)
+
+// LightBlock bundles the header of a block with the commit and the validator set signing it, so
+// that external verifiers, e.g. Tendermint light clients, can check the header independently.
+// It is encoded to JSON in the same way as by Tendermint.
+type LightBlock struct {
+	*core.LightBlock
+}
+
+// LightBlock exports the ExtendedHeader as a LightBlock.
+func (eh *ExtendedHeader) LightBlock() *LightBlock {
+	return &LightBlock{
+		LightBlock: &core.LightBlock{
+			SignedHeader: &core.SignedHeader{
+				Header: &eh.RawHeader,
+				Commit: eh.Commit,
+			},
+			ValidatorSet: eh.ValidatorSet,
+		},
+	}
+}
+
+// Verify checks the LightBlock is consistent and signed by more than 2/3 of its validators.
+// The trust in the validators themselves is established by verifying the LightBlock against a
+// trusted one, as done by Tendermint light clients.
+func (lb *LightBlock) Verify(chainID string) error {
+	if lb.LightBlock == nil {
+		return &VerifyError{Reason: errors.New("empty light block")}
+	}
+	if err := lb.ValidateBasic(chainID); err != nil {
+		return &VerifyError{Reason: err}
+	}
+	err := lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit)
+	if err != nil {
+		return &VerifyError{Reason: err}
+	}
+	return nil
+}
+
+// MarshalJSON marshals a LightBlock to JSON with amino encoding, to be able to unmarshal the
+// crypto.PubKey type back from JSON.
+func (lb *LightBlock) MarshalJSON() ([]byte, error) {
+	return amino.Marshal(lb.LightBlock)
+}
+
+// UnmarshalJSON unmarshals a LightBlock from JSON with amino encoding, to be able to unmarshal the
+// crypto.PubKey type back from JSON.
+func (lb *LightBlock) UnmarshalJSON(data []byte) error {
+	lb.LightBlock = new(core.LightBlock)
+	return amino.Unmarshal(data, lb.LightBlock)
+}
@@ -0,0 +1,33 @@
+package header
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendedHeader_LightBlock(t *testing.T) {
+	eh := NewTestSuite(t, 3).GenExtendedHeaders(2)[1]
+
+	lb := eh.LightBlock()
+	require.NoError(t, lb.Verify(eh.ChainID))
+	assert.Equal(t, eh.Hash(), lb.Hash())
+
+	// the exported light block survives JSON
+	data, err := json.Marshal(lb)
+	require.NoError(t, err)
+	out := new(LightBlock)
+	require.NoError(t, json.Unmarshal(data, out))
+	require.NoError(t, out.Verify(eh.ChainID))
+	assert.Equal(t, lb.Hash(), out.Hash())
+	assert.Equal(t, lb.ValidatorSet.Hash(), out.ValidatorSet.Hash())
+
+	// the tampered light blocks are not verified
+	assert.Error(t, out.Verify("other-chain"))
+	out.Time = out.Time.Add(time.Second)
+	assert.Error(t, out.Verify(eh.ChainID))
+	assert.Error(t, new(LightBlock).Verify(eh.ChainID))
+}
@@ -0,0 +1,101 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// minBatchSize is the least amount of headers requested from a peer at once.
+	minBatchSize uint64 = 16
+	// initialBatchSize is the amount of headers requested from a peer at once, until its latency is
+	// observed.
+	initialBatchSize uint64 = 64
+	// maxBatchLatency is the latency of a batch the size of the batches of a peer is shrunk above,
+	// far enough from readDeadline not to time out.
+	maxBatchLatency = readDeadline / 4
+	// throughputDecay is the rate the best throughput of a peer decays at with every batch, so that
+	// the size adapts to the peer slowing down over time.
+	throughputDecay = 0.98
+	// throughputTolerance is the share of the best throughput of a peer a batch may fall to, before
+	// the size of the batches of the peer is considered too large.
+	throughputTolerance = 0.9
+)
+
+// batchSizer adapts the amount of headers requested from each peer at once to the observed latency
+// and errors of its responses, converging on the batches with the highest throughput. The size of
+// the batches of a peer grows while its throughput holds up, shrinks once the throughput drops or
+// the latency approaches the deadline, and halves on errors.
+type batchSizer struct {
+	lk    sync.Mutex
+	peers map[peer.ID]*peerBatch
+}
+
+type peerBatch struct {
+	size uint64
+	// throughput is the best recent throughput in headers received per second.
+	throughput float64
+}
+
+func newBatchSizer() *batchSizer {
+	return &batchSizer{peers: make(map[peer.ID]*peerBatch)}
+}
+
+// size returns the amount of headers to request from the peer at once.
+func (bs *batchSizer) size(p peer.ID) uint64 {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	return bs.batch(p).size
+}
+
+// observe adapts the size of the batches of the peer to the result of requesting 'amount' headers
+// from it, which took 'took'.
+func (bs *batchSizer) observe(p peer.ID, amount uint64, took time.Duration, err error) {
+	bs.lk.Lock()
+	defer bs.lk.Unlock()
+	batch := bs.batch(p)
+
+	switch {
+	case err != nil:
+		batch.size = clampBatch(batch.size / 2)
+		batch.throughput /= 2
+	case took > maxBatchLatency:
+		batch.size = clampBatch(batch.size * 3 / 4)
+	case amount < batch.size:
+		// the requests trimmed to the end of the range tell nothing about the size
+	default:
+		throughput := float64(amount) / took.Seconds()
+		if throughput >= batch.throughput*throughputTolerance {
+			batch.size = clampBatch(batch.size + batch.size/2)
+		} else {
+			batch.size = clampBatch(batch.size * 3 / 4)
+		}
+		batch.throughput *= throughputDecay
+		if throughput > batch.throughput {
+			batch.throughput = throughput
+		}
+	}
+}
+
+// batch returns the batch of the peer, creating it, if missing. It must be called under the lock.
+func (bs *batchSizer) batch(p peer.ID) *peerBatch {
+	batch, ok := bs.peers[p]
+	if !ok {
+		batch = &peerBatch{size: initialBatchSize}
+		bs.peers[p] = batch
+	}
+	return batch
+}
+
+func clampBatch(size uint64) uint64 {
+	switch {
+	case size < minBatchSize:
+		return minBatchSize
+	case size > maxRequestSize:
+		return maxRequestSize
+	default:
+		return size
+	}
+}
@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSizer_Converges(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	// the peer serves up to 128 headers at the same rate, while the larger batches slow it down
+	latency := func(amount uint64) time.Duration {
+		if amount <= 128 {
+			return time.Duration(amount) * time.Millisecond
+		}
+		return time.Duration(amount*amount/128) * time.Millisecond
+	}
+
+	bs := newBatchSizer()
+	assert.Equal(t, initialBatchSize, bs.size(p))
+	for i := 0; i < 50; i++ {
+		size := bs.size(p)
+		bs.observe(p, size, latency(size), nil)
+	}
+	size := bs.size(p)
+	assert.GreaterOrEqual(t, size, uint64(64))
+	assert.LessOrEqual(t, size, uint64(256))
+}
+
+func TestBatchSizer_Errors(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	bs := newBatchSizer()
+	bs.observe(p, initialBatchSize, 0, errors.New("stream reset"))
+	assert.Equal(t, initialBatchSize/2, bs.size(p))
+	for i := 0; i < 10; i++ {
+		bs.observe(p, bs.size(p), 0, errors.New("stream reset"))
+	}
+	assert.Equal(t, minBatchSize, bs.size(p))
+
+	// the batches approaching the deadline shrink
+	bs = newBatchSizer()
+	bs.observe(p, initialBatchSize, maxBatchLatency*2, nil)
+	assert.Less(t, bs.size(p), initialBatchSize)
+
+	// the trimmed batches don't change the size
+	bs = newBatchSizer()
+	bs.observe(p, 1, time.Millisecond, nil)
+	assert.Equal(t, initialBatchSize, bs.size(p))
+}
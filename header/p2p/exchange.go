@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"sync"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
@@ -13,11 +14,14 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/celestiaorg/go-libp2p-messenger/serde"
 
 	"github.com/celestiaorg/celestia-node/header"
 	p2p_pb "github.com/celestiaorg/celestia-node/header/p2p/pb"
+	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
 var log = logging.Logger("header/p2p")
@@ -27,15 +31,22 @@ const (
 	writeDeadline = time.Second * 5
 	// readDeadline sets timeout for reading messages from the stream
 	readDeadline = time.Minute
+	// requestReadDeadline sets timeout for reading the request message, which is tiny, so that
+	// a slow peer cannot hold the server for the whole readDeadline
+	requestReadDeadline = time.Second * 5
 	// the target minimum amount of responses with the same chain head
 	minResponses = 2
 	// requestSize defines the max amount of headers that can be requested/handled at once.
 	maxRequestSize uint64 = 512
+	// rangeAttempts is the amount of attempts to request a batch of a range, each from another
+	// random peer.
+	rangeAttempts = 3
 )
 
-// PubSubTopic hardcodes the name of the ExtendedHeader
-// gossipsub topic.
-const PubSubTopic = "header-sub"
+// PubSubTopicID formats the name of the ExtendedHeader gossipsub topic of the given network.
+func PubSubTopicID(networkID string) string {
+	return fmt.Sprintf("/%s/header-sub/v0.0.1", networkID)
+}
 
 // Exchange enables sending outbound ExtendedHeaderRequests to the network as well as
 // handling inbound ExtendedHeaderRequests from the network.
@@ -45,18 +56,42 @@ type Exchange struct {
 	host host.Host
 
 	trustedPeers peer.IDSlice
+	// inflight deduplicates the concurrent identical requests
+	inflight singleflight.Group
+
+	// deprecationsLk protects the latest notices of the deprecation of the protocol by the peers,
+	// so that each one is warned about once
+	deprecationsLk sync.Mutex
+	deprecations   map[peer.ID]string
+
+	// batches adapts the amount of headers of a range requested from each peer at once
+	batches *batchSizer
+
+	Params *Parameters
 }
 
-func protocolID(protocolSuffix string) protocol.ID {
-	return protocol.ID(fmt.Sprintf("/header-ex/v0.0.3/%s", protocolSuffix))
+// ProtocolID formats the ID of the header exchange protocol of the given network.
+func ProtocolID(networkID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/header-ex/v0.0.3", networkID))
 }
 
-func NewExchange(host host.Host, peers peer.IDSlice, protocolSuffix string) *Exchange {
+func NewExchange(host host.Host, peers peer.IDSlice, networkID string, opts ...Option) (*Exchange, error) {
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(params)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &Exchange{
 		host:         host,
-		protocolID:   protocolID(protocolSuffix),
+		protocolID:   ProtocolID(networkID),
 		trustedPeers: peers,
-	}
+		deprecations: make(map[peer.ID]string),
+		batches:      newBatchSizer(),
+		Params:       params,
+	}, nil
 }
 
 // Head requests the latest ExtendedHeader. Note that the ExtendedHeader
@@ -127,6 +162,8 @@ func (ex *Exchange) GetByHeight(ctx context.Context, height uint64) (*header.Ext
 
 // GetRangeByHeight performs a request for the given range of ExtendedHeaders
 // to the network. Note that the ExtendedHeaders must be verified thereafter.
+// The range is requested in batches, whose size is adapted to the latency and errors observed for
+// each peer.
 func (ex *Exchange) GetRangeByHeight(ctx context.Context, from, amount uint64) ([]*header.ExtendedHeader, error) {
 	log.Debugw("requesting headers", "from", from, "to", from+amount)
 	// create request
@@ -134,7 +171,59 @@ func (ex *Exchange) GetRangeByHeight(ctx context.Context, from, amount uint64) (
 		Data:   &p2p_pb.ExtendedHeaderRequest_Origin{Origin: from},
 		Amount: amount,
 	}
-	return ex.performRequest(ctx, req)
+	if amount == 0 {
+		return make([]*header.ExtendedHeader, 0), nil
+	}
+	if amount > maxRequestSize {
+		return nil, header.ErrHeadersLimitExceeded
+	}
+	if len(ex.trustedPeers) == 0 {
+		return nil, fmt.Errorf("no trusted peers")
+	}
+
+	headers, err := utils.DoShared(ctx, &ex.inflight, requestKey(req),
+		func(ctx context.Context) ([]*header.ExtendedHeader, error) {
+			return ex.requestRange(ctx, from, amount)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return append([]*header.ExtendedHeader(nil), headers...), nil
+}
+
+// requestRange requests the range in batches from random peers, retrying the failed batches with
+// other ones.
+func (ex *Exchange) requestRange(ctx context.Context, from, amount uint64) ([]*header.ExtendedHeader, error) {
+	out := make([]*header.ExtendedHeader, 0, amount)
+	for failed := 0; uint64(len(out)) < amount; {
+		//nolint:gosec // G404: Use of weak random number generator
+		to := ex.trustedPeers[rand.Intn(len(ex.trustedPeers))]
+		size := ex.batches.size(to)
+		if left := amount - uint64(len(out)); size > left {
+			size = left
+		}
+
+		start := time.Now()
+		headers, err := ex.request(ctx, to, &p2p_pb.ExtendedHeaderRequest{
+			Data:   &p2p_pb.ExtendedHeaderRequest_Origin{Origin: from + uint64(len(out))},
+			Amount: size,
+		})
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		ex.batches.observe(to, size, time.Since(start), err)
+		if err != nil {
+			failed++
+			if failed == rangeAttempts {
+				return nil, err
+			}
+			log.Debugw("retrying batch of headers", "peer", to, "from", from+uint64(len(out)), "amount", size,
+				"err", err)
+			continue
+		}
+		out = append(out, headers...)
+	}
+	return out, nil
 }
 
 // Get performs a request for the ExtendedHeader by the given hash corresponding
@@ -169,9 +258,28 @@ func (ex *Exchange) performRequest(
 		return nil, fmt.Errorf("no trusted peers")
 	}
 
-	//nolint:gosec // G404: Use of weak random number generator
-	index := rand.Intn(len(ex.trustedPeers))
-	return ex.request(ctx, ex.trustedPeers[index], req)
+	// the concurrent identical requests, e.g. from the Syncer and the RPC, share one round trip
+	headers, err := utils.DoShared(ctx, &ex.inflight, requestKey(req),
+		func(ctx context.Context) ([]*header.ExtendedHeader, error) {
+			//nolint:gosec // G404: Use of weak random number generator
+			index := rand.Intn(len(ex.trustedPeers))
+			return ex.request(ctx, ex.trustedPeers[index], req)
+		})
+	if err != nil {
+		return nil, err
+	}
+	// the result may be shared, so the callers must not modify the same slice
+	return append([]*header.ExtendedHeader(nil), headers...), nil
+}
+
+// requestKey identifies the identical ExtendedHeaderRequests.
+func requestKey(req *p2p_pb.ExtendedHeaderRequest) string {
+	switch data := req.Data.(type) {
+	case *p2p_pb.ExtendedHeaderRequest_Hash:
+		return fmt.Sprintf("hash:%X", data.Hash)
+	default:
+		return fmt.Sprintf("origin:%d:%d", req.GetOrigin(), req.Amount)
+	}
 }
 
 // request sends the ExtendedHeaderRequest to a remote peer.
@@ -184,7 +292,11 @@ func (ex *Exchange) request(
 	if err != nil {
 		return nil, err
 	}
-	if err = stream.SetWriteDeadline(time.Now().Add(writeDeadline)); err != nil {
+	if ex.Params.StreamHook != nil {
+		stream = ex.Params.StreamHook(stream)
+	}
+	deadline := streamDeadline(ctx)
+	if err = stream.SetWriteDeadline(opDeadline(writeDeadline, deadline)); err != nil {
 		log.Debugf("error setting deadline: %s", err)
 	}
 	// send request
@@ -197,30 +309,51 @@ func (ex *Exchange) request(
 	if err != nil {
 		log.Error(err)
 	}
-	// read responses
+	// read responses, while verifying the received headers in parallel, as verification of their
+	// commit signatures takes longer than reading
 	headers := make([]*header.ExtendedHeader, req.Amount)
+	verifiers := new(errgroup.Group)
+	verifiers.SetLimit(ex.Params.VerifyConcurrency)
 	for i := 0; i < int(req.Amount); i++ {
 		resp := new(p2p_pb.ExtendedHeaderResponse)
-		if err = stream.SetReadDeadline(time.Now().Add(readDeadline)); err != nil {
+		if err = stream.SetReadDeadline(opDeadline(readDeadline, deadline)); err != nil {
 			log.Debugf("error setting deadline: %s", err)
 		}
-		_, err := serde.Read(stream, resp)
+		err := readMessage(stream, resp, maxResponseMessageSize)
 		if err != nil {
-			stream.Reset() //nolint:errcheck
+			stream.Reset()   //nolint:errcheck
+			verifiers.Wait() //nolint:errcheck
 			return nil, err
 		}
 
-		if err = convertStatusCodeToError(resp.StatusCode); err != nil {
-			stream.Reset() //nolint:errcheck
-			return nil, err
+		if resp.Deprecation != "" {
+			ex.warnDeprecation(to, resp.Deprecation)
 		}
-		header, err := header.UnmarshalExtendedHeader(resp.Body)
-		if err != nil {
-			stream.Reset() //nolint:errcheck
+		if err = convertStatusCodeToError(resp.StatusCode); err != nil {
+			stream.Reset()   //nolint:errcheck
+			verifiers.Wait() //nolint:errcheck
 			return nil, err
 		}
 
-		headers[i] = header
+		i := i
+		verifiers.Go(func() (err error) {
+			defer func() {
+				// malformed responses fail the request, rather than panic the node
+				if r := recover(); r != nil {
+					err = fmt.Errorf("header/p2p: panic while verifying header: %v", r)
+				}
+			}()
+			header, err := header.UnmarshalExtendedHeader(resp.Body)
+			if err != nil {
+				return err
+			}
+			headers[i] = header
+			return nil
+		})
+	}
+	if err = verifiers.Wait(); err != nil {
+		stream.Reset() //nolint:errcheck
+		return nil, err
 	}
 	if err = stream.Close(); err != nil {
 		log.Errorw("closing stream", "err", err)
@@ -231,6 +364,19 @@ func (ex *Exchange) request(
 	return headers, nil
 }
 
+// warnDeprecation warns about the deprecation of the protocol noticed by the peer, unless already
+// warned about the same notice.
+func (ex *Exchange) warnDeprecation(p peer.ID, notice string) {
+	ex.deprecationsLk.Lock()
+	defer ex.deprecationsLk.Unlock()
+	if ex.deprecations[p] == notice {
+		return
+	}
+	ex.deprecations[p] = notice
+	log.Warnw("peer deprecated the header exchange protocol, upgrade the node to keep syncing headers",
+		"peer", p, "protocol", ex.protocolID, "notice", notice)
+}
+
 // bestHead chooses ExtendedHeader that matches the conditions:
 // * should have max height among received;
 // * should be received at least from 2 peers;
@@ -3,9 +3,13 @@ package p2p
 import (
 	"bytes"
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	libhost "github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +23,7 @@ import (
 	p2p_pb "github.com/celestiaorg/celestia-node/header/p2p/pb"
 )
 
-var privateProtocolID = protocolID("private")
+var privateProtocolID = ProtocolID("private")
 
 func TestExchange_RequestHead(t *testing.T) {
 	host, peer := createMocknet(t)
@@ -54,6 +58,59 @@ func TestExchange_RequestHeaders(t *testing.T) {
 	}
 }
 
+// TestExchange_RequestHeadersSequentially tests that the Exchange instance verifies the requested
+// headers with any concurrency.
+func TestExchange_RequestHeadersSequentially(t *testing.T) {
+	host, tpeer := createMocknet(t)
+	_, store := createP2PExAndServer(t, host, tpeer)
+
+	_, err := NewExchange(host, []peer.ID{tpeer.ID()}, "private", WithVerifyConcurrency(0))
+	require.Error(t, err)
+	exchg, err := NewExchange(host, []peer.ID{tpeer.ID()}, "private", WithVerifyConcurrency(1))
+	require.NoError(t, err)
+
+	gotHeaders, err := exchg.GetRangeByHeight(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Len(t, gotHeaders, 5)
+	for _, got := range gotHeaders {
+		assert.Equal(t, store.headers[got.Height].Hash(), got.Hash())
+	}
+}
+
+// TestExchange_RequestDeduplication tests that the concurrent identical requests share one round
+// trip.
+func TestExchange_RequestDeduplication(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	var streams atomic.Int32
+	ex := newFaultyExchange(t, nil, WithStreamHook(func(stream network.Stream) network.Stream {
+		streams.Add(1)
+		// give the concurrent requests the time to join the inflight one
+		return FaultHook(Faults{Latency: time.Millisecond * 100})(stream)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h, err := ex.GetByHeight(ctx, 3)
+			assert.NoError(t, err)
+			assert.EqualValues(t, 3, h.Height)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, streams.Load())
+
+	// the different requests are not deduplicated
+	_, err := ex.GetRangeByHeight(ctx, 3, 1)
+	require.NoError(t, err)
+	_, err = ex.GetByHeight(ctx, 4)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, streams.Load())
+}
+
 // TestExchange_RequestHeadersFails tests that the Exchange instance will return
 // header.ErrNotFound if it will not have requested header.
 func TestExchange_RequestHeadersFails(t *testing.T) {
@@ -89,6 +146,26 @@ func TestExchange_RequestHeadersLimitExceed(t *testing.T) {
 	require.ErrorAs(t, err, &header.ErrHeadersLimitExceeded)
 }
 
+// TestExchange_RequestFromOtherNetwork tests that the Exchange instance does not
+// get headers from peers of another network.
+func TestExchange_RequestFromOtherNetwork(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	host, tpeer := createMocknet(t)
+	serv := NewExchangeServer(tpeer, createStore(t, 5), "private")
+	err := serv.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		serv.Stop(context.Background()) //nolint:errcheck
+	})
+
+	exchg, err := NewExchange(host, []peer.ID{tpeer.ID()}, "other")
+	require.NoError(t, err)
+	_, err = exchg.GetByHeight(ctx, 5)
+	require.Error(t, err)
+}
+
 // TestExchange_RequestByHash tests that the Exchange instance can
 // respond to an ExtendedHeaderRequest for a hash instead of a height.
 func TestExchange_RequestByHash(t *testing.T) {
@@ -132,6 +209,25 @@ func TestExchange_RequestByHash(t *testing.T) {
 	assert.Equal(t, store.headers[reqHeight].Hash(), eh.Hash())
 }
 
+// TestExchange_Deprecation tests that the Exchange gets the notice of the deprecation of the protocol
+// from the server along with the requested headers.
+func TestExchange_Deprecation(t *testing.T) {
+	host, tpeer := createMocknet(t)
+	store := createStore(t, 5)
+	server := NewExchangeServer(tpeer, store, "private", WithDeprecation("upgrade to header-ex/v0.0.4"))
+	require.NoError(t, server.Start(context.Background()))
+	t.Cleanup(func() {
+		server.Stop(context.Background()) //nolint:errcheck
+	})
+	exchg, err := NewExchange(host, []peer.ID{tpeer.ID()}, "private")
+	require.NoError(t, err)
+
+	headers, err := exchg.GetRangeByHeight(context.Background(), 1, 5)
+	require.NoError(t, err)
+	assert.Len(t, headers, 5)
+	assert.Equal(t, "upgrade to header-ex/v0.0.4", exchg.deprecations[tpeer.ID()])
+}
+
 func Test_bestHead(t *testing.T) {
 	gen := func() []*header.ExtendedHeader {
 		suite := header.NewTestSuite(t, 3)
@@ -248,7 +344,9 @@ func createP2PExAndServer(t *testing.T, host, tpeer libhost.Host) (header.Exchan
 		serverSideEx.Stop(context.Background()) //nolint:errcheck
 	})
 
-	return NewExchange(host, []peer.ID{tpeer.ID()}, "private"), store
+	exchg, err := NewExchange(host, []peer.ID{tpeer.ID()}, "private")
+	require.NoError(t, err)
+	return exchg, store
 }
 
 type mockStore struct {
@@ -317,6 +415,15 @@ func (m *mockStore) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*h
 	return headers, nil
 }
 
+func (m *mockStore) GetByTime(_ context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	for height := m.headHeight; height > 0; height-- {
+		if h := m.headers[height]; h != nil && !h.Time.After(t) {
+			return h, nil
+		}
+	}
+	return nil, header.ErrNotFound
+}
+
 func (m *mockStore) Has(context.Context, tmbytes.HexBytes) (bool, error) {
 	return false, nil
 }
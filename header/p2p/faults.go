@@ -0,0 +1,100 @@
+package p2p
+
+import (
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+)
+
+// ErrFaultInjected is returned by the streams wrapped with FaultHook on the injected faults.
+var ErrFaultInjected = errors.New("header/p2p: fault injected")
+
+// StreamHook wraps the streams of the Exchange and ExchangeServer.
+type StreamHook func(network.Stream) network.Stream
+
+// Faults defines the faults injected by FaultHook into the messages written to the streams, so that
+// the handling of misbehaving peers can be exercised deterministically.
+type Faults struct {
+	// Latency delays every message.
+	Latency time.Duration
+	// After is the amount of messages written intact before the faults below are injected.
+	After int
+	// Reset resets the stream instead of writing the message.
+	Reset bool
+	// Truncate writes only the first half of the message and closes the stream for writing, while
+	// reporting the full message written.
+	Truncate bool
+	// Corrupt replaces the message with bytes which can't be decoded as protobuf, keeping its
+	// length prefix intact.
+	Corrupt bool
+}
+
+// FaultHook returns the StreamHook injecting the given faults into the streams.
+func FaultHook(faults Faults) StreamHook {
+	return func(s network.Stream) network.Stream {
+		return &faultyStream{Stream: s, faults: faults}
+	}
+}
+
+// faultyStream injects the Faults into the messages written to the stream.
+// It relies on every message being written with a single Write call, as serde does.
+type faultyStream struct {
+	network.Stream
+
+	faults Faults
+
+	lk        sync.Mutex
+	written   int
+	truncated bool
+}
+
+func (s *faultyStream) Write(p []byte) (int, error) {
+	if s.faults.Latency > 0 {
+		time.Sleep(s.faults.Latency)
+	}
+
+	s.lk.Lock()
+	s.written++
+	intact := s.written <= s.faults.After
+	s.lk.Unlock()
+	if intact {
+		return s.Stream.Write(p)
+	}
+
+	switch {
+	case s.faults.Reset:
+		s.Stream.Reset() //nolint:errcheck
+		return 0, ErrFaultInjected
+	case s.faults.Truncate:
+		s.lk.Lock()
+		defer s.lk.Unlock()
+		if s.truncated {
+			// the writer isn't aware of the truncation, so the rest is dropped silently
+			return len(p), nil
+		}
+		s.truncated = true
+
+		_, err := s.Stream.Write(p[:len(p)/2])
+		if err != nil {
+			return 0, err
+		}
+		return len(p), s.Stream.CloseWrite()
+	case s.faults.Corrupt:
+		corrupted := make([]byte, len(p))
+		_, n := binary.Uvarint(p)
+		if n <= 0 {
+			n = 0
+		}
+		copy(corrupted, p[:n])
+		// the varint of 0xff bytes overflows, which fails decoding of any protobuf
+		for i := n; i < len(corrupted); i++ {
+			corrupted[i] = 0xff
+		}
+		return s.Stream.Write(corrupted)
+	default:
+		return s.Stream.Write(p)
+	}
+}
@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaultHook(t *testing.T) {
+	var tests = []struct {
+		name   string
+		faults Faults
+	}{
+		{name: "reset", faults: Faults{Reset: true}},
+		{name: "truncate", faults: Faults{Truncate: true}},
+		{name: "corrupt", faults: Faults{Corrupt: true}},
+		{name: "corrupt after", faults: Faults{Corrupt: true, After: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			t.Cleanup(cancel)
+
+			// faults of the server responses
+			ex := newFaultyExchange(t, nil, WithStreamHook(FaultHook(tt.faults)))
+			_, err := ex.GetRangeByHeight(ctx, 1, 4)
+			require.Error(t, err)
+
+			// faults of the client requests
+			ex = newFaultyExchange(t, []Option{WithStreamHook(FaultHook(tt.faults))})
+			_, err = ex.GetByHeight(ctx, 1)
+			if tt.faults.After > 0 {
+				// the single request message is intact
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestFaultHook_Latency(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	latency := time.Millisecond * 50
+	ex := newFaultyExchange(t, nil, WithStreamHook(FaultHook(Faults{Latency: latency})))
+	start := time.Now()
+	headers, err := ex.GetRangeByHeight(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.Len(t, headers, 4)
+	assert.GreaterOrEqual(t, time.Since(start), latency*4)
+}
+
+func newFaultyExchange(t *testing.T, exOpts []Option, servOpts ...Option) *Exchange {
+	host, tpeer := createMocknet(t)
+	server := NewExchangeServer(tpeer, createStore(t, 5), "private", servOpts...)
+	err := server.Start(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		server.Stop(context.Background()) //nolint:errcheck
+	})
+
+	ex, err := NewExchange(host, []peer.ID{tpeer.ID()}, "private", exOpts...)
+	require.NoError(t, err)
+	return ex
+}
+
+// TestExchangeServer_Recover tests that the server survives a panic while handling a request.
+func TestExchangeServer_Recover(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	var panicked atomic.Bool
+	ex := newFaultyExchange(t, nil, WithStreamHook(func(stream network.Stream) network.Stream {
+		if panicked.CompareAndSwap(false, true) {
+			panic("malformed request")
+		}
+		return stream
+	}))
+	_, err := ex.GetByHeight(ctx, 1)
+	require.Error(t, err)
+	// the server keeps serving the later requests
+	_, err = ex.GetByHeight(ctx, 1)
+	require.NoError(t, err)
+}
@@ -0,0 +1,54 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	"github.com/celestiaorg/celestia-node/header"
+	p2p_pb "github.com/celestiaorg/celestia-node/header/p2p/pb"
+)
+
+func FuzzExtendedHeaderRequest(f *testing.F) {
+	for _, req := range []*p2p_pb.ExtendedHeaderRequest{
+		{Data: &p2p_pb.ExtendedHeaderRequest_Origin{Origin: 1}, Amount: 10},
+		{Data: &p2p_pb.ExtendedHeaderRequest_Hash{Hash: make([]byte, 32)}, Amount: 1},
+	} {
+		f.Add(marshalMessage(f, req))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// malformed requests must be rejected with an error, rather than panic
+		req := new(p2p_pb.ExtendedHeaderRequest)
+		readMessage(bytes.NewReader(data), req, maxRequestMessageSize) //nolint:errcheck
+	})
+}
+
+func FuzzExtendedHeaderResponse(f *testing.F) {
+	bin, err := header.RandExtendedHeader(&testing.T{}).MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(marshalMessage(f, &p2p_pb.ExtendedHeaderResponse{Body: bin, StatusCode: p2p_pb.StatusCode_OK}))
+	f.Add(marshalMessage(f, &p2p_pb.ExtendedHeaderResponse{StatusCode: p2p_pb.StatusCode_NOT_FOUND}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// malformed responses must be rejected with an error, rather than panic
+		resp := new(p2p_pb.ExtendedHeaderResponse)
+		err := readMessage(bytes.NewReader(data), resp, maxResponseMessageSize)
+		if err != nil {
+			return
+		}
+		header.UnmarshalExtendedHeader(resp.Body) //nolint:errcheck
+	})
+}
+
+func marshalMessage(f *testing.F, msg serde.Message) []byte {
+	buf := new(bytes.Buffer)
+	_, err := serde.Write(buf, msg)
+	if err != nil {
+		f.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,64 @@
+package p2p
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Option is the functional option that is applied to the exchange and server instances
+// to configure their parameters.
+type Option func(*Parameters)
+
+// Parameters is the set of parameters that must be configured for the exchange.
+type Parameters struct {
+	// VerifyConcurrency defines the maximum amount of received headers whose commit signatures are
+	// verified in parallel.
+	VerifyConcurrency int
+
+	// StreamHook wraps the streams of the exchange and server, e.g. to inject faults with FaultHook
+	// in tests. Nil leaves the streams as they are.
+	StreamHook StreamHook
+
+	// Deprecation is the notice the server includes in its responses once the served protocol
+	// version is deprecated, e.g. as the network moves to a newer one, so that the requesting peers
+	// warn their operators to upgrade. Empty means the protocol is not deprecated.
+	Deprecation string
+}
+
+// DefaultParameters returns the default params to configure the exchange.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		VerifyConcurrency: runtime.NumCPU(),
+	}
+}
+
+func (p *Parameters) Validate() error {
+	if p.VerifyConcurrency <= 0 {
+		return fmt.Errorf("invalid verify concurrency: value should be positive and non-zero")
+	}
+	return nil
+}
+
+// WithVerifyConcurrency is a functional option that configures the
+// `VerifyConcurrency` parameter.
+func WithVerifyConcurrency(concurrency int) Option {
+	return func(p *Parameters) {
+		p.VerifyConcurrency = concurrency
+	}
+}
+
+// WithStreamHook is a functional option that configures the
+// `StreamHook` parameter.
+func WithStreamHook(hook StreamHook) Option {
+	return func(p *Parameters) {
+		p.StreamHook = hook
+	}
+}
+
+// WithDeprecation is a functional option that configures the
+// `Deprecation` parameter.
+func WithDeprecation(notice string) Option {
+	return func(p *Parameters) {
+		p.Deprecation = notice
+	}
+}
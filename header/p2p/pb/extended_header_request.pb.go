@@ -149,6 +149,9 @@ func (*ExtendedHeaderRequest) XXX_OneofWrappers() []interface{} {
 type ExtendedHeaderResponse struct {
 	Body       []byte     `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 	StatusCode StatusCode `protobuf:"varint,2,opt,name=statusCode,proto3,enum=p2p.pb.StatusCode" json:"statusCode,omitempty"`
+	// notice of the deprecation of the protocol version by the responding peer, e.g. once the network
+	// moves to a newer one, so that the requesting peer warns to upgrade
+	Deprecation string `protobuf:"bytes,3,opt,name=deprecation,proto3" json:"deprecation,omitempty"`
 }
 
 func (m *ExtendedHeaderResponse) Reset()         { *m = ExtendedHeaderResponse{} }
@@ -198,6 +201,13 @@ func (m *ExtendedHeaderResponse) GetStatusCode() StatusCode {
 	return StatusCode_INVALID
 }
 
+func (m *ExtendedHeaderResponse) GetDeprecation() string {
+	if m != nil {
+		return m.Deprecation
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("p2p.pb.StatusCode", StatusCode_name, StatusCode_value)
 	proto.RegisterType((*ExtendedHeaderRequest)(nil), "p2p.pb.ExtendedHeaderRequest")
@@ -209,26 +219,27 @@ func init() {
 }
 
 var fileDescriptor_ea2a1467b965216e = []byte{
-	// 293 bytes of a gzipped FileDescriptorProto
+	// 312 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xc1, 0x4a, 0xc3, 0x40,
-	0x10, 0x86, 0xb3, 0x6d, 0x88, 0x38, 0xd6, 0x12, 0x06, 0x2d, 0x39, 0x85, 0xd2, 0x53, 0x51, 0x48,
-	0x21, 0x3e, 0x81, 0x6d, 0x22, 0x0d, 0xd6, 0x16, 0xd6, 0x2a, 0xde, 0xe2, 0x86, 0x5d, 0xda, 0x1e,
-	0xcc, 0xae, 0xd9, 0x2d, 0xe8, 0x5b, 0xf8, 0x58, 0x1e, 0x7b, 0xf4, 0x28, 0xed, 0x8b, 0x88, 0xdb,
-	0xa0, 0xe2, 0x6d, 0xfe, 0xf9, 0x3f, 0xf8, 0x86, 0x81, 0xf3, 0xa5, 0x60, 0x5c, 0x54, 0x03, 0x15,
-	0xab, 0x81, 0x2a, 0x06, 0xe2, 0xc5, 0x88, 0x92, 0x0b, 0x9e, 0xef, 0xd7, 0x79, 0x25, 0x9e, 0xd7,
-	0x42, 0x9b, 0x48, 0x55, 0xd2, 0x48, 0xf4, 0x54, 0xac, 0x22, 0x55, 0xf4, 0x16, 0x70, 0x9a, 0xd6,
-	0xe0, 0xd8, 0x72, 0x74, 0x8f, 0x61, 0x00, 0x9e, 0xac, 0x56, 0x8b, 0x55, 0x19, 0x90, 0x2e, 0xe9,
-	0xbb, 0x63, 0x87, 0xd6, 0x19, 0x4f, 0xc0, 0x5d, 0x32, 0xbd, 0x0c, 0x1a, 0x5d, 0xd2, 0x6f, 0x8d,
-	0x1d, 0x6a, 0x13, 0x76, 0xc0, 0x63, 0x4f, 0x72, 0x5d, 0x9a, 0xa0, 0xf9, 0xcd, 0xd3, 0x3a, 0x0d,
-	0x3d, 0x70, 0x39, 0x33, 0xac, 0xf7, 0x08, 0x9d, 0xff, 0x22, 0xad, 0x64, 0xa9, 0x05, 0x22, 0xb8,
-	0x85, 0xe4, 0xaf, 0xd6, 0xd3, 0xa2, 0x76, 0xc6, 0x18, 0x40, 0x1b, 0x66, 0xd6, 0x7a, 0x24, 0xb9,
-	0xb0, 0xa6, 0x76, 0x8c, 0xd1, 0xfe, 0xe6, 0xe8, 0xf6, 0xa7, 0xa1, 0x7f, 0xa8, 0xb3, 0x04, 0xe0,
-	0xb7, 0xc1, 0x23, 0x38, 0xc8, 0xa6, 0xf7, 0x97, 0x93, 0x2c, 0xf1, 0x1d, 0xf4, 0xa0, 0x31, 0xbb,
-	0xf6, 0x09, 0x1e, 0xc3, 0xe1, 0x74, 0x36, 0xcf, 0xaf, 0x66, 0x77, 0xd3, 0xc4, 0x6f, 0x20, 0x42,
-	0x7b, 0x92, 0xdd, 0x64, 0xf3, 0x3c, 0x7d, 0x18, 0xa5, 0x69, 0x92, 0x26, 0x7e, 0x73, 0x18, 0xbc,
-	0x6f, 0x43, 0xb2, 0xd9, 0x86, 0xe4, 0x73, 0x1b, 0x92, 0xb7, 0x5d, 0xe8, 0x6c, 0x76, 0xa1, 0xf3,
-	0xb1, 0x0b, 0x9d, 0xc2, 0xb3, 0x9f, 0xbb, 0xf8, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xf4, 0x41, 0xe0,
-	0x70, 0x68, 0x01, 0x00, 0x00,
+	0x10, 0x86, 0xb3, 0x6d, 0x88, 0x74, 0x5a, 0x4b, 0x18, 0xb4, 0xe4, 0x14, 0x4a, 0x4f, 0x45, 0x21,
+	0x85, 0xf8, 0x04, 0xb6, 0x89, 0x34, 0x58, 0x5b, 0x58, 0xab, 0x78, 0x0b, 0x1b, 0x77, 0x69, 0x7b,
+	0x30, 0xbb, 0x26, 0x5b, 0xd0, 0xab, 0x4f, 0xe0, 0x63, 0x79, 0xec, 0xd1, 0xa3, 0xb4, 0x2f, 0x22,
+	0x6e, 0x83, 0x16, 0x6f, 0xf3, 0xff, 0xf3, 0x0d, 0xff, 0xcf, 0xc0, 0xf9, 0x52, 0x30, 0x2e, 0x8a,
+	0x81, 0x0a, 0xd5, 0x40, 0x65, 0x03, 0xf1, 0xa2, 0x45, 0xce, 0x05, 0x4f, 0xf7, 0x76, 0x5a, 0x88,
+	0xe7, 0xb5, 0x28, 0x75, 0xa0, 0x0a, 0xa9, 0x25, 0x3a, 0x2a, 0x54, 0x81, 0xca, 0x7a, 0x0b, 0x38,
+	0x8d, 0x2b, 0x70, 0x6c, 0x38, 0xba, 0xc7, 0xd0, 0x03, 0x47, 0x16, 0xab, 0xc5, 0x2a, 0xf7, 0x48,
+	0x97, 0xf4, 0xed, 0xb1, 0x45, 0x2b, 0x8d, 0x27, 0x60, 0x2f, 0x59, 0xb9, 0xf4, 0x6a, 0x5d, 0xd2,
+	0x6f, 0x8d, 0x2d, 0x6a, 0x14, 0x76, 0xc0, 0x61, 0x4f, 0x72, 0x9d, 0x6b, 0xaf, 0xfe, 0xc3, 0xd3,
+	0x4a, 0x0d, 0x1d, 0xb0, 0x39, 0xd3, 0xac, 0xf7, 0x46, 0xa0, 0xf3, 0x3f, 0xa9, 0x54, 0x32, 0x2f,
+	0x05, 0x22, 0xd8, 0x99, 0xe4, 0xaf, 0x26, 0xa8, 0x45, 0xcd, 0x8c, 0x21, 0x40, 0xa9, 0x99, 0x5e,
+	0x97, 0x23, 0xc9, 0x85, 0x89, 0x6a, 0x87, 0x18, 0xec, 0x4b, 0x07, 0xb7, 0xbf, 0x1b, 0x7a, 0x40,
+	0x61, 0x17, 0x9a, 0x5c, 0xa8, 0x42, 0x3c, 0x32, 0xbd, 0x92, 0xb9, 0xe9, 0xd1, 0xa0, 0x87, 0xd6,
+	0x59, 0x04, 0xf0, 0x77, 0x8b, 0x4d, 0x38, 0x4a, 0xa6, 0xf7, 0x97, 0x93, 0x24, 0x72, 0x2d, 0x74,
+	0xa0, 0x36, 0xbb, 0x76, 0x09, 0x1e, 0x43, 0x63, 0x3a, 0x9b, 0xa7, 0x57, 0xb3, 0xbb, 0x69, 0xe4,
+	0xd6, 0x10, 0xa1, 0x3d, 0x49, 0x6e, 0x92, 0x79, 0x1a, 0x3f, 0x8c, 0xe2, 0x38, 0x8a, 0x23, 0xb7,
+	0x3e, 0xf4, 0x3e, 0xb6, 0x3e, 0xd9, 0x6c, 0x7d, 0xf2, 0xb5, 0xf5, 0xc9, 0xfb, 0xce, 0xb7, 0x36,
+	0x3b, 0xdf, 0xfa, 0xdc, 0xf9, 0x56, 0xe6, 0x98, 0xe7, 0x5e, 0x7c, 0x07, 0x00, 0x00, 0xff, 0xff,
+	0x29, 0xd9, 0x50, 0xc5, 0x8b, 0x01, 0x00, 0x00,
 }
 
 func (m *ExtendedHeaderRequest) Marshal() (dAtA []byte, err error) {
@@ -316,6 +327,13 @@ func (m *ExtendedHeaderResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	if len(m.Deprecation) > 0 {
+		i -= len(m.Deprecation)
+		copy(dAtA[i:], m.Deprecation)
+		i = encodeVarintExtendedHeaderRequest(dAtA, i, uint64(len(m.Deprecation)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.StatusCode != 0 {
 		i = encodeVarintExtendedHeaderRequest(dAtA, i, uint64(m.StatusCode))
 		i--
@@ -391,6 +409,10 @@ func (m *ExtendedHeaderResponse) Size() (n int) {
 	if m.StatusCode != 0 {
 		n += 1 + sovExtendedHeaderRequest(uint64(m.StatusCode))
 	}
+	l = len(m.Deprecation)
+	if l > 0 {
+		n += 1 + l + sovExtendedHeaderRequest(uint64(l))
+	}
 	return n
 }
 
@@ -604,6 +626,38 @@ func (m *ExtendedHeaderResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deprecation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExtendedHeaderRequest
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExtendedHeaderRequest
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExtendedHeaderRequest
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deprecation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipExtendedHeaderRequest(dAtA[iNdEx:])
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/host"
@@ -23,23 +24,40 @@ type ExchangeServer struct {
 	host  host.Host
 	store header.Store
 
+	Params *Parameters
+
+	// inflightLk guards the count of requests being served, so that they can be drained on Stop
+	inflightLk sync.Mutex
+	inflight   int
+	// drained is set on Stop and closed once all the in-flight requests are served
+	drained chan struct{}
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
 // NewExchangeServer returns a new P2P server that handles inbound
 // header-related requests.
-func NewExchangeServer(host host.Host, store header.Store, protocolSuffix string) *ExchangeServer {
+func NewExchangeServer(host host.Host, store header.Store, networkID string, opts ...Option) *ExchangeServer {
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	return &ExchangeServer{
-		protocolID: protocolID(protocolSuffix),
+		protocolID: ProtocolID(networkID),
 		host:       host,
 		store:      store,
+		Params:     params,
 	}
 }
 
 // Start sets the stream handler for inbound header-related requests.
 func (serv *ExchangeServer) Start(context.Context) error {
 	serv.ctx, serv.cancel = context.WithCancel(context.Background())
+	serv.inflightLk.Lock()
+	serv.drained = nil
+	serv.inflightLk.Unlock()
 	log.Info("server: listening for inbound header requests")
 
 	serv.host.SetStreamHandler(serv.protocolID, serv.requestHandler)
@@ -47,23 +65,77 @@ func (serv *ExchangeServer) Start(context.Context) error {
 	return nil
 }
 
-// Stop removes the stream handler for serving header-related requests.
-func (serv *ExchangeServer) Stop(context.Context) error {
+// Stop removes the stream handler for serving header-related requests and waits for the in-flight
+// requests to be served until the given context is done.
+func (serv *ExchangeServer) Stop(ctx context.Context) error {
 	log.Info("server: stopping server")
-	serv.cancel()
 	serv.host.RemoveStreamHandler(serv.protocolID)
+	defer serv.cancel()
+
+	serv.inflightLk.Lock()
+	serv.drained = make(chan struct{})
+	if serv.inflight == 0 {
+		close(serv.drained)
+	}
+	drained := serv.drained
+	serv.inflightLk.Unlock()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		log.Warnw("server: stopped before in-flight requests were served", "err", ctx.Err())
+	}
 	return nil
 }
 
+// begin registers a new in-flight request, unless the server is stopping.
+func (serv *ExchangeServer) begin() bool {
+	serv.inflightLk.Lock()
+	defer serv.inflightLk.Unlock()
+	if serv.drained != nil {
+		return false
+	}
+	serv.inflight++
+	return true
+}
+
+// end marks an in-flight request as served.
+func (serv *ExchangeServer) end() {
+	serv.inflightLk.Lock()
+	defer serv.inflightLk.Unlock()
+	serv.inflight--
+	if serv.inflight == 0 && serv.drained != nil {
+		close(serv.drained)
+	}
+}
+
 // requestHandler handles inbound ExtendedHeaderRequests.
 func (serv *ExchangeServer) requestHandler(stream network.Stream) {
-	err := stream.SetReadDeadline(time.Now().Add(readDeadline))
+	if !serv.begin() {
+		// the server is stopping, so no new requests are accepted
+		stream.Reset() //nolint:errcheck
+		return
+	}
+	defer serv.end()
+	defer func() {
+		// malformed requests must never take the node down, so reset the stream instead
+		if r := recover(); r != nil {
+			log.Errorw("server: panic while handling request", "peer", stream.Conn().RemotePeer(), "err", r)
+			stream.Reset() //nolint:errcheck
+		}
+	}()
+	if serv.Params.StreamHook != nil {
+		stream = serv.Params.StreamHook(stream)
+	}
+
+	deadline := streamDeadline(serv.ctx)
+	err := stream.SetReadDeadline(opDeadline(requestReadDeadline, deadline))
 	if err != nil {
 		log.Debugf("error setting deadline: %s", err)
 	}
 	// unmarshal request
 	pbreq := new(p2p_pb.ExtendedHeaderRequest)
-	_, err = serde.Read(stream, pbreq)
+	err = readMessage(stream, pbreq, maxRequestMessageSize)
 	if err != nil {
 		log.Errorw("server: reading header request from stream", "err", err)
 		stream.Reset() //nolint:errcheck
@@ -103,8 +175,8 @@ func (serv *ExchangeServer) requestHandler(stream network.Stream) {
 		headers = make([]*header.ExtendedHeader, 1)
 	}
 	// write all headers to stream
-	for _, h := range headers {
-		if err := stream.SetWriteDeadline(time.Now().Add(writeDeadline)); err != nil {
+	for i, h := range headers {
+		if err := stream.SetWriteDeadline(opDeadline(writeDeadline, deadline)); err != nil {
 			log.Debugf("error setting deadline: %s", err)
 		}
 		var bin []byte
@@ -118,7 +190,12 @@ func (serv *ExchangeServer) requestHandler(stream network.Stream) {
 				return
 			}
 		}
-		_, err = serde.Write(stream, &p2p_pb.ExtendedHeaderResponse{Body: bin, StatusCode: code})
+		resp := &p2p_pb.ExtendedHeaderResponse{Body: bin, StatusCode: code}
+		if i == 0 {
+			// the notice is sent once per request, as it is the same for all the responses
+			resp.Deprecation = serv.Params.Deprecation
+		}
+		_, err = serde.Write(stream, resp)
 		if err != nil {
 			log.Errorw("server: writing header to stream", "height", h.Height, "err", err)
 			stream.Reset() //nolint:errcheck
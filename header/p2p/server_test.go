@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/require"
@@ -24,3 +25,51 @@ func TestExchangeServer_handleRequestTimeout(t *testing.T) {
 	_, err = server.handleRequest(1, 200)
 	require.Error(t, err)
 }
+
+func TestExchangeServer_StopDrainsRequests(t *testing.T) {
+	_, peer := createMocknet(t)
+	s, err := store.NewStore(datastore.NewMapDatastore())
+	require.NoError(t, err)
+	server := NewExchangeServer(peer, s, "private")
+	err = server.Start(context.Background())
+	require.NoError(t, err)
+
+	require.True(t, server.begin())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		server.Stop(context.Background()) //nolint:errcheck
+	}()
+
+	// no new requests are accepted while stopping
+	require.Eventually(t, func() bool {
+		return !server.begin()
+	}, time.Second, time.Millisecond)
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the in-flight request was served")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	server.end()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop after the in-flight request was served")
+	}
+}
+
+func TestExchangeServer_StopTimeout(t *testing.T) {
+	_, peer := createMocknet(t)
+	s, err := store.NewStore(datastore.NewMapDatastore())
+	require.NoError(t, err)
+	server := NewExchangeServer(peer, s, "private")
+	err = server.Start(context.Background())
+	require.NoError(t, err)
+
+	require.True(t, server.begin())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	t.Cleanup(cancel)
+	err = server.Stop(ctx)
+	require.NoError(t, err)
+}
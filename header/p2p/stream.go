@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+)
+
+const (
+	// maxRequestMessageSize limits the size of the request messages, which only carry an origin or
+	// a hash.
+	maxRequestMessageSize = 1 << 10
+	// maxResponseMessageSize limits the size of the response messages, each carrying a single
+	// ExtendedHeader.
+	maxResponseMessageSize = 1 << 20
+	// maxStreamDuration caps the lifetime of a stream, so that a slow peer cannot keep it open
+	// indefinitely by sending or reading the messages just within the per-message deadlines.
+	maxStreamDuration = time.Minute * 2
+)
+
+// readMessage reads the length-prefixed msg from the given Reader, like serde.Read, but rejects
+// the messages bigger than maxSize before allocating them.
+func readMessage(r io.Reader, msg serde.Message, maxSize uint64) error {
+	size, err := binary.ReadUvarint(serde.NewByteReader(r))
+	if err != nil {
+		return err
+	}
+	if size > maxSize {
+		return fmt.Errorf("%w: %d > %d bytes", serde.ErrMsgTooBig, size, maxSize)
+	}
+
+	buf := make([]byte, size)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return err
+	}
+	return msg.Unmarshal(buf)
+}
+
+// streamDeadline returns the deadline of the whole stream opened now, which is further bounded by
+// the deadline of the given context, if any.
+func streamDeadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(maxStreamDuration)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+// opDeadline returns the deadline of the next read or write on the stream, which never exceeds
+// the deadline of the stream itself.
+func opDeadline(timeout time.Duration, streamDeadline time.Time) time.Time {
+	deadline := time.Now().Add(timeout)
+	if deadline.After(streamDeadline) {
+		return streamDeadline
+	}
+	return deadline
+}
@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/go-libp2p-messenger/serde"
+
+	p2p_pb "github.com/celestiaorg/celestia-node/header/p2p/pb"
+)
+
+func TestReadMessage(t *testing.T) {
+	req := &p2p_pb.ExtendedHeaderRequest{Data: &p2p_pb.ExtendedHeaderRequest_Origin{Origin: 1}, Amount: 10}
+	buf := new(bytes.Buffer)
+	_, err := serde.Write(buf, req)
+	require.NoError(t, err)
+	bin := buf.Bytes()
+
+	out := new(p2p_pb.ExtendedHeaderRequest)
+	err = readMessage(bytes.NewReader(bin), out, maxRequestMessageSize)
+	require.NoError(t, err)
+	assert.Equal(t, req, out)
+
+	err = readMessage(bytes.NewReader(bin), out, uint64(len(bin)-2))
+	require.ErrorIs(t, err, serde.ErrMsgTooBig)
+}
+
+// TestExchangeServer_OversizedRequest tests that the server resets the stream of an oversized
+// request right away, rather than reading it.
+func TestExchangeServer_OversizedRequest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	host, tpeer := createMocknet(t)
+	server := NewExchangeServer(tpeer, createStore(t, 5), "private")
+	err := server.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		server.Stop(context.Background()) //nolint:errcheck
+	})
+
+	stream, err := host.NewStream(ctx, tpeer.ID(), server.protocolID)
+	require.NoError(t, err)
+	// only the size prefix of the message is sent
+	_, err = stream.Write(binary.AppendUvarint(nil, maxRequestMessageSize+1))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = stream.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), requestReadDeadline)
+}
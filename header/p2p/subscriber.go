@@ -15,28 +15,31 @@ import (
 // Subscriber manages the lifecycle and relationship of header Module
 // with the "header-sub" gossipsub topic.
 type Subscriber struct {
+	pubsubTopicID string
+
 	pubsub *pubsub.PubSub
 	topic  *pubsub.Topic
 }
 
 // NewSubscriber returns a Subscriber that manages the header Module's
-// relationship with the "header-sub" gossipsub topic.
-func NewSubscriber(ps *pubsub.PubSub) *Subscriber {
+// relationship with the "header-sub" gossipsub topic of the given network.
+func NewSubscriber(ps *pubsub.PubSub, networkID string) *Subscriber {
 	return &Subscriber{
-		pubsub: ps,
+		pubsubTopicID: PubSubTopicID(networkID),
+		pubsub:        ps,
 	}
 }
 
 // Start starts the Subscriber, registering a topic validator for the "header-sub"
 // topic and joining it.
 func (p *Subscriber) Start(context.Context) (err error) {
-	p.topic, err = p.pubsub.Join(PubSubTopic, pubsub.WithTopicMessageIdFn(msgID))
+	p.topic, err = p.pubsub.Join(p.pubsubTopicID, pubsub.WithTopicMessageIdFn(msgID))
 	return err
 }
 
 // Stop closes the topic and unregisters its validator.
 func (p *Subscriber) Stop(context.Context) error {
-	err := p.pubsub.UnregisterTopicValidator(PubSubTopic)
+	err := p.pubsub.UnregisterTopicValidator(p.pubsubTopicID)
 	if err != nil {
 		log.Warnf("unregistering validator: %s", err)
 	}
@@ -57,7 +60,7 @@ func (p *Subscriber) AddValidator(val header.Validator) error {
 		msg.ValidatorData = maybeHead
 		return val(ctx, maybeHead)
 	}
-	return p.pubsub.RegisterTopicValidator(PubSubTopic, pval)
+	return p.pubsub.RegisterTopicValidator(p.pubsubTopicID, pval)
 }
 
 // Subscribe returns a new subscription to the Subscriber's
@@ -30,7 +30,7 @@ func TestSubscriber(t *testing.T) {
 	require.NoError(t, err)
 
 	// create sub-service lifecycles for header service 1
-	p2pSub1 := NewSubscriber(pubsub1)
+	p2pSub1 := NewSubscriber(pubsub1, "private")
 	err = p2pSub1.Start(context.Background())
 	require.NoError(t, err)
 
@@ -40,7 +40,7 @@ func TestSubscriber(t *testing.T) {
 	require.NoError(t, err)
 
 	// create sub-service lifecycles for header service 2
-	p2pSub2 := NewSubscriber(pubsub2)
+	p2pSub2 := NewSubscriber(pubsub2, "private")
 	err = p2pSub2.Start(context.Background())
 	require.NoError(t, err)
 
@@ -87,3 +87,45 @@ func TestSubscriber(t *testing.T) {
 	assert.Equal(t, expectedHeader.Hash(), header.Hash())
 	assert.Equal(t, expectedHeader.DAH.Hash(), header.DAH.Hash())
 }
+
+// TestSubscriber_OtherNetwork tests that the Subscriber does not receive headers
+// gossiped by peers of another network.
+func TestSubscriber_OtherNetwork(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancel()
+
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	suite := header.NewTestSuite(t, 3)
+
+	pubsub1, err := pubsub.NewGossipSub(ctx, net.Hosts()[0], pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign))
+	require.NoError(t, err)
+	p2pSub1 := NewSubscriber(pubsub1, "private")
+	err = p2pSub1.Start(ctx)
+	require.NoError(t, err)
+
+	pubsub2, err := pubsub.NewGossipSub(ctx, net.Hosts()[1], pubsub.WithMessageSignaturePolicy(pubsub.StrictNoSign))
+	require.NoError(t, err)
+	p2pSub2 := NewSubscriber(pubsub2, "other")
+	err = p2pSub2.Start(ctx)
+	require.NoError(t, err)
+
+	subscription, err := p2pSub1.Subscribe()
+	require.NoError(t, err)
+	_, err = p2pSub2.Subscribe()
+	require.NoError(t, err)
+
+	bin, err := suite.GenExtendedHeaders(1)[0].MarshalBinary()
+	require.NoError(t, err)
+	// the peers are subscribed to the topics of different networks, so none of them is ready to receive
+	publishCtx, publishCancel := context.WithTimeout(ctx, time.Second)
+	defer publishCancel()
+	err = p2pSub2.topic.Publish(publishCtx, bin, pubsub.WithReadiness(pubsub.MinTopicSize(1)))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	nextCtx, nextCancel := context.WithTimeout(ctx, time.Second)
+	defer nextCancel()
+	_, err = subscription.NextHeader(nextCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
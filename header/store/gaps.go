@@ -0,0 +1,284 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// gapRetryInterval is the interval after which a gap, which is still not repaired, is reported
+// again.
+var gapRetryInterval = time.Minute
+
+// gapSearchLimit limits how many heights each way around a missing header are looked up to find
+// the gap it is in.
+var gapSearchLimit uint64 = 512
+
+// Gap is a range of heights missing in between the stored headers, e.g. left by a crash.
+// Both From and To heights are inclusive.
+type Gap struct {
+	From, To uint64
+}
+
+// Gaps returns the channel the gaps are reported to, once found by requests of the missing
+// headers. The gaps are expected to be repaired by Splicing the missing headers into them, until
+// then they are reported every gapRetryInterval at most.
+// The gaps reported while the channel is full are dropped.
+func (s *Store) Gaps() <-chan Gap {
+	return s.gapCh
+}
+
+// Splice stores the given headers into a gap of the stored ones. The headers must be adjacent
+// and in ascending order, as they are verified against the stored header right below them and
+// the one right above, if any.
+// It returns the amount of successfully spliced headers, similarly to Append.
+func (s *Store) Splice(ctx context.Context, headers ...*header.ExtendedHeader) (int, error) {
+	lh := len(headers)
+	if lh == 0 {
+		return 0, nil
+	}
+	select {
+	case <-s.writesDn:
+		return 0, errStoppedStore
+	default:
+	}
+
+	from, to := uint64(headers[0].Height), uint64(headers[lh-1].Height)
+	if to >= s.Height() {
+		return 0, fmt.Errorf("header/store: can't splice headers up to %d at or above the head", to)
+	}
+	head, err := s.getByHeight(ctx, from-1)
+	if err != nil {
+		return 0, fmt.Errorf("header/store: getting header below the spliced ones: %w", err)
+	}
+
+	// collect valid headers
+	verified := make([]*header.ExtendedHeader, 0, lh)
+	for i, h := range headers {
+		err = head.VerifyAdjacent(h, header.WithClock(s.Params.Clock))
+		if err != nil {
+			if i == 0 {
+				return 0, err
+			}
+			break
+		}
+		verified, head = append(verified, h), h
+	}
+	// the headers must lead to the stored ones above, if they are spliced up to them
+	above, aerr := s.getByHeight(ctx, uint64(head.Height)+1)
+	switch {
+	case aerr == nil:
+		if !bytes.Equal(above.LastHeader(), head.Hash()) {
+			return 0, fmt.Errorf("header/store: spliced header %d does not lead to the stored one above", head.Height)
+		}
+	case !errors.Is(aerr, header.ErrNotFound):
+		return 0, aerr
+	}
+
+	batch, berr := s.ds.Batch(ctx)
+	if berr != nil {
+		return 0, berr
+	}
+	if berr = s.putHeaders(ctx, batch, verified...); berr != nil {
+		return 0, berr
+	}
+	if berr = batch.Commit(ctx); berr != nil {
+		return 0, berr
+	}
+
+	// forget the reported gaps, as they are repaired, at least partially
+	s.gapsLk.Lock()
+	for gap := range s.gaps {
+		if gap.From <= uint64(head.Height) && from <= gap.To {
+			delete(s.gaps, gap)
+		}
+	}
+	s.gapsLk.Unlock()
+	log.Infow("spliced headers", "from", from, "to", head.Height)
+	// we return an error here after writing,
+	// as there might be an invalid header in between of a given range
+	return len(verified), err
+}
+
+// reportGap reports the missing header of the given height to be looked for a gap around it in
+// the background, so that the requests of the missing headers are never blocked by the search.
+// The reports made while the search is busy are dropped.
+func (s *Store) reportGap(height uint64) {
+	// the missing headers at and below the lowest stored header are not in a gap
+	tail := s.tail.Load()
+	if tail == 0 || height <= tail || height >= s.Height() {
+		return
+	}
+
+	select {
+	case s.missing <- height:
+	default:
+	}
+}
+
+// gapsLoop finds the gaps the reported missing headers are in and queues them to be repaired.
+func (s *Store) gapsLoop() {
+	defer close(s.gapsDn)
+	ctx := context.Background()
+	for {
+		select {
+		case height := <-s.missing:
+			s.queueGap(ctx, height)
+		case <-s.writesDn:
+			return
+		}
+	}
+}
+
+// queueGap queues the gap the missing header of the given height is in, unless queued recently.
+func (s *Store) queueGap(ctx context.Context, height uint64) {
+	s.gapsLk.Lock()
+	for gap, reported := range s.gaps {
+		if gap.From <= height && height <= gap.To {
+			if time.Since(reported) < gapRetryInterval {
+				s.gapsLk.Unlock()
+				return
+			}
+			delete(s.gaps, gap)
+		}
+	}
+	s.gapsLk.Unlock()
+
+	gap, err := s.findGap(ctx, height)
+	if err != nil {
+		log.Errorw("finding gap", "height", height, "err", err)
+		return
+	}
+	select {
+	case s.gapCh <- gap:
+		s.gapsLk.Lock()
+		s.gaps[gap] = time.Now()
+		s.gapsLk.Unlock()
+		log.Warnw("found gap in stored headers", "from", gap.From, "to", gap.To)
+	default:
+		log.Warnw("dropping gap report for slow reader", "from", gap.From, "to", gap.To)
+	}
+}
+
+// findGap finds the range of the missing headers around the one of the given height.
+// The search is bounded by gapSearchLimit heights each way, so the larger gaps are found and
+// repaired piece by piece.
+func (s *Store) findGap(ctx context.Context, height uint64) (Gap, error) {
+	gap := Gap{From: height, To: height}
+	tail, head := s.tail.Load(), s.Height()
+	for ; gap.From-1 > tail && height-gap.From < gapSearchLimit; gap.From-- {
+		ok, err := s.hasHeight(ctx, gap.From-1)
+		if err != nil {
+			return gap, err
+		}
+		if ok {
+			break
+		}
+	}
+	for ; gap.To+1 < head && gap.To-height < gapSearchLimit; gap.To++ {
+		ok, err := s.hasHeight(ctx, gap.To+1)
+		if err != nil {
+			return gap, err
+		}
+		if ok {
+			break
+		}
+	}
+	return gap, nil
+}
+
+// hasHeight checks whether the header of the given elapsed height is stored.
+func (s *Store) hasHeight(ctx context.Context, height uint64) (bool, error) {
+	if h := s.pending.GetByHeight(height); h != nil {
+		return true, nil
+	}
+	hash, err := s.heightIndex.HashByHeight(ctx, height)
+	switch err {
+	case nil:
+	case datastore.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+	return s.Has(ctx, hash)
+}
+
+// loadTail loads the height of the lowest stored header, if the Store is initialized.
+func (s *Store) loadTail(ctx context.Context) error {
+	if s.tail.Load() != 0 {
+		return nil
+	}
+
+	b, err := s.ds.Get(ctx, tailKey)
+	switch err {
+	case nil:
+		var hash tmbytes.HexBytes
+		if err = hash.UnmarshalJSON(b); err != nil {
+			return err
+		}
+		tail, err := s.Get(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("header/store: loading tail: %w", err)
+		}
+		s.tail.Store(uint64(tail.Height))
+		return nil
+	case datastore.ErrNotFound:
+	default:
+		return err
+	}
+
+	_, err = s.readHead(ctx)
+	switch err {
+	case nil:
+	case datastore.ErrNotFound, header.ErrNotFound:
+		// not initialized yet
+		return nil
+	default:
+		return err
+	}
+	// the tail is not persisted by the Stores initialized before, so it is found by the lowest
+	// indexed height once
+	res, err := s.ds.Query(ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	var tail uint64
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		// the keys of the headers are their hashes, which never parse into heights
+		height, err := strconv.ParseUint(datastore.RawKey(r.Key).BaseNamespace(), 10, 64)
+		if err != nil || height == 0 {
+			continue
+		}
+		if tail == 0 || height < tail {
+			tail = height
+		}
+	}
+	tailHash, err := s.heightIndex.HashByHeight(ctx, tail)
+	if err != nil {
+		return fmt.Errorf("header/store: loading tail: %w", err)
+	}
+	b, err = tailHash.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	err = s.ds.Put(ctx, tailKey, b)
+	if err != nil {
+		return err
+	}
+	s.tail.Store(tail)
+	log.Infow("found tail", "height", tail)
+	return nil
+}
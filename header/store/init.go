@@ -1,25 +1,68 @@
 package store
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
 	"github.com/celestiaorg/celestia-node/header"
 )
 
+// ErrTrustedHashMismatch is returned when the header the Store is initialized with does not match
+// the trusted hash it is expected to have.
+var ErrTrustedHashMismatch = errors.New("header/store: trusted hash mismatch")
+
 // Init ensures a Store is initialized. If it is not already initialized,
 // it initializes the Store by requesting the header with the given hash.
 func Init(ctx context.Context, store header.Store, ex header.Exchange, hash tmbytes.HexBytes) error {
+	return initWith(ctx, store, hash, func(ctx context.Context) (*header.ExtendedHeader, error) {
+		return ex.Get(ctx, hash)
+	})
+}
+
+// InitAtHeight ensures a Store is initialized. If it is not already initialized, it initializes the
+// Store by requesting the header at the given height, e.g. the genesis one, which must have the
+// given hash. Unlike requesting the header by hash, this ensures the network serves the expected
+// chain at the height, and not only knows the header.
+func InitAtHeight(
+	ctx context.Context,
+	store header.Store,
+	ex header.Exchange,
+	height uint64,
+	hash tmbytes.HexBytes,
+) error {
+	return initWith(ctx, store, hash, func(ctx context.Context) (*header.ExtendedHeader, error) {
+		return ex.GetByHeight(ctx, height)
+	})
+}
+
+// initWith initializes an uninitialized Store with the header got from the given func, once it is
+// verified to have the trusted hash.
+func initWith(
+	ctx context.Context,
+	store header.Store,
+	hash tmbytes.HexBytes,
+	get func(context.Context) (*header.ExtendedHeader, error),
+) error {
 	_, err := store.Head(ctx)
 	switch err {
 	default:
 		return err
 	case header.ErrNoHead:
-		initial, err := ex.Get(ctx, hash)
+		initial, err := get(ctx)
 		if err != nil {
 			return err
 		}
+		if !bytes.Equal(initial.Hash(), hash) {
+			return fmt.Errorf("%w: expected %s, got %s at height %d",
+				ErrTrustedHashMismatch, hash, initial.Hash(), initial.Height)
+		}
+		if err := initial.ValidateBasic(); err != nil {
+			return fmt.Errorf("header/store: invalid trusted header: %w", err)
+		}
 
 		return store.Init(ctx, initial)
 	}
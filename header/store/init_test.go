@@ -57,3 +57,34 @@ func TestInitStore_NoReinit(t *testing.T) {
 	err = reopenedStore.Stop(ctx)
 	require.NoError(t, err)
 }
+
+func TestInitStore_TrustedHash(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	genesis := suite.Head()
+	exchange := local.NewExchange(NewTestStore(ctx, t, genesis))
+	// load the head of the served store, so that the headers are served by height
+	_, err := exchange.Head(ctx)
+	require.NoError(t, err)
+
+	// the network serves another chain at the height of the trusted header
+	store, err := NewStore(sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	err = InitAtHeight(ctx, store, exchange, uint64(genesis.Height), suite.GenExtendedHeader().Hash())
+	assert.ErrorIs(t, err, ErrTrustedHashMismatch)
+	_, err = store.Head(ctx)
+	assert.ErrorIs(t, err, header.ErrNoHead)
+
+	err = InitAtHeight(ctx, store, exchange, uint64(genesis.Height), genesis.Hash())
+	require.NoError(t, err)
+	err = store.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, genesis.Hash(), head.Hash())
+}
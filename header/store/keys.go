@@ -11,6 +11,7 @@ import (
 var (
 	storePrefix = datastore.NewKey("headers")
 	headKey     = datastore.NewKey("head")
+	tailKey     = datastore.NewKey("tail")
 )
 
 func heightKey(h uint64) datastore.Key {
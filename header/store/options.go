@@ -2,6 +2,8 @@ package store
 
 import (
 	"fmt"
+
+	"github.com/celestiaorg/celestia-node/header"
 )
 
 // Option is the functional option that is applied to the store instance
@@ -19,6 +21,11 @@ type Parameters struct {
 	// WriteBatchSize defines the size of the batched header write.
 	// Headers are written in batches not to thrash the underlying Datastore with writes.
 	WriteBatchSize int
+
+	// Clock defines how much the time of the appended headers can drift into the future. It's not
+	// configurable in the config file, but set by the node. If nil, the time can drift by
+	// header.DefaultClockDrift.
+	Clock *header.Clock `toml:"-"`
 }
 
 // DefaultParameters returns the default params to configure the store.
@@ -68,3 +75,11 @@ func WithWriteBatchSize(size int) Option {
 		p.WriteBatchSize = size
 	}
 }
+
+// WithClock is a functional option that configures the
+// `Clock` parameter.
+func WithClock(clock *header.Clock) Option {
+	return func(p *Parameters) {
+		p.Clock = clock
+	}
+}
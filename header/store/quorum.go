@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// ErrNoQuorum is returned when not enough of the trusted sources agree on the initial header.
+var ErrNoQuorum = errors.New("header/store: no quorum of trusted sources")
+
+// InitWithQuorum ensures a Store is initialized. If it is not already initialized, it initializes
+// the Store with the header at least 'quorum' of the trusted sources agree on, so that a single
+// compromised source can't make the Store trust another chain.
+//
+// The heads of all the sources are requested first and the initial header is then requested from
+// all of them at the highest height at least 'quorum' of them agree on.
+func InitWithQuorum(ctx context.Context, store header.Store, sources []header.Exchange, quorum int) error {
+	if quorum < 1 || quorum > len(sources) {
+		return fmt.Errorf("header/store: quorum %d out of range of %d sources", quorum, len(sources))
+	}
+
+	_, err := store.Head(ctx)
+	switch err {
+	default:
+		return err
+	case header.ErrNoHead:
+		initial, err := quorumHeader(ctx, sources, quorum)
+		if err != nil {
+			return err
+		}
+		if err := initial.ValidateBasic(); err != nil {
+			return fmt.Errorf("header/store: invalid trusted header: %w", err)
+		}
+
+		log.Infow("initializing with header agreed on by trusted sources",
+			"height", initial.Height, "hash", initial.Hash(), "quorum", quorum, "sources", len(sources))
+		return store.Init(ctx, initial)
+	}
+}
+
+// quorumHeader requests the header at least 'quorum' of the sources agree on. The heights of the
+// heads of the sources, starting from the highest one at least 'quorum' of them reached, are tried
+// in descending order, so that the sources reporting higher heads, e.g. of another chain, can't
+// prevent the agreement of the others at a lower height.
+func quorumHeader(ctx context.Context, sources []header.Exchange, quorum int) (*header.ExtendedHeader, error) {
+	heads := requestAll(ctx, sources, func(ex header.Exchange) (*header.ExtendedHeader, error) {
+		return ex.Head(ctx)
+	})
+	heights := make([]int64, 0, len(heads))
+	for _, head := range heads {
+		if head != nil {
+			heights = append(heights, head.Height)
+		}
+	}
+	if len(heights) < quorum {
+		return nil, fmt.Errorf("%w: %d of %d sources responded, while %d are required",
+			ErrNoQuorum, len(heights), len(sources), quorum)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] > heights[j]
+	})
+
+	var votes map[string]int
+	for i := quorum - 1; i < len(heights); i++ {
+		height := heights[i]
+		if i > quorum-1 && height == heights[i-1] {
+			continue
+		}
+
+		// only the sources with the heads above the height are requested, as the others may wait for
+		// the header until they sync it
+		reached := make([]header.Exchange, 0, len(sources))
+		for j, head := range heads {
+			if head != nil && head.Height >= height {
+				reached = append(reached, sources[j])
+			}
+		}
+		headers := requestAll(ctx, reached, func(ex header.Exchange) (*header.ExtendedHeader, error) {
+			return ex.GetByHeight(ctx, uint64(height))
+		})
+		votes = make(map[string]int)
+		for _, h := range headers {
+			if h == nil || h.Height != height {
+				continue
+			}
+			hash := h.Hash().String()
+			votes[hash]++
+			if votes[hash] == quorum {
+				return h, nil
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		log.Warnw("trusted sources disagree on header", "height", height, "votes", votes)
+	}
+	return nil, fmt.Errorf("%w: sources disagree on headers, the last votes: %v", ErrNoQuorum, votes)
+}
+
+// requestAll performs the request with every source concurrently, leaving nil in place of the
+// failed ones.
+func requestAll(
+	ctx context.Context,
+	sources []header.Exchange,
+	request func(header.Exchange) (*header.ExtendedHeader, error),
+) []*header.ExtendedHeader {
+	results := make([]*header.ExtendedHeader, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source header.Exchange) {
+			defer wg.Done()
+			h, err := request(source)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warnw("requesting header from trusted source", "err", err)
+				}
+				return
+			}
+			results[i] = h
+		}(i, source)
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/local"
+)
+
+func TestInitWithQuorum(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	genesis := suite.Head()
+	headers := suite.GenExtendedHeaders(10)
+
+	// the honest sources are synced up to different heights
+	ahead := NewTestStore(ctx, t, genesis)
+	_, err := ahead.Append(ctx, headers...)
+	require.NoError(t, err)
+	behind := NewTestStore(ctx, t, genesis)
+	_, err = behind.Append(ctx, headers[:5]...)
+	require.NoError(t, err)
+	// the compromised source serves another chain, higher than the honest one
+	forkSuite := header.NewTestSuite(t, 3)
+	fork := NewTestStore(ctx, t, forkSuite.Head())
+	_, err = fork.Append(ctx, forkSuite.GenExtendedHeaders(15)...)
+	require.NoError(t, err)
+
+	sources := []header.Exchange{
+		local.NewExchange(fork),
+		local.NewExchange(ahead),
+		local.NewExchange(behind),
+	}
+
+	store, err := NewStore(sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	err = InitWithQuorum(ctx, store, sources, 3)
+	assert.ErrorIs(t, err, ErrNoQuorum)
+	_, err = store.Head(ctx)
+	assert.ErrorIs(t, err, header.ErrNoHead)
+
+	err = InitWithQuorum(ctx, store, sources, 2)
+	require.NoError(t, err)
+	err = store.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	// the highest header both honest sources have
+	assert.Equal(t, headers[4].Hash(), head.Hash())
+}
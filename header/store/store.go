@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 
@@ -51,6 +54,20 @@ type Store struct {
 	// pending keeps headers pending to be written in one batch
 	pending *batch
 
+	// gaps management
+	//
+	// height of the lowest stored header, below which missing headers are not gaps
+	tail atomic.Uint64
+	// queue of the heights of the requested missing headers, looked for gaps around
+	missing chan uint64
+	// signals when looking for gaps is finished
+	gapsDn chan struct{}
+	// gapsLk protects the gaps reported with the time of their reports
+	gapsLk sync.Mutex
+	gaps   map[Gap]time.Time
+	// queue of reported gaps
+	gapCh chan Gap
+
 	Params *Parameters
 }
 
@@ -106,6 +123,10 @@ func newStore(ds datastore.Batching, opts ...Option) (*Store, error) {
 		cache:       cache,
 		heightIndex: index,
 		pending:     newBatch(params.WriteBatchSize),
+		missing:     make(chan uint64, 16),
+		gapsDn:      make(chan struct{}),
+		gaps:        make(map[Gap]time.Time),
+		gapCh:       make(chan Gap, 16),
 	}, nil
 }
 
@@ -115,13 +136,29 @@ func (s *Store) Init(ctx context.Context, initial *header.ExtendedHeader) error
 	if err != nil {
 		return err
 	}
+	// and as the lowest one
+	b, err := initial.Hash().MarshalJSON()
+	if err != nil {
+		return err
+	}
+	err = s.ds.Put(ctx, tailKey, b)
+	if err != nil {
+		return err
+	}
+	s.tail.Store(uint64(initial.Height))
 
 	log.Infow("initialized head", "height", initial.Height, "hash", initial.Hash())
 	return nil
 }
 
-func (s *Store) Start(context.Context) error {
+func (s *Store) Start(ctx context.Context) error {
+	err := s.loadTail(ctx)
+	if err != nil {
+		return err
+	}
+
 	go s.flushLoop()
+	go s.gapsLoop()
 	return nil
 }
 
@@ -138,6 +175,11 @@ func (s *Store) Stop(ctx context.Context) error {
 	case <-ctx.Done():
 		return ctx.Err()
 	}
+	select {
+	case <-s.gapsDn: // and looking for gaps
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 
 	// cleanup caches
 	s.cache.Purge()
@@ -196,6 +238,15 @@ func (s *Store) Get(ctx context.Context, hash tmbytes.HexBytes) (*header.Extende
 }
 
 func (s *Store) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	h, err := s.getByHeight(ctx, height)
+	if errors.Is(err, header.ErrNotFound) {
+		// the header is missing, though the height is elapsed
+		s.reportGap(height)
+	}
+	return h, err
+}
+
+func (s *Store) getByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
 	if height == 0 {
 		return nil, fmt.Errorf("header/store: height must be bigger than zero")
 	}
@@ -235,8 +286,12 @@ func (s *Store) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*heade
 	headers := make([]*header.ExtendedHeader, ln)
 	for i := ln - 1; i > 0; i-- {
 		headers[i] = h
+		height := uint64(h.Height) - 1
 		h, err = s.Get(ctx, h.LastHeader())
 		if err != nil {
+			if errors.Is(err, header.ErrNotFound) {
+				s.reportGap(height)
+			}
 			return nil, err
 		}
 	}
@@ -245,6 +300,45 @@ func (s *Store) GetRangeByHeight(ctx context.Context, from, to uint64) ([]*heade
 	return headers, nil
 }
 
+// GetByTime returns the ExtendedHeader of the latest block produced at or before the given time.
+// The stored headers are contiguous and their times monotonically increase, so the height index
+// serves as the index by time as well, and is binary searched.
+func (s *Store) GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	head, err := s.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !head.Time.After(t) {
+		return head, nil
+	}
+
+	// find the lowest height produced after the time, while the heights below the stored ones are
+	// counted as produced before
+	var searchErr error
+	after := sort.Search(int(head.Height), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		h, err := s.GetByHeight(ctx, uint64(i+1))
+		switch {
+		case errors.Is(err, header.ErrNotFound):
+			return false
+		case err != nil:
+			searchErr = err
+			return true
+		}
+		return h.Time.After(t)
+	}) + 1
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if after == 1 {
+		return nil, header.ErrNotFound
+	}
+	// not found, if the time is before the stored headers
+	return s.GetByHeight(ctx, uint64(after-1))
+}
+
 func (s *Store) Has(ctx context.Context, hash tmbytes.HexBytes) (bool, error) {
 	if ok := s.cache.Contains(hash.String()); ok {
 		return ok, nil
@@ -276,7 +370,7 @@ func (s *Store) Append(ctx context.Context, headers ...*header.ExtendedHeader) (
 	// collect valid headers
 	verified := make([]*header.ExtendedHeader, 0, lh)
 	for i, h := range headers {
-		err = head.VerifyAdjacent(h)
+		err = head.VerifyAdjacent(h, header.WithClock(s.Params.Clock))
 		if err != nil {
 			var verErr *header.VerifyError
 			if errors.As(err, &verErr) {
@@ -365,16 +459,9 @@ func (s *Store) flush(ctx context.Context, headers ...*header.ExtendedHeader) er
 	}
 
 	// collect all the headers in the batch to be written
-	for _, h := range headers {
-		b, err := h.MarshalBinary()
-		if err != nil {
-			return err
-		}
-
-		err = batch.Put(ctx, headerKey(h), b)
-		if err != nil {
-			return err
-		}
+	err = s.putHeaders(ctx, batch, headers...)
+	if err != nil {
+		return err
 	}
 
 	// marshal and add to batch reference to the new head
@@ -388,16 +475,28 @@ func (s *Store) flush(ctx context.Context, headers ...*header.ExtendedHeader) er
 		return err
 	}
 
-	// write height indexes for headers as well
-	err = s.heightIndex.IndexTo(ctx, batch, headers...)
-	if err != nil {
-		return err
-	}
-
 	// finally, commit the batch on disk
 	return batch.Commit(ctx)
 }
 
+// putHeaders adds the given headers along with their height indexes to the batch.
+func (s *Store) putHeaders(ctx context.Context, batch datastore.Batch, headers ...*header.ExtendedHeader) error {
+	for _, h := range headers {
+		b, err := h.MarshalBinary()
+		if err != nil {
+			return err
+		}
+
+		err = batch.Put(ctx, headerKey(h), b)
+		if err != nil {
+			return err
+		}
+	}
+
+	// write height indexes for headers as well
+	return s.heightIndex.IndexTo(ctx, batch, headers...)
+}
+
 // readHead loads the head from the datastore.
 func (s *Store) readHead(ctx context.Context) (*header.ExtendedHeader, error) {
 	b, err := s.ds.Get(ctx, headKey)
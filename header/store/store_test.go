@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	"github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -116,3 +117,104 @@ func TestStorePendingCacheMiss(t *testing.T) {
 	_, err = store.GetRangeByHeight(ctx, 101, 151)
 	require.NoError(t, err)
 }
+
+func TestStore_GetByTime(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	// the store is initialized in the middle of the chain
+	suite.GenExtendedHeaders(4)
+	trusted := suite.GenExtendedHeader()
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	store, err := NewStoreWithHead(ctx, ds, trusted)
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+
+	in := append([]*header.ExtendedHeader{trusted}, suite.GenExtendedHeaders(20)...)
+	_, err = store.Append(ctx, in[1:]...)
+	require.NoError(t, err)
+	// wait for the headers to be applied
+	_, err = store.GetByHeight(ctx, uint64(in[len(in)-1].Height))
+	require.NoError(t, err)
+
+	for _, h := range in {
+		got, err := store.GetByTime(ctx, h.Time)
+		require.NoError(t, err)
+		assert.True(t, got.Time.Equal(h.Time))
+
+		// the header produced right before the time is returned
+		got, err = store.GetByTime(ctx, h.Time.Add(-time.Nanosecond))
+		if h == trusted {
+			assert.ErrorIs(t, err, header.ErrNotFound)
+			continue
+		}
+		require.NoError(t, err)
+		assert.True(t, got.Time.Before(h.Time))
+		assert.Less(t, got.Height, h.Height)
+	}
+
+	got, err := store.GetByTime(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, in[len(in)-1].Hash(), got.Hash())
+}
+
+func TestStore_Gaps(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+
+	ds := sync.MutexWrap(datastore.NewMapDatastore())
+	store, err := NewStoreWithHead(ctx, ds, suite.Head())
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	in := suite.GenExtendedHeaders(20)
+	_, err = store.Append(ctx, in...)
+	require.NoError(t, err)
+	require.NoError(t, store.Stop(ctx))
+
+	// emulate a gap of the heights 5-7 and a store initialized before the tail was persisted
+	nds := namespace.Wrap(ds, storePrefix)
+	for _, h := range in[3:6] {
+		require.NoError(t, nds.Delete(ctx, headerKey(h)))
+		require.NoError(t, nds.Delete(ctx, heightKey(uint64(h.Height))))
+	}
+	require.NoError(t, nds.Delete(ctx, tailKey))
+
+	store, err = NewStore(ds)
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop(ctx))
+	})
+	_, err = store.Head(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, store.tail.Load())
+
+	_, err = store.GetByHeight(ctx, 6)
+	assert.ErrorIs(t, err, header.ErrNotFound)
+	assert.Equal(t, Gap{From: 5, To: 7}, <-store.Gaps())
+	// the gap is not reported again until it is retried
+	_, err = store.GetRangeByHeight(ctx, 2, 10)
+	assert.ErrorIs(t, err, header.ErrNotFound)
+	assert.Never(t, func() bool { return len(store.Gaps()) > 0 }, time.Millisecond*100, time.Millisecond*10)
+
+	// only the headers leading to the stored ones are spliced
+	_, err = store.Splice(ctx, in[3:5]...)
+	require.NoError(t, err)
+	_, err = store.Splice(ctx, suite.GenExtendedHeaders(1)...)
+	assert.Error(t, err)
+	_, err = store.Splice(ctx, in[4:6]...)
+	require.NoError(t, err)
+
+	out, err := store.GetRangeByHeight(ctx, 2, 22)
+	require.NoError(t, err)
+	for i, h := range in {
+		assert.Equal(t, h.Hash(), out[i].Hash())
+	}
+}
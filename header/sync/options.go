@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"github.com/ipfs/go-datastore"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+// Option is the functional option that is applied to the Syncer instance
+// to configure its parameters.
+type Option func(*Parameters)
+
+// Parameters is the set of parameters that can be configured for the Syncer.
+type Parameters struct {
+	// ReinitHash is the hash of the header the Syncer re-initializes from once its subjective head
+	// is expired. If empty, it re-initializes from the head of the trusted peers.
+	ReinitHash tmbytes.HexBytes
+
+	// Datastore persists the network head, so that syncing to it resumes after a restart. If nil,
+	// the network head is only kept in memory.
+	Datastore datastore.Datastore
+
+	// TargetHeight is the height the Syncer syncs to and stops at, instead of following the network
+	// head, e.g. to analyze the chain as of a height reproducibly. If 0, there is no target.
+	// It can be changed at runtime with Syncer.SetTargetHeight.
+	TargetHeight uint64
+
+	// Events is the Bus the Syncer publishes the accepted network heads and the end of every
+	// successful sync to. If nil, nothing is published.
+	Events *events.Bus
+
+	// Prefetcher is notified of every network head the Syncer accepts, so that the work awaiting
+	// the header, e.g. sampling, is started ahead. If nil, nothing is notified.
+	Prefetcher Prefetcher
+
+	// Clock defines how much the time of the network heads can drift into the future. If nil, it
+	// can drift by header.DefaultClockDrift.
+	Clock *header.Clock
+}
+
+// Prefetcher anticipates the work awaiting the network heads accepted by the Syncer.
+type Prefetcher interface {
+	// Prefetch starts the work awaiting the given header ahead. It must not block, as it is called
+	// while the header is being synced.
+	Prefetch(*header.ExtendedHeader)
+}
+
+// DefaultParameters returns the default params to configure the Syncer.
+func DefaultParameters() *Parameters {
+	return &Parameters{}
+}
+
+// WithReinitHash is a functional option that configures the
+// `ReinitHash` parameter.
+func WithReinitHash(hash tmbytes.HexBytes) Option {
+	return func(p *Parameters) {
+		p.ReinitHash = hash
+	}
+}
+
+// WithDatastore is a functional option that configures the
+// `Datastore` parameter.
+func WithDatastore(ds datastore.Datastore) Option {
+	return func(p *Parameters) {
+		p.Datastore = ds
+	}
+}
+
+// WithEvents is a functional option that configures the
+// `Events` parameter.
+func WithEvents(bus *events.Bus) Option {
+	return func(p *Parameters) {
+		p.Events = bus
+	}
+}
+
+// WithTargetHeight is a functional option that configures the
+// `TargetHeight` parameter.
+func WithTargetHeight(height uint64) Option {
+	return func(p *Parameters) {
+		p.TargetHeight = height
+	}
+}
+
+// WithPrefetcher is a functional option that configures the
+// `Prefetcher` parameter.
+func WithPrefetcher(p Prefetcher) Option {
+	return func(params *Parameters) {
+		params.Prefetcher = p
+	}
+}
+
+// WithClock is a functional option that configures the
+// `Clock` parameter.
+func WithClock(clock *header.Clock) Option {
+	return func(p *Parameters) {
+		p.Clock = clock
+	}
+}
@@ -3,23 +3,33 @@ package sync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
-var log = logging.Logger("header/sync")
+var (
+	log    = logging.Logger("header/sync")
+	tracer = otel.Tracer("header/sync")
+)
 
 // Syncer implements efficient synchronization for headers.
 //
 // Subjective header - the latest known header that is not expired (within trusting period)
 // Network header - the latest header received from the network
 //
-// There are two main processes running in Syncer:
+// There are three main processes running in Syncer:
 // 1. Main syncing loop(s.syncLoop)
 //   - Performs syncing from the subjective(local chain view) header up to the latest known trusted header
 //   - Syncs by requesting missing headers from Exchange or
@@ -32,6 +42,9 @@ var log = logging.Logger("header/sync")
 //     verifies against the latest known trusted header
 //     adds the header to pending cache(making it the latest known trusted header)
 //     and triggers syncing loop to catch up to that point.
+//
+// 3. Repairs the gaps found by the Store in between the stored headers (s.repairLoop)
+//   - Requests the missing headers from Exchange and splices them into the gaps
 type Syncer struct {
 	sub      header.Subscriber
 	exchange header.Exchange
@@ -41,6 +54,8 @@ type Syncer struct {
 	// whether its subjective head is outdated
 	blockTime time.Duration
 
+	Params *Parameters
+
 	// stateLk protects state which represents the current or latest sync
 	stateLk sync.RWMutex
 	state   State
@@ -50,23 +65,54 @@ type Syncer struct {
 	pending ranges
 	// netReqLk ensures only one network head is requested at any moment
 	netReqLk sync.RWMutex
+	// netHeadLk protects the highest validated network header seen, which may be ahead of the store
+	netHeadLk sync.RWMutex
+	netHead   *header.ExtendedHeader
 
 	// controls lifecycle for syncLoop
 	ctx    context.Context
 	cancel context.CancelFunc
+	// running is set while the Syncer is started, as ctx is not safe to be read concurrently
+	running int32
+	// background is set while the gossiped header is verified in the background
+	background int32
+	// targetHeight is the height syncing stops at, if any, which can be changed at runtime
+	targetHeight uint64
+	// now is the clock the headers are checked to be within the unbonding period against
+	now func() time.Time
 }
 
 // NewSyncer creates a new instance of Syncer.
-func NewSyncer(exchange header.Exchange, store header.Store, sub header.Subscriber, blockTime time.Duration) *Syncer {
+func NewSyncer(
+	exchange header.Exchange,
+	store header.Store,
+	sub header.Subscriber,
+	blockTime time.Duration,
+	opts ...Option,
+) *Syncer {
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(params)
+	}
+
 	return &Syncer{
-		sub:         sub,
-		exchange:    exchange,
-		store:       store,
-		blockTime:   blockTime,
-		triggerSync: make(chan struct{}, 1), // should be buffered
+		sub:          sub,
+		exchange:     exchange,
+		store:        store,
+		blockTime:    blockTime,
+		Params:       params,
+		triggerSync:  make(chan struct{}, 1), // should be buffered
+		targetHeight: params.TargetHeight,
+		now:          time.Now,
 	}
 }
 
+// SetPrefetcher sets the Prefetcher notified of every accepted network head, for the cases it
+// cannot be passed with WithPrefetcher. It must be called before the Syncer is started.
+func (s *Syncer) SetPrefetcher(p Prefetcher) {
+	s.Params.Prefetcher = p
+}
+
 // Start starts the syncing routine.
 func (s *Syncer) Start(ctx context.Context) error {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
@@ -76,6 +122,11 @@ func (s *Syncer) Start(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// resume syncing to the network head known before the restart, if any
+	err = s.loadNetHead(ctx)
+	if err != nil {
+		return err
+	}
 	// get the latest head and set it as syncing target
 	_, err = s.networkHead(ctx)
 	if err != nil {
@@ -83,15 +134,31 @@ func (s *Syncer) Start(ctx context.Context) error {
 	}
 	// start syncLoop only if Start is errorless
 	go s.syncLoop()
+	if gs, ok := s.store.(gapStore); ok {
+		go s.repairLoop(gs)
+	}
+	atomic.StoreInt32(&s.running, 1)
 	return nil
 }
 
 // Stop stops Syncer.
 func (s *Syncer) Stop(ctx context.Context) error {
+	atomic.StoreInt32(&s.running, 0)
 	s.cancel()
 	return s.sub.Stop(ctx)
 }
 
+// Healthy reports whether the Syncer is running and its latest sync succeeded.
+func (s *Syncer) Healthy() error {
+	if atomic.LoadInt32(&s.running) == 0 {
+		return fmt.Errorf("header/sync: syncer is not running")
+	}
+	if err := s.State().Error; err != "" {
+		return fmt.Errorf("header/sync: latest sync failed: %s", err)
+	}
+	return nil
+}
+
 // WaitSync blocks until ongoing sync is done.
 func (s *Syncer) WaitSync(ctx context.Context) error {
 	state := s.State()
@@ -108,10 +175,12 @@ func (s *Syncer) WaitSync(ctx context.Context) error {
 type State struct {
 	ID                   uint64 // incrementing ID of a sync
 	Height               uint64 // height at the moment when State is requested for a sync
+	NetworkHeight        uint64 // height of the highest known network header, which may not be synced yet
+	TargetHeight         uint64 // height syncing stops at, if configured
 	FromHeight, ToHeight uint64 // the starting and the ending point of a sync
 	FromHash, ToHash     tmbytes.HexBytes
 	Start, End           time.Time
-	Error                error // the error that might happen within a sync
+	Error                string // the error that might happen within a sync
 }
 
 // Finished returns true if sync is done, false otherwise.
@@ -133,6 +202,13 @@ func (s *Syncer) State() State {
 	state := s.state
 	s.stateLk.RUnlock()
 	state.Height = s.store.Height()
+	state.TargetHeight = s.TargetHeight()
+	state.NetworkHeight = state.Height
+	s.netHeadLk.RLock()
+	if s.netHead != nil && uint64(s.netHead.Height) > state.Height {
+		state.NetworkHeight = uint64(s.netHead.Height)
+	}
+	s.netHeadLk.RUnlock()
 	return state
 }
 
@@ -199,12 +275,20 @@ func (s *Syncer) sync(ctx context.Context) {
 		"from", head.Height,
 		"to", newHead.Height,
 		"elapsed time", s.state.End.Sub(s.state.Start))
+	s.Params.Events.Publish(events.Event{Type: events.SyncFinished, Height: uint64(newHead.Height)})
 }
 
 // doSync performs actual syncing updating the internal State
 func (s *Syncer) doSync(ctx context.Context, fromHead, toHead *header.ExtendedHeader) (err error) {
 	from, to := uint64(fromHead.Height)+1, uint64(toHead.Height)
 
+	ctx, span := tracer.Start(ctx, "sync")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	)
+
 	s.stateLk.Lock()
 	s.state.ID++
 	s.state.FromHeight = from
@@ -214,6 +298,8 @@ func (s *Syncer) doSync(ctx context.Context, fromHead, toHead *header.ExtendedHe
 	s.state.Start = time.Now()
 	s.stateLk.Unlock()
 
+	// the headers in between are likely signed by the validators of either end
+	header.WarmValidatorSets(fromHead, toHead)
 	for processed := 0; from < to; from += uint64(processed) {
 		processed, err = s.processHeaders(ctx, from, to)
 		if err != nil && processed == 0 {
@@ -223,20 +309,41 @@ func (s *Syncer) doSync(ctx context.Context, fromHead, toHead *header.ExtendedHe
 
 	s.stateLk.Lock()
 	s.state.End = time.Now()
-	s.state.Error = err
+	s.state.Error = ""
+	if err != nil {
+		s.state.Error = err.Error()
+	}
 	s.stateLk.Unlock()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 	return err
 }
 
 // processHeaders gets and stores headers starting at the given 'from' height up to 'to' height -
 // [from:to]
 func (s *Syncer) processHeaders(ctx context.Context, from, to uint64) (int, error) {
+	ctx, span := tracer.Start(ctx, "process-headers")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	)
+
 	headers, err := s.findHeaders(ctx, from, to)
 	if err != nil {
+		span.RecordError(err)
 		return 0, err
 	}
+	span.AddEvent("found-headers", trace.WithAttributes(attribute.Int("amount", len(headers))))
 
-	return s.store.Append(ctx, headers...)
+	processed, err := s.store.Append(ctx, headers...)
+	span.SetAttributes(attribute.Int("processed", processed))
+	if err != nil {
+		span.RecordError(err)
+	}
+	return processed, err
 }
 
 // TODO(@Wondertan): Number of headers that can be requested at once. Either make this configurable
@@ -0,0 +1,67 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/store"
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+// gapStore is the Store finding gaps in between the stored headers, e.g. left by a crash, and
+// storing the missing headers into them.
+type gapStore interface {
+	Gaps() <-chan store.Gap
+	Splice(context.Context, ...*header.ExtendedHeader) (int, error)
+}
+
+// repairLoop repairs the gaps found by the Store with the missing headers requested from the
+// network, so that the interior heights are not missing forever.
+func (s *Syncer) repairLoop(gs gapStore) {
+	for {
+		select {
+		case gap := <-gs.Gaps():
+			err := s.repairGap(s.ctx, gs, gap)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				log.Errorw("repairing gap", "from", gap.From, "to", gap.To, "err", err)
+				continue
+			}
+			log.Infow("repaired gap", "from", gap.From, "to", gap.To)
+			s.Params.Events.Publish(events.Event{
+				Type:    events.GapRepaired,
+				Height:  gap.From,
+				Message: fmt.Sprintf("repaired headers from %d to %d", gap.From, gap.To),
+			})
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// repairGap requests the headers missing from the gap and splices them into it - [from:to]
+func (s *Syncer) repairGap(ctx context.Context, gs gapStore, gap store.Gap) error {
+	for from := gap.From; from <= gap.To; {
+		amount := gap.To - from + 1
+		if amount > requestSize {
+			amount = requestSize
+		}
+		headers, err := s.exchange.GetRangeByHeight(ctx, from, amount)
+		if err != nil {
+			return err
+		}
+		if len(headers) == 0 {
+			return fmt.Errorf("header/sync: no headers got from %d", from)
+		}
+		processed, err := gs.Splice(ctx, headers...)
+		if err != nil {
+			return err
+		}
+		from += uint64(processed)
+	}
+	return nil
+}
@@ -1,12 +1,18 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 
+	"github.com/ipfs/go-datastore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/tendermint/tendermint/light"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 // Head returns the Syncer's latest known header. It calls 'networkHead' in order to
@@ -15,9 +21,34 @@ func (s *Syncer) Head(ctx context.Context) (*header.ExtendedHeader, error) {
 	return s.networkHead(ctx)
 }
 
+// NetworkHead returns the highest known network header, which is either the head of the store or
+// the validated network header the Syncer syncs to. Unlike Head, it never requests the network.
+func (s *Syncer) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	head, err := s.store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.netHeadLk.RLock()
+	defer s.netHeadLk.RUnlock()
+	if s.netHead != nil && s.netHead.Height > head.Height {
+		return s.netHead, nil
+	}
+	return head, nil
+}
+
+// ErrOldTrustedHead is returned when the subjective head is older than the trusting period and
+// can't be re-initialized, as the header to re-initialize from is older than the unbonding period.
+// Such a header can't be trusted even subjectively, as its validators may have unbonded since.
+// Syncing recovers once the trusted peers are synced or replaced by synced ones, or once the hash
+// of a recent header to re-initialize from is configured.
+var ErrOldTrustedHead = errors.New("header/sync: trusted head is older than the unbonding period")
+
 // subjectiveHead returns the latest known local header that is not expired(within trusting period).
 // If the header is expired, it is retrieved from a trusted peer without validation;
 // in other words, an automatic subjective initialization is performed.
+// The header retrieved is the one with the ReinitHash, if configured, or the head of the trusted
+// peers otherwise. It's never used if it's older than the unbonding period, returning
+// ErrOldTrustedHead.
 func (s *Syncer) subjectiveHead(ctx context.Context) (*header.ExtendedHeader, error) {
 	// pending head is the latest known subjective head Syncer syncs to, so try to get it
 	// NOTES:
@@ -36,25 +67,53 @@ func (s *Syncer) subjectiveHead(ctx context.Context) (*header.ExtendedHeader, er
 	if !netHead.IsExpired() {
 		return netHead, nil
 	}
-	log.Infow("subjective header expired", "height", netHead.Height)
-	// otherwise, request network head from a trusted peer
-	netHead, err = s.exchange.Head(ctx)
+	log.Infow("subjective header expired, re-initializing", "height", netHead.Height)
+	// otherwise, request the header to re-initialize from a trusted peer
+	netHead, err = s.reinitHead(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if s.unbonded(netHead) {
+		log.Errorw("subjective initialization with a header older than the unbonding period",
+			"height", netHead.Height, "time", netHead.Time, "reinit_hash", s.Params.ReinitHash)
+		return nil, ErrOldTrustedHead
+	}
 	// and set as the new subjective head without validation,
 	// or, in other words, do 'automatic subjective initialization'
 	s.newNetHead(ctx, netHead, true)
-	switch {
-	default:
-		log.Infow("subjective initialization finished", "height", netHead.Height)
-		return netHead, nil
-	case netHead.IsExpired():
-		log.Warnw("subjective initialization with an expired header", "height", netHead.Height)
-	case !netHead.IsRecent(s.blockTime):
+	if s.beyondTarget(netHead) {
+		// the header at the target height is set instead
+		if pendHead := s.pending.Head(); pendHead != nil {
+			return pendHead, nil
+		}
+	}
+	if !netHead.IsRecent(s.blockTime) {
 		log.Warnw("subjective initialization with an old header", "height", netHead.Height)
+		log.Warn("trusted peer is out of sync")
+		return netHead, nil
+	}
+	log.Infow("subjective initialization finished", "height", netHead.Height)
+	return netHead, nil
+}
+
+// unbonded reports whether the validators of the header may have unbonded since it was signed.
+func (s *Syncer) unbonded(h *header.ExtendedHeader) bool {
+	return !h.Time.Add(header.UnbondingPeriod).After(s.now())
+}
+
+// reinitHead requests the header to re-initialize the expired subjective head from.
+func (s *Syncer) reinitHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	if len(s.Params.ReinitHash) == 0 {
+		return s.exchange.Head(ctx)
+	}
+	netHead, err := s.exchange.Get(ctx, s.Params.ReinitHash)
+	if err != nil {
+		return nil, fmt.Errorf("header/sync: requesting header to re-initialize from: %w", err)
+	}
+	if !bytes.Equal(netHead.Hash(), s.Params.ReinitHash) {
+		return nil, fmt.Errorf("header/sync: header to re-initialize from has hash %X, expected %X",
+			netHead.Hash(), s.Params.ReinitHash)
 	}
-	log.Warn("trusted peer is out of sync")
 	return netHead, nil
 }
 
@@ -71,6 +130,10 @@ func (s *Syncer) networkHead(ctx context.Context) (*header.ExtendedHeader, error
 	if sbjHead.IsRecent(s.blockTime) {
 		return sbjHead, nil
 	}
+	// or if it's at the target height, as the network is not synced beyond it
+	if target := s.TargetHeight(); target != 0 && uint64(sbjHead.Height) >= target {
+		return sbjHead, nil
+	}
 	// otherwise, request head from a trusted peer, as we assume it is fully synced
 	//
 	// the lock construction here ensures only one routine requests at a time
@@ -102,11 +165,20 @@ func (s *Syncer) networkHead(ctx context.Context) (*header.ExtendedHeader, error
 
 // incomingNetHead processes new gossiped network headers.
 func (s *Syncer) incomingNetHead(ctx context.Context, netHead *header.ExtendedHeader) pubsub.ValidationResult {
+	if s.beyondTarget(netHead) {
+		// never append beyond the target height, but sync up to it, requesting the header at the
+		// target height off the validation path
+		s.inBackground(func(ctx context.Context) {
+			s.newTargetHead(ctx, false)
+		})
+		return pubsub.ValidationIgnore
+	}
 	// Try to short-circuit netHead with append. If not adjacent/from future - try it as new network
 	// header
 	_, err := s.store.Append(ctx, netHead)
 	if err == nil {
 		// a happy case where we appended maybe head directly, so accept
+		s.publishNewHead(netHead)
 		return pubsub.ValidationAccept
 	}
 	var nonAdj *header.ErrNonAdjacent
@@ -125,29 +197,154 @@ func (s *Syncer) incomingNetHead(ctx context.Context, netHead *header.ExtendedHe
 			"hash", netHead.Hash().String(),
 			"err", err)
 	}
-	// try as new head
-	return s.newNetHead(ctx, netHead, false)
+	// try as new head, verified without requesting the headers in between, as that would block the
+	// validation of the gossiped headers
+	res := s.newGossipedNetHead(ctx, netHead)
+	if res == pubsub.ValidationAccept {
+		s.publishNewHead(netHead)
+	}
+	return res
+}
+
+// newGossipedNetHead sets the gossiped network header as the new subjective head, if it can be
+// verified directly against the subjective head. Otherwise, it's verified in the background by
+// requesting the headers in between, and it's ignored for now, i.e. not propagated further.
+func (s *Syncer) newGossipedNetHead(ctx context.Context, netHead *header.ExtendedHeader) pubsub.ValidationResult {
+	res := s.validate(ctx, netHead, func(context.Context, uint64) (*header.ExtendedHeader, error) {
+		return nil, errSkippingRequired
+	})
+	switch res {
+	case pubsub.ValidationAccept:
+		s.acceptNetHead(ctx, netHead)
+	case validationDeferred:
+		s.inBackground(func(ctx context.Context) {
+			if s.newNetHead(ctx, netHead, false) == pubsub.ValidationAccept {
+				s.publishNewHead(netHead)
+			}
+		})
+		res = pubsub.ValidationIgnore
+	}
+	return res
+}
+
+// inBackground runs the given function in the background, unless the previous one is still
+// running, as the headers keep arriving anyway.
+func (s *Syncer) inBackground(f func(context.Context)) {
+	if s.ctx == nil || !atomic.CompareAndSwapInt32(&s.background, 0, 1) {
+		return
+	}
+	go func(ctx context.Context) {
+		defer atomic.StoreInt32(&s.background, 0)
+		f(ctx)
+	}(s.ctx)
+}
+
+// publishNewHead publishes the accepted network header as the new head and notifies the
+// Prefetcher of it.
+func (s *Syncer) publishNewHead(netHead *header.ExtendedHeader) {
+	s.Params.Events.Publish(events.Event{Type: events.NewHead, Height: uint64(netHead.Height)})
+	// only the new heads are prefetched, as they are sampled right away, while the synced headers
+	// may be far ahead of sampling, e.g. while catching up
+	if s.Params.Prefetcher != nil {
+		s.Params.Prefetcher.Prefetch(netHead)
+	}
 }
 
 // newNetHead sets the network header as the new subjective head with preceding validation(per
 // request).
 func (s *Syncer) newNetHead(ctx context.Context, netHead *header.ExtendedHeader, trust bool) pubsub.ValidationResult {
+	if s.beyondTarget(netHead) {
+		return s.newTargetHead(ctx, trust)
+	}
 	// validate netHead against subjective head
 	if !trust {
-		if res := s.validate(ctx, netHead); res != pubsub.ValidationAccept {
+		if res := s.validate(ctx, netHead, s.exchange.GetByHeight); res != pubsub.ValidationAccept {
 			// netHead was either ignored or rejected
 			return res
 		}
 	}
 	// and if valid, set it as new subjective head
+	s.acceptNetHead(ctx, netHead)
+	return pubsub.ValidationAccept
+}
+
+// acceptNetHead sets the valid network header as the new subjective head and syncs up to it.
+func (s *Syncer) acceptNetHead(ctx context.Context, netHead *header.ExtendedHeader) {
 	s.pending.Add(netHead)
+	s.setNetHead(ctx, netHead)
 	s.wantSync()
 	log.Infow("new network head", "height", netHead.Height, "hash", netHead.Hash())
-	return pubsub.ValidationAccept
 }
 
-// validate checks validity of the given header against the subjective head.
-func (s *Syncer) validate(ctx context.Context, new *header.ExtendedHeader) pubsub.ValidationResult {
+// TargetHeight returns the height syncing stops at, or 0 if there is no target.
+func (s *Syncer) TargetHeight() uint64 {
+	return atomic.LoadUint64(&s.targetHeight)
+}
+
+// SetTargetHeight changes the height syncing stops at at runtime and syncs up to it, or to the
+// network head if the height is 0. The height can't be below the headers already synced or being
+// synced to.
+func (s *Syncer) SetTargetHeight(ctx context.Context, height uint64) error {
+	if height != 0 {
+		if synced := s.store.Height(); height < synced {
+			return fmt.Errorf("header/sync: target height %d is below the synced height %d", height, synced)
+		}
+		if pendHead := s.pending.Head(); pendHead != nil && height < uint64(pendHead.Height) {
+			return fmt.Errorf("header/sync: target height %d is below the height %d being synced to",
+				height, pendHead.Height)
+		}
+	}
+	atomic.StoreUint64(&s.targetHeight, height)
+	log.Infow("sync target height changed", "height", height)
+	// request the network head, so that syncing continues up to the new target right away
+	_, err := s.networkHead(ctx)
+	return err
+}
+
+// beyondTarget reports whether the header is above the target height the Syncer stops at.
+func (s *Syncer) beyondTarget(h *header.ExtendedHeader) bool {
+	target := s.TargetHeight()
+	return target != 0 && uint64(h.Height) > target
+}
+
+// newTargetHead requests the header at the target height, once the network is known to be past it,
+// and sets it as the new subjective head instead of the network one.
+// The network header itself is ignored, as it's not validated and so can't be propagated further.
+func (s *Syncer) newTargetHead(ctx context.Context, trust bool) pubsub.ValidationResult {
+	target := s.TargetHeight()
+	if target == 0 || s.store.Height() >= target {
+		return pubsub.ValidationIgnore
+	}
+	if pendHead := s.pending.Head(); pendHead != nil && uint64(pendHead.Height) == target {
+		return pubsub.ValidationIgnore
+	}
+	targetHead, err := s.exchange.GetByHeight(ctx, target)
+	if err != nil {
+		log.Errorw("requesting header at the target height", "height", target, "err", err)
+		return pubsub.ValidationIgnore
+	}
+	if res := s.newNetHead(ctx, targetHead, trust); res == pubsub.ValidationAccept {
+		log.Infow("syncing to the target height", "height", target)
+	}
+	return pubsub.ValidationIgnore
+}
+
+// errSkippingRequired is returned by the getter of the headers in between, which must not be
+// requested during the validation of the gossiped headers.
+var errSkippingRequired = errors.New("header/sync: verification requires headers in between")
+
+// validationDeferred is the result of validate, when the header can't be verified without the
+// headers in between, which must not be requested.
+const validationDeferred pubsub.ValidationResult = -1
+
+// validate checks validity of the given header against the subjective head. The headers in between
+// are requested with the given getter, if the validator set changed too much to verify the header
+// directly.
+func (s *Syncer) validate(
+	ctx context.Context,
+	new *header.ExtendedHeader,
+	get func(context.Context, uint64) (*header.ExtendedHeader, error),
+) pubsub.ValidationResult {
 	sbjHead, err := s.subjectiveHead(ctx)
 	if err != nil {
 		log.Errorw("getting subjective head during validation", "err", err)
@@ -159,10 +356,15 @@ func (s *Syncer) validate(ctx context.Context, new *header.ExtendedHeader) pubsu
 			"current_height", sbjHead.Height,
 			"header_height", new.Height,
 			"header_hash", new.Hash())
+		s.detectFork(ctx, new)
 		return pubsub.ValidationIgnore
 	}
-	// perform verification
-	err = sbjHead.VerifyNonAdjacent(new)
+	// perform verification, requesting the headers in between only if the validator set changed
+	// too much to verify the header directly
+	err = sbjHead.VerifySkipping(ctx, new, get, header.WithClock(s.Params.Clock))
+	if errors.Is(err, errSkippingRequired) {
+		return validationDeferred
+	}
 	var verErr *header.VerifyError
 	if errors.As(err, &verErr) {
 		log.Errorw("invalid network header",
@@ -173,6 +375,101 @@ func (s *Syncer) validate(ctx context.Context, new *header.ExtendedHeader) pubsu
 			"reason", verErr.Reason)
 		return pubsub.ValidationReject
 	}
+	if err != nil {
+		log.Errorw("requesting headers during validation", "err", err)
+		return pubsub.ValidationIgnore // local error, so ignore
+	}
 	// and accept if the header is good
 	return pubsub.ValidationAccept
 }
+
+// detectFork checks whether the given header of an already synced height conflicts with the stored
+// one, while being signed by enough of its validators to be trusted. This means the validators
+// equivocated and the network forked, so a ForkDetected event is published.
+func (s *Syncer) detectFork(ctx context.Context, conflicting *header.ExtendedHeader) {
+	if conflicting.Commit == nil || uint64(conflicting.Height) > s.store.Height() {
+		return
+	}
+	known, err := s.store.GetByHeight(ctx, uint64(conflicting.Height))
+	if err != nil {
+		log.Debugw("getting header to check for fork", "height", conflicting.Height, "err", err)
+		return
+	}
+	if bytes.Equal(known.Hash(), conflicting.Hash()) ||
+		!bytes.Equal(conflicting.Commit.BlockID.Hash, conflicting.Hash()) {
+		return
+	}
+	err = known.ValidatorSet.VerifyCommitLightTrusting(known.ChainID, conflicting.Commit, light.DefaultTrustLevel)
+	if err != nil {
+		// not signed by the trusted validators, so just an invalid header
+		return
+	}
+
+	log.Errorw("FORK DETECTED: received conflicting header signed by trusted validators",
+		"height", conflicting.Height,
+		"hash_of_conflicting", conflicting.Hash(),
+		"hash_of_known", known.Hash())
+	s.Params.Events.Publish(events.Event{
+		Type:   events.ForkDetected,
+		Height: uint64(conflicting.Height),
+		Message: fmt.Sprintf("header %s conflicts with the synced %s",
+			conflicting.Hash(), known.Hash()),
+	})
+}
+
+// networkHeadKey is the key the network head is persisted under.
+var networkHeadKey = datastore.NewKey("sync/network_head")
+
+// setNetHead tracks the given validated header as the network head, unless a higher one is
+// already known, and persists it.
+func (s *Syncer) setNetHead(ctx context.Context, netHead *header.ExtendedHeader) {
+	s.netHeadLk.Lock()
+	defer s.netHeadLk.Unlock()
+	if s.netHead != nil && s.netHead.Height >= netHead.Height {
+		return
+	}
+	s.netHead = netHead
+
+	if s.Params.Datastore == nil {
+		return
+	}
+	bin, err := netHead.MarshalBinary()
+	if err != nil {
+		log.Errorw("marshaling network head", "height", netHead.Height, "err", err)
+		return
+	}
+	err = s.Params.Datastore.Put(ctx, networkHeadKey, bin)
+	if err != nil {
+		log.Errorw("persisting network head", "height", netHead.Height, "err", err)
+	}
+}
+
+// loadNetHead loads the persisted network head and sets it as the subjective head to sync to,
+// if it is still ahead of the store and within the trusting period.
+func (s *Syncer) loadNetHead(ctx context.Context) error {
+	if s.Params.Datastore == nil {
+		return nil
+	}
+	bin, err := s.Params.Datastore.Get(ctx, networkHeadKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("header/sync: loading network head: %w", err)
+	}
+	netHead, err := header.UnmarshalExtendedHeader(bin)
+	if err != nil {
+		// the network head is only a hint, so it is requested from the network instead
+		log.Errorw("unmarshaling persisted network head", "err", err)
+		return nil
+	}
+	if uint64(netHead.Height) <= s.store.Height() || netHead.IsExpired() || s.beyondTarget(netHead) {
+		return nil
+	}
+	// the persisted network head was validated before, so it is trusted
+	s.pending.Add(netHead)
+	s.setNetHead(ctx, netHead)
+	s.wantSync()
+	log.Infow("resuming sync to the persisted network head", "height", netHead.Height, "hash", netHead.Hash())
+	return nil
+}
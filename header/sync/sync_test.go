@@ -2,9 +2,13 @@ package sync
 
 import (
 	"context"
+	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +17,7 @@ import (
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/header/local"
 	"github.com/celestiaorg/celestia-node/header/store"
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 var blockTime = 30 * time.Second
@@ -59,6 +64,76 @@ func TestSyncSimpleRequestingHead(t *testing.T) {
 	assert.True(t, state.Finished(), state)
 }
 
+// TestSyncer_OldTrustedHead tests that the Syncer refuses to use the head of a trusted peer once it
+// is older than the unbonding period.
+func TestSyncer_OldTrustedHead(t *testing.T) {
+	header.TrustingPeriod = time.Microsecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+	remoteStore := store.NewTestStore(ctx, t, head)
+	headers := suite.GenExtendedHeaders(10)
+	_, err := remoteStore.Append(ctx, headers...)
+	require.NoError(t, err)
+
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime)
+	// the unbonding period has passed since the head of the trusted peer
+	syncer.now = func() time.Time {
+		return headers[len(headers)-1].Time.Add(header.UnbondingPeriod)
+	}
+	err = syncer.Start(ctx)
+	require.ErrorIs(t, err, ErrOldTrustedHead)
+	assert.Empty(t, syncer.pending.Head())
+}
+
+func TestSyncer_ReinitHash(t *testing.T) {
+	header.TrustingPeriod = time.Millisecond * 100
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+	remoteStore := store.NewTestStore(ctx, t, head)
+	expired := suite.GenExtendedHeaders(5)
+	_, err := remoteStore.Append(ctx, expired...)
+	require.NoError(t, err)
+	time.Sleep(header.TrustingPeriod)
+	recent := suite.GenExtendedHeaders(5)
+	_, err = remoteStore.Append(ctx, recent...)
+	require.NoError(t, err)
+	// wait for the headers to be retrievable by hash
+	_, err = remoteStore.GetByHeight(ctx, uint64(recent[4].Height))
+	require.NoError(t, err)
+
+	// the header with the hash is preferred over the head of the trusted peers
+	// the unbonding period has passed since the old headers, but not since the recent ones
+	now := func() time.Time {
+		return expired[len(expired)-1].Time.Add(header.UnbondingPeriod)
+	}
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithReinitHash(recent[2].Hash()))
+	syncer.now = now
+	sbjHead, err := syncer.subjectiveHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, recent[2].Hash(), sbjHead.Hash())
+	assert.Equal(t, recent[2].Height, syncer.pending.Head().Height)
+
+	// the header with the hash must be within the unbonding period as well
+	localStore = store.NewTestStore(ctx, t, head)
+	syncer = NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithReinitHash(expired[2].Hash()))
+	syncer.now = now
+	_, err = syncer.subjectiveHead(ctx)
+	require.ErrorIs(t, err, ErrOldTrustedHead)
+	assert.Empty(t, syncer.pending.Head())
+}
+
 func TestSyncCatchUp(t *testing.T) {
 	// just set a big enough value, so we trust local header and don't request anything
 	header.TrustingPeriod = time.Minute
@@ -71,7 +146,10 @@ func TestSyncCatchUp(t *testing.T) {
 
 	remoteStore := store.NewTestStore(ctx, t, head)
 	localStore := store.NewTestStore(ctx, t, head)
-	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime)
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.NewHead, events.SyncFinished)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithEvents(bus))
 	// 1. Initial sync
 	err := syncer.Start(ctx)
 	require.NoError(t, err)
@@ -102,6 +180,178 @@ func TestSyncCatchUp(t *testing.T) {
 	assert.Equal(t, uint64(2), state.FromHeight)
 	assert.Equal(t, uint64(exp.Height+1), state.ToHeight)
 	assert.True(t, state.Finished(), state)
+
+	// 5. assert the new head and the end of the sync were published
+	e := <-sub.Out()
+	assert.Equal(t, events.NewHead, e.Type)
+	assert.Equal(t, uint64(exp.Height+1), e.Height)
+	e = <-sub.Out()
+	assert.Equal(t, events.SyncFinished, e.Type)
+	assert.Equal(t, uint64(exp.Height+1), e.Height)
+}
+
+// TestSyncer_TargetHeight tests that the Syncer syncs up to the target height exactly and stops
+// there, regardless of the network head.
+func TestSyncer_TargetHeight(t *testing.T) {
+	// just set a big enough value, so we trust local header and don't request anything
+	header.TrustingPeriod = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+
+	remoteStore := store.NewTestStore(ctx, t, head)
+	_, err := remoteStore.Append(ctx, suite.GenExtendedHeaders(100)...)
+	require.NoError(t, err)
+	_, err = remoteStore.GetByHeight(ctx, 101)
+	require.NoError(t, err)
+
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithTargetHeight(50))
+	err = syncer.Start(ctx)
+	require.NoError(t, err)
+
+	// syncer rcvs header from the future, which is beyond the target, so it's not propagated, while
+	// the header at the target height is requested in the background
+	res := syncer.incomingNetHead(ctx, suite.GenExtendedHeaders(1)[0])
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+	require.Eventually(t, func() bool {
+		return syncer.State().ToHeight == 50
+	}, time.Second, time.Millisecond*10)
+
+	err = syncer.WaitSync(ctx)
+	require.NoError(t, err)
+
+	have, err := localStore.Head(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 50, have.Height)
+
+	// the following headers are not synced anymore
+	res = syncer.incomingNetHead(ctx, suite.GenExtendedHeaders(1)[0])
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+	assert.Empty(t, syncer.pending.Head())
+	assert.EqualValues(t, 50, localStore.Height())
+
+	state := syncer.State()
+	assert.EqualValues(t, 50, state.Height)
+	assert.EqualValues(t, 50, state.ToHeight)
+	assert.EqualValues(t, 50, state.TargetHeight)
+	assert.True(t, state.Finished(), state)
+
+	// the target can't be moved below the synced headers
+	err = syncer.SetTargetHeight(ctx, 40)
+	assert.Error(t, err)
+
+	// but can be moved further, syncing up to it right away
+	err = syncer.SetTargetHeight(ctx, 80)
+	require.NoError(t, err)
+	_, err = localStore.GetByHeight(ctx, 80)
+	require.NoError(t, err)
+	assert.EqualValues(t, 80, localStore.Height())
+	assert.EqualValues(t, 80, syncer.State().TargetHeight)
+}
+
+// TestSyncer_NetworkHead tests that the Syncer tracks the network head separately from the synced
+// one and resumes syncing to it after a restart.
+func TestSyncer_NetworkHead(t *testing.T) {
+	// just set a big enough value, so we trust local header and don't request anything
+	header.TrustingPeriod = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(localStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithDatastore(ds))
+
+	// without a network header, the network head is the synced one
+	netHead, err := syncer.NetworkHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash(), netHead.Hash())
+
+	// syncer rcvs header from the future, which is not synced yet
+	future := suite.GenExtendedHeaders(10)[9]
+	res := syncer.incomingNetHead(ctx, future)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+
+	netHead, err = syncer.NetworkHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, future.Hash(), netHead.Hash())
+	state := syncer.State()
+	assert.Equal(t, uint64(head.Height), state.Height)
+	assert.Equal(t, uint64(future.Height), state.NetworkHeight)
+
+	// a restarted syncer resumes syncing to the persisted network head
+	syncer = NewSyncer(local.NewExchange(localStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithDatastore(ds))
+	err = syncer.loadNetHead(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, future.Hash(), syncer.pending.Head().Hash())
+	assert.Equal(t, uint64(future.Height), syncer.State().NetworkHeight)
+}
+
+// TestSyncer_Prefetch tests that the Syncer notifies the Prefetcher of every accepted network head.
+func TestSyncer_Prefetch(t *testing.T) {
+	// just set a big enough value, so we trust local header and don't request anything
+	header.TrustingPeriod = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+
+	remoteStore := store.NewTestStore(ctx, t, head)
+	_, err := remoteStore.Append(ctx, suite.GenExtendedHeaders(20)...)
+	require.NoError(t, err)
+
+	prefetcher := &heightsPrefetcher{heights: make(map[int64]int)}
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithPrefetcher(prefetcher))
+	err = syncer.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, syncer.Stop(ctx))
+	})
+
+	// the gossiped header is prefetched right once accepted, ahead of syncing up to it
+	netHead := suite.GenExtendedHeaders(1)[0]
+	res := syncer.incomingNetHead(ctx, netHead)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	prefetcher.lk.Lock()
+	assert.Equal(t, 1, prefetcher.heights[netHead.Height])
+	prefetcher.lk.Unlock()
+
+	time.Sleep(time.Millisecond * 10) // needs some to realize it is syncing
+	err = syncer.WaitSync(ctx)
+	require.NoError(t, err)
+
+	// while the synced headers in between are not, as they may be far ahead of sampling
+	prefetcher.lk.Lock()
+	defer prefetcher.lk.Unlock()
+	for height := head.Height + 1; height < netHead.Height; height++ {
+		assert.Zero(t, prefetcher.heights[height], height)
+	}
+}
+
+// heightsPrefetcher counts the prefetches of every height.
+type heightsPrefetcher struct {
+	lk      sync.Mutex
+	heights map[int64]int
+}
+
+func (p *heightsPrefetcher) Prefetch(h *header.ExtendedHeader) {
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	p.heights[h.Height]++
 }
 
 func TestSyncPendingRangesWithMisses(t *testing.T) {
@@ -215,3 +465,195 @@ func (e *exchangeCountingHead) GetByHeight(ctx context.Context, u uint64) (*head
 func (e *exchangeCountingHead) GetRangeByHeight(c context.Context, from, to uint64) ([]*header.ExtendedHeader, error) {
 	panic("implement me")
 }
+
+// TestSyncer_RepairGap tests that the Syncer requests the headers missing from the gaps found by
+// the Store and splices them into the gaps.
+func TestSyncer_RepairGap(t *testing.T) {
+	// just set a big enough value, so we trust local header and don't request anything
+	header.TrustingPeriod = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+
+	remoteStore := store.NewTestStore(ctx, t, head)
+	_, err := remoteStore.Append(ctx, suite.GenExtendedHeaders(100)...)
+	require.NoError(t, err)
+	_, err = remoteStore.GetByHeight(ctx, 101)
+	require.NoError(t, err)
+
+	localStore := &gapsStore{
+		Store:   store.NewTestStore(ctx, t, head),
+		gaps:    make(chan store.Gap, 1),
+		spliced: make(chan []*header.ExtendedHeader, 100),
+	}
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.GapRepaired)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithEvents(bus))
+	err = syncer.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, syncer.Stop(ctx))
+	})
+
+	localStore.gaps <- store.Gap{From: 10, To: 40}
+	e := <-sub.Out()
+	assert.EqualValues(t, 10, e.Height)
+
+	next := uint64(10)
+	for next <= 40 {
+		for _, h := range <-localStore.spliced {
+			assert.EqualValues(t, next, h.Height)
+			next++
+		}
+	}
+	assert.EqualValues(t, 41, next)
+}
+
+func TestSyncer_DetectFork(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+	localStore := store.NewTestStore(ctx, t, head)
+	_, err := localStore.Append(ctx, suite.GenExtendedHeaders(10)...)
+	require.NoError(t, err)
+
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.ForkDetected)
+	syncer := NewSyncer(local.NewExchange(localStore), localStore, &header.DummySubscriber{}, blockTime,
+		WithEvents(bus))
+
+	known, err := localStore.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+
+	// a header at the known height signed by the trusted validators, yet conflicting with the known one
+	rh := suite.GenRawHeader(known.Height, known.LastBlockID.Hash, known.LastCommitHash, known.DataHash)
+	rh.ChainID = known.ChainID
+	conflicting := &header.ExtendedHeader{
+		RawHeader:    *rh,
+		Commit:       suite.Commit(rh),
+		ValidatorSet: known.ValidatorSet,
+		DAH:          known.DAH,
+	}
+	// the known header itself is not a fork
+	syncer.detectFork(ctx, known)
+	// and neither is a conflicting one not signed by the trusted validators
+	bogus := *conflicting
+	bogus.Commit = header.RandExtendedHeader(t).Commit
+	syncer.detectFork(ctx, &bogus)
+
+	syncer.detectFork(ctx, conflicting)
+	select {
+	case e := <-sub.Out():
+		assert.EqualValues(t, 5, e.Height)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	assert.Len(t, sub.Out(), 0)
+}
+
+// gapsStore fakes the gaps found by the wrapped Store, passing the headers spliced into them.
+type gapsStore struct {
+	header.Store
+
+	gaps    chan store.Gap
+	spliced chan []*header.ExtendedHeader
+}
+
+func (s *gapsStore) Gaps() <-chan store.Gap {
+	return s.gaps
+}
+
+func (s *gapsStore) Splice(_ context.Context, headers ...*header.ExtendedHeader) (int, error) {
+	s.spliced <- headers
+	return len(headers), nil
+}
+
+// TestSyncer_Healthy tests that the health of the Syncer can be checked concurrently with its
+// lifecycle.
+func TestSyncer_Healthy(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+	remoteStore := store.NewTestStore(ctx, t, head)
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- syncer.Start(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		return syncer.Healthy() == nil
+	}, time.Second, time.Millisecond)
+	require.NoError(t, <-errCh)
+
+	require.NoError(t, syncer.Stop(ctx))
+	assert.Error(t, syncer.Healthy())
+}
+
+// TestState_JSON tests that the State, along with the error of the sync, is reported over the APIs
+// encoding it in JSON.
+func TestState_JSON(t *testing.T) {
+	state := State{ID: 1, FromHeight: 1, ToHeight: 5, Error: "sync failed"}
+	data, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	var decoded State
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, state.ID, decoded.ID)
+	assert.Equal(t, state.ToHeight, decoded.ToHeight)
+	assert.Equal(t, state.Error, decoded.Error)
+}
+
+// TestSyncer_GossipedSkipping tests that the gossiped header signed by the changed validators is
+// verified in the background, requesting the headers in between off the validation path.
+func TestSyncer_GossipedSkipping(t *testing.T) {
+	// just set a big enough value, so we trust local header and don't request anything
+	header.TrustingPeriod = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	head := suite.Head()
+
+	remoteStore := store.NewTestStore(ctx, t, head)
+	localStore := store.NewTestStore(ctx, t, head)
+	syncer := NewSyncer(local.NewExchange(remoteStore), localStore, &header.DummySubscriber{}, blockTime)
+	err := syncer.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, syncer.Stop(ctx))
+	})
+
+	_, err = remoteStore.Append(ctx, suite.GenExtendedHeaders(10)...)
+	require.NoError(t, err)
+	suite.ChangeValidators(3)
+	headers := suite.GenExtendedHeaders(10)
+	_, err = remoteStore.Append(ctx, headers...)
+	require.NoError(t, err)
+	netHead := headers[len(headers)-1]
+
+	// the validators of the subjective head are unknown to the network head, so it's not
+	// propagated, but still synced to once verified through the headers in between
+	res := syncer.incomingNetHead(ctx, netHead)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+	require.Eventually(t, func() bool {
+		return syncer.State().NetworkHeight == uint64(netHead.Height)
+	}, time.Second, time.Millisecond*10)
+
+	err = syncer.WaitSync(ctx)
+	require.NoError(t, err)
+	have, err := localStore.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, netHead.Hash(), have.Hash())
+}
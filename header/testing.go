@@ -36,6 +36,10 @@ type TestSuite struct {
 	valSet  *types.ValidatorSet
 	valPntr int
 
+	// the validators committed to by the next header
+	nextVals   []types.PrivValidator
+	nextValSet *types.ValidatorSet
+
 	head *ExtendedHeader
 }
 
@@ -103,9 +107,21 @@ func (s *TestSuite) GenExtendedHeader() *ExtendedHeader {
 		DAH:          &dah,
 	}
 	require.NoError(s.t, s.head.ValidateBasic())
+	// the following headers are signed by the new validators
+	if s.nextValSet != nil {
+		s.vals, s.valSet, s.valPntr = s.nextVals, s.nextValSet, 0
+		s.nextVals, s.nextValSet = nil, nil
+	}
 	return s.head
 }
 
+// ChangeValidators replaces all the validators with the given number of new ones. The next
+// generated header commits to them as its next validators, and the headers following it are
+// signed by them.
+func (s *TestSuite) ChangeValidators(num int) {
+	s.nextValSet, s.nextVals = core.RandValidatorSet(num, 10)
+}
+
 func (s *TestSuite) GenRawHeader(
 	height int64, lastHeader, lastCommit, dataHash bytes.HexBytes) *RawHeader {
 	rh := RandRawHeader(s.t)
@@ -116,6 +132,9 @@ func (s *TestSuite) GenRawHeader(
 	rh.DataHash = dataHash
 	rh.ValidatorsHash = s.valSet.Hash()
 	rh.NextValidatorsHash = s.valSet.Hash()
+	if s.nextValSet != nil {
+		rh.NextValidatorsHash = s.nextValSet.Hash()
+	}
 	rh.ProposerAddress = s.nextProposer().Address
 	return rh
 }
@@ -0,0 +1,163 @@
+package header
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	amino "github.com/tendermint/tendermint/libs/json"
+	core "github.com/tendermint/tendermint/types"
+)
+
+// validatorSetsCacheSize is the number of validator sets kept by the cache. The validator set of
+// the network rarely changes, so only the few most recent ones are worth keeping.
+const validatorSetsCacheSize = 16
+
+// validatorSets caches the validated validator sets of headers by their hash, so that the
+// consecutive headers signed by the same validators reuse them instead of re-validating and
+// re-hashing the same set each.
+var validatorSets = newValidatorSetCache(validatorSetsCacheSize)
+
+// WarmValidatorSets caches the validator sets of the given verified headers, so that the headers
+// following them are validated against the cached sets, e.g. before requesting a range of headers
+// during sync.
+func WarmValidatorSets(headers ...*ExtendedHeader) {
+	for _, h := range headers {
+		if h.ValidatorSet == nil || validatorSets.contains(h.ValidatorsHash) {
+			continue
+		}
+		if h.ValidatorSet.ValidateBasic() != nil || !bytes.Equal(h.ValidatorSet.Hash(), h.ValidatorsHash) {
+			continue
+		}
+		validatorSets.add(h.ValidatorsHash, h.ValidatorSet)
+	}
+}
+
+type validatorSetCache struct {
+	cache *lru.ARCCache
+}
+
+func newValidatorSetCache(size int) *validatorSetCache {
+	cache, err := lru.NewARC(size)
+	if err != nil {
+		panic(err)
+	}
+	return &validatorSetCache{cache: cache}
+}
+
+// get returns a copy of the cached validator set with the given hash, if any.
+func (c *validatorSetCache) get(hash []byte) (*core.ValidatorSet, bool) {
+	vals, ok := c.cache.Get(string(hash))
+	if !ok {
+		return nil, false
+	}
+	// the set is shared between headers, which may mutate it, e.g. by incrementing the proposer
+	// priority
+	return vals.(*core.ValidatorSet).Copy(), true
+}
+
+// add caches a copy of the given validator set, which must be validated against its hash.
+func (c *validatorSetCache) add(hash []byte, vals *core.ValidatorSet) {
+	c.cache.Add(string(hash), vals.Copy())
+}
+
+func (c *validatorSetCache) contains(hash []byte) bool {
+	return c.cache.Contains(string(hash))
+}
+
+const (
+	// defaultValidatorsPerPage and maxValidatorsPerPage are the same as of the validators
+	// endpoint of celestia-core's RPC.
+	defaultValidatorsPerPage = 30
+	maxValidatorsPerPage     = 100
+)
+
+// ValidatorsPage is a page of the validator set a block is signed by.
+type ValidatorsPage struct {
+	Height int64 `json:"height"`
+	// Hash is the hash of the whole validator set.
+	Hash tmbytes.HexBytes `json:"hash"`
+	// NextHash is the hash of the validator set of the next block, which differs from the Hash
+	// whenever the set changes.
+	NextHash         tmbytes.HexBytes `json:"next_hash"`
+	Total            int              `json:"total"`
+	TotalVotingPower int64            `json:"total_voting_power"`
+	// Validators are ordered by their voting power, the same as in the set.
+	Validators []*core.Validator `json:"validators"`
+}
+
+// ValidatorsPage returns the page with the given 1-based number of the validator set of the
+// header, holding up to perPage validators. The perPage defaults to 30 if zero, and is capped
+// at 100.
+func (eh *ExtendedHeader) ValidatorsPage(page, perPage int) (*ValidatorsPage, error) {
+	if eh.ValidatorSet == nil {
+		return nil, fmt.Errorf("header: no validator set at height %d", eh.Height)
+	}
+	switch {
+	case perPage < 0:
+		return nil, fmt.Errorf("header: negative amount of validators per page %d", perPage)
+	case perPage == 0:
+		perPage = defaultValidatorsPerPage
+	case perPage > maxValidatorsPerPage:
+		perPage = maxValidatorsPerPage
+	}
+
+	total := len(eh.ValidatorSet.Validators)
+	pages := (total + perPage - 1) / perPage
+	if page < 1 || (page > pages && total > 0) {
+		return nil, fmt.Errorf("header: page %d out of range [1, %d]", page, pages)
+	}
+	from := (page - 1) * perPage
+	to := from + perPage
+	if to > total {
+		to = total
+	}
+
+	vals := make([]*core.Validator, 0, to-from)
+	for _, val := range eh.ValidatorSet.Validators[from:to] {
+		vals = append(vals, val.Copy())
+	}
+	return &ValidatorsPage{
+		Height:           eh.Height,
+		Hash:             eh.ValidatorsHash,
+		NextHash:         eh.NextValidatorsHash,
+		Total:            total,
+		TotalVotingPower: eh.ValidatorSet.TotalVotingPower(),
+		Validators:       vals,
+	}, nil
+}
+
+// MarshalJSON marshals a ValidatorsPage to JSON. The Validators are wrapped with amino encoding,
+// to be able to unmarshal the crypto.PubKey type back from JSON.
+func (vp *ValidatorsPage) MarshalJSON() ([]byte, error) {
+	type Alias ValidatorsPage
+	validators, err := amino.Marshal(vp.Validators)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&struct {
+		Validators json.RawMessage `json:"validators"`
+		*Alias
+	}{
+		Validators: validators,
+		Alias:      (*Alias)(vp),
+	})
+}
+
+// UnmarshalJSON unmarshals a ValidatorsPage from JSON. The Validators are wrapped with amino
+// encoding, to be able to unmarshal the crypto.PubKey type back from JSON.
+func (vp *ValidatorsPage) UnmarshalJSON(data []byte) error {
+	type Alias ValidatorsPage
+	aux := &struct {
+		Validators json.RawMessage `json:"validators"`
+		*Alias
+	}{
+		Alias: (*Alias)(vp),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return amino.Unmarshal(aux.Validators, &vp.Validators)
+}
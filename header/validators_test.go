@@ -0,0 +1,69 @@
+package header
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestValidateBasic_ReusesValidatorSet(t *testing.T) {
+	h := NewTestSuite(t, 3).GenExtendedHeaders(2)
+	// the first header cached its validator set on generation
+	require.True(t, validatorSets.contains(h[0].ValidatorsHash))
+
+	// the set is taken from the cache instead of the header
+	h[1].ValidatorSet = nil
+	require.NoError(t, h[1].ValidateBasic())
+	require.NotNil(t, h[1].ValidatorSet)
+	assert.Equal(t, h[1].ValidatorsHash.Bytes(), h[1].ValidatorSet.Hash())
+}
+
+func TestWarmValidatorSets(t *testing.T) {
+	eh := RandExtendedHeader(t)
+	require.False(t, validatorSets.contains(eh.ValidatorsHash))
+	WarmValidatorSets(eh)
+	assert.True(t, validatorSets.contains(eh.ValidatorsHash))
+
+	// sets not matching the hash of their headers are never cached
+	other := RandExtendedHeader(t)
+	other.ValidatorsHash = tmrand.Bytes(32)
+	WarmValidatorSets(other)
+	assert.False(t, validatorSets.contains(other.ValidatorsHash))
+}
+
+func TestExtendedHeader_ValidatorsPage(t *testing.T) {
+	eh := NewTestSuite(t, 3).GenExtendedHeaders(1)[0]
+
+	first, err := eh.ValidatorsPage(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, first.Total)
+	assert.Equal(t, eh.ValidatorsHash, first.Hash)
+	assert.Equal(t, eh.ValidatorSet.TotalVotingPower(), first.TotalVotingPower)
+	assert.Len(t, first.Validators, 2)
+
+	last, err := eh.ValidatorsPage(2, 2)
+	require.NoError(t, err)
+	require.Len(t, last.Validators, 1)
+	assert.Equal(t, eh.ValidatorSet.Validators[2].Address, last.Validators[0].Address)
+
+	// the pages are limited by the size of the set
+	all, err := eh.ValidatorsPage(1, 0)
+	require.NoError(t, err)
+	assert.Len(t, all.Validators, 3)
+	_, err = eh.ValidatorsPage(3, 2)
+	assert.Error(t, err)
+	_, err = eh.ValidatorsPage(0, 2)
+	assert.Error(t, err)
+	_, err = eh.ValidatorsPage(1, -1)
+	assert.Error(t, err)
+
+	// the public keys survive JSON
+	data, err := json.Marshal(first)
+	require.NoError(t, err)
+	out := new(ValidatorsPage)
+	require.NoError(t, json.Unmarshal(data, out))
+	assert.Equal(t, first, out)
+}
@@ -2,10 +2,13 @@ package header
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/tendermint/tendermint/light"
+	core "github.com/tendermint/tendermint/types"
 )
 
 // TODO(@Wondertan): We should request TrustingPeriod from the network's state params or
@@ -21,6 +24,11 @@ import (
 // period.
 var TrustingPeriod = 168 * time.Hour
 
+// UnbondingPeriod is the period the validators of a header can't unbond their stake within after
+// signing it. The headers older than it can't be trusted even subjectively, as their validators may
+// have unbonded since and signed an alternative history at no cost.
+var UnbondingPeriod = 3 * 7 * 24 * time.Hour
+
 // IsExpired checks if header is expired against trusting period.
 func (eh *ExtendedHeader) IsExpired() bool {
 	expirationTime := eh.Time.Add(TrustingPeriod)
@@ -32,9 +40,24 @@ func (eh *ExtendedHeader) IsRecent(blockTime time.Duration) bool {
 	return time.Since(eh.Time) <= blockTime // TODO @renaynay: should we allow for a 5-10 block drift here?
 }
 
+// VerifyOption configures the verification of untrusted headers.
+type VerifyOption func(*verifyParams)
+
+type verifyParams struct {
+	clock *Clock
+}
+
+// WithClock sets the Clock defining how much the time of untrusted headers can drift into the
+// future. If not set, it can drift by DefaultClockDrift.
+func WithClock(clock *Clock) VerifyOption {
+	return func(p *verifyParams) {
+		p.clock = clock
+	}
+}
+
 // VerifyNonAdjacent validates non-adjacent untrusted header against trusted 'eh'.
-func (eh *ExtendedHeader) VerifyNonAdjacent(untrst *ExtendedHeader) error {
-	if err := eh.verify(untrst); err != nil {
+func (eh *ExtendedHeader) VerifyNonAdjacent(untrst *ExtendedHeader, opts ...VerifyOption) error {
+	if err := eh.verify(untrst, opts...); err != nil {
 		return &VerifyError{Reason: err}
 	}
 
@@ -48,7 +71,7 @@ func (eh *ExtendedHeader) VerifyNonAdjacent(untrst *ExtendedHeader) error {
 }
 
 // VerifyAdjacent validates adjacent untrusted header against trusted 'eh'.
-func (eh *ExtendedHeader) VerifyAdjacent(untrst *ExtendedHeader) error {
+func (eh *ExtendedHeader) VerifyAdjacent(untrst *ExtendedHeader, opts ...VerifyOption) error {
 	if untrst.Height != eh.Height+1 {
 		return &ErrNonAdjacent{
 			Head:      eh.Height,
@@ -56,7 +79,7 @@ func (eh *ExtendedHeader) VerifyAdjacent(untrst *ExtendedHeader) error {
 		}
 	}
 
-	if err := eh.verify(untrst); err != nil {
+	if err := eh.verify(untrst, opts...); err != nil {
 		return &VerifyError{Reason: err}
 	}
 
@@ -73,12 +96,55 @@ func (eh *ExtendedHeader) VerifyAdjacent(untrst *ExtendedHeader) error {
 	return nil
 }
 
-// clockDrift defines how much new header's time can drift into
-// the future relative to the now time during verification.
-var clockDrift = 10 * time.Second
+// VerifySkipping verifies the untrusted header against the trusted 'eh', skipping the headers in
+// between, as light clients do. If the validators of 'eh' do not have enough voting power in the
+// commit of 'untrst', as the validator set changed too much in between, the range is bisected: the
+// header in the middle is requested with the given 'get' and verified first to become the new
+// trusted one. Bisection stops at adjacent headers, so in the worst case every header in between
+// is verified sequentially.
+func (eh *ExtendedHeader) VerifySkipping(
+	ctx context.Context,
+	untrst *ExtendedHeader,
+	get func(context.Context, uint64) (*ExtendedHeader, error),
+	opts ...VerifyOption,
+) error {
+	trusted, target := eh, untrst
+	for {
+		var err error
+		if target.Height == trusted.Height+1 {
+			err = trusted.VerifyAdjacent(target, opts...)
+		} else {
+			err = trusted.VerifyNonAdjacent(target, opts...)
+		}
+		if err == nil {
+			if target == untrst {
+				return nil
+			}
+			// the target is trusted now, so try to skip to the untrusted header from it
+			trusted, target = target, untrst
+			continue
+		}
+		if !errors.As(err, &core.ErrNotEnoughVotingPowerSigned{}) {
+			if target != untrst {
+				// the untrusted header is not proven invalid by an invalid header in between
+				return fmt.Errorf("header: verifying header at height %d in between: %v", target.Height, err)
+			}
+			return err
+		}
+
+		pivot := trusted.Height + (target.Height-trusted.Height)/2
+		target, err = get(ctx, uint64(pivot))
+		if err != nil {
+			return err
+		}
+		if target.Height != pivot {
+			return fmt.Errorf("header: requested header at height %d, got %d", pivot, target.Height)
+		}
+	}
+}
 
 // verify performs basic verification of untrusted header.
-func (eh *ExtendedHeader) verify(untrst *ExtendedHeader) error {
+func (eh *ExtendedHeader) verify(untrst *ExtendedHeader, opts ...VerifyOption) error {
 	if untrst.ChainID != eh.ChainID {
 		return fmt.Errorf("new untrusted header has different chain %s, not %s", untrst.ChainID, eh.ChainID)
 	}
@@ -87,10 +153,14 @@ func (eh *ExtendedHeader) verify(untrst *ExtendedHeader) error {
 		return fmt.Errorf("expected new untrusted header time %v to be after old header time %v", untrst.Time, eh.Time)
 	}
 
-	now := time.Now()
-	if !untrst.Time.Before(now.Add(clockDrift)) {
+	var params verifyParams
+	for _, opt := range opts {
+		opt(&params)
+	}
+	now, drift := time.Now(), params.clock.Drift()
+	if !untrst.Time.Before(now.Add(drift)) {
 		return fmt.Errorf(
-			"new untrusted header has a time from the future %v (now: %v, clockDrift: %v)", untrst.Time, now, clockDrift)
+			"new untrusted header has a time from the future %v (now: %v, clockDrift: %v)", untrst.Time, now, drift)
 	}
 
 	return nil
@@ -104,3 +174,7 @@ type VerifyError struct {
 func (vr *VerifyError) Error() string {
 	return fmt.Sprintf("header: verify: %s", vr.Reason.Error())
 }
+
+func (vr *VerifyError) Unwrap() error {
+	return vr.Reason
+}
@@ -1,11 +1,13 @@
 package header
 
 import (
+	"context"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
@@ -65,3 +67,58 @@ func TestVerifyAdjacent(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyClockDrift(t *testing.T) {
+	h := NewTestSuite(t, 2).GenExtendedHeaders(2)
+	trusted, untrusted := h[0], h[1]
+	untrusted.Time = time.Now().Add(time.Second * 20)
+
+	// from the future
+	assert.Error(t, trusted.VerifyAdjacent(untrusted))
+
+	clock := NewClock(time.Minute)
+	assert.NoError(t, trusted.VerifyAdjacent(untrusted, WithClock(clock)))
+
+	// the local clock is estimated to be behind, which widens the drift up to MaxClockOffset only
+	clock = NewClock(time.Second * 10)
+	clock.SetOffset(time.Hour)
+	assert.Equal(t, time.Second*10+MaxClockOffset, clock.Drift())
+	assert.Error(t, trusted.VerifyAdjacent(untrusted, WithClock(clock)))
+	untrusted.Time = time.Now().Add(time.Second * 12)
+	assert.NoError(t, trusted.VerifyAdjacent(untrusted, WithClock(clock)))
+
+	// the local clock being ahead never narrows the drift
+	clock.SetOffset(-time.Hour)
+	assert.Equal(t, time.Second*10, clock.Drift())
+}
+
+func TestVerifySkipping(t *testing.T) {
+	suite := NewTestSuite(t, 3)
+	headers := suite.GenExtendedHeaders(10)
+	suite.ChangeValidators(3)
+	headers = append(headers, suite.GenExtendedHeaders(10)...)
+
+	var requested int
+	get := func(_ context.Context, height uint64) (*ExtendedHeader, error) {
+		requested++
+		return headers[height-1], nil
+	}
+	trusted, untrusted := headers[0], headers[len(headers)-1]
+
+	// the validators of the trusted header are unknown to the untrusted one
+	require.Error(t, trusted.VerifyNonAdjacent(untrusted))
+	require.NoError(t, trusted.VerifySkipping(context.Background(), untrusted, get))
+	assert.NotZero(t, requested)
+	assert.Less(t, requested, len(headers)-2)
+
+	// headers signed by the same validators are verified without requests
+	requested = 0
+	require.NoError(t, headers[11].VerifySkipping(context.Background(), untrusted, get))
+	assert.Zero(t, requested)
+
+	// a header at an unexpected height in between fails verification
+	invalid := func(_ context.Context, height uint64) (*ExtendedHeader, error) {
+		return headers[height], nil
+	}
+	require.Error(t, trusted.VerifySkipping(context.Background(), untrusted, invalid))
+}
@@ -0,0 +1,102 @@
+package authtoken
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// JWTPayload is the payload of the token granting the listed permissions to its bearer.
+type JWTPayload struct {
+	Allow []auth.Permission
+	Restrictions
+}
+
+// Restrictions narrow down what the bearer of a token can do within its permissions, so that a
+// node can be shared by many tenants, each with a token of its own.
+type Restrictions struct {
+	// Methods lists the methods the token may call, either as "namespace.Method", e.g.
+	// "share.GetShare", or as "namespace.*" for all the methods of the namespace.
+	// All the methods are allowed if empty.
+	Methods []string `json:",omitempty"`
+	// RateLimit is the amount of calls per second the token may make. Unlimited if zero.
+	RateLimit float64 `json:",omitempty"`
+	// Burst is the amount of calls the token may make at once. Defaults to the RateLimit.
+	Burst int `json:",omitempty"`
+}
+
+// Restricted reports whether there are any restrictions.
+func (r Restrictions) Restricted() bool {
+	return len(r.Methods) > 0 || r.RateLimit > 0
+}
+
+// Allows reports whether the method of the namespace is allowed to be called.
+func (r Restrictions) Allows(namespace, method string) bool {
+	if len(r.Methods) == 0 {
+		return true
+	}
+	for _, m := range r.Methods {
+		if m == namespace+"."+method || m == namespace+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate performs basic validation of the Restrictions.
+func (r Restrictions) Validate() error {
+	for _, m := range r.Methods {
+		namespace, method, ok := strings.Cut(m, ".")
+		if !ok || namespace == "" || method == "" || strings.Contains(method, ".") {
+			return fmt.Errorf("authtoken: method %q must be in form <namespace>.<Method> or <namespace>.*", m)
+		}
+	}
+	if r.RateLimit < 0 {
+		return fmt.Errorf("authtoken: negative rate limit %v", r.RateLimit)
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("authtoken: negative burst %d", r.Burst)
+	}
+	return nil
+}
+
+// ExtractSignedPermissions returns the permissions granted to the token by the passed signer.
+// If the token isn't signed by the signer, it will not pass verification.
+func ExtractSignedPermissions(signer jwt.Algorithm, token string) ([]auth.Permission, error) {
+	payload, err := ExtractSignedPayload(signer, token)
+	if err != nil {
+		return nil, err
+	}
+	return payload.Allow, nil
+}
+
+// ExtractSignedPayload returns the whole payload of the token signed by the passed signer.
+// If the token isn't signed by the signer, it will not pass verification.
+func ExtractSignedPayload(signer jwt.Algorithm, token string) (*JWTPayload, error) {
+	var payload JWTPayload
+	_, err := jwt.Verify([]byte(token), signer, &payload)
+	if err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+
+// NewSignedJWT returns a signed JWT token with the passed permissions and signer.
+func NewSignedJWT(signer jwt.Algorithm, permissions []auth.Permission) (string, error) {
+	return NewRestrictedJWT(signer, permissions, Restrictions{})
+}
+
+// NewRestrictedJWT returns a signed JWT token with the passed permissions narrowed down by the
+// Restrictions.
+func NewRestrictedJWT(signer jwt.Algorithm, permissions []auth.Permission, r Restrictions) (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+	token, err := jwt.Sign(JWTPayload{Allow: permissions, Restrictions: r}, signer)
+	if err != nil {
+		return "", err
+	}
+	return string(token), nil
+}
@@ -0,0 +1,218 @@
+// Package dsbackup backs up the keys and values of datastores into streams and restores them.
+package dsbackup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// magic starts every backup, identifying the format and its version.
+const magic = "celestia-dsbackup/1\n"
+
+// ErrFormat is returned when restoring a stream that is not a backup or is corrupted.
+var ErrFormat = errors.New("dsbackup: invalid backup format")
+
+// Backup writes all the keys and values of the datastore into the writer, returning the number of
+// entries written. The entries are read from a snapshot of the datastore, if it supports read-only
+// transactions, as Badger does, so that the backup is consistent while the datastore is written
+// to.
+// The wrapping datastores, e.g. the ones encrypting the values, are backed up as they store their
+// values in the wrapped ones, so the values are never exposed in a backup.
+func Backup(ctx context.Context, ds datastore.Datastore, w io.Writer) (int, error) {
+	ds = unwrap(ds)
+	var read datastore.Read = ds
+	if txnds, ok := ds.(datastore.TxnDatastore); ok {
+		txn, err := txnds.NewTransaction(ctx, true)
+		if err != nil {
+			return 0, fmt.Errorf("dsbackup: opening snapshot: %w", err)
+		}
+		defer txn.Discard(ctx)
+		read = txn
+	}
+
+	res, err := read.Query(ctx, dsq.Query{})
+	if err != nil {
+		return 0, fmt.Errorf("dsbackup: querying entries: %w", err)
+	}
+	defer res.Close()
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	if _, err = bw.WriteString(magic); err != nil {
+		return 0, err
+	}
+	var n int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return n, fmt.Errorf("dsbackup: reading entries: %w", r.Error)
+		}
+		if err = writeField(bw, []byte(r.Key)); err != nil {
+			return n, err
+		}
+		if err = writeField(bw, r.Value); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err = bw.Flush(); err != nil {
+		return n, err
+	}
+	return n, zw.Close()
+}
+
+// Restore replaces all the keys and values of the datastore with the ones of the backup read from
+// the reader, returning the number of entries restored. The backup is decoded entirely before the
+// data is swapped, so that an invalid backup leaves the datastore untouched. The datastore must
+// not be used by anything else while restoring, i.e. the node must not be running.
+func Restore(ctx context.Context, ds datastore.Datastore, r io.Reader) (int, error) {
+	ds = unwrap(ds)
+	entries, err := decode(r)
+	if err != nil {
+		return 0, err
+	}
+	err = swap(ctx, ds, entries)
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), ds.Sync(ctx, datastore.NewKey("/"))
+}
+
+// decode reads all the entries of the backup, validating its format.
+func decode(r io.Reader) (map[string][]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFormat, err)
+	}
+	br := bufio.NewReader(zr)
+	head := make([]byte, len(magic))
+	if _, err = io.ReadFull(br, head); err != nil || string(head) != magic {
+		return nil, ErrFormat
+	}
+
+	entries := make(map[string][]byte)
+	for {
+		key, err := readField(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := readField(br)
+		if err != nil {
+			if err == io.EOF {
+				err = ErrFormat
+			}
+			return nil, err
+		}
+		if _, ok := entries[string(key)]; ok {
+			return nil, fmt.Errorf("%w: duplicate key %s", ErrFormat, key)
+		}
+		entries[string(key)] = value
+	}
+	return entries, nil
+}
+
+// swap replaces the entries of the datastore with the given ones in a single batch, deleting the
+// ones missing from the given entries.
+func swap(ctx context.Context, ds datastore.Datastore, entries map[string][]byte) error {
+	res, err := ds.Query(ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return fmt.Errorf("dsbackup: querying entries: %w", err)
+	}
+	existing, err := res.Rest()
+	if err != nil {
+		return fmt.Errorf("dsbackup: querying entries: %w", err)
+	}
+
+	wr, commit, err := writer(ctx, ds)
+	if err != nil {
+		return err
+	}
+	for _, e := range existing {
+		if _, ok := entries[e.Key]; ok {
+			continue
+		}
+		if err = wr.Delete(ctx, datastore.RawKey(e.Key)); err != nil {
+			return fmt.Errorf("dsbackup: deleting entry: %w", err)
+		}
+	}
+	for key, value := range entries {
+		if err = wr.Put(ctx, datastore.RawKey(key), value); err != nil {
+			return fmt.Errorf("dsbackup: restoring entry: %w", err)
+		}
+	}
+	if err = commit(); err != nil {
+		return fmt.Errorf("dsbackup: committing restored entries: %w", err)
+	}
+	return nil
+}
+
+// writer returns a batch of writes to the datastore, if it supports batching, or the datastore
+// itself otherwise, along with the function committing the writes.
+func writer(ctx context.Context, ds datastore.Datastore) (datastore.Write, func() error, error) {
+	bds, ok := ds.(datastore.Batching)
+	if !ok {
+		return ds, func() error { return nil }, nil
+	}
+	b, err := bds.Batch(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, func() error { return b.Commit(ctx) }, nil
+}
+
+// unwrap returns the innermost datastore wrapped by the given one.
+func unwrap(ds datastore.Datastore) datastore.Datastore {
+	for {
+		shim, ok := ds.(datastore.Shim)
+		if !ok {
+			return ds
+		}
+		children := shim.Children()
+		if len(children) != 1 {
+			return ds
+		}
+		ds = children[0]
+	}
+}
+
+func writeField(w io.Writer, field []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(field)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(field)
+	return err
+}
+
+// maxFieldSize bounds the size of the fields read from a backup, so that corrupted backups can't
+// exhaust the memory.
+const maxFieldSize = 1 << 30
+
+func readField(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%w: %s", ErrFormat, err)
+	}
+	if size > maxFieldSize {
+		return nil, ErrFormat
+	}
+	field := make([]byte, size)
+	if _, err = io.ReadFull(r, field); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFormat, err)
+	}
+	return field, nil
+}
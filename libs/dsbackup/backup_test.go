@@ -0,0 +1,120 @@
+package dsbackup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/dscrypt"
+)
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	src, err := dsbadger.NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, src.Close())
+	})
+	entries := putEntries(t, src, 2049)
+
+	var buf bytes.Buffer
+	n, err := Backup(ctx, src, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, len(entries), n)
+
+	// the entries are replaced with the backed up ones
+	dst := datastore.NewMapDatastore()
+	stale := datastore.NewKey("stale")
+	require.NoError(t, dst.Put(ctx, stale, []byte("value")))
+	n, err = Restore(ctx, dst, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, len(entries), n)
+
+	for key, value := range entries {
+		got, err := dst.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+	has, err := dst.Has(ctx, stale)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = Restore(ctx, dst, bytes.NewReader([]byte("not a backup")))
+	assert.ErrorIs(t, err, ErrFormat)
+}
+
+// TestRestore_Truncated ensures a truncated backup leaves the datastore untouched.
+func TestRestore_Truncated(t *testing.T) {
+	ctx := context.Background()
+	src := datastore.NewMapDatastore()
+	putEntries(t, src, 100)
+	var buf bytes.Buffer
+	_, err := Backup(ctx, src, &buf)
+	require.NoError(t, err)
+
+	dst := datastore.NewMapDatastore()
+	kept := datastore.NewKey("kept")
+	require.NoError(t, dst.Put(ctx, kept, []byte("value")))
+	_, err = Restore(ctx, dst, bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	assert.ErrorIs(t, err, ErrFormat)
+
+	res, err := dst.Query(ctx, dsq.Query{KeysOnly: true})
+	require.NoError(t, err)
+	all, err := res.Rest()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, kept.String(), all[0].Key)
+}
+
+// TestBackupRestore_Encrypted ensures the values of the encrypted datastores stay encrypted in
+// their backups.
+func TestBackupRestore_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	key, err := dscrypt.NewKey()
+	require.NoError(t, err)
+	src, err := dscrypt.Wrap(datastore.NewMapDatastore(), key)
+	require.NoError(t, err)
+	entries := putEntries(t, src, 10)
+
+	var buf bytes.Buffer
+	_, err = Backup(ctx, src, &buf)
+	require.NoError(t, err)
+
+	// the backup can be restored into another datastore encrypted under the same key only
+	dst, err := dscrypt.Wrap(datastore.NewMapDatastore(), key)
+	require.NoError(t, err)
+	_, err = Restore(ctx, dst, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	for key, value := range entries {
+		got, err := dst.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+
+	plain := datastore.NewMapDatastore()
+	_, err = Restore(ctx, plain, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	for key, value := range entries {
+		got, err := plain.Get(ctx, key)
+		require.NoError(t, err)
+		assert.NotEqual(t, value, got)
+	}
+}
+
+func putEntries(t *testing.T, ds datastore.Datastore, amount int) map[datastore.Key][]byte {
+	entries := make(map[datastore.Key][]byte, amount)
+	for i := 0; i < amount; i++ {
+		key := datastore.NewKey(fmt.Sprintf("/ns/%d", i))
+		value := []byte(fmt.Sprintf("value-%d", i))
+		require.NoError(t, ds.Put(context.Background(), key, value))
+		entries[key] = value
+	}
+	return entries
+}
@@ -0,0 +1,225 @@
+// Package dscrypt implements the datastore.Batching encrypting the values of the wrapped
+// datastore at rest with AES-GCM.
+package dscrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// KeySize is the size of the keys the values are encrypted under.
+const KeySize = 32
+
+// ErrDecrypt is returned when a value can't be decrypted, e.g. because it was encrypted under
+// another key or tampered with.
+var ErrDecrypt = errors.New("dscrypt: can't decrypt value")
+
+var (
+	_ datastore.Batching            = (*Datastore)(nil)
+	_ datastore.PersistentDatastore = (*Datastore)(nil)
+	_ datastore.GCDatastore         = (*Datastore)(nil)
+	_ datastore.Shim                = (*Datastore)(nil)
+)
+
+// Datastore encrypts the values of the wrapped datastore, while the keys are left in plaintext
+// to keep the queries by key prefix and order served by the wrapped datastore.
+type Datastore struct {
+	child datastore.Batching
+	aead  cipher.AEAD
+}
+
+// Wrap wraps the given datastore, encrypting its values under the given key of KeySize.
+func Wrap(child datastore.Batching, key []byte) (*Datastore, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("dscrypt: key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Datastore{child: child, aead: aead}, nil
+}
+
+// NewKey generates a random key for Wrap.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (d *Datastore) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	sealed, err := d.child.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return d.open(key, sealed)
+}
+
+func (d *Datastore) Has(ctx context.Context, key datastore.Key) (bool, error) {
+	return d.child.Has(ctx, key)
+}
+
+func (d *Datastore) GetSize(ctx context.Context, key datastore.Key) (int, error) {
+	size, err := d.child.GetSize(ctx, key)
+	if err != nil {
+		return size, err
+	}
+	return d.plainSize(size), nil
+}
+
+func (d *Datastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	sealed, err := d.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return d.child.Put(ctx, key, sealed)
+}
+
+func (d *Datastore) Delete(ctx context.Context, key datastore.Key) error {
+	return d.child.Delete(ctx, key)
+}
+
+func (d *Datastore) Sync(ctx context.Context, prefix datastore.Key) error {
+	return d.child.Sync(ctx, prefix)
+}
+
+func (d *Datastore) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	// the prefix and key ordering are served by the wrapped datastore, while the filters and
+	// orders which may depend on the values are applied naively over the decrypted results
+	qChild, naive := q, len(q.Filters) > 0
+	for _, o := range q.Orders {
+		switch o.(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey, dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+		default:
+			naive = true
+		}
+	}
+	if naive {
+		qChild = dsq.Query{Prefix: q.Prefix, ReturnsSizes: q.ReturnsSizes, ReturnExpirations: q.ReturnExpirations}
+	}
+
+	res, err := d.child.Query(ctx, qChild)
+	if err != nil {
+		return nil, err
+	}
+	results := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			r, ok := res.NextSync()
+			if !ok || r.Error != nil {
+				return r, ok
+			}
+			if r.Size > 0 {
+				r.Size = d.plainSize(r.Size)
+			}
+			if !qChild.KeysOnly {
+				r.Value, r.Error = d.open(datastore.RawKey(r.Key), r.Value)
+			}
+			return r, true
+		},
+		Close: res.Close,
+	})
+	if naive {
+		results = dsq.NaiveQueryApply(dsq.Query{
+			Filters: q.Filters,
+			Orders:  q.Orders,
+			Offset:  q.Offset,
+			Limit:   q.Limit,
+		}, results)
+	}
+	return results, nil
+}
+
+func (d *Datastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	b, err := d.child.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &batch{ds: d, b: b}, nil
+}
+
+// DiskUsage reports the disk usage of the wrapped datastore.
+func (d *Datastore) DiskUsage(ctx context.Context) (uint64, error) {
+	return datastore.DiskUsage(ctx, d.child)
+}
+
+// CollectGarbage collects the garbage of the wrapped datastore, if it supports that.
+func (d *Datastore) CollectGarbage(ctx context.Context) error {
+	if gc, ok := d.child.(datastore.GCDatastore); ok {
+		return gc.CollectGarbage(ctx)
+	}
+	return nil
+}
+
+func (d *Datastore) Children() []datastore.Datastore {
+	return []datastore.Datastore{d.child}
+}
+
+func (d *Datastore) Close() error {
+	return d.child.Close()
+}
+
+// seal encrypts the value, binding it to the key, so that the values can't be swapped between
+// the keys. The result is laid out as nonce | ciphertext.
+func (d *Datastore) seal(key datastore.Key, value []byte) ([]byte, error) {
+	nonce := make([]byte, d.aead.NonceSize(), d.aead.NonceSize()+len(value)+d.aead.Overhead())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return d.aead.Seal(nonce, nonce, value, key.Bytes()), nil
+}
+
+func (d *Datastore) open(key datastore.Key, sealed []byte) ([]byte, error) {
+	if len(sealed) < d.aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce := sealed[:d.aead.NonceSize()]
+	value, err := d.aead.Open(nil, nonce, sealed[d.aead.NonceSize():], key.Bytes())
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return value, nil
+}
+
+func (d *Datastore) plainSize(size int) int {
+	size -= d.aead.NonceSize() + d.aead.Overhead()
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+type batch struct {
+	ds *Datastore
+	b  datastore.Batch
+}
+
+func (b *batch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	sealed, err := b.ds.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return b.b.Put(ctx, key, sealed)
+}
+
+func (b *batch) Delete(ctx context.Context, key datastore.Key) error {
+	return b.b.Delete(ctx, key)
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	return b.b.Commit(ctx)
+}
@@ -0,0 +1,59 @@
+package dscrypt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dstest "github.com/ipfs/go-datastore/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastore(t *testing.T) {
+	ds := newDatastore(t, datastore.NewMapDatastore())
+	dstest.SubtestAll(t, ds)
+}
+
+func TestDatastore_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	child := datastore.NewMapDatastore()
+	ds := newDatastore(t, child)
+
+	key, value := datastore.NewKey("key"), []byte("value")
+	err := ds.Put(ctx, key, value)
+	require.NoError(t, err)
+
+	// the value is not stored in plaintext
+	sealed, err := child.Get(ctx, key)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(value))
+
+	// the value can't be decrypted under another key or moved to another key
+	_, err = newDatastore(t, child).Get(ctx, key)
+	assert.ErrorIs(t, err, ErrDecrypt)
+	err = child.Put(ctx, datastore.NewKey("other"), sealed)
+	require.NoError(t, err)
+	_, err = ds.Get(ctx, datastore.NewKey("other"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	got, err := ds.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+	size, err := ds.GetSize(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, len(value), size)
+}
+
+func TestWrap_InvalidKey(t *testing.T) {
+	_, err := Wrap(datastore.NewMapDatastore(), []byte("short"))
+	assert.Error(t, err)
+}
+
+func newDatastore(t *testing.T, child datastore.Batching) *Datastore {
+	key, err := NewKey()
+	require.NoError(t, err)
+	ds, err := Wrap(child, key)
+	require.NoError(t, err)
+	return ds
+}
@@ -0,0 +1,285 @@
+// Package dspebble implements the go-datastore interfaces over Pebble, the LevelDB/RocksDB
+// inspired key-value store of CockroachDB, which sustains higher write throughput than Badger.
+package dspebble
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// ErrClosed is returned on attempt to use a closed Datastore.
+var ErrClosed = errors.New("dspebble: datastore closed")
+
+var (
+	_ datastore.Batching            = (*Datastore)(nil)
+	_ datastore.PersistentDatastore = (*Datastore)(nil)
+)
+
+// Datastore is a datastore.Batching backed by Pebble.
+type Datastore struct {
+	db *pebble.DB
+
+	// closeLk guards the DB from being used after closing, as Pebble panics on that
+	closeLk sync.RWMutex
+	closed  bool
+}
+
+// NewDatastore opens the Pebble database under the given 'path', creating it if it doesn't
+// exist. Nil 'opts' means the default Pebble options.
+func NewDatastore(path string, opts *pebble.Options) (*Datastore, error) {
+	db, err := pebble.Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Datastore{db: db}, nil
+}
+
+func (d *Datastore) Get(_ context.Context, key datastore.Key) ([]byte, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	val, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, datastore.ErrNotFound
+		}
+		return nil, err
+	}
+	defer closer.Close()
+	// the value is only valid until the closer is closed
+	return append([]byte(nil), val...), nil
+}
+
+func (d *Datastore) Has(ctx context.Context, key datastore.Key) (bool, error) {
+	_, err := d.GetSize(ctx, key)
+	switch err {
+	case nil:
+		return true, nil
+	case datastore.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (d *Datastore) GetSize(_ context.Context, key datastore.Key) (int, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return -1, ErrClosed
+	}
+
+	val, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return -1, datastore.ErrNotFound
+		}
+		return -1, err
+	}
+	defer closer.Close()
+	return len(val), nil
+}
+
+func (d *Datastore) Put(_ context.Context, key datastore.Key, value []byte) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	return d.db.Set(key.Bytes(), value, pebble.NoSync)
+}
+
+func (d *Datastore) Delete(_ context.Context, key datastore.Key) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	return d.db.Delete(key.Bytes(), pebble.NoSync)
+}
+
+// Sync syncs the write-ahead log to disk, persisting all the writes so far regardless of the
+// prefix.
+func (d *Datastore) Sync(context.Context, datastore.Key) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	return d.db.LogData(nil, pebble.Sync)
+}
+
+func (d *Datastore) Query(_ context.Context, q dsq.Query) (dsq.Results, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	// the prefix and key ordering are applied by the iterator, while the rest of the query is
+	// applied naively over its results
+	qNaive := q
+	opts := &pebble.IterOptions{}
+	prefix := datastore.NewKey(q.Prefix).String()
+	if prefix != "/" {
+		opts.LowerBound = []byte(prefix + "/")
+		opts.UpperBound = upperBound(opts.LowerBound)
+		qNaive.Prefix = ""
+	}
+	iter := d.db.NewIter(opts)
+
+	first, next := iter.First, iter.Next
+	if len(q.Orders) > 0 {
+		switch q.Orders[0].(type) {
+		case dsq.OrderByKey, *dsq.OrderByKey:
+			qNaive.Orders = nil
+		case dsq.OrderByKeyDescending, *dsq.OrderByKeyDescending:
+			first, next = iter.Last, iter.Prev
+			qNaive.Orders = nil
+		}
+	}
+
+	started, failed := false, false
+	results := dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: func() (dsq.Result, bool) {
+			d.closeLk.RLock()
+			defer d.closeLk.RUnlock()
+			if failed {
+				return dsq.Result{}, false
+			}
+			if d.closed {
+				failed = true
+				return dsq.Result{Error: ErrClosed}, true
+			}
+
+			var ok bool
+			if !started {
+				ok, started = first(), true
+			} else {
+				ok = next()
+			}
+			if !ok {
+				return dsq.Result{}, false
+			}
+
+			entry := dsq.Entry{Key: string(iter.Key()), Size: len(iter.Value())}
+			if !q.KeysOnly {
+				// the value is only valid until the iterator moves
+				entry.Value = append([]byte(nil), iter.Value()...)
+			}
+			return dsq.Result{Entry: entry}, true
+		},
+		Close: func() error {
+			d.closeLk.RLock()
+			defer d.closeLk.RUnlock()
+			if iter == nil || d.closed {
+				return nil
+			}
+			err := iter.Close()
+			iter = nil
+			return err
+		},
+	})
+	return dsq.NaiveQueryApply(qNaive, results), nil
+}
+
+func (d *Datastore) Batch(context.Context) (datastore.Batch, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	return &batch{ds: d, b: d.db.NewBatch()}, nil
+}
+
+// DiskUsage reports the disk space taken by the database files.
+func (d *Datastore) DiskUsage(context.Context) (uint64, error) {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return 0, ErrClosed
+	}
+	return d.db.Metrics().DiskSpaceUsage(), nil
+}
+
+// Compact compacts all the data of the database on disk, reclaiming the space taken by the
+// overwritten and deleted values.
+func (d *Datastore) Compact(context.Context) error {
+	d.closeLk.RLock()
+	defer d.closeLk.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+
+	iter := d.db.NewIter(nil)
+	if !iter.First() {
+		// the database is empty
+		return iter.Close()
+	}
+	start := append([]byte(nil), iter.Key()...)
+	iter.Last()
+	// the end of the range is exclusive, so the last key is extended to be included
+	end := append(append([]byte(nil), iter.Key()...), 0)
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	return d.db.Compact(start, end, true)
+}
+
+func (d *Datastore) Close() error {
+	d.closeLk.Lock()
+	defer d.closeLk.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	d.closed = true
+	return d.db.Close()
+}
+
+type batch struct {
+	ds *Datastore
+	b  *pebble.Batch
+}
+
+func (b *batch) Put(_ context.Context, key datastore.Key, value []byte) error {
+	return b.b.Set(key.Bytes(), value, nil)
+}
+
+func (b *batch) Delete(_ context.Context, key datastore.Key) error {
+	return b.b.Delete(key.Bytes(), nil)
+}
+
+func (b *batch) Commit(context.Context) error {
+	b.ds.closeLk.RLock()
+	defer b.ds.closeLk.RUnlock()
+	if b.ds.closed {
+		return ErrClosed
+	}
+
+	err := b.b.Commit(pebble.NoSync)
+	if err != nil {
+		return err
+	}
+	return b.b.Close()
+}
+
+// upperBound returns the smallest key greater than all the keys with the given prefix.
+func upperBound(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	// the prefix consists of 0xff bytes only, so there is no upper bound
+	return nil
+}
@@ -0,0 +1,47 @@
+package dspebble
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dstest "github.com/ipfs/go-datastore/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastore(t *testing.T) {
+	ds, err := NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, ds.Close())
+	})
+
+	dstest.SubtestAll(t, ds)
+}
+
+func TestDatastore_Closed(t *testing.T) {
+	ctx := context.Background()
+	ds, err := NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+
+	err = ds.Put(ctx, datastore.NewKey("key"), []byte("value"))
+	require.NoError(t, err)
+	res, err := ds.Query(ctx, dsq.Query{})
+	require.NoError(t, err)
+	require.NoError(t, res.Close())
+
+	require.NoError(t, ds.Close())
+	_, err = ds.Get(ctx, datastore.NewKey("key"))
+	assert.ErrorIs(t, err, ErrClosed)
+	_, err = ds.Query(ctx, dsq.Query{})
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.ErrorIs(t, ds.Close(), ErrClosed)
+}
+
+func TestUpperBound(t *testing.T) {
+	assert.Equal(t, []byte("/b"), upperBound([]byte("/a")))
+	assert.Equal(t, []byte{'/', 1}, upperBound([]byte{'/', 0, 0xff}))
+	assert.Nil(t, upperBound([]byte{0xff, 0xff}))
+}
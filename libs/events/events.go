@@ -0,0 +1,157 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var log = logging.Logger("events")
+
+// subscriptionBufferSize is the amount of events buffered for a Subscription.
+// Events published while the buffer of a Subscription is full are dropped for it, so that slow
+// subscribers never block the node.
+var subscriptionBufferSize = 256
+
+// Type identifies the kind of an Event.
+type Type string
+
+const (
+	// NewHead is published for every new header received from the network.
+	NewHead Type = "new_head"
+	// SyncFinished is published once the node finishes syncing headers up to a subjective head.
+	SyncFinished Type = "sync_finished"
+	// DASFailure is published for every header that failed to be sampled.
+	DASFailure Type = "das_failure"
+	// FraudProof is published for every valid fraud proof received from the network.
+	FraudProof Type = "fraud_proof"
+	// PeerBanned is published once a peer is blocked from connecting to the node.
+	PeerBanned Type = "peer_banned"
+	// GapRepaired is published once the headers missing from a gap in the stored ones, e.g. left by
+	// a crash, are fetched from peers and stored.
+	GapRepaired Type = "gap_repaired"
+	// PeerStarvation is published once the node stays without connected peers for longer than
+	// allowed.
+	PeerStarvation Type = "peer_starvation"
+	// ForkDetected is published once a header conflicting with the synced one at the same height, yet
+	// signed by enough of the trusted validators, is received, which means the validators
+	// equivocated.
+	ForkDetected Type = "fork_detected"
+	// DiskSpaceLow is published once the free disk space falls below the configured threshold and
+	// the node enters the protective mode.
+	DiskSpaceLow Type = "disk_space_low"
+	// DiskSpaceRecovered is published once the free disk space recovers and the node leaves the
+	// protective mode.
+	DiskSpaceRecovered Type = "disk_space_recovered"
+	// SyncStalled is published once the synced or the sampled chain head lags behind the network
+	// head for longer than allowed.
+	SyncStalled Type = "sync_stalled"
+	// SyncRecovered is published once the stalled chain head catches up with the network head.
+	SyncRecovered Type = "sync_recovered"
+	// BootstrapperUnhealthy is published once a bootstrapper fails to respond to the probes of the
+	// node, before it is rotated to an alternate.
+	BootstrapperUnhealthy Type = "bootstrapper_unhealthy"
+)
+
+// Event describes a change of the node's state.
+type Event struct {
+	Type Type      `json:"type"`
+	Time time.Time `json:"time"`
+	// Height is the height of the header the Event is about, if any.
+	Height uint64 `json:"height,omitempty"`
+	// Peer is the peer the Event is about, if any.
+	Peer peer.ID `json:"peer,omitempty"`
+	// Message describes the Event further, e.g. with the reason of a failure.
+	Message string `json:"message,omitempty"`
+}
+
+// Bus delivers the Events published by the node's services to its subscribers.
+// A nil Bus is valid and discards all the published Events.
+type Bus struct {
+	lk   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewBus creates a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Publish delivers the Event to all the subscribers of its Type without blocking.
+// The time of the Event is set to the current one, unless given.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.lk.RLock()
+	defer b.lk.RUnlock()
+	for sub := range b.subs {
+		if !sub.wants(e.Type) {
+			continue
+		}
+		select {
+		case sub.out <- e:
+		default:
+			log.Warnw("dropping event for slow subscriber", "type", e.Type, "height", e.Height)
+		}
+	}
+}
+
+// Subscribe creates a Subscription to the Events of the given Types, or to all of them if none are
+// given.
+func (b *Bus) Subscribe(types ...Type) *Subscription {
+	sub := &Subscription{
+		bus: b,
+		out: make(chan Event, subscriptionBufferSize),
+	}
+	if len(types) > 0 {
+		sub.types = make(map[Type]struct{}, len(types))
+		for _, tp := range types {
+			sub.types[tp] = struct{}{}
+		}
+	}
+
+	b.lk.Lock()
+	b.subs[sub] = struct{}{}
+	b.lk.Unlock()
+	return sub
+}
+
+// Subscription receives the Events published on a Bus.
+type Subscription struct {
+	bus   *Bus
+	types map[Type]struct{}
+	out   chan Event
+
+	cancelOnce sync.Once
+}
+
+// Out returns the channel the Events are delivered to. It is closed once the Subscription is
+// canceled.
+func (s *Subscription) Out() <-chan Event {
+	return s.out
+}
+
+// Cancel stops delivery of the Events to the Subscription.
+func (s *Subscription) Cancel() {
+	s.cancelOnce.Do(func() {
+		s.bus.lk.Lock()
+		delete(s.bus.subs, s)
+		s.bus.lk.Unlock()
+		close(s.out)
+	})
+}
+
+func (s *Subscription) wants(tp Type) bool {
+	if s.types == nil {
+		return true
+	}
+	_, ok := s.types[tp]
+	return ok
+}
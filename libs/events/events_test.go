@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	all := bus.Subscribe()
+	heads := bus.Subscribe(NewHead)
+
+	bus.Publish(Event{Type: NewHead, Height: 1})
+	bus.Publish(Event{Type: DASFailure, Height: 1, Message: "timeout"})
+
+	e := <-all.Out()
+	assert.Equal(t, NewHead, e.Type)
+	assert.False(t, e.Time.IsZero())
+	e = <-all.Out()
+	assert.Equal(t, DASFailure, e.Type)
+	assert.Equal(t, "timeout", e.Message)
+
+	e = <-heads.Out()
+	assert.Equal(t, NewHead, e.Type)
+	assert.Empty(t, heads.Out())
+
+	heads.Cancel()
+	heads.Cancel()
+	_, ok := <-heads.Out()
+	require.False(t, ok)
+	bus.Publish(Event{Type: NewHead, Height: 2})
+	assert.Len(t, all.Out(), 1)
+}
+
+func TestBus_SlowSubscriber(t *testing.T) {
+	bus := NewBus()
+	sub := bus.Subscribe()
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		bus.Publish(Event{Type: NewHead, Height: uint64(i)})
+	}
+	// the overflowing event is dropped, rather than blocking the publisher
+	assert.Len(t, sub.Out(), subscriptionBufferSize)
+}
+
+func TestBus_Nil(t *testing.T) {
+	var bus *Bus
+	bus.Publish(Event{Type: NewHead})
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Checker is a service able to report its health.
+type Checker interface {
+	// Healthy returns nil if the service is healthy, or an error describing why it is not.
+	Healthy() error
+}
+
+// CheckerFunc is an adapter allowing plain functions to be used as Checkers.
+type CheckerFunc func() error
+
+// Healthy calls f.
+func (f CheckerFunc) Healthy() error {
+	return f()
+}
+
+// Status reports the health of the node.
+type Status struct {
+	// Healthy is true only if all the checked services are healthy.
+	Healthy bool `json:"healthy"`
+	// Services maps names of the checked services to the reasons they are unhealthy,
+	// which are empty for the healthy ones.
+	Services map[string]string `json:"services"`
+}
+
+// Registry keeps the health Checkers of the node's services.
+// Services register their Checkers on construction, so only the services of the running node
+// type are checked.
+type Registry struct {
+	lk       sync.Mutex
+	checkers map[string]Checker
+}
+
+// NewRegistry creates a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{checkers: make(map[string]Checker)}
+}
+
+// Register adds the Checker of the service with the given name.
+func (r *Registry) Register(name string, checker Checker) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if _, ok := r.checkers[name]; ok {
+		return fmt.Errorf("health: checker for %s is already registered", name)
+	}
+	r.checkers[name] = checker
+	return nil
+}
+
+// Services lists the names of all the checked services.
+func (r *Registry) Services() []string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	names := make([]string, 0, len(r.checkers))
+	for name := range r.checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check checks the health of all the registered services.
+func (r *Registry) Check() Status {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	status := Status{Healthy: true, Services: make(map[string]string, len(r.checkers))}
+	for name, checker := range r.checkers {
+		err := checker.Healthy()
+		if err != nil {
+			status.Healthy = false
+			status.Services[name] = err.Error()
+			continue
+		}
+		status.Services[name] = ""
+	}
+	return status
+}
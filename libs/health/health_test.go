@@ -0,0 +1,32 @@
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	assert.True(t, r.Check().Healthy)
+
+	var syncErr error
+	err := r.Register("das", CheckerFunc(func() error { return nil }))
+	require.NoError(t, err)
+	err = r.Register("sync", CheckerFunc(func() error { return syncErr }))
+	require.NoError(t, err)
+	err = r.Register("sync", CheckerFunc(func() error { return nil }))
+	require.Error(t, err)
+	assert.Equal(t, []string{"das", "sync"}, r.Services())
+
+	status := r.Check()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, map[string]string{"das": "", "sync": ""}, status.Services)
+
+	syncErr = errors.New("stalled")
+	status = r.Check()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, map[string]string{"das": "", "sync": "stalled"}, status.Services)
+}
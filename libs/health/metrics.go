@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+var meter = global.MeterProvider().Meter("health")
+
+// WithMetrics enables Otel metrics to monitor the health of the node and its services.
+func WithMetrics(r *Registry) {
+	nodeG, _ := meter.AsyncInt64().Gauge(
+		"node_healthy",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Whether all the services of the node are healthy"),
+	)
+	serviceG, _ := meter.AsyncInt64().Gauge(
+		"service_healthy",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Whether the service of the node is healthy"),
+	)
+
+	err := meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			nodeG,
+			serviceG,
+		},
+		func(ctx context.Context) {
+			status := r.Check()
+			nodeG.Observe(ctx, boolToInt(status.Healthy))
+			for name, reason := range status.Services {
+				serviceG.Observe(ctx, boolToInt(reason == ""), attribute.String("service", name))
+			}
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
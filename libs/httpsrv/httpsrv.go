@@ -0,0 +1,181 @@
+// Package httpsrv configures the HTTP servers of the node's APIs to be exposed either directly or
+// behind reverse proxies and CDNs, e.g. nginx or Cloudflare.
+package httpsrv
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config configures an HTTP server of the node.
+type Config struct {
+	// CORSOrigins lists the origins the browsers are allowed to make requests from, e.g.
+	// "https://example.com", or "*" for any. Cross-origin requests are not allowed if empty.
+	CORSOrigins []string
+	// MaxBodySize is the maximum size of a request body in bytes. Unlimited if 0.
+	MaxBodySize int64
+	// ReadTimeout is the maximum duration of reading a whole request. Unlimited if 0.
+	ReadTimeout time.Duration
+	// WriteTimeout is the maximum duration of writing a response, including the streamed ones.
+	// WebSocket connections are exempt from it. Unlimited if 0.
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum duration a keep-alive connection awaits the next request.
+	// Defaults to the ReadTimeout if 0.
+	IdleTimeout time.Duration
+	// TrustedProxies lists the IP addresses or CIDR ranges of the reverse proxies in front of the
+	// server, whose X-Forwarded-For header is trusted to report the address of the client.
+	TrustedProxies []string
+}
+
+// DefaultConfig provides the defaults exposing the server directly.
+func DefaultConfig() Config {
+	return Config{
+		MaxBodySize: 32 << 20,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	for _, origin := range cfg.CORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("httpsrv: invalid CORS origin %q, expected <scheme>://<host>[:<port>] or *", origin)
+		}
+	}
+	if cfg.MaxBodySize < 0 {
+		return fmt.Errorf("httpsrv: negative max body size")
+	}
+	if cfg.ReadTimeout < 0 || cfg.WriteTimeout < 0 || cfg.IdleTimeout < 0 {
+		return fmt.Errorf("httpsrv: negative timeout")
+	}
+	_, err := parseProxies(cfg.TrustedProxies)
+	return err
+}
+
+// Apply applies the config to the given server, wrapping its handler.
+func (cfg *Config) Apply(srv *http.Server) error {
+	proxies, err := parseProxies(cfg.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
+	srv.ReadTimeout = cfg.ReadTimeout
+	srv.WriteTimeout = cfg.WriteTimeout
+	srv.IdleTimeout = cfg.IdleTimeout
+	srv.Handler = &handler{
+		next:    srv.Handler,
+		origins: cfg.CORSOrigins,
+		maxBody: cfg.MaxBodySize,
+		proxies: proxies,
+	}
+	return nil
+}
+
+type handler struct {
+	next    http.Handler
+	origins []string
+	maxBody int64
+	proxies []*net.IPNet
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && h.allowOrigin(w, origin) &&
+		r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		// the preflight request is answered without reaching the handlers
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if h.maxBody > 0 && r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxBody)
+	}
+	if client := h.clientAddr(r); client != "" {
+		r.RemoteAddr = client
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// allowOrigin sets the CORS headers, if the origin is allowed.
+func (h *handler) allowOrigin(w http.ResponseWriter, origin string) bool {
+	w.Header().Add("Vary", "Origin")
+	for _, allowed := range h.origins {
+		if allowed != "*" && !strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			continue
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		w.Header().Set("Access-Control-Max-Age", "600")
+		return true
+	}
+	return false
+}
+
+// clientAddr finds the address of the client in the X-Forwarded-For header, if the request comes
+// from a trusted proxy. The header is walked from the end, as only the addresses appended by the
+// trusted proxies can be relied on, while the preceding ones may be forged by the client.
+func (h *handler) clientAddr(r *http.Request) string {
+	if len(h.proxies) == 0 {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil || !h.trusted(net.ParseIP(host)) {
+		return ""
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if ip == nil {
+			return ""
+		}
+		if !h.trusted(ip) {
+			return net.JoinHostPort(ip.String(), "0")
+		}
+	}
+	return ""
+}
+
+func (h *handler) trusted(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range h.proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxies parses the IP addresses and CIDR ranges of the trusted proxies.
+func parseProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("httpsrv: invalid trusted proxy %q, expected IP or CIDR", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("httpsrv: invalid trusted proxy %q, expected IP or CIDR", proxy)
+		}
+		nets = append(nets, ipnet)
+	}
+	return nets, nil
+}
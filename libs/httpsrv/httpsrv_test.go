@@ -0,0 +1,100 @@
+package httpsrv
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CORSOrigins = []string{"*", "https://example.com", "http://localhost:3000/"}
+	cfg.TrustedProxies = []string{"10.0.0.1", "172.16.0.0/12", "::1"}
+	require.NoError(t, cfg.Validate())
+
+	for _, invalid := range []Config{
+		{CORSOrigins: []string{"example.com"}},
+		{CORSOrigins: []string{"https://example.com/path"}},
+		{MaxBodySize: -1},
+		{WriteTimeout: -1},
+		{TrustedProxies: []string{"10.0.0"}},
+		{TrustedProxies: []string{"10.0.0.0/33"}},
+	} {
+		assert.Error(t, invalid.Validate(), invalid)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var (
+		remoteAddr string
+		body       []byte
+	)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var err error
+			remoteAddr = r.RemoteAddr
+			body, err = io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+			}
+		}),
+	}
+	cfg := Config{
+		CORSOrigins:    []string{"https://example.com"},
+		MaxBodySize:    8,
+		TrustedProxies: []string{"10.0.0.0/8"},
+	}
+	require.NoError(t, cfg.Apply(srv))
+
+	serve := func(r *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	t.Run("CORS", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodOptions, "/", nil)
+		r.Header.Set("Origin", "https://example.com")
+		r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		resp := serve(r)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, resp.Header.Get("Access-Control-Allow-Headers"), "Authorization")
+
+		r = httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Origin", "https://evil.com")
+		resp = serve(r)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("MaxBodySize", func(t *testing.T) {
+		resp := serve(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345678")))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "12345678", string(body))
+
+		resp = serve(httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456789")))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+
+	t.Run("X-Forwarded-For", func(t *testing.T) {
+		forwarded := func(from, header string) string {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = from
+			r.Header.Set("X-Forwarded-For", header)
+			serve(r)
+			return remoteAddr
+		}
+		// the addresses of the trusted proxies are skipped
+		assert.Equal(t, "1.2.3.4:0", forwarded("10.0.0.1:1234", "6.6.6.6, 1.2.3.4, 10.0.0.2"))
+		// the header is ignored if not set by a trusted proxy
+		assert.Equal(t, "5.6.7.8:1234", forwarded("5.6.7.8:1234", "1.2.3.4"))
+		// the garbage is not trusted
+		assert.Equal(t, "10.0.0.1:1234", forwarded("10.0.0.1:1234", "1.2.3.4, garbage"))
+	})
+}
@@ -0,0 +1,198 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrWrongPassphrase is returned on attempt to read a key encrypted with another passphrase.
+var ErrWrongPassphrase = errors.New("keystore: wrong passphrase")
+
+// encryptedPrefix prefixes the bodies of the encrypted keys, telling them apart from the keys
+// stored in plaintext before the encryption was enabled.
+var encryptedPrefix = []byte("\x00enc")
+
+// stagedSuffix suffixes the names of the encrypted copies of the plaintext keys, which are
+// written and verified before they are renamed over the plaintext keys.
+const stagedSuffix = ".encrypting"
+
+const (
+	saltSize = 16
+	// scrypt parameters recommended for interactive logins
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// encryptedKeystore is the Keystore encrypting the keys of the wrapped Keystore.
+type encryptedKeystore struct {
+	Keystore
+
+	passphrase []byte
+}
+
+// NewEncryptedKeystore wraps the given Keystore, so that the keys are encrypted at rest with
+// AES-GCM under the keys derived from the passphrase.
+// The keys stored in plaintext before are encrypted on their first read.
+func NewEncryptedKeystore(ks Keystore, passphrase []byte) Keystore {
+	return &encryptedKeystore{
+		Keystore:   ks,
+		passphrase: passphrase,
+	}
+}
+
+// Passphrase reports the passphrase the keys of the given Keystore are encrypted with, if they are,
+// so that the keys kept along with them, e.g. in the keyring, can be encrypted with it too.
+func Passphrase(ks Keystore) ([]byte, bool) {
+	eks, ok := ks.(*encryptedKeystore)
+	if !ok {
+		return nil, false
+	}
+	return eks.passphrase, true
+}
+
+func (ks *encryptedKeystore) Put(n KeyName, pk PrivKey) error {
+	body, err := ks.seal(pk.Body)
+	if err != nil {
+		return err
+	}
+	return ks.Keystore.Put(n, PrivKey{Body: body})
+}
+
+func (ks *encryptedKeystore) Get(n KeyName) (PrivKey, error) {
+	pk, err := ks.Keystore.Get(n)
+	if err != nil {
+		return PrivKey{}, err
+	}
+
+	if !bytes.HasPrefix(pk.Body, encryptedPrefix) {
+		// the key was stored before the encryption was enabled, so encrypt it now
+		err = ks.encrypt(n, pk)
+		if err != nil {
+			return PrivKey{}, fmt.Errorf("keystore: encrypting key %s: %w", n, err)
+		}
+		return pk, nil
+	}
+
+	body, err := ks.open(pk.Body)
+	if err != nil {
+		return PrivKey{}, fmt.Errorf("keystore: decrypting key %s: %w", n, err)
+	}
+	return PrivKey{Body: body}, nil
+}
+
+func (ks *encryptedKeystore) List() ([]KeyName, error) {
+	names, err := ks.Keystore.List()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := names[:0]
+	for _, n := range names {
+		if !strings.HasSuffix(string(n), stagedSuffix) {
+			listed = append(listed, n)
+		}
+	}
+	return listed, nil
+}
+
+// encrypt replaces the plaintext key with its encrypted copy. The copy is staged and verified
+// first and then renamed over the plaintext key, so that the key is never lost, even if the
+// encryption is interrupted or fails.
+func (ks *encryptedKeystore) encrypt(n KeyName, pk PrivKey) error {
+	body, err := ks.seal(pk.Body)
+	if err != nil {
+		return err
+	}
+
+	staged := n + stagedSuffix
+	// the copy staged by an interrupted encryption is stale, as the plaintext key is still there
+	if _, err = ks.Keystore.Get(staged); err == nil {
+		err = ks.Keystore.Delete(staged)
+		if err != nil {
+			return err
+		}
+	}
+	err = ks.Keystore.Put(staged, PrivKey{Body: body})
+	if err != nil {
+		return err
+	}
+	written, err := ks.Keystore.Get(staged)
+	if err != nil {
+		return err
+	}
+	opened, err := ks.open(written.Body)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(opened, pk.Body) {
+		return errors.New("encrypted copy does not match")
+	}
+
+	return ks.Keystore.Rename(staged, n)
+}
+
+// seal encrypts the body under a key derived with a fresh salt.
+// The result is laid out as prefix | salt | nonce | ciphertext.
+func (ks *encryptedKeystore) seal(body []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := ks.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(encryptedPrefix)+saltSize+len(nonce)+len(body)+aead.Overhead())
+	out = append(out, encryptedPrefix...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, body, nil), nil
+}
+
+func (ks *encryptedKeystore) open(sealed []byte) ([]byte, error) {
+	sealed = sealed[len(encryptedPrefix):]
+	if len(sealed) < saltSize {
+		return nil, errors.New("malformed body")
+	}
+	aead, err := ks.aead(sealed[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[saltSize:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("malformed body")
+	}
+
+	body, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return body, nil
+}
+
+func (ks *encryptedKeystore) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(ks.passphrase, salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,78 @@
+package keystore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedKeystore(t *testing.T) {
+	inner := NewMapKeystore()
+	kstore := NewEncryptedKeystore(inner, []byte("passphrase"))
+
+	err := kstore.Put("test", PrivKey{Body: []byte("test_private_key")})
+	require.NoError(t, err)
+
+	key, err := kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test_private_key"), key.Body)
+
+	// the key is not stored in plaintext
+	raw, err := inner.Get("test")
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw.Body), "test_private_key")
+
+	_, err = NewEncryptedKeystore(inner, []byte("wrong")).Get("test")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestEncryptedKeystore_Plaintext(t *testing.T) {
+	inner := NewMapKeystore()
+	err := inner.Put("test", PrivKey{Body: []byte("test_private_key")})
+	require.NoError(t, err)
+
+	kstore := NewEncryptedKeystore(inner, []byte("passphrase"))
+	key, err := kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test_private_key"), key.Body)
+
+	// the plaintext key is encrypted on the first read
+	raw, err := inner.Get("test")
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte("test_private_key"), raw.Body)
+
+	key, err = kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test_private_key"), key.Body)
+}
+
+func TestEncryptedKeystore_InterruptedEncryption(t *testing.T) {
+	inner := NewMapKeystore()
+	err := inner.Put("test", PrivKey{Body: []byte("test_private_key")})
+	require.NoError(t, err)
+
+	kstore := NewEncryptedKeystore(inner, []byte("passphrase"))
+	// stage the encrypted copy of the key as the encryption does, as if the encryption was
+	// interrupted before the copy was renamed over the plaintext key
+	sealed, err := kstore.(*encryptedKeystore).seal([]byte("test_private_key"))
+	require.NoError(t, err)
+	err = inner.Put("test"+stagedSuffix, PrivKey{Body: sealed})
+	require.NoError(t, err)
+
+	names, err := kstore.List()
+	require.NoError(t, err)
+	assert.Equal(t, []KeyName{"test"}, names)
+
+	key, err := kstore.Get("test")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test_private_key"), key.Body)
+
+	names, err = inner.List()
+	require.NoError(t, err)
+	assert.Equal(t, []KeyName{"test"}, names)
+
+	raw, err := inner.Get("test")
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte("test_private_key"), raw.Body)
+}
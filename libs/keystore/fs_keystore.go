@@ -95,6 +95,16 @@ func (f *fsKeystore) Delete(n KeyName) error {
 	return nil
 }
 
+func (f *fsKeystore) Rename(from, to KeyName) error {
+	err := os.Rename(f.pathTo(from.Base32()), f.pathTo(to.Base32()))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("keystore: key '%s' not found", from)
+	} else if err != nil {
+		return fmt.Errorf("keystore: failed to rename key '%s' to '%s': %w", from, to, err)
+	}
+	return nil
+}
+
 func (f *fsKeystore) List() ([]KeyName, error) {
 	entries, err := fs.ReadDir(os.DirFS(filepath.Dir(f.path)), filepath.Base(f.path))
 	if err != nil {
@@ -22,7 +22,18 @@ func TestFSKeystore(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, keys, 1)
 
-	err = kstore.Delete("test")
+	err = kstore.Put("renamed", PrivKey{Body: []byte("stale_private_key")})
+	require.NoError(t, err)
+	err = kstore.Rename("test", "renamed")
+	require.NoError(t, err)
+
+	key, err = kstore.Get("renamed")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("test_private_key"), key.Body)
+	_, err = kstore.Get("test")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = kstore.Delete("renamed")
 	require.NoError(t, err)
 
 	keys, err = kstore.List()
@@ -29,6 +29,9 @@ type Keystore interface {
 	// Delete erases PrivKey using given KeyName.
 	Delete(name KeyName) error
 
+	// Rename atomically moves PrivKey to the new KeyName, replacing the PrivKey stored under it, if any.
+	Rename(from, to KeyName) error
+
 	// List lists all stored key names.
 	List() ([]KeyName, error)
 
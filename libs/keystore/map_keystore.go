@@ -54,6 +54,20 @@ func (m *mapKeystore) Delete(n KeyName) error {
 	return nil
 }
 
+func (m *mapKeystore) Rename(from, to KeyName) error {
+	m.keysLk.Lock()
+	defer m.keysLk.Unlock()
+
+	k, ok := m.keys[from]
+	if !ok {
+		return fmt.Errorf("keystore: key '%s' not found", from)
+	}
+
+	m.keys[to] = k
+	delete(m.keys, from)
+	return nil
+}
+
 func (m *mapKeystore) List() ([]KeyName, error) {
 	m.keysLk.Lock()
 	defer m.keysLk.Unlock()
@@ -0,0 +1,105 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+// epochOffset is the amount of seconds between the NTP and the Unix epochs.
+const epochOffset = 2208988800
+
+// packetSize is the size of the SNTP packet without the optional fields.
+const packetSize = 48
+
+// ErrNoServers is returned by Estimate if none of the servers responded.
+var ErrNoServers = errors.New("ntp: no server responded")
+
+// Offset measures the offset of the local clock from the given SNTP server, i.e. the duration to
+// add to the local time to get the server's one. The query is bounded by the given timeout.
+func Offset(ctx context.Context, server string, timeout time.Duration) (time.Duration, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, packetSize)
+	req[0] = 0x1B // no leap warning, version 3, client mode
+	sent := time.Now()
+	if _, err = conn.Write(req); err != nil {
+		return 0, err
+	}
+	resp := make([]byte, packetSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+	if n < packetSize {
+		return 0, errors.New("ntp: short response")
+	}
+
+	// the offset is the average of the differences of the server's receive and transmit times with
+	// the client's send and receive times, which cancels out the network delay
+	serverReceived, serverSent := parseTime(resp[32:40]), parseTime(resp[40:48])
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// Estimate measures the offset of the local clock from every given SNTP server concurrently and
+// returns the median of the offsets, so that a single misbehaving server can't skew the estimate.
+// It fails only if none of the servers responded.
+func Estimate(ctx context.Context, servers []string, timeout time.Duration) (time.Duration, error) {
+	type result struct {
+		offset time.Duration
+		err    error
+	}
+	results := make([]result, len(servers))
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			offset, err := Offset(ctx, server, timeout)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", server, err)
+			}
+			results[i] = result{offset: offset, err: err}
+		}(i, server)
+	}
+	wg.Wait()
+
+	offsets := make([]time.Duration, 0, len(servers))
+	var errs []error
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		offsets = append(offsets, res.offset)
+	}
+	if len(offsets) == 0 {
+		return 0, fmt.Errorf("%w: %v", ErrNoServers, errs)
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i] < offsets[j]
+	})
+	return offsets[len(offsets)/2], nil
+}
+
+func parseTime(b []byte) time.Time {
+	secs, frac := binary.BigEndian.Uint32(b[:4]), binary.BigEndian.Uint32(b[4:])
+	nanos := (int64(frac) * 1e9) >> 32
+	return time.Unix(int64(secs)-epochOffset, nanos)
+}
@@ -0,0 +1,61 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	servers := []string{
+		fakeServer(t, time.Minute),
+		fakeServer(t, time.Minute+time.Second),
+		// a misbehaving server doesn't skew the median
+		fakeServer(t, -time.Hour),
+	}
+	offset, err := Estimate(ctx, servers, time.Second)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, offset, float64(time.Second))
+
+	// nothing listens on the discard port
+	_, err = Estimate(ctx, []string{"127.0.0.1:9"}, time.Millisecond*100)
+	assert.True(t, errors.Is(err, ErrNoServers))
+}
+
+// fakeServer serves the time off by the given skew over SNTP and returns its address.
+func fakeServer(t *testing.T, skew time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, packetSize)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := make([]byte, packetSize)
+			now := time.Now().Add(skew)
+			secs := uint32(now.Unix() + epochOffset)
+			frac := uint32((uint64(now.Nanosecond()) << 32) / 1e9)
+			for _, off := range []int{32, 40} {
+				binary.BigEndian.PutUint32(resp[off:], secs)
+				binary.BigEndian.PutUint32(resp[off+4:], frac)
+			}
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
@@ -0,0 +1,141 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/multierr"
+)
+
+var log = logging.Logger("reload")
+
+// ErrUnknownSetting is returned on attempt to register a setting which does not exist in the
+// config.
+var ErrUnknownSetting = errors.New("reload: unknown setting")
+
+// ApplyFn applies the changed value of a setting to the running component.
+// Both 'old' and 'new' are of the type of the setting's config field.
+type ApplyFn func(ctx context.Context, old, new interface{}) error
+
+// Loader loads the latest version of the config, e.g. from disk.
+type Loader func() (interface{}, error)
+
+// Registry keeps the config settings which can be changed without restarting the node.
+// Components declare their reloadable settings by registering them along with an ApplyFn,
+// which is called on Reload only when the setting's value changes.
+type Registry struct {
+	cfg  interface{}
+	load Loader
+
+	lk       sync.Mutex
+	settings map[string]*setting
+}
+
+type setting struct {
+	value interface{}
+	apply ApplyFn
+}
+
+// NewRegistry creates a new Registry over the running config 'cfg' with the Loader providing its
+// updated versions. Both have to be pointers to the same struct type.
+func NewRegistry(cfg interface{}, load Loader) *Registry {
+	return &Registry{
+		cfg:      cfg,
+		load:     load,
+		settings: make(map[string]*setting),
+	}
+}
+
+// Register declares the setting under the given 'name' as reloadable.
+// The name is a dot-separated path of the setting's field in the config, e.g.
+// "DASer.ConcurrencyLimit".
+func (r *Registry) Register(name string, apply ApplyFn) error {
+	value, err := lookup(r.cfg, name)
+	if err != nil {
+		return err
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if _, ok := r.settings[name]; ok {
+		return fmt.Errorf("reload: setting %s is already registered", name)
+	}
+	r.settings[name] = &setting{value: value, apply: apply}
+	return nil
+}
+
+// Settings lists the names of all the reloadable settings.
+func (r *Registry) Settings() []string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	names := make([]string, 0, len(r.settings))
+	for name := range r.settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reload loads the latest config and applies the changed values of all the reloadable settings.
+// Changes of settings which are not reloadable are ignored until the node is restarted.
+// A setting which fails to be applied keeps its previous value and does not prevent others from
+// being applied.
+func (r *Registry) Reload(ctx context.Context) error {
+	cfg, err := r.load()
+	if err != nil {
+		return fmt.Errorf("reload: loading config: %w", err)
+	}
+
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	names := make([]string, 0, len(r.settings))
+	for name := range r.settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := r.settings[name]
+		value, lookupErr := lookup(cfg, name)
+		if lookupErr != nil {
+			err = multierr.Append(err, lookupErr)
+			continue
+		}
+		if reflect.DeepEqual(s.value, value) {
+			continue
+		}
+
+		applyErr := s.apply(ctx, s.value, value)
+		if applyErr != nil {
+			err = multierr.Append(err, fmt.Errorf("reload: applying %s: %w", name, applyErr))
+			continue
+		}
+		log.Infow("reloaded setting", "name", name, "old", s.value, "new", value)
+		s.value = value
+	}
+	return err
+}
+
+// lookup finds the value of a field under the dot-separated path 'name' in the given struct.
+func lookup(cfg interface{}, name string) (interface{}, error) {
+	v := reflect.ValueOf(cfg)
+	for _, field := range strings.Split(name, ".") {
+		for v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+		}
+		v = v.FieldByName(field)
+		if !v.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+		}
+	}
+	return v.Interface(), nil
+}
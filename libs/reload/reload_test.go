@@ -0,0 +1,68 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Log struct {
+		Level string
+	}
+	Peers []string
+	Limit int
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	running := &testConfig{Peers: []string{"a"}, Limit: 1}
+	running.Log.Level = "info"
+	stored := *running
+	r := NewRegistry(running, func() (interface{}, error) {
+		cp := stored
+		return &cp, nil
+	})
+
+	var levels []string
+	err := r.Register("Log.Level", func(_ context.Context, old, new interface{}) error {
+		levels = append(levels, old.(string), new.(string))
+		return nil
+	})
+	require.NoError(t, err)
+	var applied int
+	err = r.Register("Peers", func(context.Context, interface{}, interface{}) error {
+		applied++
+		return errors.New("broken")
+	})
+	require.NoError(t, err)
+
+	err = r.Register("Log.Unknown", nil)
+	require.ErrorIs(t, err, ErrUnknownSetting)
+	err = r.Register("Log.Level", nil)
+	require.Error(t, err)
+	assert.Equal(t, []string{"Log.Level", "Peers"}, r.Settings())
+
+	// nothing changed
+	err = r.Reload(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, levels)
+	assert.Zero(t, applied)
+
+	stored.Log.Level = "debug"
+	stored.Peers = []string{"a", "b"}
+	stored.Limit = 2 // not reloadable
+	err = r.Reload(ctx)
+	require.Error(t, err)
+	assert.Equal(t, []string{"info", "debug"}, levels)
+	assert.Equal(t, 1, applied)
+
+	// the failed setting is retried, while the applied one is not
+	err = r.Reload(ctx)
+	require.Error(t, err)
+	assert.Len(t, levels, 2)
+	assert.Equal(t, 2, applied)
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DoShared deduplicates the concurrent calls of 'fn' with the same 'key' within the 'group', so
+// that 'fn' runs once for all of them and its result is shared.
+// Unlike the plain singleflight, the callers are not failed by the cancellation of the caller
+// whose context 'fn' runs with, but retry instead, as long as their own context is alive.
+func DoShared[T any](
+	ctx context.Context,
+	group *singleflight.Group,
+	key string,
+	fn func(context.Context) (T, error),
+) (T, error) {
+	for {
+		resCh := group.DoChan(key, func() (interface{}, error) {
+			return fn(ctx)
+		})
+
+		select {
+		case res := <-resCh:
+			if res.Err != nil {
+				if isContextErr(res.Err) && ctx.Err() == nil {
+					// the context of another caller is done, but the context of this one isn't
+					continue
+				}
+				var empty T
+				return empty, res.Err
+			}
+			return res.Val.(T), nil
+		case <-ctx.Done():
+			var empty T
+			return empty, ctx.Err()
+		}
+	}
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/singleflight"
+)
+
+func TestDoShared(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	var (
+		group   singleflight.Group
+		calls   atomic.Int32
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	fn := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := DoShared(ctx, &group, "key", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, val)
+		}()
+	}
+	// let all the callers join the call
+	time.Sleep(time.Millisecond * 100)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+// TestDoShared_CanceledCaller tests that a caller is not failed by the cancellation of another
+// caller's context.
+func TestDoShared_CanceledCaller(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	var group singleflight.Group
+	fn := func(ctx context.Context) (int, error) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(time.Millisecond * 100):
+			return 42, nil
+		}
+	}
+
+	leaderCtx, leaderCancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := DoShared(leaderCtx, &group, "key", fn)
+		errCh <- err
+	}()
+	time.Sleep(time.Millisecond * 10)
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		leaderCancel()
+	}()
+	val, err := DoShared(ctx, &group, "key", fn)
+	require.NoError(t, err)
+	assert.Equal(t, 42, val)
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+}
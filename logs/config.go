@@ -0,0 +1,98 @@
+package logs
+
+import (
+	"fmt"
+
+	logging "github.com/ipfs/go-log/v2"
+)
+
+// Supported formats of the log output.
+const (
+	ColorizedFormat = "colorized"
+	PlaintextFormat = "plaintext"
+	JSONFormat      = "json"
+)
+
+// Config configures the logging of the node.
+type Config struct {
+	// Level is the level of all the loggers: DEBUG, INFO, WARN, ERROR, DPANIC, PANIC, FATAL
+	// and their lower-case forms. If empty, INFO is used.
+	Level string
+	// Modules overrides the levels of the loggers of individual modules,
+	// e.g. "header/p2p" = "debug".
+	Modules map[string]string
+	// Format is the format of the log output: "colorized", "plaintext" or "json" for log
+	// aggregation pipelines. If empty, the format set by the GOLOG_LOG_FMT environment variable is
+	// kept.
+	Format string
+}
+
+// DefaultConfig provides the default logging Config.
+func DefaultConfig() Config {
+	return Config{
+		Level:   "INFO",
+		Modules: make(map[string]string),
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	_, err := parseLevel(cfg.Level)
+	if err != nil {
+		return fmt.Errorf("logs: invalid level: %w", err)
+	}
+	for module, level := range cfg.Modules {
+		_, err = logging.LevelFromString(level)
+		if err != nil {
+			return fmt.Errorf("logs: invalid level of module %s: %w", module, err)
+		}
+	}
+	_, err = parseFormat(cfg.Format)
+	return err
+}
+
+// Apply sets up the output format and the levels of all the loggers according to the given
+// Config.
+func Apply(cfg Config) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Format != "" {
+		format, _ := parseFormat(cfg.Format)
+		setup := logging.GetConfig()
+		setup.Format = format
+		logging.SetupLogging(setup)
+	}
+
+	level, _ := parseLevel(cfg.Level)
+	SetAllLoggers(level)
+	for module, level := range cfg.Modules {
+		err = logging.SetLogLevel(module, level)
+		if err != nil {
+			return fmt.Errorf("logs: setting level of module %s: %w", module, err)
+		}
+	}
+	return nil
+}
+
+func parseLevel(level string) (logging.LogLevel, error) {
+	if level == "" {
+		return logging.LevelInfo, nil
+	}
+	return logging.LevelFromString(level)
+}
+
+func parseFormat(format string) (logging.LogFormat, error) {
+	switch format {
+	case "", ColorizedFormat:
+		return logging.ColorizedOutput, nil
+	case PlaintextFormat:
+		return logging.PlaintextOutput, nil
+	case JSONFormat:
+		return logging.JSONOutput, nil
+	default:
+		return 0, fmt.Errorf("logs: unknown format: %s", format)
+	}
+}
@@ -0,0 +1,42 @@
+package logs
+
+import (
+	"testing"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestApply(t *testing.T) {
+	logging.Logger("test/a")
+	logging.Logger("test/b")
+
+	cfg := DefaultConfig()
+	cfg.Level = "warn"
+	cfg.Modules["test/b"] = "debug"
+	cfg.Format = JSONFormat
+	err := Apply(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, Apply(Config{Format: ColorizedFormat}))
+	})
+
+	assert.False(t, logging.Logger("test/a").Desugar().Core().Enabled(zapcore.InfoLevel))
+	assert.True(t, logging.Logger("test/a").Desugar().Core().Enabled(zapcore.WarnLevel))
+	assert.True(t, logging.Logger("test/b").Desugar().Core().Enabled(zapcore.DebugLevel))
+	assert.Equal(t, logging.JSONOutput, logging.GetConfig().Format)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Modules["test/a"] = "loud"
+	require.Error(t, cfg.Validate())
+
+	cfg = DefaultConfig()
+	cfg.Format = "xml"
+	require.Error(t, cfg.Validate())
+}
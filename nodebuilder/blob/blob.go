@@ -0,0 +1,31 @@
+package blob
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+)
+
+// Module provides access to the Blobs included into the blocks.
+// Any method signature changed here needs to also be changed in the API struct.
+//
+//go:generate mockgen -destination=mocks/api.go -package=mocks . Module
+type Module interface {
+	// GetAll returns all the Blobs under the given namespaces included into the block at the given
+	// height, along with their commitments.
+	GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error)
+}
+
+// API is a wrapper around Module for the RPC.
+// TODO(@distractedm1nd): These structs need to be autogenerated.
+type API struct {
+	Internal struct {
+		GetAll func(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error) `perm:"read"`
+	}
+}
+
+func (api *API) GetAll(ctx context.Context, height uint64, nIDs []namespace.ID) ([]*blob.Blob, error) {
+	return api.Internal.GetAll(ctx, height, nIDs)
+}
@@ -0,0 +1,27 @@
+package blob
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var _ Module = (*blob.Service)(nil)
+
+// ConstructModule provides the Module retrieving the Blobs from the shares of the blocks.
+func ConstructModule(tp node.Type) fx.Option {
+	switch tp {
+	case node.Light, node.Full, node.Bridge:
+		return fx.Module(
+			"blob",
+			fx.Provide(func(shares share.Getter, store header.Store) Module {
+				return blob.NewService(shares, store)
+			}),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
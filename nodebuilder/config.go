@@ -6,15 +6,19 @@ import (
 
 	"github.com/BurntSushi/toml"
 
+	"github.com/celestiaorg/celestia-node/logs"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/grpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/notify"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
 )
 
 // ConfigLoader defines a function that loads a config from any source.
@@ -23,27 +27,40 @@ type ConfigLoader func() (*Config, error)
 // Config is main configuration structure for a Node.
 // It combines configuration units for all Node subsystems.
 type Config struct {
-	Core    core.Config
-	State   state.Config
-	P2P     p2p.Config
-	RPC     rpc.Config
-	Gateway gateway.Config
-	Share   share.Config
-	Header  header.Config
-	DASer   das.Config `toml:",omitempty"`
+	// Version of the Config format. See ConfigVersion.
+	Version  uint
+	Log      logs.Config
+	Node     node.Config
+	Core     core.Config
+	State    state.Config
+	P2P      p2p.Config
+	RPC      rpc.Config
+	Gateway  gateway.Config
+	GRPC     grpc.Config
+	Share    share.Config
+	Header   header.Config
+	DASer    das.Config `toml:",omitempty"`
+	Watchdog watchdog.Config
+	Notify   notify.Config
 }
 
 // DefaultConfig provides a default Config for a given Node Type 'tp'.
 // NOTE: Currently, configs are identical, but this will change.
 func DefaultConfig(tp node.Type) *Config {
 	commonConfig := &Config{
-		Core:    core.DefaultConfig(),
-		State:   state.DefaultConfig(),
-		P2P:     p2p.DefaultConfig(),
-		RPC:     rpc.DefaultConfig(),
-		Gateway: gateway.DefaultConfig(),
-		Share:   share.DefaultConfig(),
-		Header:  header.DefaultConfig(),
+		Version:  ConfigVersion,
+		Log:      logs.DefaultConfig(),
+		Node:     node.DefaultConfig(),
+		Core:     core.DefaultConfig(),
+		State:    state.DefaultConfig(),
+		P2P:      p2p.DefaultConfig(),
+		RPC:      rpc.DefaultConfig(),
+		Gateway:  gateway.DefaultConfig(),
+		GRPC:     grpc.DefaultConfig(),
+		Share:    share.DefaultConfig(),
+		Header:   header.DefaultConfig(),
+		Watchdog: watchdog.DefaultConfig(),
+		Notify:   notify.DefaultConfig(),
 	}
 
 	switch tp {
@@ -0,0 +1,136 @@
+package nodebuilder
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// ConfigVersion is the version of the Config format produced by this version of the node.
+// Any change to the Config which requires more than filling in defaults for new fields must bump
+// it and append a corresponding migration to configMigrations.
+const ConfigVersion = 2
+
+// configMigration upgrades the raw TOML representation of a Config by one version.
+type configMigration func(raw map[string]interface{}) error
+
+// configMigrations holds all the known migrations, where the migration at index 'i' upgrades a
+// Config of version 'i' to version 'i+1'.
+var configMigrations = []configMigration{
+	migrateConfigV0,
+	migrateConfigV1,
+}
+
+// migrateConfigV0 upgrades unversioned configs. They only lack the fields introduced
+// since, which are filled in from the defaults.
+func migrateConfigV0(map[string]interface{}) error {
+	return nil
+}
+
+// migrateConfigV1 moves the Watchdog.Webhooks into the Notify.Webhooks, as the watchdog alerts
+// are delivered along with the other notifications.
+func migrateConfigV1(raw map[string]interface{}) error {
+	watchdog, ok := raw["Watchdog"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	hooks, ok := watchdog["Webhooks"].([]interface{})
+	delete(watchdog, "Webhooks")
+	if !ok || len(hooks) == 0 {
+		return nil
+	}
+
+	notify, ok := raw["Notify"].(map[string]interface{})
+	if !ok {
+		notify = make(map[string]interface{})
+		raw["Notify"] = notify
+	}
+	var webhooks []map[string]interface{}
+	switch existing := notify["Webhooks"].(type) {
+	case []map[string]interface{}:
+		webhooks = existing
+	case []interface{}:
+		for _, hook := range existing {
+			if hook, ok := hook.(map[string]interface{}); ok {
+				webhooks = append(webhooks, hook)
+			}
+		}
+	}
+	for _, hook := range hooks {
+		url, ok := hook.(string)
+		if !ok {
+			return fmt.Errorf("invalid Watchdog webhook: %v", hook)
+		}
+		webhooks = append(webhooks, map[string]interface{}{"URL": url})
+	}
+	notify["Webhooks"] = webhooks
+	return nil
+}
+
+// MigrateConfig upgrades the Config stored under the given 'path' to the ConfigVersion in place,
+// keeping the original file next to it with a '.v<version>.bak' suffix.
+// Fields missing in the stored Config are filled in from the DefaultConfig of the given Node
+// Type 'tp' and unknown fields are dropped.
+// It reports whether the Config was migrated.
+func MigrateConfig(path string, tp node.Type) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	raw := make(map[string]interface{})
+	_, err = toml.Decode(string(data), &raw)
+	if err != nil {
+		return false, fmt.Errorf("node: can't decode Config: %w", err)
+	}
+
+	var version int64
+	if v, ok := raw["Version"]; ok {
+		version, ok = v.(int64)
+		if !ok || version < 0 {
+			return false, fmt.Errorf("node: invalid Config version: %v", v)
+		}
+	}
+	switch {
+	case version == ConfigVersion:
+		return false, nil
+	case version > ConfigVersion:
+		return false, fmt.Errorf("node: Config version %d is newer than the supported %d", version, ConfigVersion)
+	}
+
+	for v := version; v < ConfigVersion; v++ {
+		err = configMigrations[v](raw)
+		if err != nil {
+			return false, fmt.Errorf("node: migrating Config from version %d: %w", v, err)
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err = toml.NewEncoder(buf).Encode(raw)
+	if err != nil {
+		return false, err
+	}
+	cfg := DefaultConfig(tp)
+	err = cfg.Decode(buf)
+	if err != nil {
+		return false, fmt.Errorf("node: can't decode migrated Config: %w", err)
+	}
+	cfg.Version = ConfigVersion
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	err = os.WriteFile(backup, data, 0600)
+	if err != nil {
+		return false, fmt.Errorf("node: can't back up Config: %w", err)
+	}
+
+	err = SaveConfig(path, cfg)
+	if err != nil {
+		return false, err
+	}
+	log.Infow("Migrated config", "path", path, "from", version, "to", ConfigVersion, "backup", backup)
+	return true, nil
+}
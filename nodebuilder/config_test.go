@@ -2,12 +2,15 @@ package nodebuilder
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/notify"
 )
 
 // TestConfigWriteRead tests that the configs for all node types can be encoded to and from TOML.
@@ -33,3 +36,77 @@ func TestConfigWriteRead(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrateConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	// an unversioned config lacking the keyring backend and with a field that no longer exists
+	old := `
+[State]
+  KeyringAccName = "my_key"
+  UnknownField = true
+
+[RPC]
+  Address = "127.0.0.1"
+  Port = "1234"
+`
+	err := os.WriteFile(path, []byte(old), 0600)
+	require.NoError(t, err)
+
+	migrated, err := MigrateConfig(path, node.Light)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	backup, err := os.ReadFile(path + ".v0.bak")
+	require.NoError(t, err)
+	assert.Equal(t, old, string(backup))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, ConfigVersion, cfg.Version)
+	assert.Equal(t, "my_key", cfg.State.KeyringAccName)
+	assert.Equal(t, DefaultConfig(node.Light).State.KeyringBackend, cfg.State.KeyringBackend)
+	assert.Equal(t, "1234", cfg.RPC.Port)
+	assert.Equal(t, DefaultConfig(node.Light).RPC.DrainTimeout, cfg.RPC.DrainTimeout)
+	assert.Equal(t, DefaultConfig(node.Light).DASer, cfg.DASer)
+	assert.Equal(t, DefaultConfig(node.Light).Log, cfg.Log)
+
+	// an up-to-date config is left untouched
+	migrated, err = MigrateConfig(path, node.Light)
+	require.NoError(t, err)
+	assert.False(t, migrated)
+
+	// a config of a newer version can't be migrated
+	cfg.Version = ConfigVersion + 1
+	err = SaveConfig(path, cfg)
+	require.NoError(t, err)
+	_, err = MigrateConfig(path, node.Light)
+	require.Error(t, err)
+}
+
+func TestMigrateConfig_WatchdogWebhooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	old := `
+Version = 1
+
+[Watchdog]
+  Webhooks = ["https://alerts.example.com"]
+
+[[Notify.Webhooks]]
+  URL = "https://events.example.com"
+  Secret = "secret"
+`
+	err := os.WriteFile(path, []byte(old), 0600)
+	require.NoError(t, err)
+
+	migrated, err := MigrateConfig(path, node.Light)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	// the watchdog alerts keep being delivered to the same webhooks
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []notify.Webhook{
+		{URL: "https://events.example.com", Secret: "secret"},
+		{URL: "https://alerts.example.com"},
+	}, cfg.Notify.Webhooks)
+}
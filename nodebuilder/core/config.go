@@ -1,9 +1,15 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
+	"google.golang.org/grpc"
+
+	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
@@ -12,32 +18,110 @@ type Config struct {
 	IP       string
 	RPCPort  string
 	GRPCPort string
+	// Fallbacks are the endpoints of other Core nodes to fail over to, in the given order,
+	// whenever the one in use becomes unhealthy.
+	Fallbacks []core.Endpoint
+	// TLS secures the connections to the RPC and gRPC endpoints with TLS.
+	TLS bool
+	// TLSCACertPath is the path to the PEM-encoded certificate of the CA the endpoints are
+	// verified with. The system certificates are used, if empty.
+	TLSCACertPath string
+	// AuthTokenPath is the path to the file holding the token every gRPC call and RPC request is
+	// authorized with. The token is only sent over TLS.
+	AuthTokenPath string
 }
 
 // DefaultConfig returns default configuration for managing the
 // node's connection to a Celestia-Core endpoint.
 func DefaultConfig() Config {
 	return Config{
-		IP:       "0.0.0.0",
-		RPCPort:  "0",
-		GRPCPort: "0",
+		IP:        "0.0.0.0",
+		RPCPort:   "0",
+		GRPCPort:  "0",
+		Fallbacks: []core.Endpoint{},
 	}
 }
 
+// Endpoints lists all the configured Core endpoints, starting with the primary one.
+func (cfg *Config) Endpoints() []core.Endpoint {
+	primary := core.Endpoint{IP: cfg.IP, RPCPort: cfg.RPCPort, GRPCPort: cfg.GRPCPort}
+	return append([]core.Endpoint{primary}, cfg.Fallbacks...)
+}
+
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
-	ip, err := utils.ValidateAddr(cfg.IP)
+	ip, err := validateEndpoint(cfg.IP, cfg.RPCPort, cfg.GRPCPort)
 	if err != nil {
 		return err
 	}
 	cfg.IP = ip
-	_, err = strconv.Atoi(cfg.RPCPort)
+	for i, fallback := range cfg.Fallbacks {
+		ip, err = validateEndpoint(fallback.IP, fallback.RPCPort, fallback.GRPCPort)
+		if err != nil {
+			return fmt.Errorf("nodebuilder/core: invalid fallback %s: %w", fallback.IP, err)
+		}
+		cfg.Fallbacks[i].IP = ip
+	}
+	if !cfg.TLS && (cfg.TLSCACertPath != "" || cfg.AuthTokenPath != "") {
+		return errors.New("nodebuilder/core: CA certificate and auth token require TLS to be enabled")
+	}
+	return nil
+}
+
+// GRPCDialOptions returns the options securing the connections to the gRPC endpoints.
+func (cfg *Config) GRPCDialOptions() ([]grpc.DialOption, error) {
+	token, err := cfg.authToken()
 	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
+		return nil, err
+	}
+	return core.GRPCDialOptions(cfg.TLS, cfg.TLSCACertPath, token)
+}
+
+// RemoteOptions returns the options securing the connections to the RPC endpoints.
+func (cfg *Config) RemoteOptions() ([]core.RemoteOption, error) {
+	if !cfg.TLS {
+		return nil, nil
 	}
-	_, err = strconv.Atoi(cfg.GRPCPort)
+	tlsCfg, err := core.NewTLSConfig(cfg.TLSCACertPath)
 	if err != nil {
-		return fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+		return nil, err
 	}
-	return nil
+	token, err := cfg.authToken()
+	if err != nil {
+		return nil, err
+	}
+	opts := []core.RemoteOption{core.WithTLS(tlsCfg)}
+	if token != "" {
+		opts = append(opts, core.WithAuthToken(token))
+	}
+	return opts, nil
+}
+
+// authToken reads the auth token, if any.
+func (cfg *Config) authToken() (string, error) {
+	if cfg.AuthTokenPath == "" {
+		return "", nil
+	}
+	raw, err := os.ReadFile(cfg.AuthTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: reading auth token: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// validateEndpoint validates the address of the endpoint and returns its sanitized IP.
+func validateEndpoint(ip, rpcPort, grpcPort string) (string, error) {
+	ip, err := utils.ValidateAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	_, err = strconv.Atoi(rpcPort)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: invalid rpc port: %s", err.Error())
+	}
+	_, err = strconv.Atoi(grpcPort)
+	if err != nil {
+		return "", fmt.Errorf("nodebuilder/core: invalid grpc port: %s", err.Error())
+	}
+	return ip, nil
 }
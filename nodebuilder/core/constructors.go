@@ -1,9 +1,32 @@
 package core
 
 import (
+	"context"
+	"time"
+
 	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/libs/health"
 )
 
+// healthCheckTimeout is the time the Core endpoint has to report its health.
+var healthCheckTimeout = time.Second * 5
+
+// Remote provides a Client to the configured Core endpoint, failing over to the fallback
+// endpoints if there are any. The connections are secured with TLS, if enabled.
 func Remote(cfg Config) (core.Client, error) {
-	return core.NewRemote(cfg.IP, cfg.RPCPort)
+	opts, err := cfg.RemoteOptions()
+	if err != nil {
+		return nil, err
+	}
+	return core.NewRemoteWithFailover(cfg.Endpoints(), opts...)
+}
+
+// registerHealthCheck checks the health of the Core endpoint in use.
+func registerHealthCheck(r *health.Registry, client core.Client) error {
+	return r.Register("core", health.CheckerFunc(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+		_, err := client.Health(ctx)
+		return err
+	}))
 }
@@ -2,15 +2,22 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/core"
 )
 
 var (
 	coreFlag     = "core.ip"
 	coreRPCFlag  = "core.rpc.port"
 	coreGRPCFlag = "core.grpc.port"
+	fallbackFlag = "core.fallback"
+	tlsFlag      = "core.grpc.tls"
+	tlsCAFlag    = "core.grpc.tls.ca"
+	tokenFlag    = "core.grpc.token"
 )
 
 // Flags gives a set of hardcoded Core flags.
@@ -33,6 +40,29 @@ func Flags() *flag.FlagSet {
 		"9090",
 		"Set a custom gRPC port for the core node connection. The --core.ip flag must also be provided.",
 	)
+	flags.StringSlice(
+		fallbackFlag,
+		nil,
+		"Adds core nodes to fail over to, in the given order, whenever the one in use becomes unhealthy. "+
+			"Example: <ip>:<rpc port>:<grpc port>, 127.0.0.1:26657:9090. The --core.ip flag must also be provided.",
+	)
+	flags.Bool(
+		tlsFlag,
+		false,
+		"Secures the connection to the RPC and gRPC endpoints of the core nodes with TLS.",
+	)
+	flags.String(
+		tlsCAFlag,
+		"",
+		"Path to the PEM-encoded CA certificate to verify the endpoints of the core nodes with, "+
+			"instead of the system ones. The --core.grpc.tls flag must also be provided.",
+	)
+	flags.String(
+		tokenFlag,
+		"",
+		"Path to the file holding the token to authorize the RPC and gRPC calls to the core nodes with. "+
+			"The --core.grpc.tls flag must also be provided.",
+	)
 	return flags
 }
 
@@ -41,11 +71,28 @@ func ParseFlags(
 	cmd *cobra.Command,
 	cfg *Config,
 ) error {
+	if cmd.Flag(tlsFlag).Changed {
+		tlsEnabled, err := cmd.Flags().GetBool(tlsFlag)
+		if err != nil {
+			return err
+		}
+		cfg.TLS = tlsEnabled
+	}
+	if cmd.Flag(tlsCAFlag).Changed {
+		cfg.TLSCACertPath = cmd.Flag(tlsCAFlag).Value.String()
+	}
+	if cmd.Flag(tokenFlag).Changed {
+		cfg.AuthTokenPath = cmd.Flag(tokenFlag).Value.String()
+	}
+
 	coreIP := cmd.Flag(coreFlag).Value.String()
 	if coreIP == "" {
 		if cmd.Flag(coreGRPCFlag).Changed || cmd.Flag(coreRPCFlag).Changed {
 			return fmt.Errorf("cannot specify RPC/gRPC ports without specifying an IP address for --core.ip")
 		}
+		if cmd.Flag(fallbackFlag).Changed {
+			return fmt.Errorf("cannot specify fallback core nodes without specifying an IP address for --core.ip")
+		}
 		return nil
 	}
 
@@ -55,5 +102,20 @@ func ParseFlags(
 	cfg.IP = coreIP
 	cfg.RPCPort = rpc
 	cfg.GRPCPort = grpc
+
+	if cmd.Flag(fallbackFlag).Changed {
+		fallbacks, err := cmd.Flags().GetStringSlice(fallbackFlag)
+		if err != nil {
+			return err
+		}
+		cfg.Fallbacks = make([]core.Endpoint, len(fallbacks))
+		for i, fallback := range fallbacks {
+			parts := strings.Split(fallback, ":")
+			if len(parts) != 3 {
+				return fmt.Errorf("invalid fallback core node %s, expected <ip>:<rpc port>:<grpc port>", fallback)
+			}
+			cfg.Fallbacks[i] = core.Endpoint{IP: parts[0], RPCPort: parts[1], GRPCPort: parts[2]}
+		}
+	}
 	return nil
 }
@@ -50,6 +50,7 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 					return client.Stop()
 				}),
 			)),
+			fx.Invoke(registerHealthCheck),
 		)
 	default:
 		panic("invalid node type")
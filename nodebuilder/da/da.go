@@ -0,0 +1,62 @@
+package da
+
+import (
+	"context"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/blob"
+	"github.com/celestiaorg/celestia-node/da"
+)
+
+// Module implements the generic data availability interface of the rollup frameworks, so that
+// they plug the node in as their DA layer with a single adapter.
+// Any method signature changed here needs to also be changed in the API struct.
+//
+// NOTE: The Module is served under the "da" namespace of the RPC for the DA clients of the
+// frameworks, but it is not part of the API of the node's own client, as its method names overlap
+// with the ones of the other modules.
+type Module interface {
+	// Submit submits the blobs under the given namespace and returns their IDs once they are
+	// included into the chain.
+	Submit(ctx context.Context, blobs [][]byte, nID namespace.ID) ([]da.ID, error)
+	// Get returns the blobs with the given IDs under the given namespace.
+	Get(ctx context.Context, ids []da.ID, nID namespace.ID) ([][]byte, error)
+	// Commit returns the commitments to the blobs under the given namespace for all the square
+	// sizes they fit in.
+	Commit(ctx context.Context, blobs [][]byte, nID namespace.ID) ([][]*blob.Commitment, error)
+	// Validate reports whether the blobs with the given IDs are included into the chain under the
+	// given namespace.
+	Validate(ctx context.Context, ids []da.ID, nID namespace.ID) ([]bool, error)
+}
+
+// API is a wrapper around Module for the RPC.
+// TODO(@distractedm1nd): These structs need to be autogenerated.
+type API struct {
+	Internal struct {
+		Submit func(ctx context.Context, blobs [][]byte, nID namespace.ID) ([]da.ID, error) `perm:"write"`
+		Get    func(ctx context.Context, ids []da.ID, nID namespace.ID) ([][]byte, error)   `perm:"read"`
+		Commit func(
+			ctx context.Context,
+			blobs [][]byte,
+			nID namespace.ID,
+		) ([][]*blob.Commitment, error) `perm:"read"`
+		Validate func(ctx context.Context, ids []da.ID, nID namespace.ID) ([]bool, error) `perm:"read"`
+	}
+}
+
+func (api *API) Submit(ctx context.Context, blobs [][]byte, nID namespace.ID) ([]da.ID, error) {
+	return api.Internal.Submit(ctx, blobs, nID)
+}
+
+func (api *API) Get(ctx context.Context, ids []da.ID, nID namespace.ID) ([][]byte, error) {
+	return api.Internal.Get(ctx, ids, nID)
+}
+
+func (api *API) Commit(ctx context.Context, blobs [][]byte, nID namespace.ID) ([][]*blob.Commitment, error) {
+	return api.Internal.Commit(ctx, blobs, nID)
+}
+
+func (api *API) Validate(ctx context.Context, ids []da.ID, nID namespace.ID) ([]bool, error) {
+	return api.Internal.Validate(ctx, ids, nID)
+}
@@ -0,0 +1,29 @@
+package da
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/da"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/state"
+)
+
+var _ Module = (*da.Service)(nil)
+
+// ConstructModule provides the Module implementing the data availability interface of the rollup
+// frameworks over the blob, state and header services.
+func ConstructModule(tp node.Type) fx.Option {
+	switch tp {
+	case node.Light, node.Full, node.Bridge:
+		return fx.Module(
+			"da",
+			fx.Provide(func(blobs blob.Module, ca *state.CoreAccessor, store header.Store) Module {
+				return da.NewService(blobs, ca, store)
+			}),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
@@ -9,15 +9,16 @@ import (
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/reload"
 	"github.com/celestiaorg/celestia-node/share"
 )
 
 var _ Module = (*daserStub)(nil)
 
-var errStub = fmt.Errorf("module/das: stubbed: dasing is not available on bridge nodes")
+var errStub = fmt.Errorf("module/das: stubbed: dasing is not available on bridge and read-only nodes")
 
-// daserStub is a stub implementation of the DASer that is used on bridge nodes, so that we can
-// provide a friendlier error when users try to access the daser over the API.
+// daserStub is a stub implementation of the DASer that is used on bridge and read-only nodes, so
+// that we can provide a friendlier error when users try to access the daser over the API.
 type daserStub struct{}
 
 func (d daserStub) SamplingStats(context.Context) (das.SamplingStats, error) {
@@ -42,3 +43,10 @@ func NewDASer(
 ) (*das.DASer, error) {
 	return das.NewDASer(da, hsub, store, batching, fraudService, options...)
 }
+
+// registerReloadable declares the DASer settings which can be changed without restarting the node.
+func registerReloadable(r *reload.Registry, daser *das.DASer) error {
+	return r.Register("DASer.ConcurrencyLimit", func(ctx context.Context, _, new interface{}) error {
+		return daser.SetConcurrencyLimit(ctx, new.(int))
+	})
+}
@@ -17,6 +17,16 @@ type Module interface {
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
-	SamplingStats func(ctx context.Context) (das.SamplingStats, error)
-	WaitCatchUp   func(ctx context.Context) error
+	Internal struct {
+		SamplingStats func(ctx context.Context) (das.SamplingStats, error) `perm:"read"`
+		WaitCatchUp   func(ctx context.Context) error                      `perm:"read"`
+	}
+}
+
+func (api *API) SamplingStats(ctx context.Context) (das.SamplingStats, error) {
+	return api.Internal.SamplingStats(ctx)
+}
+
+func (api *API) WaitCatchUp(ctx context.Context) error {
+	return api.Internal.WaitCatchUp(ctx)
 }
@@ -7,11 +7,17 @@ import (
 
 	"github.com/celestiaorg/celestia-node/das"
 	"github.com/celestiaorg/celestia-node/fraud"
+	"github.com/celestiaorg/celestia-node/header/sync"
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/libs/health"
 	fraudServ "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
-func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+// ConstructModule collects all the components and services related to sampling.
+// If 'readOnly', nothing is sampled.
+func ConstructModule(tp node.Type, cfg *Config, readOnly bool) fx.Option {
 	var err error
 	// do not validate daser config for bridge node as it
 	// does not need it
@@ -23,13 +29,17 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Supply(*cfg),
 		fx.Error(err),
 		fx.Provide(
-			func(c Config) []das.Option {
+			func(c Config, bus *events.Bus, poisoned *share.PoisonList, disk node.DiskSpace) []das.Option {
 				return []das.Option{
 					das.WithSamplingRange(c.SamplingRange),
 					das.WithConcurrencyLimit(c.ConcurrencyLimit),
 					das.WithPriorityQueueSize(c.PriorityQueueSize),
 					das.WithBackgroundStoreInterval(c.BackgroundStoreInterval),
 					das.WithSampleFrom(c.SampleFrom),
+					das.WithIntermittent(c.Intermittent),
+					das.WithEvents(bus),
+					das.WithPoisonList(poisoned),
+					das.WithStorePaused(disk.Protective),
 				}
 			},
 		),
@@ -37,6 +47,13 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 
 	switch tp {
 	case node.Light, node.Full:
+		if readOnly {
+			return fx.Module(
+				"daser",
+				baseComponents,
+				fx.Provide(newDaserStub),
+			)
+		}
 		return fx.Module(
 			"daser",
 			baseComponents,
@@ -54,6 +71,18 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 			fx.Provide(func(das *das.DASer) Module {
 				return das
 			}),
+			// the Syncer announces the new heads to the DASer of light nodes to prefetch them, while full
+			// nodes reconstruct whole squares, which is not done ahead. The DASer is not passed to the
+			// constructor of the Syncer, so that it is still started after the Syncer.
+			fx.Invoke(func(syncer *sync.Syncer, das *das.DASer) {
+				if tp == node.Light {
+					syncer.SetPrefetcher(das)
+				}
+			}),
+			fx.Invoke(registerReloadable),
+			fx.Invoke(func(r *health.Registry, daser *das.DASer) error {
+				return r.Register("daser", daser)
+			}),
 		)
 	case node.Bridge:
 		return fx.Module(
@@ -21,7 +21,7 @@ func TestConstructModule_DASBridgeStub(t *testing.T) {
 
 	cfg := DefaultConfig()
 	app := fxtest.New(t,
-		ConstructModule(node.Bridge, &cfg),
+		ConstructModule(node.Bridge, &cfg, false),
 		fx.Populate(&mod)).
 		RequireStart()
 	defer app.RequireStop()
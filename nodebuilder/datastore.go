@@ -0,0 +1,116 @@
+package nodebuilder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/dgraph-io/badger/v2/options"
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	dsleveldb "github.com/ipfs/go-ds-leveldb"
+
+	"github.com/celestiaorg/celestia-node/libs/dspebble"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// openDatastore opens the Datastore of the given backend under the given 'path'.
+func openDatastore(path string, backend node.DatastoreBackend) (datastore.Batching, error) {
+	// configs predating the configurable backends were always using Badger
+	if backend == "" {
+		backend = node.BadgerBackend
+	}
+	if backend == node.MemoryBackend {
+		return ds_sync.MutexWrap(datastore.NewMapDatastore()), nil
+	}
+
+	err := ensureBackend(path, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case node.BadgerBackend:
+		return openBadger(path)
+	case node.LevelDBBackend:
+		return dsleveldb.NewDatastore(path, nil)
+	case node.PebbleBackend:
+		return openPebble(path)
+	default:
+		return nil, fmt.Errorf("unknown datastore backend: %s", backend)
+	}
+}
+
+func openBadger(path string) (datastore.Batching, error) {
+	opts := dsbadger.DefaultOptions // this should be copied
+
+	// Badger sets ValueThreshold to 1K by default and this makes shares being stored in LSM tree
+	// instead of the value log, so we change the value to be lower than share size,
+	// so shares are store in value log. For value log and LSM definitions
+	opts.ValueThreshold = 128
+	// We always write unique values to Badger transaction so there is no need to detect conflicts.
+	opts.DetectConflicts = false
+	// Use MemoryMap for better performance
+	opts.ValueLogLoadingMode = options.MemoryMap
+	opts.TableLoadingMode = options.MemoryMap
+	// Truncate set to true will truncate corrupted data on start if there is any.
+	// If we don't truncate, the node will refuse to start and will beg for recovering, etc.
+	// If we truncate, the node will start with any uncorrupted data and reliably sync again what was
+	// corrupted in most cases.
+	opts.Truncate = true
+	// MaxTableSize defines in memory and on disk size of LSM tree
+	// Bigger values constantly takes more RAM
+	// TODO(@Wondertan): Make configurable with more conservative defaults for Light Node
+	opts.MaxTableSize = 64 << 20
+	// Disable the periodic GC of Badger, as the node module schedules it on the configured
+	// Node.DatastoreGCInterval instead.
+	opts.GcInterval = 0
+
+	return dsbadger.NewDatastore(path, &opts)
+}
+
+func openPebble(path string) (datastore.Batching, error) {
+	opts := &pebble.Options{
+		// Bigger memtables are flushed less often, sustaining higher write throughput.
+		MemTableSize: 64 << 20,
+		// Compactions keep up with the writes only when running concurrently.
+		MaxConcurrentCompactions: runtime.NumCPU,
+	}
+	return dspebble.NewDatastore(path, opts)
+}
+
+// ensureBackend records the backend of the datastore under 'path' on its first opening and
+// ensures the datastore is never opened with another backend afterwards.
+func ensureBackend(path string, backend node.DatastoreBackend) error {
+	markerPath := backendPath(path)
+	recorded, err := os.ReadFile(markerPath)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		if len(entries) != 0 {
+			// the stores with data but without the record predate the configurable backends
+			recorded = []byte(node.BadgerBackend)
+			break
+		}
+		return os.WriteFile(markerPath, []byte(backend), 0600)
+	default:
+		return err
+	}
+
+	if node.DatastoreBackend(recorded) != backend {
+		return fmt.Errorf("datastore holds the data of the %s backend, while %s is configured",
+			recorded, backend)
+	}
+	return nil
+}
+
+func backendPath(data string) string {
+	return filepath.Join(data, "BACKEND")
+}
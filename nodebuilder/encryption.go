@@ -0,0 +1,91 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/celestiaorg/celestia-node/libs/dscrypt"
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+)
+
+// EnvStorePassphrase is the environment variable name used for setting the passphrase the Store
+// is encrypted with, once enabled with Node.EncryptStore.
+const EnvStorePassphrase = "CELESTIA_STORE_PASSPHRASE"
+
+// datastoreKeyName is the name of the key the Datastore values are encrypted under.
+const datastoreKeyName = "datastore-key"
+
+// storePassphrase reads the passphrase the Store is encrypted with from the environment.
+func storePassphrase() ([]byte, error) {
+	passphrase := os.Getenv(EnvStorePassphrase)
+	if passphrase == "" {
+		return nil, fmt.Errorf("node: Store encryption is enabled, but %s is not set", EnvStorePassphrase)
+	}
+	return []byte(passphrase), nil
+}
+
+// encryptDatastore wraps the Datastore, so that its values are encrypted under the key kept in
+// the encrypted Keystore. The key is generated on the first use.
+func encryptDatastore(ctx context.Context, ds datastore.Batching, ks keystore.Keystore) (datastore.Batching, error) {
+	pk, err := ks.Get(datastoreKeyName)
+	switch {
+	case err == nil:
+	case errors.Is(err, keystore.ErrNotFound):
+		// the values stored before can't be told apart from the encrypted ones, so the encryption
+		// can only be enabled for an empty Datastore
+		empty, err := isEmpty(ctx, ds)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, errors.New("node: can't enable encryption of the Datastore holding plaintext data")
+		}
+
+		key, err := dscrypt.NewKey()
+		if err != nil {
+			return nil, err
+		}
+		pk = keystore.PrivKey{Body: key}
+		err = ks.Put(datastoreKeyName, pk)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	return dscrypt.Wrap(ds, pk.Body)
+}
+
+// ensurePlaintext ensures the Datastore is not encrypted, as it is unreadable without the
+// encryption enabled.
+func ensurePlaintext(ks keystore.Keystore) error {
+	_, err := ks.Get(datastoreKeyName)
+	switch {
+	case err == nil:
+		return errors.New("node: Datastore is encrypted, but Node.EncryptStore is disabled")
+	case errors.Is(err, keystore.ErrNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+func isEmpty(ctx context.Context, ds datastore.Datastore) (bool, error) {
+	res, err := ds.Query(ctx, dsq.Query{KeysOnly: true, Limit: 1})
+	if err != nil {
+		return false, err
+	}
+	defer res.Close()
+
+	entries, err := res.Rest()
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/events"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
@@ -17,7 +18,8 @@ func newFraudService(syncerEnabled bool) func(
 	host.Host,
 	header.Store,
 	datastore.Batching,
-	p2p.Network,
+	p2p.NetworkID,
+	*events.Bus,
 ) (Module, fraud.Service, error) {
 	return func(
 		lc fx.Lifecycle,
@@ -25,9 +27,11 @@ func newFraudService(syncerEnabled bool) func(
 		host host.Host,
 		hstore header.Store,
 		ds datastore.Batching,
-		network p2p.Network,
+		netID p2p.NetworkID,
+		bus *events.Bus,
 	) (Module, fraud.Service, error) {
-		pservice := fraud.NewProofService(sub, host, hstore.GetByHeight, ds, syncerEnabled, string(network))
+		pservice := fraud.NewProofService(sub, host, hstore.GetByHeight, ds, syncerEnabled, string(netID),
+			fraud.WithEvents(bus))
 		lc.Append(fx.Hook{
 			OnStart: pservice.Start,
 			OnStop:  pservice.Stop,
@@ -20,6 +20,16 @@ type Module interface {
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
-	Subscribe func(context.Context, fraud.ProofType) (<-chan Proof, error)
-	Get       func(context.Context, fraud.ProofType) ([]Proof, error)
+	Internal struct {
+		Subscribe func(context.Context, fraud.ProofType) (<-chan Proof, error) `perm:"read"`
+		Get       func(context.Context, fraud.ProofType) ([]Proof, error)      `perm:"read"`
+	}
+}
+
+func (api *API) Subscribe(ctx context.Context, proofType fraud.ProofType) (<-chan Proof, error) {
+	return api.Internal.Subscribe(ctx, proofType)
+}
+
+func (api *API) Get(ctx context.Context, proofType fraud.ProofType) ([]Proof, error) {
+	return api.Internal.Get(ctx, proofType)
 }
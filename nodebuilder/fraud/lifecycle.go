@@ -18,25 +18,44 @@ func Lifecycle(
 	fraudServ fraud.Service,
 	start, stop func(context.Context) error,
 ) error {
+	// subscribe before checking the stored proofs, so that no proof arriving in between is missed
+	sub, err := fraudServ.Subscribe(p)
+	if err != nil {
+		return err
+	}
+
 	proofs, err := fraudServ.Get(startCtx, p)
 	switch err {
 	default:
+		sub.Cancel()
 		return err
 	case nil:
+		sub.Cancel()
 		return &fraud.ErrFraudExists{Proof: proofs}
 	case datastore.ErrNotFound:
 	}
 	err = start(startCtx)
 	if err != nil {
+		sub.Cancel()
 		return err
 	}
 	// handle incoming Fraud Proofs
-	go fraud.OnProof(lifecycleCtx, fraudServ, p, func(fraud.Proof) {
+	go func() {
+		defer sub.Cancel()
+		// at this point we receive already verified fraud proof
+		_, err := sub.Proof(lifecycleCtx)
+		if err != nil {
+			if err != context.Canceled {
+				log.Errorw("reading next proof failed", "err", err)
+			}
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(lifecycleCtx, time.Minute)
 		defer cancel()
 		if err := stop(ctx); err != nil {
 			log.Error(err)
 		}
-	})
+	}()
 	return nil
 }
@@ -0,0 +1,94 @@
+package fraud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/fraud"
+)
+
+func TestLifecycle(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	serv := newTestService()
+	stopped := make(chan struct{})
+	err := Lifecycle(ctx, ctx, fraud.BadEncoding, serv,
+		func(context.Context) error { return nil },
+		func(context.Context) error {
+			close(stopped)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+
+	serv.proofs <- nil
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		t.Fatal("service was not stopped on fraud proof")
+	}
+}
+
+func TestLifecycle_FraudExists(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	serv := newTestService()
+	serv.stored = []fraud.Proof{nil}
+	err := Lifecycle(ctx, ctx, fraud.BadEncoding, serv,
+		func(context.Context) error {
+			t.Fatal("service must not be started while a fraud proof exists")
+			return nil
+		},
+		func(context.Context) error { return nil },
+	)
+	var errFraud *fraud.ErrFraudExists
+	require.ErrorAs(t, err, &errFraud)
+	require.True(t, serv.canceled)
+}
+
+// testService is a fraud.Service delivering proofs sent over its channel to the subscribers.
+type testService struct {
+	fraud.Service
+
+	stored   []fraud.Proof
+	proofs   chan fraud.Proof
+	canceled bool
+}
+
+func newTestService() *testService {
+	return &testService{proofs: make(chan fraud.Proof)}
+}
+
+func (s *testService) Get(context.Context, fraud.ProofType) ([]fraud.Proof, error) {
+	if len(s.stored) == 0 {
+		return nil, datastore.ErrNotFound
+	}
+	return s.stored, nil
+}
+
+func (s *testService) Subscribe(fraud.ProofType) (fraud.Subscription, error) {
+	return &testSubscription{serv: s}, nil
+}
+
+type testSubscription struct {
+	serv *testService
+}
+
+func (s *testSubscription) Proof(ctx context.Context) (fraud.Proof, error) {
+	select {
+	case p := <-s.serv.proofs:
+		return p, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *testSubscription) Cancel() {
+	s.serv.canceled = true
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/celestiaorg/celestia-node/libs/httpsrv"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
@@ -11,6 +12,8 @@ type Config struct {
 	Address string
 	Port    string
 	Enabled bool
+	// the HTTP server settings, e.g. to run the gateway behind a reverse proxy
+	httpsrv.Config
 }
 
 func DefaultConfig() Config {
@@ -19,6 +22,7 @@ func DefaultConfig() Config {
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
 		Port:    "26659",
 		Enabled: false,
+		Config:  httpsrv.DefaultConfig(),
 	}
 }
 
@@ -33,5 +37,8 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("gateway: invalid port: %s", err.Error())
 	}
+	if err = cfg.Config.Validate(); err != nil {
+		return fmt.Errorf("gateway: %w", err)
+	}
 	return nil
 }
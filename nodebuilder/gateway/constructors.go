@@ -21,6 +21,7 @@ func Handler(
 	handler.RegisterMiddleware(serv)
 }
 
-func Server(cfg *Config) *gateway.Server {
-	return gateway.NewServer(cfg.Address, cfg.Port)
+func Server(cfg *Config) (*gateway.Server, error) {
+	srv := gateway.NewServer(cfg.Address, cfg.Port)
+	return srv, srv.Configure(cfg.Config)
 }
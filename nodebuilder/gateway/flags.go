@@ -9,6 +9,8 @@ var (
 	enabledFlag = "gateway"
 	addrFlag    = "gateway.addr"
 	portFlag    = "gateway.port"
+	corsFlag    = "gateway.cors-origins"
+	proxiesFlag = "gateway.trusted-proxies"
 )
 
 // Flags gives a set of hardcoded node/gateway package flags.
@@ -30,6 +32,16 @@ func Flags() *flag.FlagSet {
 		"",
 		"Set a custom gateway port (default: 26659)",
 	)
+	flags.StringSlice(
+		corsFlag,
+		nil,
+		"Comma-separated origins allowed to make gateway requests from browsers, e.g. https://example.com, or * for any",
+	)
+	flags.StringSlice(
+		proxiesFlag,
+		nil,
+		"Comma-separated IPs or CIDRs of reverse proxies trusted to report the client address in X-Forwarded-For",
+	)
 
 	return flags
 }
@@ -52,4 +64,10 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if portVal != "" {
 		cfg.Port = portVal
 	}
+	if origins, err := cmd.Flags().GetStringSlice(corsFlag); err == nil && len(origins) > 0 {
+		cfg.CORSOrigins = origins
+	}
+	if proxies, err := cmd.Flags().GetStringSlice(proxiesFlag); err == nil && len(proxies) > 0 {
+		cfg.TrustedProxies = proxies
+	}
 }
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"github.com/gbrlsnchs/jwt/v3"
+
+	"github.com/celestiaorg/celestia-node/api/grpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+)
+
+// Handler registers the given services onto the gRPC Server.
+func Handler(
+	state state.Module,
+	share share.Module,
+	header header.Module,
+	blob blob.Module,
+	serv *grpc.Server,
+) {
+	grpc.NewHandler(state, share, header, blob).RegisterServices(serv)
+}
+
+// Server constructs the gRPC Server authenticating requests with the same tokens as the RPC.
+func Server(cfg *Config, signer jwt.Algorithm) *grpc.Server {
+	return grpc.NewServer(cfg.Address, cfg.Port, signer)
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	enabledFlag = "grpc"
+	addrFlag    = "grpc.addr"
+	portFlag    = "grpc.port"
+)
+
+// Flags gives a set of hardcoded node/grpc package flags.
+func Flags() *flag.FlagSet {
+	flags := &flag.FlagSet{}
+
+	flags.Bool(
+		enabledFlag,
+		false,
+		"Enables the gRPC server",
+	)
+	flags.String(
+		addrFlag,
+		"",
+		"Set a custom gRPC listen address (default: localhost)",
+	)
+	flags.String(
+		portFlag,
+		"",
+		"Set a custom gRPC port (default: 26662)",
+	)
+
+	return flags
+}
+
+// ParseFlags parses gRPC flags from the given cmd and saves them to the passed config.
+func ParseFlags(cmd *cobra.Command, cfg *Config) {
+	enabled, err := cmd.Flags().GetBool(enabledFlag)
+	if err == nil {
+		cfg.Enabled = enabled
+	}
+	addr, port := cmd.Flag(addrFlag), cmd.Flag(portFlag)
+	if !enabled && (addr.Changed || port.Changed) {
+		log.Warn("custom address or port provided without enabling gRPC, setting config values")
+	}
+	addrVal := addr.Value.String()
+	if addrVal != "" {
+		cfg.Address = addrVal
+	}
+	portVal := port.Value.String()
+	if portVal != "" {
+		cfg.Port = portVal
+	}
+}
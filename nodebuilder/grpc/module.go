@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/api/grpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+var log = logging.Logger("module/grpc")
+
+func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if !cfg.Enabled {
+		return fx.Options()
+	}
+
+	switch tp {
+	case node.Light, node.Full, node.Bridge:
+		return fx.Module(
+			"grpc",
+			fx.Supply(cfg),
+			fx.Error(cfgErr),
+			fx.Provide(fx.Annotate(
+				Server,
+				fx.OnStart(func(ctx context.Context, server *grpc.Server) error {
+					return server.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, server *grpc.Server) error {
+					return server.Stop(ctx)
+				}),
+			)),
+			fx.Invoke(Handler),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
@@ -0,0 +1,96 @@
+package header
+
+import (
+	"context"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/ntp"
+)
+
+// ntpTimeout bounds a single estimation of the offset of the local clock.
+var ntpTimeout = 5 * time.Second
+
+// clockEstimator estimates the offset of the local clock over NTP on the configured interval and
+// widens the drift the time of new headers is tolerated with by it, up to header.MaxClockOffset, so
+// that the nodes with slightly wrong clocks don't reject valid new headers as coming from the
+// future.
+type clockEstimator struct {
+	servers  []string
+	interval time.Duration
+	clock    *header.Clock
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newClockEstimator(cfg Config, clock *header.Clock) *clockEstimator {
+	return &clockEstimator{
+		servers:  cfg.NTPServers,
+		interval: cfg.ClockSyncInterval,
+		clock:    clock,
+	}
+}
+
+// Start schedules the estimations, if they are enabled. The first one runs right away in the
+// background, not to delay the start of the node while the servers are unreachable.
+func (ce *clockEstimator) Start(context.Context) error {
+	if ce.interval == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ce.cancel, ce.done = cancel, make(chan struct{})
+	go ce.loop(ctx)
+	return nil
+}
+
+// Stop stops scheduling the estimations and waits for the ongoing one to finish.
+func (ce *clockEstimator) Stop(ctx context.Context) error {
+	if ce.cancel == nil {
+		return nil
+	}
+
+	ce.cancel()
+	select {
+	case <-ce.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (ce *clockEstimator) loop(ctx context.Context) {
+	defer close(ce.done)
+
+	ticker := time.NewTicker(ce.interval)
+	defer ticker.Stop()
+	for {
+		ce.estimate(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ce *clockEstimator) estimate(ctx context.Context) {
+	offset, err := ntp.Estimate(ctx, ce.servers, ntpTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			// keep the last estimate, as the clock is unlikely to drift fast
+			log.Warnw("estimating clock offset", "err", err)
+		}
+		return
+	}
+
+	ce.clock.SetOffset(offset)
+	if drift := ce.clock.Drift(); offset > drift || offset < -drift {
+		log.Warnw("local clock is off beyond the tolerated drift, new headers may be rejected;"+
+			" synchronize the system clock, e.g. with chrony or systemd-timesyncd",
+			"offset", offset, "clock_drift", drift)
+	} else {
+		log.Debugw("estimated clock offset", "offset", offset)
+	}
+}
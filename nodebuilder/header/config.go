@@ -3,33 +3,104 @@ package header
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/multiformats/go-multiaddr"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/gateway"
 	"github.com/celestiaorg/celestia-node/header/store"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
+// genesisHeight is the height of the genesis header.
+const genesisHeight = 1
+
 // Config contains configuration parameters for header retrieval and management.
 type Config struct {
 	// TrustedHash is the Block/Header hash that Nodes use as starting point for header synchronization.
 	// Only affects the node once on initial sync.
 	TrustedHash string
+	// TrustedHeight is the height of the TrustedHash header. If set, the node verifies the network
+	// serves the header at the height, so that a light node can be initialized from a recent header
+	// instead of the genesis one and start in seconds.
+	TrustedHeight uint64
+	// GenesisHash is the hash of the genesis header of the network, which the node verifies the
+	// network serves before accepting anything else from it, if no TrustedHash is configured.
+	// Defaults to the hardcoded genesis hash of the network.
+	GenesisHash string
+	// ReinitHash is the hash of a recent header the node re-initializes header synchronization from
+	// once its latest header is older than the trusting period, e.g. after being offline for long.
+	// If empty, the node re-initializes from the latest header of the TrustedPeers.
+	ReinitHash string
+	// SyncTargetHeight is the height the node syncs headers up to and stops at, instead of
+	// following the head of the network, e.g. for reproducible analysis of the chain as of the
+	// height. If 0, the node follows the head of the network.
+	SyncTargetHeight uint64
+	// TrustedSources are the sources the header synchronization is initialized from instead of the
+	// TrustedHash, once TrustedSourcesQuorum of them agree on the header, so that a single
+	// compromised source can't make the node trust another chain. The sources are the multiaddrs of
+	// peers, including the peer ID, or the HTTPS endpoints of gateways.
+	// Only affects the node once on initial sync.
+	TrustedSources []string
+	// TrustedSourcesQuorum is the amount of the TrustedSources which must agree on the header.
+	// If 0, the majority of them.
+	TrustedSourcesQuorum int
 	// TrustedPeers are the peers we trust to fetch headers from.
 	// Note: The trusted does *not* imply Headers are not verified, but trusted as reliable to fetch
 	// headers at any moment.
 	TrustedPeers []string
+	// DrainTimeout is the time the header exchange server waits for in-flight requests to be
+	// served on shutdown.
+	DrainTimeout time.Duration
+	// ProtocolDeprecation is the notice the header exchange server includes in its responses once
+	// the served protocol version is deprecated, e.g. as the network moves to a newer one, so that
+	// the requesting peers warn their operators to upgrade. Empty, if not deprecated.
+	// Only the header exchange carries the notice, as shares are exchanged over Bitswap, whose
+	// protocol is not versioned by the node.
+	ProtocolDeprecation string
+	// VerifyConcurrency is the number of headers requested in a range whose commit signatures are
+	// verified in parallel. If 0, it's the number of CPU cores.
+	VerifyConcurrency int
+	// FallbackGateways are the HTTPS endpoints of the gateways of trusted nodes headers are
+	// requested from whenever requesting them over libp2p fails, e.g. behind firewalls blocking
+	// libp2p. The headers are verified the same way as the ones from TrustedPeers.
+	FallbackGateways []string
+	// ClockDrift is how much a new header's time can drift into the future relative to the local
+	// time, before the header is rejected as coming from the future.
+	ClockDrift time.Duration
+	// NTPServers are the SNTP servers the offset of the local clock is estimated with. While the
+	// local clock is behind, the ClockDrift is widened by the offset, up to 5s, so that the nodes
+	// with slightly wrong clocks don't reject valid new headers.
+	NTPServers []string
+	// ClockSyncInterval is the interval the offset of the local clock is re-estimated on.
+	// If 0, the offset is not estimated and the local clock is trusted as is.
+	ClockSyncInterval time.Duration
 
 	Store *store.Parameters
 }
 
 func DefaultConfig() Config {
 	return Config{
-		TrustedHash:  "",
-		TrustedPeers: make([]string, 0),
-		Store:        store.DefaultParameters(),
+		TrustedHash:          "",
+		TrustedHeight:        0,
+		GenesisHash:          "",
+		ReinitHash:           "",
+		SyncTargetHeight:     0,
+		TrustedSources:       make([]string, 0),
+		TrustedSourcesQuorum: 0,
+		TrustedPeers:         make([]string, 0),
+		DrainTimeout:         time.Second * 5,
+		ProtocolDeprecation:  "",
+		VerifyConcurrency:    0,
+		FallbackGateways:     make([]string, 0),
+		ClockDrift:           header.DefaultClockDrift,
+		NTPServers:           []string{"pool.ntp.org:123", "time.google.com:123", "time.cloudflare.com:123"},
+		ClockSyncInterval:    time.Minute * 15,
+		Store:                store.DefaultParameters(),
 	}
 }
 
@@ -54,22 +125,120 @@ func (cfg *Config) trustedPeers(bpeers p2p.Bootstrappers) (infos []peer.AddrInfo
 	return
 }
 
-func (cfg *Config) trustedHash(net p2p.Network) (tmbytes.HexBytes, error) {
-	if cfg.TrustedHash == "" {
-		gen, err := p2p.GenesisFor(net)
+// trustedHash reports the hash of the header header synchronization is initialized from and the
+// height the header is expected at, which is 0 if unknown. The header is the genesis one, unless
+// the TrustedHash is configured.
+func (cfg *Config) trustedHash(net p2p.Network) (uint64, tmbytes.HexBytes, error) {
+	if cfg.TrustedHash != "" {
+		hash, err := hex.DecodeString(cfg.TrustedHash)
+		return cfg.TrustedHeight, hash, err
+	}
+
+	gen := cfg.GenesisHash
+	if gen == "" {
+		var err error
+		gen, err = p2p.GenesisFor(net)
 		if err != nil {
-			return nil, err
+			return 0, nil, err
 		}
-		return hex.DecodeString(gen)
 	}
-	return hex.DecodeString(cfg.TrustedHash)
+	hash, err := hex.DecodeString(gen)
+	return genesisHeight, hash, err
+}
+
+// trustedSourcesQuorum returns the amount of the TrustedSources which must agree on the header.
+func (cfg *Config) trustedSourcesQuorum() int {
+	if cfg.TrustedSourcesQuorum != 0 {
+		return cfg.TrustedSourcesQuorum
+	}
+	return len(cfg.TrustedSources)/2 + 1
+}
+
+// parseTrustedSource parses the trusted source either as the HTTPS endpoint of a gateway or as the
+// multiaddr of a peer.
+func parseTrustedSource(source string) (endpoint string, info *peer.AddrInfo, err error) {
+	if strings.HasPrefix(source, "https://") {
+		return source, nil, gateway.ValidateEndpoint(source)
+	}
+	ma, err := multiaddr.NewMultiaddr(source)
+	if err != nil {
+		return "", nil, err
+	}
+	info, err = peer.AddrInfoFromP2pAddr(ma)
+	return "", info, err
+}
+
+func (cfg *Config) reinitHash() (tmbytes.HexBytes, error) {
+	if cfg.ReinitHash == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(cfg.ReinitHash)
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
+	if _, err := hex.DecodeString(cfg.TrustedHash); err != nil {
+		return fmt.Errorf("module/header: invalid trusted hash: %w", err)
+	}
+	if cfg.TrustedHeight != 0 && cfg.TrustedHash == "" {
+		return fmt.Errorf("module/header: trusted height requires trusted hash")
+	}
+	if _, err := hex.DecodeString(cfg.GenesisHash); err != nil {
+		return fmt.Errorf("module/header: invalid genesis hash: %w", err)
+	}
+	if _, err := cfg.reinitHash(); err != nil {
+		return fmt.Errorf("module/header: invalid reinit hash: %w", err)
+	}
+	if cfg.SyncTargetHeight != 0 && cfg.TrustedHeight > cfg.SyncTargetHeight {
+		return fmt.Errorf("module/header: sync target height %d is below trusted height %d",
+			cfg.SyncTargetHeight, cfg.TrustedHeight)
+	}
+	if len(cfg.TrustedSources) != 0 && cfg.TrustedHash != "" {
+		return fmt.Errorf("module/header: trusted sources can't be used along with trusted hash")
+	}
+	if cfg.TrustedSourcesQuorum < 0 || cfg.TrustedSourcesQuorum > len(cfg.TrustedSources) {
+		return fmt.Errorf("module/header: trusted sources quorum %d out of range of %d sources",
+			cfg.TrustedSourcesQuorum, len(cfg.TrustedSources))
+	}
+	// the same source listed twice would vote twice
+	sources := make(map[string]struct{}, len(cfg.TrustedSources))
+	for _, source := range cfg.TrustedSources {
+		endpoint, info, err := parseTrustedSource(source)
+		if err != nil {
+			return fmt.Errorf("module/header: invalid trusted source: %w", err)
+		}
+		key := endpoint
+		if info != nil {
+			key = info.ID.String()
+		}
+		if _, ok := sources[key]; ok {
+			return fmt.Errorf("module/header: duplicate trusted source %s", key)
+		}
+		sources[key] = struct{}{}
+	}
 	err := cfg.Store.Validate()
 	if err != nil {
 		return fmt.Errorf("module/header: misconfiguration of store: %w", err)
 	}
+	if cfg.DrainTimeout <= 0 {
+		return fmt.Errorf("module/header: drain timeout must be positive")
+	}
+	if cfg.VerifyConcurrency < 0 {
+		return fmt.Errorf("module/header: verify concurrency must not be negative")
+	}
+	if cfg.ClockDrift <= 0 {
+		return fmt.Errorf("module/header: clock drift must be positive")
+	}
+	if cfg.ClockSyncInterval < 0 {
+		return fmt.Errorf("module/header: clock sync interval must not be negative")
+	}
+	if cfg.ClockSyncInterval != 0 && len(cfg.NTPServers) == 0 {
+		return fmt.Errorf("module/header: clock sync requires NTP servers")
+	}
+	for _, endpoint := range cfg.FallbackGateways {
+		if err := gateway.ValidateEndpoint(endpoint); err != nil {
+			return fmt.Errorf("module/header: invalid fallback gateway: %w", err)
+		}
+	}
 	return nil
 }
@@ -2,28 +2,55 @@ package header
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/gateway"
 	"github.com/celestiaorg/celestia-node/header/p2p"
 	"github.com/celestiaorg/celestia-node/header/store"
 	"github.com/celestiaorg/celestia-node/header/sync"
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/libs/health"
 	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
-// newP2PServer constructs a new ExchangeServer using the given Network as a protocolID suffix.
-func newP2PServer(host host.Host, store header.Store, network modp2p.Network) *p2p.ExchangeServer {
-	return p2p.NewExchangeServer(host, store, string(network))
+// newP2PServer constructs a new ExchangeServer serving the header exchange protocol of the given
+// network.
+func newP2PServer(
+	cfg Config,
+	host host.Host,
+	store header.Store,
+	netID modp2p.NetworkID,
+) *p2p.ExchangeServer {
+	return p2p.NewExchangeServer(host, store, string(netID), p2p.WithDeprecation(cfg.ProtocolDeprecation))
+}
+
+// newP2PSubscriber constructs a new Subscriber to the header gossipsub topic of the given network.
+func newP2PSubscriber(ps *pubsub.PubSub, netID modp2p.NetworkID) *p2p.Subscriber {
+	return p2p.NewSubscriber(ps, string(netID))
 }
 
-// newP2PExchange constructs a new Exchange for headers.
-func newP2PExchange(cfg Config) func(modp2p.Bootstrappers, modp2p.Network, host.Host) (header.Exchange, error) {
-	return func(bpeers modp2p.Bootstrappers, network modp2p.Network, host host.Host) (header.Exchange, error) {
+// trustedPeersTag protects the connections with the trusted peers headers are requested from
+// from being pruned by ConnManager.
+const trustedPeersTag = "protected-trusted"
+
+// fallbackRequestTimeout bounds the requests to the FallbackGateways.
+const fallbackRequestTimeout = time.Minute
+
+// newP2PExchange constructs a new Exchange for headers, falling back to the FallbackGateways, if
+// any.
+func newP2PExchange(cfg Config) func(modp2p.Bootstrappers, modp2p.NetworkID, host.Host) (header.Exchange, error) {
+	return func(bpeers modp2p.Bootstrappers, netID modp2p.NetworkID, host host.Host) (header.Exchange, error) {
 		peers, err := cfg.trustedPeers(bpeers)
 		if err != nil {
 			return nil, err
@@ -32,13 +59,113 @@ func newP2PExchange(cfg Config) func(modp2p.Bootstrappers, modp2p.Network, host.
 		for index, peer := range peers {
 			ids[index] = peer.ID
 			host.Peerstore().AddAddrs(peer.ID, peer.Addrs, peerstore.PermanentAddrTTL)
+			host.ConnManager().Protect(peer.ID, trustedPeersTag)
+		}
+		var opts []p2p.Option
+		// the exchange verifies with all the CPU cores by default
+		if cfg.VerifyConcurrency > 0 {
+			opts = append(opts, p2p.WithVerifyConcurrency(cfg.VerifyConcurrency))
+		}
+		ex, err := p2p.NewExchange(host, ids, string(netID), opts...)
+		if err != nil || len(cfg.FallbackGateways) == 0 {
+			return ex, err
+		}
+
+		fallback, err := gateway.NewExchange(cfg.FallbackGateways, &http.Client{Timeout: fallbackRequestTimeout})
+		if err != nil {
+			return nil, err
+		}
+		return &fallbackExchange{primary: ex, fallback: fallback}, nil
+	}
+}
+
+// trustedSources are the Exchanges of the TrustedSources, each requesting headers from a single
+// source only.
+type trustedSources []header.Exchange
+
+// trustedSourcesTag protects the connections with the trusted peer sources from being pruned by
+// ConnManager, while the headers are requested from them.
+const trustedSourcesTag = "protected-trusted-sources"
+
+// newTrustedSources constructs the Exchanges of the TrustedSources.
+func newTrustedSources(
+	lc fx.Lifecycle,
+	cfg Config,
+	netID modp2p.NetworkID,
+	host host.Host,
+) (trustedSources, error) {
+	sources := make(trustedSources, len(cfg.TrustedSources))
+	peers := make([]peer.ID, 0, len(cfg.TrustedSources))
+	for i, source := range cfg.TrustedSources {
+		endpoint, info, err := parseTrustedSource(source)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			sources[i], err = gateway.NewExchange([]string{endpoint}, &http.Client{Timeout: fallbackRequestTimeout})
+		} else {
+			host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+			sources[i], err = p2p.NewExchange(host, peer.IDSlice{info.ID}, string(netID))
+			peers = append(peers, info.ID)
+		}
+		if err != nil {
+			return nil, err
 		}
-		return p2p.NewExchange(host, ids, string(network)), nil
 	}
+
+	// the p2p Exchanges have no lifecycle of their own, so the connections of their sources are
+	// protected while the node runs, which is when the store is initialized from them
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			for _, id := range peers {
+				host.ConnManager().Protect(id, trustedSourcesTag)
+			}
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			for _, id := range peers {
+				host.ConnManager().Unprotect(id, trustedSourcesTag)
+			}
+			return nil
+		},
+	})
+	return sources, nil
 }
 
 // newSyncer constructs new Syncer for headers.
-func newSyncer(ex header.Exchange, store initStore, sub header.Subscriber, duration time.Duration) *sync.Syncer {
+func newSyncer(
+	cfg Config,
+	ex header.Exchange,
+	store initStore,
+	sub header.Subscriber,
+	duration time.Duration,
+	checker *health.Registry,
+	ds datastore.Batching,
+	bus *events.Bus,
+	clock *header.Clock,
+) (*sync.Syncer, error) {
+	reinitHash, err := cfg.reinitHash()
+	if err != nil {
+		return nil, err
+	}
+	syncer := sync.NewSyncer(ex, store, sub, duration,
+		sync.WithReinitHash(reinitHash),
+		sync.WithDatastore(ds),
+		sync.WithEvents(bus),
+		sync.WithTargetHeight(cfg.SyncTargetHeight),
+		sync.WithClock(clock),
+	)
+	return syncer, checker.Register("syncer", syncer)
+}
+
+// newReadOnlySyncer constructs a Syncer for headers, which is never started, so that it only reports
+// the state of the store.
+func newReadOnlySyncer(
+	ex header.Exchange,
+	store initStore,
+	sub header.Subscriber,
+	duration time.Duration,
+) *sync.Syncer {
 	return sync.NewSyncer(ex, store, sub, duration)
 }
 
@@ -53,15 +180,39 @@ func newInitStore(
 	net modp2p.Network,
 	s header.Store,
 	ex header.Exchange,
+	sources trustedSources,
 ) (initStore, error) {
-	trustedHash, err := cfg.trustedHash(net)
+	height, trustedHash, err := cfg.trustedHash(net)
 	if err != nil {
 		return nil, err
 	}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			err = store.Init(ctx, s, ex, trustedHash)
+			if len(sources) != 0 {
+				err := store.InitWithQuorum(ctx, s, sources, cfg.trustedSourcesQuorum())
+				if err != nil {
+					return fmt.Errorf("module/header: initializing from trusted sources: %w: ensure "+
+						"Header.TrustedSources are reachable and serve network %s", err, net)
+				}
+				return nil
+			}
+			if len(trustedHash) == 0 {
+				log.Warnw("no trusted or genesis hash is known for the network, header store is not initialized",
+					"network", net)
+				return nil
+			}
+
+			if height != 0 {
+				err = store.InitAtHeight(ctx, s, ex, height, trustedHash)
+			} else {
+				err = store.Init(ctx, s, ex, trustedHash)
+			}
+			if errors.Is(err, store.ErrTrustedHashMismatch) {
+				// the network serves another chain, so nothing can be accepted from it
+				return fmt.Errorf("%w: ensure Header.TrustedHash or Header.GenesisHash belongs to network %s",
+					err, net)
+			}
 			if err != nil {
 				// TODO(@Wondertan): Error is ignored, as otherwise unit tests for Node construction fail.
 				// 	This is due to requesting step of initialization, which fetches initial Header by trusted hash from
@@ -77,3 +228,18 @@ func newInitStore(
 
 	return s, nil
 }
+
+// newReadOnlyStore constructs a store, which must have been initialized before, as a read-only
+// node can't request the headers to initialize it from.
+func newReadOnlyStore(lc fx.Lifecycle, s header.Store) initStore {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			_, err := s.Head(ctx)
+			if err != nil {
+				return fmt.Errorf("module/header: read-only node requires an initialized header store: %w", err)
+			}
+			return nil
+		},
+	})
+	return s
+}
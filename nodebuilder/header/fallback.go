@@ -0,0 +1,60 @@
+package header
+
+import (
+	"context"
+	"errors"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// fallbackExchange requests headers from the fallback Exchange whenever the primary one fails,
+// e.g. from the gateways of trusted nodes when libp2p connectivity is blocked.
+type fallbackExchange struct {
+	primary, fallback header.Exchange
+}
+
+func (fe *fallbackExchange) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	return withFallback(ctx, "head", func(ex header.Exchange) (*header.ExtendedHeader, error) {
+		return ex.Head(ctx)
+	}, fe.primary, fe.fallback)
+}
+
+func (fe *fallbackExchange) Get(ctx context.Context, hash tmbytes.HexBytes) (*header.ExtendedHeader, error) {
+	return withFallback(ctx, "get", func(ex header.Exchange) (*header.ExtendedHeader, error) {
+		return ex.Get(ctx, hash)
+	}, fe.primary, fe.fallback)
+}
+
+func (fe *fallbackExchange) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return withFallback(ctx, "get by height", func(ex header.Exchange) (*header.ExtendedHeader, error) {
+		return ex.GetByHeight(ctx, height)
+	}, fe.primary, fe.fallback)
+}
+
+func (fe *fallbackExchange) GetRangeByHeight(
+	ctx context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return withFallback(ctx, "get range", func(ex header.Exchange) ([]*header.ExtendedHeader, error) {
+		return ex.GetRangeByHeight(ctx, from, amount)
+	}, fe.primary, fe.fallback)
+}
+
+// withFallback performs the request with the primary Exchange and retries it with the fallback one
+// on failure, unless the request is canceled.
+func withFallback[T any](
+	ctx context.Context,
+	op string,
+	request func(header.Exchange) (T, error),
+	primary, fallback header.Exchange,
+) (T, error) {
+	res, err := request(primary)
+	if err == nil || errors.Is(err, context.Canceled) || ctx.Err() != nil {
+		return res, err
+	}
+
+	log.Warnw("header request failed, falling back to gateways", "op", op, "err", err)
+	return request(fallback)
+}
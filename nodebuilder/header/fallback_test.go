@@ -0,0 +1,45 @@
+package header
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/local"
+	"github.com/celestiaorg/celestia-node/header/store"
+)
+
+func TestFallbackExchange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	fallback := local.NewExchange(store.NewTestStore(ctx, t, suite.Head()))
+	ex := &fallbackExchange{primary: failingExchange{}, fallback: fallback}
+
+	head, err := ex.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, suite.Head().Hash(), head.Hash())
+
+	// canceled requests are not retried
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = ex.Head(canceled)
+	assert.ErrorIs(t, err, errFailingExchange)
+}
+
+var errFailingExchange = errors.New("failing exchange")
+
+// failingExchange fails all the requests, as if libp2p connectivity was blocked.
+type failingExchange struct {
+	header.Exchange
+}
+
+func (failingExchange) Head(context.Context) (*header.ExtendedHeader, error) {
+	return nil, errFailingExchange
+}
@@ -7,11 +7,20 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+
+	"github.com/celestiaorg/celestia-node/header/gateway"
 )
 
 var (
-	headersTrustedHashFlag  = "headers.trusted-hash"
-	headersTrustedPeersFlag = "headers.trusted-peers"
+	headersTrustedHashFlag   = "headers.trusted-hash"
+	headersTrustedHeightFlag = "headers.trusted-height"
+	headersTrustedPeersFlag  = "headers.trusted-peers"
+	headersReinitHashFlag    = "headers.reinit-hash"
+	headersGenesisHashFlag   = "headers.genesis-hash"
+	headersFallbackFlag      = "headers.fallback-gateways"
+	headersSourcesFlag       = "headers.trusted-sources"
+	headersQuorumFlag        = "headers.trusted-sources-quorum"
+	syncTargetHeightFlag     = "sync.target-height"
 )
 
 // Flags gives a set of hardcoded Header package flags.
@@ -20,6 +29,12 @@ func Flags() *flag.FlagSet {
 
 	flags.AddFlagSet(TrustedPeersFlags())
 	flags.AddFlagSet(TrustedHashFlags())
+	flags.Uint64(
+		syncTargetHeightFlag,
+		0,
+		"Height to sync headers up to and stop at, instead of following the head of the network. "+
+			"Used to analyze the chain as of the height reproducibly",
+	)
 
 	return flags
 }
@@ -32,6 +47,13 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) error {
 	if err := ParseTrustedPeerFlags(cmd, cfg); err != nil {
 		return err
 	}
+	if cmd.Flag(syncTargetHeightFlag).Changed {
+		height, err := cmd.Flags().GetUint64(syncTargetHeightFlag)
+		if err != nil {
+			return err
+		}
+		cfg.SyncTargetHeight = height
+	}
 
 	return nil
 }
@@ -44,6 +66,11 @@ func TrustedPeersFlags() *flag.FlagSet {
 		nil,
 		"Multiaddresses of a reliable peers to fetch headers from. (Format: multiformats.io/multiaddr)",
 	)
+	flags.StringSlice(
+		headersFallbackFlag,
+		nil,
+		"HTTPS endpoints of the gateways of trusted nodes to fetch headers from when fetching them from peers fails",
+	)
 	return flags
 }
 
@@ -65,6 +92,17 @@ func ParseTrustedPeerFlags(
 		}
 	}
 	cfg.TrustedPeers = append(cfg.TrustedPeers, tpeers...)
+
+	gateways, err := cmd.Flags().GetStringSlice(headersFallbackFlag)
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range gateways {
+		if err := gateway.ValidateEndpoint(endpoint); err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", headersFallbackFlag, err)
+		}
+	}
+	cfg.FallbackGateways = append(cfg.FallbackGateways, gateways...)
 	return nil
 }
 
@@ -77,6 +115,35 @@ func TrustedHashFlags() *flag.FlagSet {
 		"",
 		"Hex encoded header hash. Used to subjectively initialize header synchronization",
 	)
+	flags.Uint64(
+		headersTrustedHeightFlag,
+		0,
+		"Height of the header of the trusted hash. Initializes header synchronization from the header at the "+
+			"height, e.g. a recent one got from a trusted node, instead of the genesis one",
+	)
+	flags.String(
+		headersReinitHashFlag,
+		"",
+		"Hex encoded hash of a recent header. Used to re-initialize header synchronization once the latest "+
+			"synced header is older than the trusting period",
+	)
+	flags.String(
+		headersGenesisHashFlag,
+		"",
+		"Hex encoded hash of the genesis header of the network. Verified to be served by the network on initial "+
+			"header synchronization, unless a trusted hash is given. Defaults to the genesis hash of the network",
+	)
+	flags.StringSlice(
+		headersSourcesFlag,
+		nil,
+		"Multiaddresses of peers or HTTPS endpoints of gateways to initialize header synchronization from, "+
+			"instead of a trusted hash, once the quorum of them agree on the header",
+	)
+	flags.Int(
+		headersQuorumFlag,
+		0,
+		"Amount of the trusted sources which must agree on the header. Defaults to the majority of them",
+	)
 	return flags
 }
 
@@ -95,5 +162,53 @@ func ParseTrustedHashFlags(
 
 		cfg.TrustedHash = hash
 	}
+	if cmd.Flag(headersTrustedHeightFlag).Changed {
+		height, err := cmd.Flags().GetUint64(headersTrustedHeightFlag)
+		if err != nil {
+			return err
+		}
+		if cfg.TrustedHash == "" {
+			return fmt.Errorf("cmd: '%s' requires '%s'", headersTrustedHeightFlag, headersTrustedHashFlag)
+		}
+		cfg.TrustedHeight = height
+	}
+
+	hash = cmd.Flag(headersReinitHashFlag).Value.String()
+	if hash != "" {
+		_, err := hex.DecodeString(hash)
+		if err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", headersReinitHashFlag, err)
+		}
+
+		cfg.ReinitHash = hash
+	}
+
+	hash = cmd.Flag(headersGenesisHashFlag).Value.String()
+	if hash != "" {
+		_, err := hex.DecodeString(hash)
+		if err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", headersGenesisHashFlag, err)
+		}
+
+		cfg.GenesisHash = hash
+	}
+
+	sources, err := cmd.Flags().GetStringSlice(headersSourcesFlag)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		if _, _, err := parseTrustedSource(source); err != nil {
+			return fmt.Errorf("cmd: while parsing '%s' with source '%s': %w", headersSourcesFlag, source, err)
+		}
+	}
+	cfg.TrustedSources = append(cfg.TrustedSources, sources...)
+	if cmd.Flag(headersQuorumFlag).Changed {
+		quorum, err := cmd.Flags().GetInt(headersQuorumFlag)
+		if err != nil {
+			return err
+		}
+		cfg.TrustedSourcesQuorum = quorum
+	}
 	return nil
 }
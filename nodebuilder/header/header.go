@@ -2,8 +2,10 @@ package header
 
 import (
 	"context"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/sync"
 )
 
 // Module exposes the functionality needed for querying headers from the network.
@@ -14,16 +16,121 @@ type Module interface {
 	// GetByHeight returns the ExtendedHeader at the given height, blocking
 	// until header has been processed by the store or context deadline is exceeded.
 	GetByHeight(context.Context, uint64) (*header.ExtendedHeader, error)
-	// Head returns the ExtendedHeader of the chain head.
+	// GetByTime returns the ExtendedHeader of the latest block produced at or before the given time,
+	// out of the locally stored ones.
+	GetByTime(context.Context, time.Time) (*header.ExtendedHeader, error)
+	// Head returns the ExtendedHeader of the local chain head, i.e. the latest synced one.
 	Head(context.Context) (*header.ExtendedHeader, error)
+	// NetworkHead returns the ExtendedHeader of the network chain head, i.e. the highest one seen
+	// from the network, which may be ahead of Head while the node is syncing.
+	NetworkHead(context.Context) (*header.ExtendedHeader, error)
 	// IsSyncing returns the status of sync
-	IsSyncing() bool
+	IsSyncing(context.Context) bool
+	// SyncWait blocks until the node has synced up to the network chain head, as known at the moment
+	// of the call, or the context is done.
+	SyncWait(context.Context) error
+	// SyncState returns the state of the current or the latest sync, including both the local and
+	// the network chain head heights.
+	SyncState(context.Context) (sync.State, error)
+	// SetSyncTargetHeight changes the height header synchronization stops at, overriding the
+	// Header.SyncTargetHeight, or makes it follow the network chain head again if zero. The height
+	// can't be below the headers already synced or being synced to.
+	SetSyncTargetHeight(ctx context.Context, height uint64) error
+	// SubscribeHeaders subscribes to the ExtendedHeaders validated from the network.
+	// The returned channel is closed once the given context is canceled.
+	SubscribeHeaders(context.Context) (<-chan *header.ExtendedHeader, error)
+	// ValidatorSet returns the page with the given 1-based number of the validator set the block at
+	// the given height, or the local chain head if zero, is signed by. The perPage defaults to 30 if
+	// zero and is capped at 100. The changes of the set can be tracked by its hash and the hash of
+	// the next set.
+	ValidatorSet(ctx context.Context, height uint64, page, perPage int) (*header.ValidatorsPage, error)
+	// LightBlock exports the header at the given height, or the local chain head if zero, along with
+	// the commit and the validator set signing it, for external verifiers to check it independently.
+	LightBlock(ctx context.Context, height uint64) (*header.LightBlock, error)
+	// TrustedHeader returns the height and the hash of the local chain head, which a node trusting
+	// this one can initialize header synchronization from, instead of the genesis header, by setting
+	// them as its Header.TrustedHeight and Header.TrustedHash.
+	TrustedHeader(context.Context) (*TrustedHeader, error)
+}
+
+// TrustedHeader identifies a header to initialize header synchronization from.
+type TrustedHeader struct {
+	Height uint64 `json:"height"`
+	// Hash is hex encoded, as the Header.TrustedHash expects it.
+	Hash string `json:"hash"`
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
-	GetByHeight func(context.Context, uint64) (*header.ExtendedHeader, error)
-	Head        func(context.Context) (*header.ExtendedHeader, error)
-	IsSyncing   func() bool
+	Internal struct {
+		GetByHeight         func(context.Context, uint64) (*header.ExtendedHeader, error)    `perm:"read"`
+		GetByTime           func(context.Context, time.Time) (*header.ExtendedHeader, error) `perm:"read"`
+		Head                func(context.Context) (*header.ExtendedHeader, error)            `perm:"read"`
+		NetworkHead         func(context.Context) (*header.ExtendedHeader, error)            `perm:"read"`
+		IsSyncing           func(context.Context) bool                                       `perm:"read"`
+		SyncWait            func(context.Context) error                                      `perm:"read"`
+		SyncState           func(context.Context) (sync.State, error)                        `perm:"read"`
+		SetSyncTargetHeight func(ctx context.Context, height uint64) error                   `perm:"admin"`
+		SubscribeHeaders    func(context.Context) (<-chan *header.ExtendedHeader, error)     `perm:"read"`
+		ValidatorSet        func(
+			ctx context.Context,
+			height uint64,
+			page, perPage int,
+		) (*header.ValidatorsPage, error) `perm:"read"`
+		LightBlock    func(ctx context.Context, height uint64) (*header.LightBlock, error) `perm:"read"`
+		TrustedHeader func(context.Context) (*TrustedHeader, error)                        `perm:"read"`
+	}
+}
+
+func (api *API) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return api.Internal.GetByHeight(ctx, height)
+}
+
+func (api *API) GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	return api.Internal.GetByTime(ctx, t)
+}
+
+func (api *API) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	return api.Internal.Head(ctx)
+}
+
+func (api *API) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	return api.Internal.NetworkHead(ctx)
+}
+
+func (api *API) IsSyncing(ctx context.Context) bool {
+	return api.Internal.IsSyncing(ctx)
+}
+
+func (api *API) SyncWait(ctx context.Context) error {
+	return api.Internal.SyncWait(ctx)
+}
+
+func (api *API) SyncState(ctx context.Context) (sync.State, error) {
+	return api.Internal.SyncState(ctx)
+}
+
+func (api *API) SetSyncTargetHeight(ctx context.Context, height uint64) error {
+	return api.Internal.SetSyncTargetHeight(ctx, height)
+}
+
+func (api *API) SubscribeHeaders(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+	return api.Internal.SubscribeHeaders(ctx)
+}
+
+func (api *API) ValidatorSet(
+	ctx context.Context,
+	height uint64,
+	page, perPage int,
+) (*header.ValidatorsPage, error) {
+	return api.Internal.ValidatorSet(ctx, height, page, perPage)
+}
+
+func (api *API) LightBlock(ctx context.Context, height uint64) (*header.LightBlock, error) {
+	return api.Internal.LightBlock(ctx, height)
+}
+
+func (api *API) TrustedHeader(ctx context.Context) (*TrustedHeader, error) {
+	return api.Internal.TrustedHeader(ctx)
 }
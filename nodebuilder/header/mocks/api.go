@@ -7,10 +7,13 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 
 	header "github.com/celestiaorg/celestia-node/header"
+	sync "github.com/celestiaorg/celestia-node/header/sync"
+	header0 "github.com/celestiaorg/celestia-node/nodebuilder/header"
 )
 
 // MockModule is a mock of Module interface.
@@ -51,6 +54,21 @@ func (mr *MockModuleMockRecorder) GetByHeight(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockModule)(nil).GetByHeight), arg0, arg1)
 }
 
+// GetByTime mocks base method.
+func (m *MockModule) GetByTime(arg0 context.Context, arg1 time.Time) (*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTime", arg0, arg1)
+	ret0, _ := ret[0].(*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTime indicates an expected call of GetByTime.
+func (mr *MockModuleMockRecorder) GetByTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTime", reflect.TypeOf((*MockModule)(nil).GetByTime), arg0, arg1)
+}
+
 // Head mocks base method.
 func (m *MockModule) Head(arg0 context.Context) (*header.ExtendedHeader, error) {
 	m.ctrl.T.Helper()
@@ -67,15 +85,133 @@ func (mr *MockModuleMockRecorder) Head(arg0 interface{}) *gomock.Call {
 }
 
 // IsSyncing mocks base method.
-func (m *MockModule) IsSyncing() bool {
+func (m *MockModule) IsSyncing(arg0 context.Context) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsSyncing")
+	ret := m.ctrl.Call(m, "IsSyncing", arg0)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // IsSyncing indicates an expected call of IsSyncing.
-func (mr *MockModuleMockRecorder) IsSyncing() *gomock.Call {
+func (mr *MockModuleMockRecorder) IsSyncing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSyncing", reflect.TypeOf((*MockModule)(nil).IsSyncing), arg0)
+}
+
+// LightBlock mocks base method.
+func (m *MockModule) LightBlock(arg0 context.Context, arg1 uint64) (*header.LightBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LightBlock", arg0, arg1)
+	ret0, _ := ret[0].(*header.LightBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LightBlock indicates an expected call of LightBlock.
+func (mr *MockModuleMockRecorder) LightBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LightBlock", reflect.TypeOf((*MockModule)(nil).LightBlock), arg0, arg1)
+}
+
+// NetworkHead mocks base method.
+func (m *MockModule) NetworkHead(arg0 context.Context) (*header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkHead", arg0)
+	ret0, _ := ret[0].(*header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetworkHead indicates an expected call of NetworkHead.
+func (mr *MockModuleMockRecorder) NetworkHead(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkHead", reflect.TypeOf((*MockModule)(nil).NetworkHead), arg0)
+}
+
+// SetSyncTargetHeight mocks base method.
+func (m *MockModule) SetSyncTargetHeight(arg0 context.Context, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSyncTargetHeight", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSyncTargetHeight indicates an expected call of SetSyncTargetHeight.
+func (mr *MockModuleMockRecorder) SetSyncTargetHeight(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncTargetHeight", reflect.TypeOf((*MockModule)(nil).SetSyncTargetHeight), arg0, arg1)
+}
+
+// SubscribeHeaders mocks base method.
+func (m *MockModule) SubscribeHeaders(arg0 context.Context) (<-chan *header.ExtendedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeHeaders", arg0)
+	ret0, _ := ret[0].(<-chan *header.ExtendedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeHeaders indicates an expected call of SubscribeHeaders.
+func (mr *MockModuleMockRecorder) SubscribeHeaders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeHeaders", reflect.TypeOf((*MockModule)(nil).SubscribeHeaders), arg0)
+}
+
+// SyncState mocks base method.
+func (m *MockModule) SyncState(arg0 context.Context) (sync.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncState", arg0)
+	ret0, _ := ret[0].(sync.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncState indicates an expected call of SyncState.
+func (mr *MockModuleMockRecorder) SyncState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncState", reflect.TypeOf((*MockModule)(nil).SyncState), arg0)
+}
+
+// SyncWait mocks base method.
+func (m *MockModule) SyncWait(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncWait", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncWait indicates an expected call of SyncWait.
+func (mr *MockModuleMockRecorder) SyncWait(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncWait", reflect.TypeOf((*MockModule)(nil).SyncWait), arg0)
+}
+
+// TrustedHeader mocks base method.
+func (m *MockModule) TrustedHeader(arg0 context.Context) (*header0.TrustedHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrustedHeader", arg0)
+	ret0, _ := ret[0].(*header0.TrustedHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrustedHeader indicates an expected call of TrustedHeader.
+func (mr *MockModuleMockRecorder) TrustedHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrustedHeader", reflect.TypeOf((*MockModule)(nil).TrustedHeader), arg0)
+}
+
+// ValidatorSet mocks base method.
+func (m *MockModule) ValidatorSet(arg0 context.Context, arg1 uint64, arg2, arg3 int) (*header.ValidatorsPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorSet", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*header.ValidatorsPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorSet indicates an expected call of ValidatorSet.
+func (mr *MockModuleMockRecorder) ValidatorSet(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSyncing", reflect.TypeOf((*MockModule)(nil).IsSyncing))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorSet", reflect.TypeOf((*MockModule)(nil).ValidatorSet), arg0, arg1, arg2, arg3)
 }
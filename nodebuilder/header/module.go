@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/local"
 	"github.com/celestiaorg/celestia-node/header/p2p"
 	"github.com/celestiaorg/celestia-node/header/store"
 	"github.com/celestiaorg/celestia-node/header/sync"
@@ -19,7 +21,10 @@ import (
 
 var log = logging.Logger("module/header")
 
-func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+// ConstructModule collects all the components and services related to headers.
+// If 'readOnly', headers are only served from the store, which must be initialized, and never
+// synced.
+func ConstructModule(tp node.Type, cfg *Config, readOnly bool) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
 
@@ -27,16 +32,19 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Supply(*cfg),
 		fx.Error(cfgErr),
 		fx.Supply(modp2p.BlockTime),
+		fx.Provide(func(cfg Config) *header.Clock {
+			return header.NewClock(cfg.ClockDrift)
+		}),
 		fx.Provide(
-			func(cfg Config) []store.Option {
+			func(cfg Config, clock *header.Clock) []store.Option {
 				return []store.Option{
 					store.WithStoreCacheSize(cfg.Store.StoreCacheSize),
 					store.WithIndexCacheSize(cfg.Store.IndexCacheSize),
 					store.WithWriteBatchSize(cfg.Store.WriteBatchSize),
+					store.WithClock(clock),
 				}
 			},
 		),
-		fx.Provide(NewHeaderService),
 		fx.Provide(fx.Annotate(
 			func(ds datastore.Batching, opts []store.Option) (header.Store, error) {
 				return store.NewStore(ds, opts...)
@@ -48,10 +56,50 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				return store.Stop(ctx)
 			}),
 		)),
+	)
+
+	subscriberComponents := fx.Provide(func(subscriber *p2p.Subscriber) header.Subscriber {
+		return subscriber
+	})
+
+	p2pComponents := fx.Options(
+		fx.Provide(NewHeaderService),
+		fx.Provide(fx.Annotate(
+			newP2PSubscriber,
+			fx.OnStart(func(ctx context.Context, sub *p2p.Subscriber) error {
+				return sub.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, sub *p2p.Subscriber) error {
+				return sub.Stop(ctx)
+			}),
+		)),
+		fx.Provide(fx.Annotate(
+			newP2PServer,
+			fx.OnStart(func(ctx context.Context, server *p2p.ExchangeServer) error {
+				return server.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, server *p2p.ExchangeServer) error {
+				ctx, cancel := context.WithTimeout(ctx, cfg.DrainTimeout)
+				defer cancel()
+				return server.Stop(ctx)
+			}),
+		)),
+	)
+
+	syncComponents := fx.Options(
+		// the clock is only estimated by the nodes syncing new headers, which it matters for
+		fx.Invoke(fx.Annotate(
+			newClockEstimator,
+			fx.OnStart(func(ctx context.Context, ce *clockEstimator) error {
+				return ce.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, ce *clockEstimator) error {
+				return ce.Stop(ctx)
+			}),
+		)),
+		fx.Provide(newTrustedSources),
 		fx.Provide(newInitStore),
-		fx.Provide(func(subscriber *p2p.Subscriber) header.Subscriber {
-			return subscriber
-		}),
+		subscriberComponents,
 		fx.Provide(fx.Annotate(
 			newSyncer,
 			fx.OnStart(func(startCtx, ctx context.Context, fservice fraud.Service, syncer *sync.Syncer) error {
@@ -62,6 +110,9 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 						return err
 					case header.ErrNoHead:
 						log.Warnw("Syncer running on uninitialized Store - headers won't be synced")
+					case sync.ErrOldTrustedHead:
+						return fmt.Errorf("%w: configure Header.TrustedPeers with synced peers or "+
+							"Header.ReinitHash with the hash of a recent header to re-initialize from", err)
 					case nil:
 					}
 					return nil
@@ -73,37 +124,44 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 				return syncer.Stop(ctx)
 			}),
 		)),
-		fx.Provide(fx.Annotate(
-			p2p.NewSubscriber,
-			fx.OnStart(func(ctx context.Context, sub *p2p.Subscriber) error {
-				return sub.Start(ctx)
-			}),
-			fx.OnStop(func(ctx context.Context, sub *p2p.Subscriber) error {
-				return sub.Stop(ctx)
-			}),
-		)),
-		fx.Provide(fx.Annotate(
-			newP2PServer,
-			fx.OnStart(func(ctx context.Context, server *p2p.ExchangeServer) error {
-				return server.Start(ctx)
-			}),
-			fx.OnStop(func(ctx context.Context, server *p2p.ExchangeServer) error {
-				return server.Stop(ctx)
-			}),
-		)),
 	)
 
 	switch tp {
 	case node.Light, node.Full:
+		if readOnly {
+			// neither subscribed to the network nor serving it
+			return fx.Module(
+				"header",
+				baseComponents,
+				fx.Provide(newReadOnlyStore),
+				fx.Supply(fx.Annotate(offlineSubscriber{}, fx.As(new(header.Subscriber)))),
+				fx.Provide(newReadOnlySyncer),
+				fx.Provide(func(
+					syncer *sync.Syncer,
+					sub header.Subscriber,
+					ex header.Exchange,
+					store header.Store,
+				) Module {
+					return NewHeaderService(syncer, sub, nil, ex, store)
+				}),
+				fx.Provide(func(store header.Store) header.Exchange {
+					return local.NewExchange(store)
+				}),
+			)
+		}
 		return fx.Module(
 			"header",
 			baseComponents,
+			syncComponents,
+			p2pComponents,
 			fx.Provide(newP2PExchange(*cfg)),
 		)
 	case node.Bridge:
 		return fx.Module(
 			"header",
 			baseComponents,
+			syncComponents,
+			p2pComponents,
 			fx.Provide(func(subscriber *p2p.Subscriber) header.Broadcaster {
 				return subscriber
 			}),
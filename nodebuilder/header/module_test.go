@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
@@ -11,6 +14,7 @@ import (
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/header/store"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	modp2p "github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 // TestConstructModule_StoreParams ensures that all passed via functional options
@@ -26,7 +30,7 @@ func TestConstructModule_StoreParams(t *testing.T) {
 		fx.Provide(func() datastore.Batching {
 			return datastore.NewMapDatastore()
 		}),
-		ConstructModule(node.Light, &cfg),
+		ConstructModule(node.Light, &cfg, false),
 		fx.Invoke(
 			func(s header.Store) {
 				ss := s.(*store.Store)
@@ -38,3 +42,108 @@ func TestConstructModule_StoreParams(t *testing.T) {
 	require.Equal(t, headerStore.Params.IndexCacheSize, cfg.Store.IndexCacheSize)
 	require.Equal(t, headerStore.Params.WriteBatchSize, cfg.Store.WriteBatchSize)
 }
+
+// TestNewP2PExchange_ProtectsTrustedPeers ensures the connections with trusted peers are never
+// pruned by the connection manager.
+func TestNewP2PExchange_ProtectsTrustedPeers(t *testing.T) {
+	cm, err := connmgr.NewConnManager(1, 2)
+	require.NoError(t, err)
+	h, err := libp2p.New(libp2p.ConnectionManager(cm), libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+	trusted, err := libp2p.New(libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, trusted.Close())
+	})
+
+	cfg := DefaultConfig()
+	bpeers := modp2p.Bootstrappers{peer.AddrInfo{ID: trusted.ID()}}
+	_, err = newP2PExchange(cfg)(bpeers, modp2p.NetworkID(modp2p.Private), h)
+	require.NoError(t, err)
+	require.True(t, h.ConnManager().IsProtected(trusted.ID(), trustedPeersTag))
+}
+
+// TestNewTrustedSources_ProtectsPeers ensures the connections with the trusted peer sources are
+// protected while the node runs.
+func TestNewTrustedSources_ProtectsPeers(t *testing.T) {
+	cm, err := connmgr.NewConnManager(1, 2)
+	require.NoError(t, err)
+	h, err := libp2p.New(libp2p.ConnectionManager(cm), libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+	source, err := libp2p.New(libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, source.Close())
+	})
+
+	cfg := DefaultConfig()
+	cfg.TrustedSources = []string{"/ip4/127.0.0.1/tcp/2121/p2p/" + source.ID().String()}
+	lc := fxtest.NewLifecycle(t)
+	sources, err := newTrustedSources(lc, cfg, modp2p.NetworkID(modp2p.Private), h)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	lc.RequireStart()
+	require.True(t, h.ConnManager().IsProtected(source.ID(), trustedSourcesTag))
+	lc.RequireStop()
+	require.False(t, h.ConnManager().IsProtected(source.ID(), trustedSourcesTag))
+}
+
+// TestConfig_DuplicateTrustedSources ensures the same source can't be listed twice, as it would
+// vote twice for the header.
+func TestConfig_DuplicateTrustedSources(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TrustedSources = []string{
+		"/ip4/127.0.0.1/tcp/2121/p2p/12D3KooWCFMYxGuhtaCSQMVnbK9JVzFsTsGSWQdUWDfN36oirXAJ",
+		"/dns4/source.example/tcp/2121/p2p/12D3KooWCFMYxGuhtaCSQMVnbK9JVzFsTsGSWQdUWDfN36oirXAJ",
+	}
+	require.Error(t, cfg.Validate())
+
+	cfg.TrustedSources = []string{"https://gateway.example", "https://gateway.example"}
+	require.Error(t, cfg.Validate())
+
+	cfg.TrustedSources = []string{
+		"/ip4/127.0.0.1/tcp/2121/p2p/12D3KooWCFMYxGuhtaCSQMVnbK9JVzFsTsGSWQdUWDfN36oirXAJ",
+		"https://gateway.example",
+	}
+	require.NoError(t, cfg.Validate())
+}
+
+// TestConfig_TrustedHash ensures that the genesis header is verified at its height, unless a
+// trusted hash is configured along with its height, if known.
+func TestConfig_TrustedHash(t *testing.T) {
+	cfg := DefaultConfig()
+	height, hash, err := cfg.trustedHash(modp2p.Arabica)
+	require.NoError(t, err)
+	require.EqualValues(t, genesisHeight, height)
+	require.Equal(t, "04EE55B212745B88F29943D7B9528C415473A211F12EEF6E9333EF32E4DEAF3C", hash.String())
+
+	cfg.GenesisHash = "AB"
+	_, hash, err = cfg.trustedHash(modp2p.Arabica)
+	require.NoError(t, err)
+	require.Equal(t, "AB", hash.String())
+
+	cfg.TrustedHash = "CD"
+	height, hash, err = cfg.trustedHash(modp2p.Arabica)
+	require.NoError(t, err)
+	require.Zero(t, height)
+	require.Equal(t, "CD", hash.String())
+
+	cfg.TrustedHeight = 100
+	height, _, err = cfg.trustedHash(modp2p.Arabica)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, height)
+
+	cfg.TrustedHash = ""
+	require.Error(t, cfg.Validate())
+	cfg.TrustedHash = "CD"
+
+	cfg.GenesisHash = "not hex"
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,45 @@
+package header
+
+import (
+	"context"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// offlineSubscriber is the Subscriber of the read-only node, which never receives any headers, as
+// the node is not subscribed to the network.
+type offlineSubscriber struct{}
+
+func (offlineSubscriber) Subscribe() (header.Subscription, error) {
+	return &offlineSubscription{done: make(chan struct{})}, nil
+}
+
+func (offlineSubscriber) AddValidator(header.Validator) error {
+	return nil
+}
+
+func (offlineSubscriber) Stop(context.Context) error {
+	return nil
+}
+
+// offlineSubscription blocks until it's canceled, as no headers ever arrive.
+type offlineSubscription struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func (s *offlineSubscription) NextHeader(ctx context.Context) (*header.ExtendedHeader, error) {
+	select {
+	case <-s.done:
+		return nil, context.Canceled
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *offlineSubscription) Cancel() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
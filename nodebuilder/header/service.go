@@ -2,6 +2,7 @@ package header
 
 import (
 	"context"
+	"time"
 
 	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/header/p2p"
@@ -40,10 +41,102 @@ func (s *Service) GetByHeight(ctx context.Context, height uint64) (*header.Exten
 	return s.store.GetByHeight(ctx, height)
 }
 
+func (s *Service) GetByTime(ctx context.Context, t time.Time) (*header.ExtendedHeader, error) {
+	return s.store.GetByTime(ctx, t)
+}
+
 func (s *Service) Head(ctx context.Context) (*header.ExtendedHeader, error) {
 	return s.store.Head(ctx)
 }
 
-func (s *Service) IsSyncing() bool {
+func (s *Service) NetworkHead(ctx context.Context) (*header.ExtendedHeader, error) {
+	return s.syncer.NetworkHead(ctx)
+}
+
+func (s *Service) IsSyncing(context.Context) bool {
 	return !s.syncer.State().Finished()
 }
+
+func (s *Service) SyncWait(ctx context.Context) error {
+	netHead, err := s.syncer.NetworkHead(ctx)
+	if err != nil {
+		return err
+	}
+	// this store method blocks until header is available
+	_, err = s.store.GetByHeight(ctx, uint64(netHead.Height))
+	return err
+}
+
+func (s *Service) SyncState(context.Context) (sync.State, error) {
+	return s.syncer.State(), nil
+}
+
+func (s *Service) SetSyncTargetHeight(ctx context.Context, height uint64) error {
+	return s.syncer.SetTargetHeight(ctx, height)
+}
+
+func (s *Service) SubscribeHeaders(ctx context.Context) (<-chan *header.ExtendedHeader, error) {
+	subscription, err := s.sub.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+	headers := make(chan *header.ExtendedHeader)
+	go func() {
+		defer close(headers)
+		defer subscription.Cancel()
+		for {
+			h, err := subscription.NextHeader(ctx)
+			if err != nil {
+				if err != context.DeadlineExceeded && err != context.Canceled {
+					log.Errorw("fetching header from subscription", "err", err)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case headers <- h:
+			}
+		}
+	}()
+	return headers, nil
+}
+
+func (s *Service) ValidatorSet(
+	ctx context.Context,
+	height uint64,
+	page, perPage int,
+) (*header.ValidatorsPage, error) {
+	h, err := s.headOrByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return h.ValidatorsPage(page, perPage)
+}
+
+func (s *Service) LightBlock(ctx context.Context, height uint64) (*header.LightBlock, error) {
+	h, err := s.headOrByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return h.LightBlock(), nil
+}
+
+func (s *Service) TrustedHeader(ctx context.Context) (*TrustedHeader, error) {
+	head, err := s.store.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedHeader{
+		Height: uint64(head.Height),
+		Hash:   head.Hash().String(),
+	}, nil
+}
+
+// headOrByHeight returns the header at the given height, or the local chain head if zero.
+func (s *Service) headOrByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	if height == 0 {
+		return s.store.Head(ctx)
+	}
+	return s.store.GetByHeight(ctx, height)
+}
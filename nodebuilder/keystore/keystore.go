@@ -0,0 +1,144 @@
+package keystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+)
+
+// ErrSignerKey is returned on attempt to delete the key the node signs its transactions with.
+var ErrSignerKey = errors.New("keystore: key is in use by the node's signer")
+
+// Module encompasses the behavior necessary to manage the keys held in the node's keyring.
+// Any method signature changed here needs to also be changed in the API struct.
+//
+//go:generate mockgen -destination=mocks/api.go -package=mocks . Module
+type Module interface {
+	// ListKeys lists all the keys held in the keyring.
+	ListKeys(ctx context.Context) ([]keyring.KeyOutput, error)
+	// NewKey generates a new key under the given name. The returned KeyOutput contains
+	// the mnemonic the key can be recovered from.
+	NewKey(ctx context.Context, name string) (keyring.KeyOutput, error)
+	// ImportKeyMnemonic recovers the key from the given mnemonic and stores it under the given name.
+	ImportKeyMnemonic(ctx context.Context, name, mnemonic string) (keyring.KeyOutput, error)
+	// ImportKeyArmor imports the ASCII-armored private key encrypted with the given passphrase
+	// and stores it under the given name.
+	ImportKeyArmor(ctx context.Context, name, armor, passphrase string) (keyring.KeyOutput, error)
+	// ExportKeyArmor exports the private key with the given name, ASCII-armored and encrypted
+	// with the given passphrase.
+	ExportKeyArmor(ctx context.Context, name, passphrase string) (string, error)
+	// DeleteKey removes the key with the given name from the keyring.
+	DeleteKey(ctx context.Context, name string) error
+}
+
+// module manages the keys of the keyring the node's signer is backed by.
+type module struct {
+	signer *apptypes.KeyringSigner
+}
+
+func newModule(signer *apptypes.KeyringSigner) Module {
+	return &module{signer: signer}
+}
+
+func (m *module) ListKeys(context.Context) ([]keyring.KeyOutput, error) {
+	records, err := m.signer.List()
+	if err != nil {
+		return nil, err
+	}
+	return keyring.MkAccKeysOutput(records)
+}
+
+func (m *module) NewKey(_ context.Context, name string) (keyring.KeyOutput, error) {
+	record, mnemonic, err := m.signer.NewMnemonic(name, keyring.English, sdk.FullFundraiserPath, "", hd.Secp256k1)
+	if err != nil {
+		return keyring.KeyOutput{}, err
+	}
+	out, err := keyring.MkAccKeyOutput(record)
+	if err != nil {
+		return keyring.KeyOutput{}, err
+	}
+	out.Mnemonic = mnemonic
+	return out, nil
+}
+
+func (m *module) ImportKeyMnemonic(_ context.Context, name, mnemonic string) (keyring.KeyOutput, error) {
+	record, err := m.signer.NewAccount(name, mnemonic, "", sdk.FullFundraiserPath, hd.Secp256k1)
+	if err != nil {
+		return keyring.KeyOutput{}, err
+	}
+	return keyring.MkAccKeyOutput(record)
+}
+
+func (m *module) ImportKeyArmor(_ context.Context, name, armor, passphrase string) (keyring.KeyOutput, error) {
+	err := m.signer.ImportPrivKey(name, armor, passphrase)
+	if err != nil {
+		return keyring.KeyOutput{}, err
+	}
+	record, err := m.signer.Key(name)
+	if err != nil {
+		return keyring.KeyOutput{}, err
+	}
+	return keyring.MkAccKeyOutput(record)
+}
+
+func (m *module) ExportKeyArmor(_ context.Context, name, passphrase string) (string, error) {
+	return m.signer.ExportPrivKeyArmor(name, passphrase)
+}
+
+func (m *module) DeleteKey(_ context.Context, name string) error {
+	if name == m.signer.GetSignerInfo().Name {
+		return fmt.Errorf("%w: %s", ErrSignerKey, name)
+	}
+	return m.signer.Delete(name)
+}
+
+// API is a wrapper around Module for the RPC.
+// All the methods expose private key material or mutate the keyring,
+// so they require the admin permission.
+// TODO(@distractedm1nd): These structs need to be autogenerated.
+type API struct {
+	Internal struct {
+		ListKeys          func(ctx context.Context) ([]keyring.KeyOutput, error)            `perm:"admin"`
+		NewKey            func(ctx context.Context, name string) (keyring.KeyOutput, error) `perm:"admin"`
+		ImportKeyMnemonic func(
+			ctx context.Context,
+			name, mnemonic string,
+		) (keyring.KeyOutput, error) `perm:"admin"`
+		ImportKeyArmor func(
+			ctx context.Context,
+			name, armor, passphrase string,
+		) (keyring.KeyOutput, error) `perm:"admin"`
+		ExportKeyArmor func(ctx context.Context, name, passphrase string) (string, error) `perm:"admin"`
+		DeleteKey      func(ctx context.Context, name string) error                       `perm:"admin"`
+	}
+}
+
+func (api *API) ListKeys(ctx context.Context) ([]keyring.KeyOutput, error) {
+	return api.Internal.ListKeys(ctx)
+}
+
+func (api *API) NewKey(ctx context.Context, name string) (keyring.KeyOutput, error) {
+	return api.Internal.NewKey(ctx, name)
+}
+
+func (api *API) ImportKeyMnemonic(ctx context.Context, name, mnemonic string) (keyring.KeyOutput, error) {
+	return api.Internal.ImportKeyMnemonic(ctx, name, mnemonic)
+}
+
+func (api *API) ImportKeyArmor(ctx context.Context, name, armor, passphrase string) (keyring.KeyOutput, error) {
+	return api.Internal.ImportKeyArmor(ctx, name, armor, passphrase)
+}
+
+func (api *API) ExportKeyArmor(ctx context.Context, name, passphrase string) (string, error) {
+	return api.Internal.ExportKeyArmor(ctx, name, passphrase)
+}
+
+func (api *API) DeleteKey(ctx context.Context, name string) error {
+	return api.Internal.DeleteKey(ctx, name)
+}
@@ -0,0 +1,54 @@
+package keystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+)
+
+func TestModule(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ring := keyring.NewInMemory(encConf.Codec)
+	signer := apptypes.NewKeyringSigner(ring, "signer", "private")
+	_, _, err := signer.NewMnemonic("signer", keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	ks := newModule(signer)
+
+	key, err := ks.NewKey(ctx, "new")
+	require.NoError(t, err)
+	require.NotEmpty(t, key.Mnemonic)
+
+	keys, err := ks.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	armor, err := ks.ExportKeyArmor(ctx, "new", "passphrase")
+	require.NoError(t, err)
+	require.NoError(t, ks.DeleteKey(ctx, "new"))
+
+	// recovering from the mnemonic yields the same account
+	recovered, err := ks.ImportKeyMnemonic(ctx, "recovered", key.Mnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, key.Address, recovered.Address)
+	require.NoError(t, ks.DeleteKey(ctx, "recovered"))
+
+	_, err = ks.ImportKeyArmor(ctx, "new", armor, "wrong")
+	require.Error(t, err)
+	imported, err := ks.ImportKeyArmor(ctx, "new", armor, "passphrase")
+	require.NoError(t, err)
+	assert.Equal(t, key.Address, imported.Address)
+
+	err = ks.DeleteKey(ctx, "signer")
+	require.ErrorIs(t, err, ErrSignerKey)
+}
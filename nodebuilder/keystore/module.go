@@ -0,0 +1,20 @@
+package keystore
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// ConstructModule provides the Module managing the keys of the node's keyring.
+func ConstructModule(tp node.Type) fx.Option {
+	switch tp {
+	case node.Light, node.Full, node.Bridge:
+		return fx.Module(
+			"keystore",
+			fx.Provide(newModule),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
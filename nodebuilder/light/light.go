@@ -0,0 +1,160 @@
+// Package light packages the Light Node to be embedded into other Go programs, e.g. wallets and
+// rollup clients, which need to verify the data availability in-process.
+package light
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/fx"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/share"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+)
+
+// Config configures the embedded Light Node.
+type Config struct {
+	// StorePath is the directory the Node persists its data in.
+	// The Store is initialized on the first use.
+	StorePath string
+	// Network is the network the Node joins. Defaults to p2p.DefaultNetwork.
+	Network p2p.Network
+	// Node is the Config the Node is constructed with. Defaults to the Config of the Store, which is
+	// the default Light Node config, unless the Store was initialized with another one.
+	Node *nodebuilder.Config
+	// TrustedHeight and TrustedHash identify a recent header, e.g. got from a trusted node over RPC,
+	// which the Node initializes header synchronization from on the first start instead of the
+	// genesis header, so that it starts in seconds. Ignored once the Node has synced any headers.
+	TrustedHeight uint64
+	TrustedHash   string
+	// Intermittent adapts the Node to being often offline, e.g. on mobile and IoT devices, so that it
+	// efficiently catches up on the headers missed while offline once back online.
+	// See das.Parameters.Intermittent.
+	Intermittent bool
+	// Options customize the components of the Node, e.g. with p2p.WithHost or
+	// state.WithKeyringSigner.
+	Options []fx.Option
+}
+
+// Node is the Light Node embedded into the program.
+type Node struct {
+	node  *nodebuilder.Node
+	store nodebuilder.Store
+}
+
+// New constructs a new Light Node with the given Config.
+// The Node holds the lock on its Store until stopped.
+func New(cfg Config) (*Node, error) {
+	if cfg.StorePath == "" {
+		return nil, errors.New("light: store path must be set")
+	}
+	if cfg.Network == "" {
+		cfg.Network = p2p.DefaultNetwork
+	}
+
+	if !nodebuilder.IsInit(cfg.StorePath) {
+		ndCfg := cfg.Node
+		if ndCfg == nil {
+			ndCfg = nodebuilder.DefaultConfig(node.Light)
+		}
+		err := nodebuilder.Init(*ndCfg, cfg.StorePath, node.Light)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	store, err := nodebuilder.OpenStore(cfg.StorePath)
+	if err != nil {
+		return nil, err
+	}
+	ndCfg := cfg.Node
+	if ndCfg == nil {
+		ndCfg, err = store.Config()
+		if err != nil {
+			store.Close() //nolint: errcheck
+			return nil, err
+		}
+	}
+
+	if cfg.TrustedHash != "" || cfg.Intermittent {
+		// copied not to modify the given Config
+		cpCfg := *ndCfg
+		if cfg.TrustedHash != "" {
+			cpCfg.Header.TrustedHash, cpCfg.Header.TrustedHeight = cfg.TrustedHash, cfg.TrustedHeight
+		}
+		if cfg.Intermittent {
+			cpCfg.DASer.Intermittent = true
+		}
+		ndCfg = &cpCfg
+	}
+
+	nd, err := nodebuilder.NewWithConfig(node.Light, cfg.Network, store, ndCfg, cfg.Options...)
+	if err != nil {
+		store.Close() //nolint: errcheck
+		return nil, err
+	}
+	return &Node{node: nd, store: store}, nil
+}
+
+// Start starts the Node. The given context only bounds the starting.
+func (n *Node) Start(ctx context.Context) error {
+	return n.node.Start(ctx)
+}
+
+// Stop stops the Node and releases its Store.
+// Canceling the given context aborts the graceful shutdown.
+func (n *Node) Stop(ctx context.Context) (err error) {
+	// the Store is released even if the Node fails to stop gracefully, so that it can be reopened
+	defer func() {
+		err = multierr.Append(err, n.store.Close())
+	}()
+	return n.node.Stop(ctx)
+}
+
+// Run starts the Node and blocks until the given context is canceled, stopping the Node then.
+func (n *Node) Run(ctx context.Context) error {
+	err := n.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	// the given context is done, so the Node is stopped with a fresh one
+	err = n.Stop(context.Background())
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Header provides the access to the headers of the network.
+func (n *Node) Header() header.Module {
+	return n.node.HeaderServ
+}
+
+// Share provides the access to the shares of the network.
+func (n *Node) Share() share.Module {
+	return n.node.ShareServ
+}
+
+// DAS provides the access to the data availability sampling of the Node.
+func (n *Node) DAS() das.Module {
+	return n.node.DASer
+}
+
+// State provides the access to the state of the network, e.g. to submit transactions.
+func (n *Node) State() state.Module {
+	return n.node.StateServ
+}
+
+// Fraud provides the access to the fraud proofs of the network.
+func (n *Node) Fraud() fraud.Module {
+	return n.node.FraudServ
+}
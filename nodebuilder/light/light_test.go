@@ -0,0 +1,69 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	_, err := New(Config{})
+	require.Error(t, err)
+
+	cfg := nodebuilder.DefaultConfig(node.Light)
+	cfg.RPC.Port = "0"
+	nd, err := New(Config{StorePath: dir, Network: p2p.Private, Node: cfg})
+	require.NoError(t, err)
+	assert.True(t, nodebuilder.IsInit(dir))
+	assert.NotNil(t, nd.Header())
+	assert.NotNil(t, nd.Share())
+	assert.NotNil(t, nd.DAS())
+	assert.NotNil(t, nd.State())
+	assert.NotNil(t, nd.Fraud())
+
+	// the Store is locked until the Node is stopped
+	_, err = New(Config{StorePath: dir, Network: p2p.Private})
+	require.ErrorIs(t, err, nodebuilder.ErrOpened)
+	require.NoError(t, nd.Stop(ctx))
+
+	// the stored Config is used by default
+	nd, err = New(Config{StorePath: dir, Network: p2p.Private})
+	require.NoError(t, err)
+	assert.Equal(t, "0", nd.node.Config.RPC.Port)
+	require.NoError(t, nd.Stop(ctx))
+}
+
+func TestStop_ReleasesStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	cfg := nodebuilder.DefaultConfig(node.Light)
+	cfg.RPC.Port = "0"
+	failStop := fx.Invoke(func(lc fx.Lifecycle) {
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				return errors.New("failed")
+			},
+		})
+	})
+	nd, err := New(Config{StorePath: dir, Network: p2p.Private, Node: cfg, Options: []fx.Option{failStop}})
+	require.NoError(t, err)
+	require.NoError(t, nd.Start(ctx))
+	require.Error(t, nd.Stop(ctx))
+
+	// the Store is released despite the failed stop
+	nd, err = New(Config{StorePath: dir, Network: p2p.Private})
+	require.NoError(t, err)
+	require.NoError(t, nd.Stop(ctx))
+}
@@ -0,0 +1,269 @@
+// Package mobile wraps the Light Node into the API binding to iOS and Android with gomobile.
+// It only uses the types supported by gomobile, so the headers and stats are passed JSON encoded,
+// while the calls are bounded by the lifetime of the Node instead of contexts.
+package mobile
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/light"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+var log = logging.Logger("mobile")
+
+// ErrNotStarted is returned on attempt to use the Node which is not started.
+var ErrNotStarted = errors.New("mobile: node is not started")
+
+// HeaderHandler handles the headers validated from the network.
+type HeaderHandler interface {
+	// OnHeader receives the JSON encoded header.
+	OnHeader(header []byte)
+}
+
+// Node is the Light Node running within a mobile app.
+type Node struct {
+	storePath string
+	network   p2p.Network
+	// trustedHeight and trustedHash identify the header the Node initializes from
+	trustedHeight int64
+	trustedHash   string
+
+	lock sync.Mutex
+	nd   *light.Node
+	// ctx bounds the calls to the running Node, being canceled once it stops
+	ctx    context.Context
+	cancel context.CancelFunc
+	// background reports whether the Node was stopped by EnterBackground
+	background bool
+}
+
+// NewNode creates a new Node persisting its data under the given path, e.g. the app's documents
+// directory, and joining the given network. An empty network means the default one.
+func NewNode(storePath, network string) *Node {
+	return &Node{
+		storePath: storePath,
+		network:   p2p.Network(network),
+	}
+}
+
+// SetTrustedHeader sets the height and the hex encoded hash of a recent header, e.g. got from a
+// trusted node, which the Node initializes from on the first start instead of the genesis header.
+// It takes effect on the next start.
+func (n *Node) SetTrustedHeader(height int64, hash string) error {
+	if height <= 0 {
+		return errors.New("mobile: trusted height must be positive")
+	}
+	if _, err := hex.DecodeString(hash); err != nil || hash == "" {
+		return fmt.Errorf("mobile: invalid trusted hash %q", hash)
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.trustedHeight, n.trustedHash = height, hash
+	return nil
+}
+
+// Start starts the Node. Only a stopped Node can be started.
+func (n *Node) Start() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.start()
+}
+
+// Stop stops the Node, aborting its ongoing calls.
+func (n *Node) Stop() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.background = false
+	return n.stop(context.Background())
+}
+
+// IsRunning reports whether the Node is running.
+func (n *Node) IsRunning() bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.nd != nil
+}
+
+// EnterBackground stops the running Node within the given time, as the mobile platforms only grant
+// the apps moving to background a limited time to finish their work before suspending them.
+// The Node is restarted by EnterForeground.
+func (n *Node) EnterBackground(timeoutMillis int64) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.nd == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMillis)*time.Millisecond)
+	defer cancel()
+	n.background = true
+	return n.stop(ctx)
+}
+
+// EnterForeground restarts the Node stopped by EnterBackground.
+func (n *Node) EnterForeground() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if !n.background {
+		return nil
+	}
+
+	n.background = false
+	return n.start()
+}
+
+// HeadHeight reports the height of the chain head.
+func (n *Node) HeadHeight() (int64, error) {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return 0, err
+	}
+	h, err := nd.Header().Head(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return h.Height, nil
+}
+
+// Head returns the JSON encoded header of the chain head.
+func (n *Node) Head() ([]byte, error) {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return nil, err
+	}
+	h, err := nd.Header().Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(h)
+}
+
+// HeaderByHeight returns the JSON encoded header at the given height, blocking until the Node
+// syncs it.
+func (n *Node) HeaderByHeight(height int64) ([]byte, error) {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return nil, err
+	}
+	h, err := nd.Header().GetByHeight(ctx, uint64(height))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(h)
+}
+
+// SharesAvailable samples the data of the block at the given height, erroring unless it is
+// available on the network.
+func (n *Node) SharesAvailable(height int64) error {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return err
+	}
+	h, err := nd.Header().GetByHeight(ctx, uint64(height))
+	if err != nil {
+		return err
+	}
+	return nd.Share().SharesAvailable(ctx, h.DAH)
+}
+
+// SamplingStats returns the JSON encoded statistics of the data availability sampling, including
+// the progress of catching up on the headers missed while the Node was stopped or in background.
+func (n *Node) SamplingStats() ([]byte, error) {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return nil, err
+	}
+	stats, err := nd.DAS().SamplingStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(stats)
+}
+
+// SubscribeHeaders passes the headers validated from the network to the given handler until the
+// Node is stopped.
+func (n *Node) SubscribeHeaders(handler HeaderHandler) error {
+	nd, ctx, err := n.running()
+	if err != nil {
+		return err
+	}
+	sub, err := nd.Header().SubscribeHeaders(ctx)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for h := range sub {
+			bin, err := json.Marshal(h)
+			if err != nil {
+				log.Errorw("marshaling header", "height", h.Height, "err", err)
+				continue
+			}
+			handler.OnHeader(bin)
+		}
+	}()
+	return nil
+}
+
+func (n *Node) start() error {
+	if n.nd != nil {
+		return errors.New("mobile: node is already started")
+	}
+
+	nd, err := light.New(light.Config{
+		StorePath:     n.storePath,
+		Network:       n.network,
+		TrustedHeight: uint64(n.trustedHeight),
+		TrustedHash:   n.trustedHash,
+		// the apps are suspended in background, so the Node is offline for most of the time
+		Intermittent: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = nd.Start(ctx)
+	if err != nil {
+		cancel()
+		// the Node is stopped to release its Store
+		if stopErr := nd.Stop(context.Background()); stopErr != nil {
+			log.Errorw("stopping node after failed start", "err", stopErr)
+		}
+		return err
+	}
+
+	n.nd, n.ctx, n.cancel = nd, ctx, cancel
+	return nil
+}
+
+func (n *Node) stop(ctx context.Context) error {
+	if n.nd == nil {
+		return nil
+	}
+
+	n.cancel()
+	err := n.nd.Stop(ctx)
+	n.nd, n.ctx, n.cancel = nil, nil, nil
+	return err
+}
+
+// running provides the running Node along with the context bounding the calls to it.
+func (n *Node) running() (*light.Node, context.Context, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.nd == nil {
+		return nil, nil, ErrNotStarted
+	}
+	return n.nd, n.ctx, nil
+}
@@ -0,0 +1,38 @@
+package mobile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+func TestNode_NotStarted(t *testing.T) {
+	nd := NewNode(t.TempDir(), string(p2p.Private))
+	assert.False(t, nd.IsRunning())
+
+	_, err := nd.HeadHeight()
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = nd.HeaderByHeight(1)
+	assert.ErrorIs(t, err, ErrNotStarted)
+	err = nd.SharesAvailable(1)
+	assert.ErrorIs(t, err, ErrNotStarted)
+	_, err = nd.SamplingStats()
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	// the lifecycle hooks are no-ops for the stopped Node
+	require.NoError(t, nd.EnterBackground(1000))
+	require.NoError(t, nd.EnterForeground())
+	assert.False(t, nd.IsRunning())
+	require.NoError(t, nd.Stop())
+}
+
+func TestNode_SetTrustedHeader(t *testing.T) {
+	nd := NewNode(t.TempDir(), string(p2p.Private))
+	assert.Error(t, nd.SetTrustedHeader(0, "AB"))
+	assert.Error(t, nd.SetTrustedHeader(10, ""))
+	assert.Error(t, nd.SetTrustedHeader(10, "not hex"))
+	require.NoError(t, nd.SetTrustedHeader(10, "AB"))
+}
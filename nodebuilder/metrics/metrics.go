@@ -0,0 +1,255 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/das"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/sync"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+const namespace = "celestia"
+
+// collectTimeout is the time a gauge has to collect its value on scrape.
+var collectTimeout = time.Second * 5
+
+// newRegistry creates the Prometheus registry for all the node's metrics.
+func newRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// nodeGauges registers the node-level gauges collected on every scrape.
+func nodeGauges(
+	reg *prometheus.Registry,
+	store header.Store,
+	syncer *sync.Syncer,
+	host host.Host,
+	p2pMod p2p.Module,
+	ds datastore.Batching,
+) error {
+	return register(reg,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "head_height",
+			Help:      "Height of the most recent header known in the network",
+		}, func() float64 {
+			head := syncer.State().ToHeight
+			if height := store.Height(); height > head {
+				head = height
+			}
+			return float64(head)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "synced_height",
+			Help:      "Height of the most recent header synced by the node",
+		}, func() float64 {
+			return float64(store.Height())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "peers",
+			Help:      "Number of connected peers",
+		}, func() float64 {
+			return float64(len(host.Network().Peers()))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "nat_reachability",
+			Help:      "Reachability of the node detected by AutoNAT: 0 - unknown, 1 - public, 2 - private",
+		}, func() float64 {
+			status, err := p2pMod.NATStatus(context.Background())
+			if err != nil {
+				log.Errorw("collecting reachability", "err", err)
+			}
+			return float64(status)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "store_size_bytes",
+			Help:      "Size of the node's datastore on disk",
+		}, func() float64 {
+			ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+			defer cancel()
+			size, err := datastore.DiskUsage(ctx, ds)
+			if err != nil {
+				log.Errorw("collecting datastore size", "err", err)
+			}
+			return float64(size)
+		}),
+	)
+}
+
+// badgerGauges registers the gauges of the value log and LSM tree sizes of the datastore, if it's
+// backed by Badger, to follow the disk space reclaimed by its garbage collections.
+func badgerGauges(reg *prometheus.Registry, ds datastore.Batching) error {
+	bds, ok := ds.(*dsbadger.Datastore)
+	if !ok {
+		return nil
+	}
+	return register(reg,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "store_value_log_size_bytes",
+			Help:      "Size of the value log of the node's datastore on disk",
+		}, func() float64 {
+			_, vlog := bds.DB.Size()
+			return float64(vlog)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "store_lsm_size_bytes",
+			Help:      "Size of the LSM tree of the node's datastore on disk",
+		}, func() float64 {
+			lsm, _ := bds.DB.Size()
+			return float64(lsm)
+		}),
+	)
+}
+
+// dasGauges registers the gauges of the DASer.
+func dasGauges(reg *prometheus.Registry, daser *das.DASer) error {
+	return register(reg,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "sampled_height",
+			Help:      "Height up to which all the headers were successfully sampled",
+		}, func() float64 {
+			// stats are unavailable once the DASer is stopped
+			if daser.Healthy() != nil {
+				return 0
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+			defer cancel()
+			stats, err := daser.SamplingStats(ctx)
+			if err != nil {
+				log.Errorw("collecting sampling stats", "err", err)
+			}
+			return float64(stats.SampledChainHead)
+		}),
+	)
+}
+
+// rpcCounters registers the counters of calls to the RPC, per module and method.
+func rpcCounters(reg *prometheus.Registry, server *rpc.Server) error {
+	calls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "calls_total",
+		Help:      "Number of calls to the RPC",
+	}, []string{"module", "method"})
+	server.SetCallObserver(func(module, method string) {
+		calls.WithLabelValues(module, method).Inc()
+	})
+	return reg.Register(calls)
+}
+
+// bandwidthCounters registers the counters of bytes sent and received per protocol and per
+// connected peer.
+func bandwidthCounters(reg *prometheus.Registry, p2pMod p2p.Module) error {
+	return reg.Register(&bandwidthCollector{
+		p2pMod: p2pMod,
+		protocolBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "p2p", "protocol_bytes_total"),
+			"Number of bytes sent and received over each protocol",
+			[]string{"protocol", "direction"}, nil,
+		),
+		peerBytes: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "p2p", "peer_bytes_total"),
+			"Number of bytes sent to and received from each connected peer",
+			[]string{"peer", "direction"}, nil,
+		),
+	})
+}
+
+// bandwidthCollector collects the counters of the bandwidth reporter of the Host on every scrape,
+// as the sets of protocols and peers change over time.
+type bandwidthCollector struct {
+	p2pMod        p2p.Module
+	protocolBytes *prometheus.Desc
+	peerBytes     *prometheus.Desc
+}
+
+func (bc *bandwidthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bc.protocolBytes
+	ch <- bc.peerBytes
+}
+
+func (bc *bandwidthCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	for proto, stats := range bc.p2pMod.BandwidthByProtocol(ctx) {
+		ch <- prometheus.MustNewConstMetric(bc.protocolBytes, prometheus.CounterValue,
+			float64(stats.TotalIn), string(proto), "in")
+		ch <- prometheus.MustNewConstMetric(bc.protocolBytes, prometheus.CounterValue,
+			float64(stats.TotalOut), string(proto), "out")
+	}
+	for id, stats := range bc.p2pMod.BandwidthByPeer(ctx) {
+		ch <- prometheus.MustNewConstMetric(bc.peerBytes, prometheus.CounterValue,
+			float64(stats.TotalIn), id.String(), "in")
+		ch <- prometheus.MustNewConstMetric(bc.peerBytes, prometheus.CounterValue,
+			float64(stats.TotalOut), id.String(), "out")
+	}
+}
+
+// versionGauges registers the gauges of the versions run by the connected peers.
+func versionGauges(reg *prometheus.Registry, p2pMod p2p.Module) error {
+	return reg.Register(&versionCollector{
+		p2pMod: p2pMod,
+		peerVersions: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "p2p", "peer_versions"),
+			"Number of connected peers per version they run",
+			[]string{"version"}, nil,
+		),
+		outdatedPeers: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "p2p", "outdated_peers"),
+			"Number of connected peers running older versions than the node",
+			[]string{"version"}, nil,
+		),
+	})
+}
+
+// versionCollector collects the distribution of the peer versions on every scrape, as the set of
+// versions changes over time.
+type versionCollector struct {
+	p2pMod        p2p.Module
+	peerVersions  *prometheus.Desc
+	outdatedPeers *prometheus.Desc
+}
+
+func (vc *versionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vc.peerVersions
+	ch <- vc.outdatedPeers
+}
+
+func (vc *versionCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := vc.p2pMod.PeerVersions(context.Background())
+	for version, count := range stats.Peers {
+		ch <- prometheus.MustNewConstMetric(vc.peerVersions, prometheus.GaugeValue, float64(count), version)
+	}
+	ch <- prometheus.MustNewConstMetric(vc.outdatedPeers, prometheus.GaugeValue,
+		float64(stats.Outdated), stats.Version)
+}
+
+func register(reg *prometheus.Registry, cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		err := reg.Register(c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
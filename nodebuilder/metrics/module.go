@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+var log = logging.Logger("module/metrics")
+
+// DefaultAddress is the default listen address of the Prometheus metrics endpoint.
+const DefaultAddress = "localhost:26661"
+
+// ConstructModule provides the Prometheus metrics of the node, exposed on the '/metrics' path at
+// the given listen address.
+func ConstructModule(tp node.Type, address string) fx.Option {
+	baseComponents := fx.Options(
+		fx.Provide(newRegistry),
+		fx.Invoke(nodeGauges),
+		fx.Invoke(badgerGauges),
+		fx.Invoke(rpcCounters),
+		fx.Invoke(bandwidthCounters),
+		fx.Invoke(versionGauges),
+		fx.Invoke(func(lc fx.Lifecycle, reg *prometheus.Registry) {
+			srv := newServer(address, reg)
+			lc.Append(fx.Hook{
+				OnStart: srv.start,
+				OnStop:  srv.stop,
+			})
+		}),
+	)
+
+	switch tp {
+	case node.Light, node.Full:
+		return fx.Module(
+			"metrics",
+			baseComponents,
+			fx.Invoke(dasGauges),
+		)
+	case node.Bridge:
+		return fx.Module(
+			"metrics",
+			baseComponents,
+		)
+	default:
+		panic("invalid node type")
+	}
+}
+
+// server serves the Prometheus metrics over HTTP.
+type server struct {
+	srv *http.Server
+}
+
+func newServer(address string, reg *prometheus.Registry) *server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	return &server{
+		srv: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 2 * time.Second,
+		},
+	}
+}
+
+func (s *server) start(context.Context) error {
+	listener, err := net.Listen("tcp", s.srv.Addr)
+	if err != nil {
+		return err
+	}
+	log.Infow("serving metrics", "address", listener.Addr().String())
+	go func() {
+		err := s.srv.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorw("serving metrics", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (s *server) stop(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/metrics"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestConstructModule(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var reg *prometheus.Registry
+	nd := nodebuilder.TestNode(t, node.Full,
+		metrics.ConstructModule(node.Full, "127.0.0.1:0"),
+		fx.Populate(&reg),
+	)
+	err := nd.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = nd.Stop(ctx)
+		require.NoError(t, err)
+	})
+
+	// the call is counted under its module and method
+	req := []byte(`{"jsonrpc":"2.0","method":"node.Health","params":[],"id":1}`)
+	resp, err := http.Post("http://"+nd.RPCServer.ListenAddr(), "application/json", bytes.NewReader(req))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	gathered := make(map[string]float64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch {
+			case metric.GetGauge() != nil:
+				gathered[family.GetName()] = metric.GetGauge().GetValue()
+			case metric.GetCounter() != nil:
+				for _, label := range metric.GetLabel() {
+					gathered[family.GetName()+"/"+label.GetValue()] = metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{
+		"celestia_head_height",
+		"celestia_synced_height",
+		"celestia_sampled_height",
+		"celestia_peers",
+		"celestia_nat_reachability",
+		"celestia_store_size_bytes",
+		"celestia_p2p_outdated_peers",
+	} {
+		assert.Contains(t, gathered, name)
+	}
+	assert.Equal(t, float64(1), gathered["celestia_rpc_calls_total/node"])
+	assert.Equal(t, float64(1), gathered["celestia_rpc_calls_total/Health"])
+}
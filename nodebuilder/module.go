@@ -3,22 +3,37 @@ package nodebuilder
 import (
 	"context"
 
+	"github.com/ipfs/go-datastore"
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/celestia-node/libs/events"
 	"github.com/celestiaorg/celestia-node/libs/fxutil"
+	"github.com/celestiaorg/celestia-node/libs/health"
+	"github.com/celestiaorg/celestia-node/libs/reload"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
+	"github.com/celestiaorg/celestia-node/nodebuilder/da"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/gateway"
+	"github.com/celestiaorg/celestia-node/nodebuilder/grpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/keystore"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/notify"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
 )
 
 func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store) fx.Option {
+	p2pCfg := &cfg.P2P
+	if cfg.Node.ReadOnly {
+		p2pCfg = readOnlyP2PConfig(cfg.P2P)
+	}
+
 	baseComponents := fx.Options(
 		fx.Supply(tp),
 		fx.Supply(network),
@@ -30,17 +45,37 @@ func ConstructModule(tp node.Type, network p2p.Network, cfg *Config, store Store
 		fx.Supply(store.Config),
 		fx.Provide(store.Datastore),
 		fx.Provide(store.Keystore),
+		fx.Invoke(func(ctx context.Context, ds datastore.Batching) error {
+			return ensureNetwork(ctx, ds, network)
+		}),
+		fx.Provide(health.NewRegistry),
+		fx.Provide(events.NewBus),
+		fx.Provide(func() *reload.Registry {
+			return reload.NewRegistry(cfg, func() (interface{}, error) {
+				return store.Config()
+			})
+		}),
 		// modules provided by the node
-		p2p.ConstructModule(tp, &cfg.P2P),
+		p2p.ConstructModule(tp, p2pCfg, cfg.Node.ReadOnly),
 		state.ConstructModule(tp, &cfg.State),
-		header.ConstructModule(tp, &cfg.Header),
-		share.ConstructModule(tp, &cfg.Share),
+		header.ConstructModule(tp, &cfg.Header, cfg.Node.ReadOnly),
+		share.ConstructModule(tp, &cfg.Share, store.Path()),
+		blob.ConstructModule(tp),
+		da.ConstructModule(tp),
 		rpc.ConstructModule(tp, &cfg.RPC),
 		gateway.ConstructModule(tp, &cfg.Gateway),
+		grpc.ConstructModule(tp, &cfg.GRPC),
 		core.ConstructModule(tp, &cfg.Core),
-		das.ConstructModule(tp, &cfg.DASer),
+		das.ConstructModule(tp, &cfg.DASer, cfg.Node.ReadOnly),
+		watchdog.ConstructModule(tp, &cfg.Watchdog, cfg.Node.ReadOnly),
+		notify.ConstructModule(tp, &cfg.Notify),
 		fraud.ConstructModule(tp),
+		keystore.ConstructModule(tp),
+		node.ConstructModule(tp, &cfg.Node, cfg.RPC.EnableDiagnostics, store.Path()),
 	)
+	if cfg.Node.ReadOnly {
+		baseComponents = fx.Options(baseComponents, readOnlyComponents())
+	}
 
 	return fx.Module(
 		"node",
@@ -0,0 +1,34 @@
+package nodebuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+// networkKey is the key under which the network the Store belongs to is kept in the Datastore.
+var networkKey = datastore.NewKey("network")
+
+// ErrWrongNetwork is thrown on attempt to run a Node over a Store holding the data of another
+// network.
+var ErrWrongNetwork = errors.New("node: store belongs to another network")
+
+// ensureNetwork binds the Datastore to the given network on the first run and ensures it is not
+// used for any other network afterwards, preventing data of different networks from being mixed.
+func ensureNetwork(ctx context.Context, ds datastore.Datastore, network p2p.Network) error {
+	stored, err := ds.Get(ctx, networkKey)
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+		return ds.Put(ctx, networkKey, []byte(network))
+	case err != nil:
+		return err
+	case p2p.Network(stored) != network:
+		return fmt.Errorf("%w: the store holds data of %s, not %s", ErrWrongNetwork, stored, network)
+	default:
+		return nil
+	}
+}
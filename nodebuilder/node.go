@@ -6,11 +6,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gbrlsnchs/jwt/v3"
 	"github.com/ipfs/go-blockservice"
 	exchange "github.com/ipfs/go-ipfs-exchange-interface"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p-core/host"
-	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/routing"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
@@ -18,6 +18,8 @@ import (
 
 	"github.com/celestiaorg/celestia-node/api/gateway"
 	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/libs/reload"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
@@ -44,6 +46,8 @@ type Node struct {
 	Network       p2p.Network
 	Bootstrappers p2p.Bootstrappers
 	Config        *Config
+	AdminSigner   jwt.Algorithm
+	Reloader      *reload.Registry
 
 	// rpc components
 	RPCServer     *rpc.Server     // not optional
@@ -59,6 +63,7 @@ type Node struct {
 	PubSub *pubsub.PubSub
 	// services
 	ShareServ  share.Module  // not optional
+	BlobServ   blob.Module   // not optional
 	HeaderServ header.Module // not optional
 	StateServ  state.Module  // not optional
 	FraudServ  fraud.Module  // not optional
@@ -99,17 +104,6 @@ func (n *Node) Start(ctx context.Context) error {
 	log.Infof("\n\n/_____/  /_____/  /_____/  /_____/  /_____/ \n\nStarted celestia DA node \nnode "+
 		"type: 	%s\nnetwork: 	%s\n\n/_____/  /_____/  /_____/  /_____/  /_____/ \n", strings.ToLower(n.Type.String()),
 		n.Network)
-
-	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(n.Host))
-	if err != nil {
-		log.Errorw("Retrieving multiaddress information", "err", err)
-		return err
-	}
-	fmt.Println("The p2p host is listening on:")
-	for _, addr := range addrs {
-		fmt.Println("* ", addr.String())
-	}
-	fmt.Println()
 	return nil
 }
 
@@ -0,0 +1,216 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/libs/health"
+	"github.com/celestiaorg/celestia-node/libs/reload"
+)
+
+// Module defines the administrative API of the node itself.
+// Any method signature changed here needs to also be changed in the API struct.
+//
+//go:generate mockgen -destination=mocks/api.go -package=mocks . Module
+type Module interface {
+	// LogLevelSet sets the logging level of the logger with the given name, or of all the loggers
+	// if the name is "*".
+	LogLevelSet(ctx context.Context, name, level string) error
+	// ReloadConfig reloads the node config from disk and applies the changed values of the
+	// reloadable settings without restarting the node, i.e. of Log, P2P.MutualPeers,
+	// DASer.ConcurrencyLimit and the Share.Serve* quotas. The other peer lists, i.e.
+	// Header.TrustedPeers and Header.TrustedSources, are applied on restart only, while the rate
+	// limits of the RPC are carried by the auth tokens themselves.
+	ReloadConfig(ctx context.Context) error
+	// ReloadableSettings lists the settings of the node config which can be reloaded.
+	ReloadableSettings(ctx context.Context) ([]string, error)
+	// Health reports whether the node and each of its services are healthy.
+	Health(ctx context.Context) (health.Status, error)
+	// SubscribeEvents subscribes to the events of the node of the given types, or of all types if
+	// none are given. Events are dropped for subscribers not keeping up with them.
+	// The returned channel is closed once the given context is canceled.
+	SubscribeEvents(ctx context.Context, types []events.Type) (<-chan events.Event, error)
+
+	// Profile collects the runtime profile with the given name, e.g. "heap", "allocs" or
+	// "goroutine", formatted according to the given pprof debug level. A "goroutine" profile with
+	// a debug level of 2 dumps the stacks of all the goroutines.
+	// It fails with ErrDiagnosticsDisabled unless diagnostics are enabled in the RPC config.
+	Profile(ctx context.Context, name string, debugLevel int) ([]byte, error)
+	// CPUProfile collects a pprof CPU profile for the given duration.
+	// It fails with ErrDiagnosticsDisabled unless diagnostics are enabled in the RPC config.
+	CPUProfile(ctx context.Context, duration time.Duration) ([]byte, error)
+	// GCStats reports the memory and garbage collection statistics of the node's runtime.
+	// It fails with ErrDiagnosticsDisabled unless diagnostics are enabled in the RPC config.
+	GCStats(ctx context.Context) (GCStats, error)
+
+	// DatastoreGC collects the garbage of the node's datastore right away, instead of waiting for
+	// the next scheduled collection. It's a no-op for datastores not collecting garbage.
+	DatastoreGC(ctx context.Context) error
+	// DatastoreCompact compacts the node's datastore on disk right away, instead of waiting for the
+	// next scheduled compaction. It's a no-op for datastores not supporting compactions.
+	DatastoreCompact(ctx context.Context) error
+	// DatastoreBackup backs up the node's datastore into the configured Node.BackupDir right away,
+	// instead of waiting for the next scheduled backup, returning the path of the backup.
+	DatastoreBackup(ctx context.Context) (string, error)
+	// DiskUsage reports the disk space taken by the node's stores and left on their file system,
+	// along with whether the node is in the protective mode due to low free space, as of the last
+	// check.
+	DiskUsage(ctx context.Context) (DiskUsage, error)
+}
+
+type module struct {
+	reloader    *reload.Registry
+	checker     *health.Registry
+	bus         *events.Bus
+	gc          *datastoreGC
+	backup      *datastoreBackup
+	disk        *diskMonitor
+	diagnostics bool
+}
+
+func newModule(
+	diagnostics bool,
+) func(*reload.Registry, *health.Registry, *events.Bus, *datastoreGC, *datastoreBackup, *diskMonitor) Module {
+	return func(
+		reloader *reload.Registry,
+		checker *health.Registry,
+		bus *events.Bus,
+		gc *datastoreGC,
+		backup *datastoreBackup,
+		disk *diskMonitor,
+	) Module {
+		return &module{
+			reloader:    reloader,
+			checker:     checker,
+			bus:         bus,
+			gc:          gc,
+			backup:      backup,
+			disk:        disk,
+			diagnostics: diagnostics,
+		}
+	}
+}
+
+func (m *module) LogLevelSet(_ context.Context, name, level string) error {
+	return logging.SetLogLevel(name, level)
+}
+
+func (m *module) ReloadConfig(ctx context.Context) error {
+	return m.reloader.Reload(ctx)
+}
+
+func (m *module) ReloadableSettings(context.Context) ([]string, error) {
+	return m.reloader.Settings(), nil
+}
+
+func (m *module) Health(context.Context) (health.Status, error) {
+	return m.checker.Check(), nil
+}
+
+func (m *module) SubscribeEvents(ctx context.Context, types []events.Type) (<-chan events.Event, error) {
+	sub := m.bus.Subscribe(types...)
+	out := make(chan events.Event)
+	go func() {
+		defer close(out)
+		defer sub.Cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-sub.Out():
+				select {
+				case <-ctx.Done():
+					return
+				case out <- e:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (m *module) DatastoreGC(ctx context.Context) error {
+	return m.gc.collect(ctx)
+}
+
+func (m *module) DatastoreCompact(ctx context.Context) error {
+	return m.gc.compact(ctx)
+}
+
+func (m *module) DatastoreBackup(ctx context.Context) (string, error) {
+	return m.backup.backup(ctx)
+}
+
+func (m *module) DiskUsage(context.Context) (DiskUsage, error) {
+	return m.disk.Usage(), nil
+}
+
+// API is a wrapper around Module for the RPC.
+// TODO(@distractedm1nd): These structs need to be autogenerated.
+type API struct {
+	Internal struct {
+		LogLevelSet        func(ctx context.Context, name, level string) error                         `perm:"admin"`
+		ReloadConfig       func(ctx context.Context) error                                             `perm:"admin"`
+		ReloadableSettings func(ctx context.Context) ([]string, error)                                 `perm:"admin"`
+		Health             func(ctx context.Context) (health.Status, error)                            `perm:"read"`
+		SubscribeEvents    func(ctx context.Context, types []events.Type) (<-chan events.Event, error) `perm:"read"`
+		Profile            func(ctx context.Context, name string, debugLevel int) ([]byte, error)      `perm:"admin"`
+		CPUProfile         func(ctx context.Context, duration time.Duration) ([]byte, error)           `perm:"admin"`
+		GCStats            func(ctx context.Context) (GCStats, error)                                  `perm:"admin"`
+		DatastoreGC        func(ctx context.Context) error                                             `perm:"admin"`
+		DatastoreCompact   func(ctx context.Context) error                                             `perm:"admin"`
+		DatastoreBackup    func(ctx context.Context) (string, error)                                   `perm:"admin"`
+		DiskUsage          func(ctx context.Context) (DiskUsage, error)                                `perm:"read"`
+	}
+}
+
+func (api *API) LogLevelSet(ctx context.Context, name, level string) error {
+	return api.Internal.LogLevelSet(ctx, name, level)
+}
+
+func (api *API) ReloadConfig(ctx context.Context) error {
+	return api.Internal.ReloadConfig(ctx)
+}
+
+func (api *API) ReloadableSettings(ctx context.Context) ([]string, error) {
+	return api.Internal.ReloadableSettings(ctx)
+}
+
+func (api *API) Health(ctx context.Context) (health.Status, error) {
+	return api.Internal.Health(ctx)
+}
+
+func (api *API) SubscribeEvents(ctx context.Context, types []events.Type) (<-chan events.Event, error) {
+	return api.Internal.SubscribeEvents(ctx, types)
+}
+
+func (api *API) Profile(ctx context.Context, name string, debugLevel int) ([]byte, error) {
+	return api.Internal.Profile(ctx, name, debugLevel)
+}
+
+func (api *API) CPUProfile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	return api.Internal.CPUProfile(ctx, duration)
+}
+
+func (api *API) GCStats(ctx context.Context) (GCStats, error) {
+	return api.Internal.GCStats(ctx)
+}
+
+func (api *API) DatastoreGC(ctx context.Context) error {
+	return api.Internal.DatastoreGC(ctx)
+}
+
+func (api *API) DatastoreCompact(ctx context.Context) error {
+	return api.Internal.DatastoreCompact(ctx)
+}
+
+func (api *API) DatastoreBackup(ctx context.Context) (string, error) {
+	return api.Internal.DatastoreBackup(ctx)
+}
+
+func (api *API) DiskUsage(ctx context.Context) (DiskUsage, error) {
+	return api.Internal.DiskUsage(ctx)
+}
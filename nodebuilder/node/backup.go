@@ -0,0 +1,201 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+
+	"github.com/celestiaorg/celestia-node/libs/dsbackup"
+)
+
+const (
+	backupPrefix = "datastore-"
+	backupSuffix = ".bak"
+	// backupTimeFormat sorts the names of the backups chronologically.
+	backupTimeFormat = "20060102T150405.000Z"
+)
+
+// errNoBackupDir is returned when backing up without the configured Node.BackupDir.
+var errNoBackupDir = errors.New("node: backup directory is not configured")
+
+// datastoreBackup backs up the datastore into the configured directory on the configured interval
+// and on demand, keeping the configured number of the latest backups.
+type datastoreBackup struct {
+	ds       datastore.Batching
+	dir      string
+	interval time.Duration
+	keep     int
+	disk     *diskMonitor
+
+	// lk serializes the backups
+	lk sync.Mutex
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDatastoreBackup(cfg Config, ds datastore.Batching, disk *diskMonitor) *datastoreBackup {
+	return &datastoreBackup{
+		ds:       ds,
+		dir:      cfg.BackupDir,
+		interval: cfg.BackupInterval,
+		keep:     cfg.BackupKeep,
+		disk:     disk,
+	}
+}
+
+// Start schedules the backups, if they are enabled.
+func (b *datastoreBackup) Start(context.Context) error {
+	if b.dir == "" || b.interval == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel, b.done = cancel, make(chan struct{})
+	go b.loop(ctx)
+	return nil
+}
+
+// Stop stops scheduling the backups and waits for the ongoing one to finish.
+func (b *datastoreBackup) Stop(ctx context.Context) error {
+	if b.cancel == nil {
+		return nil
+	}
+
+	b.cancel()
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *datastoreBackup) loop(ctx context.Context) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if b.disk.Protective() {
+				log.Warn("skipping scheduled datastore backup in protective mode")
+				continue
+			}
+			_, err := b.backup(ctx)
+			if err != nil {
+				log.Errorw("backing up datastore", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// backup backs up the datastore into a new file of the backup directory and rotates the older
+// backups out, returning the path of the new one.
+func (b *datastoreBackup) backup(ctx context.Context) (string, error) {
+	if b.dir == "" {
+		return "", errNoBackupDir
+	}
+
+	b.lk.Lock()
+	defer b.lk.Unlock()
+
+	err := os.MkdirAll(b.dir, 0o700)
+	if err != nil {
+		return "", err
+	}
+	start := time.Now()
+	path := filepath.Join(b.dir, backupPrefix+start.UTC().Format(backupTimeFormat)+backupSuffix)
+	// the backup is written under a temporary name first, so that the interrupted ones are never
+	// mistaken for complete
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	n, err := dsbackup.Backup(ctx, b.ds, f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp) //nolint: errcheck
+		return "", err
+	}
+	log.Infow("backed up datastore", "path", path, "entries", n, "took", time.Since(start))
+
+	if err = b.rotate(); err != nil {
+		log.Errorw("rotating datastore backups", "err", err)
+	}
+	return path, nil
+}
+
+// rotate removes the oldest backups beyond the amount to keep, if limited.
+func (b *datastoreBackup) rotate() error {
+	if b.keep == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && strings.HasPrefix(name, backupPrefix) && strings.HasSuffix(name, backupSuffix) {
+			backups = append(backups, name)
+		}
+	}
+	if len(backups) <= b.keep {
+		return nil
+	}
+	sort.Strings(backups)
+	for _, name := range backups[:len(backups)-b.keep] {
+		if err = os.Remove(filepath.Join(b.dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreDatastore replaces the data of the datastore with the backup under the given path, which
+// is relative to the Node.BackupDir, unless absolute. The datastore must not be used by a running
+// node.
+func RestoreDatastore(ctx context.Context, cfg Config, ds datastore.Datastore, path string) error {
+	if !filepath.IsAbs(path) {
+		if cfg.BackupDir == "" {
+			return errNoBackupDir
+		}
+		path = filepath.Join(cfg.BackupDir, path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	start := time.Now()
+	n, err := dsbackup.Restore(ctx, ds, f)
+	if err != nil {
+		return fmt.Errorf("node: restoring datastore from %s: %w", path, err)
+	}
+	log.Infow("restored datastore", "path", path, "entries", n, "took", time.Since(start))
+	return nil
+}
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastoreBackup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	ds, err := dsbadger.NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, ds.Close())
+	})
+	key := datastore.NewKey("key")
+	err = ds.Put(ctx, key, []byte("value"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.BackupDir, cfg.BackupInterval, cfg.BackupKeep = dir, time.Millisecond, 2
+	b := newDatastoreBackup(cfg, ds, &diskMonitor{})
+	require.NoError(t, b.Start(ctx))
+	// on demand backups wait for the scheduled ones
+	path, err := b.backup(ctx)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 50)
+	require.NoError(t, b.Stop(ctx))
+
+	// only the latest backups are kept
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, cfg.BackupKeep)
+
+	// the backed up data is restored
+	latest := entries[len(entries)-1].Name()
+	err = ds.Put(ctx, key, []byte("changed"))
+	require.NoError(t, err)
+	require.NoError(t, RestoreDatastore(ctx, cfg, ds, latest))
+	value, err := ds.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Error(t, RestoreDatastore(ctx, cfg, ds, filepath.Base(path)+".missing"))
+
+	// backups require the backup directory
+	b = newDatastoreBackup(DefaultConfig(), ds, &diskMonitor{})
+	require.NoError(t, b.Start(ctx))
+	require.Nil(t, b.cancel)
+	_, err = b.backup(ctx)
+	assert.ErrorIs(t, err, errNoBackupDir)
+	require.NoError(t, b.Stop(ctx))
+}
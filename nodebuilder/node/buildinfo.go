@@ -0,0 +1,33 @@
+package node
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// the build information is set with ldflags on build, e.g. by `make build`
+var (
+	buildTime       string
+	lastCommit      string
+	semanticVersion string
+)
+
+// BuildInfo describes the build of the node's binary.
+type BuildInfo struct {
+	BuildTime       string
+	LastCommit      string
+	SemanticVersion string
+	SystemVersion   string
+	GolangVersion   string
+}
+
+// GetBuildInfo returns the information about the build of the node's binary.
+func GetBuildInfo() BuildInfo {
+	return BuildInfo{
+		BuildTime:       buildTime,
+		LastCommit:      lastCommit,
+		SemanticVersion: semanticVersion,
+		SystemVersion:   fmt.Sprintf("%s/%s", runtime.GOARCH, runtime.GOOS),
+		GolangVersion:   runtime.Version(),
+	}
+}
@@ -0,0 +1,106 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DatastoreBackend is the key-value store the node persists its data in.
+type DatastoreBackend string
+
+const (
+	// BadgerBackend persists the data in Badger.
+	BadgerBackend DatastoreBackend = "badger"
+	// LevelDBBackend persists the data in LevelDB.
+	LevelDBBackend DatastoreBackend = "leveldb"
+	// PebbleBackend persists the data in Pebble, which sustains higher write throughput, e.g. for
+	// bridge nodes.
+	PebbleBackend DatastoreBackend = "pebble"
+	// MemoryBackend keeps the data in memory only, losing it on restart, e.g. for ephemeral nodes.
+	MemoryBackend DatastoreBackend = "memory"
+)
+
+// Config contains configuration parameters for administrating the node.
+type Config struct {
+	// DatastoreBackend is the key-value store the node persists its data in.
+	// It can't be changed once the node persisted data, as the backends can't read each other's.
+	DatastoreBackend DatastoreBackend
+	// DatastoreGCInterval is the interval between the garbage collections of the datastore, which
+	// reclaim the disk space taken by deleted and overwritten data. Zero disables the scheduled
+	// collections, though they can still be triggered over the RPC.
+	DatastoreGCInterval time.Duration
+	// DatastoreCompactionInterval is the interval between the compactions of the datastore, which
+	// rewrite its data on disk to drop the deleted and overwritten values the garbage collections
+	// leave behind, e.g. in the LSM tree of Badger. Zero disables the scheduled compactions, though
+	// they can still be triggered over the RPC.
+	DatastoreCompactionInterval time.Duration
+	// EncryptStore enables the encryption at rest of the keystore and the datastore values with
+	// AES-GCM, under the keys derived from the CELESTIA_STORE_PASSPHRASE environment variable.
+	// It can only be enabled before the node persisted data, while the keys stored before are
+	// encrypted on their first use. The keyring is kept in the file backend under the same
+	// passphrase instead of the test one, with the keys held in the test one moved over.
+	EncryptStore bool
+	// ReadOnly runs the node purely from its existing store, without any networking, e.g. to
+	// analyze a snapshot of the store offline. Headers and shares are served from the store over the
+	// APIs, but nothing is synced, sampled or fetched from peers. Bridge nodes can't run read-only.
+	ReadOnly bool
+	// BackupDir is the directory the datastore is backed up into. If empty, the datastore is never
+	// backed up.
+	BackupDir string
+	// BackupInterval is the interval between the scheduled backups of the datastore. Zero disables
+	// the scheduled backups, though they can still be triggered over the RPC.
+	BackupInterval time.Duration
+	// BackupKeep is the number of the latest backups kept in the BackupDir, while the older ones are
+	// removed. Zero keeps all of them.
+	BackupKeep int
+	// DiskCheckInterval is the interval between the checks of the disk usage of the stores. Zero
+	// disables the checks.
+	DiskCheckInterval time.Duration
+	// MinFreeDiskSpace is the amount of bytes of free disk space, below which the node enters the
+	// protective mode: the non-essential writes, like the scheduled backups, the DAS checkpoints and
+	// the squares stored by full nodes, are paused and an alert is raised, until the space is freed
+	// up.
+	MinFreeDiskSpace uint64
+}
+
+// DefaultConfig returns the default Config.
+func DefaultConfig() Config {
+	return Config{
+		DatastoreBackend:    BadgerBackend,
+		DatastoreGCInterval: time.Hour,
+		// compactions rewrite the whole datastore, so they are run rarely
+		DatastoreCompactionInterval: time.Hour * 24,
+		BackupKeep:                  3,
+		DiskCheckInterval:           time.Minute,
+		MinFreeDiskSpace:            2 << 30, // 2 GiB
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	switch cfg.DatastoreBackend {
+	case BadgerBackend, LevelDBBackend, PebbleBackend, MemoryBackend:
+	default:
+		return fmt.Errorf("node: unknown datastore backend: %s", cfg.DatastoreBackend)
+	}
+	if cfg.DatastoreGCInterval < 0 {
+		return errors.New("node: datastore GC interval must not be negative")
+	}
+	if cfg.DatastoreCompactionInterval < 0 {
+		return errors.New("node: datastore compaction interval must not be negative")
+	}
+	if cfg.BackupInterval < 0 {
+		return errors.New("node: backup interval must not be negative")
+	}
+	if cfg.BackupInterval > 0 && cfg.BackupDir == "" {
+		return errors.New("node: scheduled backups require the backup directory")
+	}
+	if cfg.BackupKeep < 0 {
+		return errors.New("node: amount of backups to keep must not be negative")
+	}
+	if cfg.DiskCheckInterval < 0 {
+		return errors.New("node: disk check interval must not be negative")
+	}
+	return nil
+}
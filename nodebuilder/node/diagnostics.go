@@ -0,0 +1,100 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"time"
+)
+
+// ErrDiagnosticsDisabled is returned by the diagnostics methods of the Module when they are not
+// enabled in the RPC config.
+var ErrDiagnosticsDisabled = errors.New("node: diagnostics are disabled")
+
+// MaxCPUProfileDuration is the longest a CPU profile can be collected for.
+var MaxCPUProfileDuration = time.Minute * 5
+
+// GCStats reports the memory and garbage collection statistics of the node's runtime.
+type GCStats struct {
+	// NumGC is the number of completed garbage collections.
+	NumGC int64 `json:"num_gc"`
+	// LastGC is the time of the last garbage collection.
+	LastGC time.Time `json:"last_gc"`
+	// PauseTotal is the total pause time of all the garbage collections.
+	PauseTotal time.Duration `json:"pause_total"`
+	// HeapAlloc is the number of bytes of allocated heap objects.
+	HeapAlloc uint64 `json:"heap_alloc"`
+	// HeapSys is the number of bytes of heap memory obtained from the OS.
+	HeapSys uint64 `json:"heap_sys"`
+	// HeapObjects is the number of allocated heap objects.
+	HeapObjects uint64 `json:"heap_objects"`
+	// Sys is the total number of bytes of memory obtained from the OS.
+	Sys uint64 `json:"sys"`
+	// NumGoroutine is the number of currently running goroutines.
+	NumGoroutine int `json:"num_goroutine"`
+}
+
+func (m *module) Profile(_ context.Context, name string, debugLevel int) ([]byte, error) {
+	if !m.diagnostics {
+		return nil, ErrDiagnosticsDisabled
+	}
+	p := pprof.Lookup(name)
+	if p == nil {
+		return nil, fmt.Errorf("node: unknown profile: %s", name)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := p.WriteTo(buf, debugLevel)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *module) CPUProfile(ctx context.Context, duration time.Duration) ([]byte, error) {
+	if !m.diagnostics {
+		return nil, ErrDiagnosticsDisabled
+	}
+	if duration <= 0 || duration > MaxCPUProfileDuration {
+		return nil, fmt.Errorf("node: CPU profile duration must be in (0, %s]", MaxCPUProfileDuration)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := pprof.StartCPUProfile(buf)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(duration):
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return nil, ctx.Err()
+	}
+	pprof.StopCPUProfile()
+	return buf.Bytes(), nil
+}
+
+func (m *module) GCStats(context.Context) (GCStats, error) {
+	if !m.diagnostics {
+		return GCStats{}, ErrDiagnosticsDisabled
+	}
+
+	var gc debug.GCStats
+	debug.ReadGCStats(&gc)
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	return GCStats{
+		NumGC:        gc.NumGC,
+		LastGC:       gc.LastGC,
+		PauseTotal:   gc.PauseTotal,
+		HeapAlloc:    mem.HeapAlloc,
+		HeapSys:      mem.HeapSys,
+		HeapObjects:  mem.HeapObjects,
+		Sys:          mem.Sys,
+		NumGoroutine: runtime.NumGoroutine(),
+	}, nil
+}
@@ -0,0 +1,221 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+// ErrLowDiskSpace is returned by the health check while the node is in the protective mode.
+var ErrLowDiskSpace = errors.New("node: low disk space")
+
+// the directories of the stores under the node's store path
+var (
+	datastoreDirs = []string{"data"}
+	// the EDS store keeps the CAR files, their indexes and the transient ones separately
+	edsStoreDirs = []string{"blocks", "index", "transients"}
+)
+
+// DiskUsage reports the disk space taken by the node's stores and left on their file system.
+type DiskUsage struct {
+	// Datastore is the amount of bytes taken by the datastore.
+	Datastore uint64 `json:"datastore"`
+	// EDSStore is the amount of bytes taken by the EDS store, if any.
+	EDSStore uint64 `json:"eds_store"`
+	// Free is the amount of bytes available on the file system of the stores.
+	Free uint64 `json:"free"`
+	// Total is the size of the file system of the stores in bytes.
+	Total uint64 `json:"total"`
+	// Protective reports whether the node is in the protective mode, as the Free space fell below
+	// the configured Node.MinFreeDiskSpace.
+	Protective bool `json:"protective"`
+	// CheckedAt is the time of the last check of the usage.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// DiskSpace reports whether the node is in the protective mode, so that the components making
+// non-essential writes pause them.
+type DiskSpace interface {
+	// Protective reports whether the node is in the protective mode.
+	Protective() bool
+}
+
+// diskMonitor accounts the disk usage of the stores on the configured interval and switches the
+// node into the protective mode once the free space falls below the configured threshold, so that
+// the non-essential writes, like the scheduled backups, the DAS checkpoints and the squares stored
+// by full nodes, are paused before the disk is full and the datastore fails with opaque errors.
+type diskMonitor struct {
+	path     string
+	interval time.Duration
+	minFree  uint64
+	bus      *events.Bus
+
+	lk    sync.RWMutex
+	usage DiskUsage
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDiskMonitor(storePath string) func(Config, *events.Bus) *diskMonitor {
+	return func(cfg Config, bus *events.Bus) *diskMonitor {
+		return &diskMonitor{
+			path:     storePath,
+			interval: cfg.DiskCheckInterval,
+			minFree:  cfg.MinFreeDiskSpace,
+			bus:      bus,
+		}
+	}
+}
+
+// Start checks the usage and schedules the next checks, if the node persists its data on disk and
+// the checks are enabled.
+func (d *diskMonitor) Start(context.Context) error {
+	if d.path == "" || d.interval == 0 {
+		return nil
+	}
+	if err := d.check(); err != nil {
+		return fmt.Errorf("node: checking disk usage: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel, d.done = cancel, make(chan struct{})
+	go d.loop(ctx)
+	return nil
+}
+
+// Stop stops scheduling the checks and waits for the ongoing one to finish.
+func (d *diskMonitor) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+
+	d.cancel()
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Usage returns the disk usage as of the last check.
+func (d *diskMonitor) Usage() DiskUsage {
+	d.lk.RLock()
+	defer d.lk.RUnlock()
+	return d.usage
+}
+
+// Protective reports whether the node is in the protective mode.
+func (d *diskMonitor) Protective() bool {
+	return d.Usage().Protective
+}
+
+// Healthy returns ErrLowDiskSpace while the node is in the protective mode.
+func (d *diskMonitor) Healthy() error {
+	usage := d.Usage()
+	if usage.Protective {
+		return fmt.Errorf("%w: %d bytes left", ErrLowDiskSpace, usage.Free)
+	}
+	return nil
+}
+
+func (d *diskMonitor) loop(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			err := d.check()
+			if err != nil {
+				log.Errorw("checking disk usage", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check accounts the disk usage and enters or leaves the protective mode accordingly.
+func (d *diskMonitor) check() error {
+	free, total, err := diskSpace(d.path)
+	if err != nil {
+		return err
+	}
+	usage := DiskUsage{
+		Free:  free,
+		Total: total,
+		// the space of the file systems not accounting it is unknown
+		Protective: total > 0 && free < d.minFree,
+		CheckedAt:  time.Now(),
+	}
+	if usage.Datastore, err = dirsSize(d.path, datastoreDirs); err != nil {
+		return err
+	}
+	if usage.EDSStore, err = dirsSize(d.path, edsStoreDirs); err != nil {
+		return err
+	}
+
+	d.lk.Lock()
+	wasProtective := d.usage.Protective
+	d.usage = usage
+	d.lk.Unlock()
+
+	switch {
+	case usage.Protective && !wasProtective:
+		log.Errorw("low disk space, entering protective mode: non-essential writes are paused",
+			"free", usage.Free, "min_free", d.minFree)
+		d.bus.Publish(events.Event{
+			Type:    events.DiskSpaceLow,
+			Message: fmt.Sprintf("%d bytes left on disk, while at least %d are required", usage.Free, d.minFree),
+		})
+	case !usage.Protective && wasProtective:
+		log.Infow("disk space recovered, leaving protective mode", "free", usage.Free)
+		d.bus.Publish(events.Event{
+			Type:    events.DiskSpaceRecovered,
+			Message: fmt.Sprintf("%d bytes left on disk", usage.Free),
+		})
+	}
+	return nil
+}
+
+// dirsSize sums up the sizes of the files under the given directories of the base path, skipping
+// the missing ones.
+func dirsSize(base string, dirs []string) (uint64, error) {
+	var size uint64
+	for _, dir := range dirs {
+		err := filepath.WalkDir(filepath.Join(base, dir), func(_ string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					// the files may be removed during the walk, e.g. by compactions
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			size += uint64(info.Size())
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return size, nil
+}
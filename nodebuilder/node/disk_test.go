@@ -0,0 +1,58 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+func TestDiskMonitor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	path := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "data"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "data", "000001.vlog"), make([]byte, 1024), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "blocks"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "blocks", "car"), make([]byte, 512), 0o600))
+
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.DiskSpaceLow, events.DiskSpaceRecovered)
+	t.Cleanup(sub.Cancel)
+
+	cfg := DefaultConfig()
+	cfg.MinFreeDiskSpace = 0
+	d := newDiskMonitor(path)(cfg, bus)
+	require.NoError(t, d.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, d.Stop(ctx))
+	})
+
+	usage := d.Usage()
+	assert.EqualValues(t, 1024, usage.Datastore)
+	assert.EqualValues(t, 512, usage.EDSStore)
+	assert.NotZero(t, usage.Free)
+	assert.False(t, usage.Protective)
+	assert.NoError(t, d.Healthy())
+
+	// no file system has that much space left
+	d.minFree = usage.Total + 1
+	require.NoError(t, d.check())
+	assert.True(t, d.Protective())
+	assert.ErrorIs(t, d.Healthy(), ErrLowDiskSpace)
+	e := <-sub.Out()
+	assert.Equal(t, events.DiskSpaceLow, e.Type)
+
+	d.minFree = 0
+	require.NoError(t, d.check())
+	assert.False(t, d.Protective())
+	e = <-sub.Out()
+	assert.Equal(t, events.DiskSpaceRecovered, e.Type)
+}
@@ -0,0 +1,16 @@
+//go:build darwin || freebsd || linux
+
+package node
+
+import "syscall"
+
+// diskSpace reports the free and the total bytes of the file system the path is on.
+func diskSpace(path string) (free, total uint64, err error) {
+	var st syscall.Statfs_t
+	err = syscall.Statfs(path, &st)
+	if err != nil {
+		return 0, 0, err
+	}
+	//nolint:unconvert // the types of the fields differ between the platforms
+	return uint64(st.Bavail) * uint64(st.Bsize), uint64(st.Blocks) * uint64(st.Bsize), nil
+}
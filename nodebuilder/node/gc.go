@@ -0,0 +1,156 @@
+package node
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	dsleveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/celestiaorg/celestia-node/libs/dspebble"
+)
+
+// datastoreGC collects the garbage of the datastore and compacts it on the configured intervals
+// and on demand.
+type datastoreGC struct {
+	ds                 datastore.Batching
+	gcds               datastore.GCDatastore
+	interval           time.Duration
+	compactionInterval time.Duration
+
+	// lk serializes the collections and the compactions, as Badger rejects concurrent ones
+	lk sync.Mutex
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newDatastoreGC(cfg Config, ds datastore.Batching) *datastoreGC {
+	gcds, _ := ds.(datastore.GCDatastore)
+	return &datastoreGC{
+		ds:                 ds,
+		gcds:               gcds,
+		interval:           cfg.DatastoreGCInterval,
+		compactionInterval: cfg.DatastoreCompactionInterval,
+	}
+}
+
+// Start schedules the collections, if the datastore supports them, and the compactions, if they
+// are enabled.
+func (gc *datastoreGC) Start(context.Context) error {
+	collects := gc.gcds != nil && gc.interval > 0
+	if !collects && gc.compactionInterval == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gc.cancel, gc.done = cancel, make(chan struct{})
+	go gc.loop(ctx, collects)
+	return nil
+}
+
+// Stop stops scheduling the collections and the compactions and waits for the ongoing one to
+// finish.
+func (gc *datastoreGC) Stop(ctx context.Context) error {
+	if gc.cancel == nil {
+		return nil
+	}
+
+	gc.cancel()
+	select {
+	case <-gc.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (gc *datastoreGC) loop(ctx context.Context, collects bool) {
+	defer close(gc.done)
+
+	var collectCh, compactCh <-chan time.Time
+	if collects {
+		ticker := time.NewTicker(gc.interval)
+		defer ticker.Stop()
+		collectCh = ticker.C
+	}
+	if gc.compactionInterval > 0 {
+		ticker := time.NewTicker(gc.compactionInterval)
+		defer ticker.Stop()
+		compactCh = ticker.C
+	}
+	for {
+		select {
+		case <-collectCh:
+			err := gc.collect(ctx)
+			if err != nil {
+				log.Errorw("collecting datastore garbage", "err", err)
+			}
+		case <-compactCh:
+			err := gc.compact(ctx)
+			if err != nil {
+				log.Errorw("compacting datastore", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect runs a garbage collection, waiting for the ongoing one to finish first, if any.
+func (gc *datastoreGC) collect(ctx context.Context) error {
+	if gc.gcds == nil {
+		return nil
+	}
+
+	gc.lk.Lock()
+	defer gc.lk.Unlock()
+
+	start := time.Now()
+	err := gc.gcds.CollectGarbage(ctx)
+	if err != nil {
+		return err
+	}
+	log.Infow("collected datastore garbage", "took", time.Since(start))
+	return nil
+}
+
+// compact runs a compaction, waiting for the ongoing collection or compaction to finish first, if
+// any.
+func (gc *datastoreGC) compact(ctx context.Context) error {
+	gc.lk.Lock()
+	defer gc.lk.Unlock()
+
+	start := time.Now()
+	err := compact(ctx, gc.ds)
+	if err != nil {
+		return err
+	}
+	log.Infow("compacted datastore", "took", time.Since(start))
+	return nil
+}
+
+// compact compacts the data of the datastore on disk, if its backend supports that, unwrapping the
+// datastores wrapping the backend, e.g. the encrypting one.
+func compact(ctx context.Context, ds datastore.Datastore) error {
+	switch ds := ds.(type) {
+	case *dsbadger.Datastore:
+		// merges all the levels of the LSM tree, dropping the deleted and overwritten keys
+		return ds.DB.Flatten(runtime.NumCPU())
+	case *dsleveldb.Datastore:
+		return ds.DB.CompactRange(util.Range{})
+	case *dspebble.Datastore:
+		return ds.Compact(ctx)
+	case datastore.Shim:
+		for _, child := range ds.Children() {
+			if err := compact(ctx, child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	dsbadger "github.com/ipfs/go-ds-badger2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/dspebble"
+)
+
+func TestDatastoreGC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	ds, err := dsbadger.NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, ds.Close())
+	})
+	err = ds.Put(ctx, datastore.NewKey("key"), []byte("value"))
+	require.NoError(t, err)
+
+	gc := newDatastoreGC(Config{DatastoreGCInterval: time.Millisecond}, ds)
+	require.NoError(t, gc.Start(ctx))
+	// on demand collections wait for the scheduled ones
+	require.NoError(t, gc.collect(ctx))
+	require.NoError(t, gc.compact(ctx))
+	require.NoError(t, gc.Stop(ctx))
+
+	// datastores without garbage collection are never scheduled, unless compacted
+	cfg := DefaultConfig()
+	cfg.DatastoreCompactionInterval = 0
+	gc = newDatastoreGC(cfg, &struct{ datastore.Batching }{datastore.NewMapDatastore()})
+	require.NoError(t, gc.Start(ctx))
+	require.Nil(t, gc.cancel)
+	require.NoError(t, gc.collect(ctx))
+	require.NoError(t, gc.Stop(ctx))
+}
+
+func TestDatastoreGC_Compact(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	ds, err := dspebble.NewDatastore(t.TempDir(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, ds.Close())
+	})
+	for i := 0; i < 100; i++ {
+		key := datastore.NewKey(strconv.Itoa(i))
+		require.NoError(t, ds.Put(ctx, key, []byte("value")))
+		require.NoError(t, ds.Delete(ctx, key))
+	}
+
+	// the backends are compacted through the datastores wrapping them
+	gc := newDatastoreGC(Config{DatastoreCompactionInterval: time.Millisecond}, dssync.MutexWrap(ds))
+	require.NoError(t, gc.Start(ctx))
+	require.NoError(t, gc.compact(ctx))
+	require.NoError(t, gc.Stop(ctx))
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+var meter = global.MeterProvider().Meter("node")
+
+// WithMetrics enables Otel metrics to monitor the disk usage of the node's stores.
+func WithMetrics(d *diskMonitor) error {
+	datastoreG, err := meter.AsyncInt64().Gauge(
+		"node_disk_datastore",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("disk space taken by the datastore"),
+	)
+	if err != nil {
+		return err
+	}
+
+	edsStoreG, err := meter.AsyncInt64().Gauge(
+		"node_disk_eds_store",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("disk space taken by the EDS store"),
+	)
+	if err != nil {
+		return err
+	}
+
+	freeG, err := meter.AsyncInt64().Gauge(
+		"node_disk_free",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("free disk space on the file system of the stores"),
+	)
+	if err != nil {
+		return err
+	}
+
+	protectiveG, err := meter.AsyncInt64().Gauge(
+		"node_disk_protective_mode",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("whether the node is in the protective mode due to low disk space (1) or not (0)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			datastoreG, edsStoreG, freeG, protectiveG,
+		},
+		func(ctx context.Context) {
+			usage := d.Usage()
+			datastoreG.Observe(ctx, int64(usage.Datastore))
+			edsStoreG.Observe(ctx, int64(usage.EDSStore))
+			freeG.Observe(ctx, int64(usage.Free))
+
+			var protective int64
+			if usage.Protective {
+				protective = 1
+			}
+			protectiveG.Observe(ctx, protective)
+		},
+	)
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/health"
+	"github.com/celestiaorg/celestia-node/libs/reload"
+	"github.com/celestiaorg/celestia-node/logs"
+)
+
+var log = logging.Logger("module/node")
+
+// ConstructModule provides the Module administrating the node itself.
+// Its runtime diagnostics are only served if 'diagnostics' is enabled. The disk usage is accounted
+// for the stores under the 'storePath', unless empty.
+func ConstructModule(tp Type, cfg *Config, diagnostics bool, storePath string) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if cfgErr == nil && tp == Bridge && cfg.ReadOnly {
+		// bridge nodes get the blocks from core only
+		cfgErr = errors.New("node: bridge nodes can't run read-only")
+	}
+
+	switch tp {
+	case Light, Full, Bridge:
+		return fx.Module(
+			"node",
+			fx.Supply(*cfg),
+			fx.Error(cfgErr),
+			fx.Provide(fx.Annotate(
+				newDiskMonitor(storePath),
+				fx.OnStart(func(ctx context.Context, d *diskMonitor) error {
+					return d.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, d *diskMonitor) error {
+					return d.Stop(ctx)
+				}),
+			)),
+			fx.Provide(func(d *diskMonitor) DiskSpace {
+				return d
+			}),
+			fx.Provide(fx.Annotate(
+				newDatastoreGC,
+				fx.OnStart(func(ctx context.Context, gc *datastoreGC) error {
+					return gc.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, gc *datastoreGC) error {
+					return gc.Stop(ctx)
+				}),
+			)),
+			fx.Provide(fx.Annotate(
+				newDatastoreBackup,
+				fx.OnStart(func(ctx context.Context, b *datastoreBackup) error {
+					return b.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, b *datastoreBackup) error {
+					return b.Stop(ctx)
+				}),
+			)),
+			fx.Provide(newModule(diagnostics)),
+			fx.Invoke(registerReloadable),
+			fx.Invoke(func(r *health.Registry, d *diskMonitor) error {
+				return r.Register("disk", d)
+			}),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
+
+// registerReloadable declares the logging settings, which can be changed without restarting the
+// node.
+func registerReloadable(r *reload.Registry) error {
+	return r.Register("Log", func(_ context.Context, _, new interface{}) error {
+		return logs.Apply(new.(logs.Config))
+	})
+}
@@ -1,16 +1,28 @@
 package nodebuilder
 
 import (
+	"context"
 	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/header"
+	headerstore "github.com/celestiaorg/celestia-node/header/store"
 	nodebuilder "github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	"github.com/celestiaorg/celestia-node/nodebuilder/state"
+	"github.com/celestiaorg/celestia-node/replay"
 )
 
 func TestNewLightWithP2PKey(t *testing.T) {
@@ -44,3 +56,78 @@ func TestLight_WithNetwork(t *testing.T) {
 	require.NotNil(t, node)
 	assert.Equal(t, p2p.Private, node.Network)
 }
+
+func TestLight_WithTrace(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	TestNode(t, nodebuilder.Light, WithTraceRecording(path))
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+
+	blk := blocks.NewBlock([]byte("share"))
+	trace, err := replay.LoadTrace(strings.NewReader(
+		`{"kind":"blocks","request":"` + blk.Cid().String() + `","response":["c2hhcmU="]}`))
+	require.NoError(t, err)
+
+	node := TestNode(t, nodebuilder.Light, WithTraceReplay(trace))
+	got, err := node.BlockService.GetBlock(ctx, blk.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, blk.RawData(), got.RawData())
+}
+
+func TestLight_ReadOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	cfg := DefaultConfig(nodebuilder.Light)
+	cfg.Node.ReadOnly = true
+	// the store has to be populated beforehand
+	node := TestNodeWithConfig(t, nodebuilder.Light, cfg)
+	require.Error(t, node.Start(ctx))
+
+	suite := header.NewTestSuite(t, 3)
+	store := MockStore(t, cfg)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	hstore, err := headerstore.NewStoreWithHead(ctx, ds, suite.Head())
+	require.NoError(t, err)
+	require.NoError(t, hstore.Start(ctx))
+	_, err = hstore.Append(ctx, suite.GenExtendedHeaders(10)...)
+	require.NoError(t, err)
+	require.NoError(t, hstore.Stop(ctx))
+
+	node, err = New(nodebuilder.Light, p2p.Private, store, state.WithKeyringSigner(TestKeyringSigner(t)))
+	require.NoError(t, err)
+	require.NoError(t, node.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, node.Stop(ctx))
+	})
+	// nothing is listened on
+	assert.Empty(t, node.Host.Addrs())
+	// nor dialed, even the reachable peers
+	other, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, other.Close())
+	})
+	err = node.Host.Connect(ctx, peer.AddrInfo{ID: other.ID(), Addrs: other.Addrs()})
+	assert.Error(t, err)
+	assert.Empty(t, node.Host.Network().Conns())
+	// and the known peers are not reconnected to, nor the bootstrappers probed, nor the starvation
+	// watched
+	p2pCfg := readOnlyP2PConfig(cfg.P2P)
+	assert.Zero(t, p2pCfg.ReconnectPeers)
+	assert.Zero(t, p2pCfg.BootstrapProbeInterval)
+	assert.Zero(t, p2pCfg.StarvationTimeout)
+
+	head, err := node.HeaderServ.Head(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 11, head.Height)
+	got, err := node.HeaderServ.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, got.Height)
+	_, err = node.DASer.SamplingStats(ctx)
+	assert.Error(t, err)
+}
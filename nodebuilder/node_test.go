@@ -5,9 +5,11 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/gbrlsnchs/jwt/v3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/rpc"
 )
 
 func TestLifecycle(t *testing.T) {
@@ -37,13 +39,19 @@ func TestLifecycle(t *testing.T) {
 			require.NoError(t, err)
 
 			// ensure the state service is running
-			require.False(t, node.StateServ.IsStopped())
+			require.False(t, node.StateServ.IsStopped(ctx))
 
 			err = node.Stop(ctx)
 			require.NoError(t, err)
 
 			// ensure the state service is stopped
-			require.True(t, node.StateServ.IsStopped())
+			require.True(t, node.StateServ.IsStopped(ctx))
 		})
 	}
 }
+
+func TestNode_WithSecret(t *testing.T) {
+	signer := jwt.NewHS256([]byte("secret"))
+	nd := TestNode(t, node.Light, rpc.WithSecret(signer))
+	require.Equal(t, signer, nd.AdminSigner)
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/notify"
+)
+
+// Config configures the webhooks the critical events of the node are delivered to.
+type Config struct {
+	// Webhooks are the endpoints the events are POSTed to. None disables the notifications.
+	Webhooks []notify.Webhook
+	// Events are the types of the events delivered to the Webhooks.
+	Events []events.Type
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Webhooks: []notify.Webhook{},
+		Events: []events.Type{
+			events.DASFailure,
+			events.FraudProof,
+			events.ForkDetected,
+			events.PeerStarvation,
+			events.SyncStalled,
+			events.DiskSpaceLow,
+		},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	if len(cfg.Webhooks) == 0 {
+		return nil
+	}
+	if len(cfg.Events) == 0 {
+		return fmt.Errorf("notify: no events configured for the webhooks")
+	}
+	for _, hook := range cfg.Webhooks {
+		u, err := url.Parse(hook.URL)
+		if err != nil {
+			return fmt.Errorf("notify: invalid webhook %s: %w", hook.URL, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("notify: webhook %s must be an http or https URL", hook.URL)
+		}
+	}
+	return nil
+}
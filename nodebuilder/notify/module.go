@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/notify"
+)
+
+// ConstructModule collects the Notifier delivering the critical events of the node to the
+// configured webhooks, if any.
+func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if len(cfg.Webhooks) == 0 {
+		return fx.Options()
+	}
+
+	switch tp {
+	case node.Light, node.Full, node.Bridge:
+		return fx.Module(
+			"notify",
+			fx.Supply(*cfg),
+			fx.Error(cfgErr),
+			fx.Provide(fx.Annotate(
+				func(cfg Config, id peer.ID, bus *events.Bus) *notify.Notifier {
+					return notify.NewNotifier(id, bus, cfg.Webhooks, cfg.Events)
+				},
+				fx.OnStart(func(ctx context.Context, n *notify.Notifier) error {
+					return n.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, n *notify.Notifier) error {
+					return n.Stop(ctx)
+				}),
+			)),
+			fx.Invoke(func(*notify.Notifier) {}),
+		)
+	default:
+		panic("invalid node type")
+	}
+}
@@ -6,23 +6,33 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	p2pconfig "github.com/libp2p/go-libp2p/config"
 	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 )
 
 // Listen returns invoke function that starts listening for inbound connections with libp2p.Host.
-func Listen(listen []string) func(host host.Host) (err error) {
+// Addresses of the transports which are not enabled are skipped.
+func Listen(listen []string, transports TransportsConfig) func(host host.Host) (err error) {
 	return func(host host.Host) (err error) {
-		maListen := make([]ma.Multiaddr, len(listen))
-		for i, addr := range listen {
-			maListen[i], err = ma.NewMultiaddr(addr)
+		maListen := make([]ma.Multiaddr, 0, len(listen))
+		for _, addr := range listen {
+			maddr, err := ma.NewMultiaddr(addr)
 			if err != nil {
 				return fmt.Errorf("failure to parse config.P2P.ListenAddresses: %s", err)
 			}
+			if !transports.enabled(maddr) {
+				log.Warnw("skipping listen address of disabled transport", "addr", maddr)
+				continue
+			}
+			maListen = append(maListen, maddr)
 		}
 		return host.Network().Listen(maListen...)
 	}
 }
 
-// AddrsFactory returns a constructor for AddrsFactory.
+// AddrsFactory returns a constructor for AddrsFactory advertising the announce addresses, e.g.
+// the public ones of a load balancer or a static IP, along with the listen addresses not matching
+// the noAnnounce ones. A noAnnounce address with the ipcidr component, e.g. /ip4/10.0.0.0/ipcidr/8,
+// filters out all the addresses in its network.
 func AddrsFactory(announce []string, noAnnounce []string) func() (_ p2pconfig.AddrsFactory, err error) {
 	return func() (_ p2pconfig.AddrsFactory, err error) {
 		// Convert maAnnounce strings to Multiaddresses
@@ -34,14 +44,22 @@ func AddrsFactory(announce []string, noAnnounce []string) func() (_ p2pconfig.Ad
 			}
 		}
 
-		// TODO(@Wondertan): Support filtering with network masks for noAnnounce, e.g. 255.255.255.0
-		// Collect all addresses that should not be announced
+		// Collect all addresses and networks that should not be announced
 		maNoAnnounce := make(map[string]bool, len(noAnnounce))
+		filters := ma.NewFilters()
 		for _, addr := range noAnnounce {
 			maddr, err := ma.NewMultiaddr(addr)
 			if err != nil {
 				return nil, fmt.Errorf("failure to parse config.P2P.NoAnnounceAddresses: %s", err)
 			}
+			if _, err := maddr.ValueForProtocol(ma.P_IPCIDR); err == nil {
+				ipnet, err := manet.MultiaddrToIPNet(maddr)
+				if err != nil {
+					return nil, fmt.Errorf("failure to parse config.P2P.NoAnnounceAddresses: %s", err)
+				}
+				filters.AddFilter(*ipnet, ma.ActionDeny)
+				continue
+			}
 			maNoAnnounce[string(maddr.Bytes())] = true
 		}
 
@@ -52,10 +70,10 @@ func AddrsFactory(announce []string, noAnnounce []string) func() (_ p2pconfig.Ad
 
 			// filter out unneeded
 			for _, maddr := range maListen {
-				ok := maNoAnnounce[string(maddr.Bytes())]
-				if !ok {
-					out = append(out, maddr)
+				if maNoAnnounce[string(maddr.Bytes())] || filters.AddrBlocked(maddr) {
+					continue
 				}
+				out = append(out, maddr)
 			}
 			return out
 		}, nil
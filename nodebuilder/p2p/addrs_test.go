@@ -0,0 +1,31 @@
+package p2p
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddrsFactory(t *testing.T) {
+	factory, err := AddrsFactory(
+		[]string{"/ip4/1.2.3.4/tcp/2121"},
+		[]string{"/ip4/127.0.0.1/tcp/2121", "/ip4/10.0.0.0/ipcidr/8"},
+	)()
+	require.NoError(t, err)
+
+	listen := []ma.Multiaddr{
+		ma.StringCast("/ip4/127.0.0.1/tcp/2121"),
+		ma.StringCast("/ip4/10.1.2.3/tcp/2121"),
+		ma.StringCast("/ip4/10.1.2.3/udp/2121/quic"),
+		ma.StringCast("/ip4/192.168.1.2/tcp/2121"),
+	}
+	assert.Equal(t, []ma.Multiaddr{
+		ma.StringCast("/ip4/1.2.3.4/tcp/2121"),
+		ma.StringCast("/ip4/192.168.1.2/tcp/2121"),
+	}, factory(listen))
+
+	_, err = AddrsFactory(nil, []string{"/ip4/10.0.0.0/ipcidr/33"})()
+	assert.Error(t, err)
+}
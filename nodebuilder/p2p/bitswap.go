@@ -2,17 +2,21 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ipfs/go-bitswap"
+	bsmsg "github.com/ipfs/go-bitswap/message"
 	"github.com/ipfs/go-bitswap/network"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	exchange "github.com/ipfs/go-ipfs-exchange-interface"
 	"github.com/libp2p/go-libp2p-core/host"
-	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/libp2p/go-libp2p-core/peer"
 	routinghelpers "github.com/libp2p/go-libp2p-routing-helpers"
 	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 )
 
 const (
@@ -22,13 +26,20 @@ const (
 	defaultBloomFilterHashes = 7
 	// default size of arc cache in blockStore
 	defaultARCCacheSize = 64 << 10
+	// default size of the read-through cache of blocks in bytes
+	defaultBlockCacheSize = 64 << 20
 )
 
-// DataExchange provides a constructor for IPFS block's DataExchange over BitSwap.
-func DataExchange(params bitSwapParams) (exchange.Interface, blockstore.Blockstore, error) {
+// Blockstore constructs the blockstore of IPFS blocks with a read-through cache of blocks in front
+// of it.
+func Blockstore(
+	ctx context.Context,
+	cfg Config,
+	ds datastore.Batching,
+) (blockstore.Blockstore, *ipld.BlockCache, error) {
 	bs, err := blockstore.CachedBlockstore(
-		params.Ctx,
-		blockstore.NewBlockstore(params.Ds),
+		ctx,
+		blockstore.NewBlockstore(ds),
 		blockstore.CacheOpts{
 			HasBloomFilterSize:   defaultBloomFilterSize,
 			HasBloomFilterHashes: defaultBloomFilterHashes,
@@ -38,24 +49,79 @@ func DataExchange(params bitSwapParams) (exchange.Interface, blockstore.Blocksto
 	if err != nil {
 		return nil, nil, err
 	}
-	prefix := protocol.ID(fmt.Sprintf("/celestia/%s", params.Net))
-	return bitswap.New(
-		params.Ctx,
-		network.NewFromIpfsHost(params.Host, &routinghelpers.Null{}, network.Prefix(prefix)),
-		bs,
+	bc, err := ipld.NewBlockCache(bs, cfg.BlockCacheSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bc, bc, nil
+}
+
+// DataExchange provides a constructor for IPFS block's DataExchange over BitSwap.
+func DataExchange(params bitSwapParams) exchange.Interface {
+	prefix := params.NetID.ProtocolPrefix()
+	opts := []bitswap.Option{
 		bitswap.ProvideEnabled(false),
 		// NOTE: These below ar required for our protocol to work reliably.
 		// See https://github.com/celestiaorg/celestia-node/issues/732
 		bitswap.SetSendDontHaves(false),
 		bitswap.SetSimulateDontHavesOnTimeout(false),
-	), bs, nil
+	}
+	var tracers multiTracer
+	if params.Tracer != nil {
+		tracers = append(tracers, params.Tracer)
+	}
+	// the quota is installed even if disabled, as it can be enabled without restarting the node
+	if params.Quota != nil {
+		tracers = append(tracers, params.Quota)
+	}
+	if params.Quota != nil || params.Poisoned != nil {
+		opts = append(opts, bitswap.WithPeerBlockRequestFilter(params.allow))
+	}
+	if len(tracers) != 0 {
+		opts = append(opts, bitswap.WithTracer(tracers))
+	}
+	return bitswap.New(
+		params.Ctx,
+		network.NewFromIpfsHost(params.Host, &routinghelpers.Null{}, network.Prefix(prefix)),
+		params.Bs,
+		opts...,
+	)
 }
 
 type bitSwapParams struct {
 	fx.In
 
-	Ctx  context.Context
-	Net  Network
-	Host host.Host
-	Ds   datastore.Batching
+	Ctx   context.Context
+	NetID NetworkID
+	Host  host.Host
+	Bs    blockstore.Blockstore
+	// Tracer accounts the served blocks of shares, if provided.
+	Tracer *ipld.ServeTracer `optional:"true"`
+	// Quota enforces the quotas of serving the blocks of shares to the peers, if provided.
+	Quota *ipld.ServeQuota `optional:"true"`
+	// Poisoned lists the blocks of the squares proven fraudulent, which are never served, if provided.
+	Poisoned *share.PoisonList `optional:"true"`
+}
+
+// allow reports whether the block requested by the peer is served.
+func (params bitSwapParams) allow(p peer.ID, c cid.Cid) bool {
+	if params.Poisoned != nil && params.Poisoned.IsBlockPoisoned(c) {
+		return false
+	}
+	return params.Quota == nil || params.Quota.Allow(p, c)
+}
+
+// multiTracer passes the messages of Bitswap to every tracer, as Bitswap accepts a single one.
+type multiTracer []bitswap.Tracer
+
+func (mt multiTracer) MessageReceived(p peer.ID, msg bsmsg.BitSwapMessage) {
+	for _, t := range mt {
+		t.MessageReceived(p, msg)
+	}
+}
+
+func (mt multiTracer) MessageSent(p peer.ID, msg bsmsg.BitSwapMessage) {
+	for _, t := range mt {
+		t.MessageSent(p, msg)
+	}
 }
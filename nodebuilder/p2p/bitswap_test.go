@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestDataExchange_RefusesPoisoned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+
+	served, poisonedBlock := blocks.NewBlock([]byte("served")), blocks.NewBlock([]byte("poisoned"))
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, bs.PutMany(ctx, []blocks.Block{served, poisonedBlock}))
+	poisoned := share.NewPoisonList()
+	poisoned.PoisonBlocks(poisonedBlock.Cid())
+
+	server := DataExchange(bitSwapParams{
+		Ctx:      ctx,
+		NetID:    NetworkID(Private),
+		Host:     net.Hosts()[0],
+		Bs:       bs,
+		Poisoned: poisoned,
+	})
+	t.Cleanup(func() {
+		server.Close() //nolint:errcheck
+	})
+	client := DataExchange(bitSwapParams{
+		Ctx:   ctx,
+		NetID: NetworkID(Private),
+		Host:  net.Hosts()[1],
+		Bs:    blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore())),
+	})
+	t.Cleanup(func() {
+		client.Close() //nolint:errcheck
+	})
+
+	require.NoError(t, net.ConnectAllButSelf())
+
+	got, err := client.GetBlock(ctx, served.Cid())
+	require.NoError(t, err)
+	assert.Equal(t, served.RawData(), got.RawData())
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, time.Second)
+	defer reqCancel()
+	_, err = client.GetBlock(reqCtx, poisonedBlock.Cid())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
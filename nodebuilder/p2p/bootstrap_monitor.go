@@ -0,0 +1,249 @@
+package p2p
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+const (
+	// maxBootstrapFailures is the amount of the consecutive failed probes a bootstrapper is marked
+	// unhealthy after.
+	maxBootstrapFailures = 3
+	// bootstrapProbeTimeout is the timeout of connecting to and pinging a bootstrapper.
+	bootstrapProbeTimeout = 10 * time.Second
+)
+
+// BootstrapperStatus is the health of a bootstrapper as probed by the node.
+type BootstrapperStatus struct {
+	ID peer.ID
+	// Active reports whether the node keeps connected to the bootstrapper, instead of keeping it as
+	// an alternate to rotate to.
+	Active bool
+	// Healthy reports whether the bootstrapper responds to the probes. The bootstrappers never
+	// probed are considered healthy.
+	Healthy bool
+	// Failures is the amount of the consecutive failed probes.
+	Failures int
+	// LastProbe is the time of the last probe, if any.
+	LastProbe time.Time
+	// RTT is the round trip time measured by the last successful probe.
+	RTT time.Duration
+	// Error is the error of the last failed probe.
+	Error string
+}
+
+// bootstrapMonitor periodically probes the active bootstrappers and rotates the unhealthy ones to
+// the healthy alternates of the network, so that an outage of the bootstrappers does not silently
+// degrade the discovery of peers. The unhealthy bootstrappers are probed as well, until they
+// recover.
+type bootstrapMonitor struct {
+	host     HostBase
+	bus      *events.Bus
+	interval time.Duration
+	peers    Bootstrappers
+
+	lk       sync.Mutex
+	statuses []BootstrapperStatus
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newBootstrapMonitor(cfg Config, h HostBase, bpeers Bootstrappers, bus *events.Bus) *bootstrapMonitor {
+	// the bootstrappers don't probe themselves
+	peers := make(Bootstrappers, 0, len(bpeers))
+	for _, info := range bpeers {
+		if info.ID != h.ID() {
+			peers = append(peers, info)
+		}
+	}
+	active := cfg.ActiveBootstrappers
+	if active == 0 || active > len(peers) {
+		active = len(peers)
+	}
+
+	statuses := make([]BootstrapperStatus, len(peers))
+	for i, info := range peers {
+		statuses[i] = BootstrapperStatus{ID: info.ID, Healthy: true}
+	}
+	// the active bootstrappers are chosen at random, so that the nodes spread over all of them
+	for _, i := range rand.Perm(len(peers))[:active] {
+		statuses[i].Active = true
+	}
+	return &bootstrapMonitor{
+		host:     h,
+		bus:      bus,
+		interval: cfg.BootstrapProbeInterval,
+		peers:    peers,
+		statuses: statuses,
+	}
+}
+
+// Start starts probing the bootstrappers, unless disabled. The alternates are not protected from
+// being pruned by ConnManager until rotated to.
+func (bm *bootstrapMonitor) Start(context.Context) error {
+	if bm.interval == 0 || len(bm.peers) == 0 {
+		return nil
+	}
+	for _, status := range bm.statuses {
+		if !status.Active {
+			bm.host.ConnManager().Unprotect(status.ID, bootstrapTag)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bm.cancel, bm.done = cancel, make(chan struct{})
+	go bm.run(ctx)
+	return nil
+}
+
+// Stop stops probing the bootstrappers.
+func (bm *bootstrapMonitor) Stop(ctx context.Context) error {
+	if bm.cancel == nil {
+		return nil
+	}
+	bm.cancel()
+	select {
+	case <-bm.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Statuses returns the current statuses of the bootstrappers.
+func (bm *bootstrapMonitor) Statuses() []BootstrapperStatus {
+	bm.lk.Lock()
+	defer bm.lk.Unlock()
+	return append([]BootstrapperStatus{}, bm.statuses...)
+}
+
+func (bm *bootstrapMonitor) run(ctx context.Context) {
+	defer close(bm.done)
+	ticker := time.NewTicker(bm.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			bm.probeAll(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probeAll probes the active and the unhealthy bootstrappers, rotating the unhealthy active ones to
+// the healthy alternates, which are probed right away.
+func (bm *bootstrapMonitor) probeAll(ctx context.Context) {
+	bm.lk.Lock()
+	var probed []int
+	for i, status := range bm.statuses {
+		if status.Active || !status.Healthy {
+			probed = append(probed, i)
+		}
+	}
+	bm.lk.Unlock()
+
+	bm.probe(ctx, probed)
+	bm.probe(ctx, bm.rotate())
+}
+
+// probe probes the bootstrappers under the given indexes concurrently, recording the results.
+func (bm *bootstrapMonitor) probe(ctx context.Context, indexes []int) {
+	var wg sync.WaitGroup
+	for _, i := range indexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rtt, err := bm.ping(ctx, bm.peers[i])
+			if ctx.Err() != nil {
+				return
+			}
+			bm.record(i, rtt, err)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ping connects to the bootstrapper, if not connected yet, and measures the round trip time to it.
+func (bm *bootstrapMonitor) ping(ctx context.Context, info peer.AddrInfo) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, bootstrapProbeTimeout)
+	defer cancel()
+
+	err := bm.host.Connect(ctx, info)
+	if err != nil {
+		return 0, err
+	}
+	select {
+	case res := <-ping.Ping(ctx, bm.host, info.ID):
+		return res.RTT, res.Error
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// record records the result of probing the bootstrapper under the given index.
+func (bm *bootstrapMonitor) record(i int, rtt time.Duration, err error) {
+	bm.lk.Lock()
+	defer bm.lk.Unlock()
+	status := &bm.statuses[i]
+	status.LastProbe = time.Now()
+	if err == nil {
+		if !status.Healthy {
+			log.Infow("bootstrapper recovered", "peer", status.ID, "failures", status.Failures)
+		}
+		status.Healthy, status.Failures, status.RTT, status.Error = true, 0, rtt, ""
+		return
+	}
+
+	status.Failures++
+	status.Error = err.Error()
+	if status.Healthy && status.Failures >= maxBootstrapFailures {
+		status.Healthy = false
+		log.Warnw("bootstrapper is unhealthy", "peer", status.ID, "failures", status.Failures, "err", err)
+		bm.bus.Publish(events.Event{
+			Type:    events.BootstrapperUnhealthy,
+			Peer:    status.ID,
+			Message: status.Error,
+		})
+	}
+}
+
+// rotate replaces the unhealthy active bootstrappers with the healthy alternates, returning the
+// indexes of the ones rotated to. The unhealthy ones stay active, while there are no alternates.
+func (bm *bootstrapMonitor) rotate() []int {
+	bm.lk.Lock()
+	defer bm.lk.Unlock()
+
+	var rotated []int
+	for i := range bm.statuses {
+		if !bm.statuses[i].Active || bm.statuses[i].Healthy {
+			continue
+		}
+		alt := -1
+		for j, status := range bm.statuses {
+			if !status.Active && status.Healthy {
+				alt = j
+				break
+			}
+		}
+		if alt == -1 {
+			log.Warnw("no healthy alternate to rotate unhealthy bootstrapper to", "peer", bm.statuses[i].ID)
+			continue
+		}
+
+		bm.statuses[i].Active, bm.statuses[alt].Active = false, true
+		bm.host.ConnManager().Unprotect(bm.statuses[i].ID, bootstrapTag)
+		bm.host.ConnManager().Protect(bm.statuses[alt].ID, bootstrapTag)
+		log.Infow("rotated unhealthy bootstrapper", "from", bm.statuses[i].ID, "to", bm.statuses[alt].ID)
+		rotated = append(rotated, alt)
+	}
+	return rotated
+}
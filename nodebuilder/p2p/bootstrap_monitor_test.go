@@ -0,0 +1,85 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libhost "github.com/libp2p/go-libp2p-core/host"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+func TestBootstrapMonitor_Rotate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+	host := net.Hosts()[0]
+	var bpeers Bootstrappers
+	for _, h := range net.Hosts()[1:] {
+		ping.NewPingService(h)
+		bpeers = append(bpeers, *libhost.InfoFromHost(h))
+	}
+
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.BootstrapperUnhealthy)
+	t.Cleanup(sub.Cancel)
+
+	cfg := DefaultConfig()
+	cfg.ActiveBootstrappers = 1
+	// the node itself is among the bootstrappers
+	bm := newBootstrapMonitor(cfg, host, append(bpeers, *libhost.InfoFromHost(host)), bus)
+
+	bm.probeAll(ctx)
+	statuses := bm.Statuses()
+	require.Len(t, statuses, 2)
+	// the active bootstrapper is chosen at random
+	active, alt := 0, 1
+	if statuses[1].Active {
+		active, alt = 1, 0
+	}
+	assert.True(t, statuses[active].Active)
+	assert.True(t, statuses[active].Healthy)
+	assert.False(t, statuses[active].LastProbe.IsZero())
+	// the alternate is not probed, while not needed
+	assert.False(t, statuses[alt].Active)
+	assert.True(t, statuses[alt].LastProbe.IsZero())
+
+	// the active bootstrapper goes down
+	require.NoError(t, net.UnlinkPeers(host.ID(), bpeers[active].ID))
+	require.NoError(t, net.DisconnectPeers(host.ID(), bpeers[active].ID))
+	for i := 0; i < maxBootstrapFailures; i++ {
+		bm.probeAll(ctx)
+	}
+
+	statuses = bm.Statuses()
+	assert.False(t, statuses[active].Active)
+	assert.False(t, statuses[active].Healthy)
+	assert.Equal(t, maxBootstrapFailures, statuses[active].Failures)
+	assert.NotEmpty(t, statuses[active].Error)
+	// and is rotated to the alternate, probed right away
+	assert.True(t, statuses[alt].Active)
+	assert.True(t, statuses[alt].Healthy)
+	assert.False(t, statuses[alt].LastProbe.IsZero())
+
+	select {
+	case e := <-sub.Out():
+		assert.Equal(t, bpeers[active].ID, e.Peer)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+
+	// the unhealthy bootstrapper is probed until it recovers
+	_, err = net.LinkPeers(host.ID(), bpeers[active].ID)
+	require.NoError(t, err)
+	bm.probeAll(ctx)
+	statuses = bm.Statuses()
+	assert.True(t, statuses[active].Healthy)
+	assert.False(t, statuses[active].Active)
+}
@@ -2,13 +2,20 @@ package p2p
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 )
 
-const defaultRoutingRefreshPeriod = time.Minute
+const (
+	defaultRoutingRefreshPeriod = time.Minute
+	defaultStarvationTimeout    = 5 * time.Minute
+	defaultReconnectPeers       = 20
+	defaultBootstrapProbe       = time.Minute
+	defaultActiveBootstrappers  = 2
+)
 
 // Config combines all configuration fields for P2P subsystem.
 type Config struct {
@@ -34,6 +41,35 @@ type Config struct {
 	// ConnManager is a configuration tuple for ConnectionManager.
 	ConnManager               ConnManagerConfig
 	RoutingTableRefreshPeriod time.Duration
+	// MDNS enables the discovery of peers in the local network over mDNS, so that nodes of local
+	// test networks interconnect automatically. It is never enabled on public networks.
+	MDNS bool
+	// Transports enables the transports of the host.
+	Transports TransportsConfig
+	// NAT configures the traversal of NATs.
+	NAT NATConfig
+	// PeerScoring configures the scoring of peers on the header and fraud gossipsub topics.
+	PeerScoring PeerScoringConfig
+	// BlockCacheSize is the size in bytes of the read-through cache of blocks kept in memory in front
+	// of the blockstore, accelerating repeated retrievals of the same data. 0 disables it.
+	BlockCacheSize int
+	// ResourceLimits limits the inbound streams and memory of the protocols in total and per peer.
+	ResourceLimits ResourceLimitsConfig
+	// NetworkID overrides the identifier the gossipsub topics and libp2p protocols are namespaced
+	// with, which is the chain ID of the network by default.
+	NetworkID string
+	// StarvationTimeout is the period of time the node may stay without connected peers for before a
+	// PeerStarvation event is published. 0 disables the check.
+	StarvationTimeout time.Duration
+	// ReconnectPeers is the amount of the known peers with the best history of connections the node
+	// reconnects to on start. The known peers are persisted across restarts, unless it is 0.
+	ReconnectPeers int
+	// BootstrapProbeInterval is the period of time between the probes of the active bootstrappers,
+	// which are rotated to the alternate ones of the network once unhealthy. 0 disables the probes.
+	BootstrapProbeInterval time.Duration
+	// ActiveBootstrappers is the amount of the bootstrappers of the network, chosen at random, the
+	// node keeps connected to, while the rest are the alternates. 0 keeps all of them active.
+	ActiveBootstrappers int
 }
 
 // DefaultConfig returns default configuration for P2P subsystem.
@@ -59,6 +95,16 @@ func DefaultConfig() Config {
 		PeerExchange:              false,
 		ConnManager:               DefaultConnManagerConfig(),
 		RoutingTableRefreshPeriod: defaultRoutingRefreshPeriod,
+		MDNS:                      false,
+		Transports:                DefaultTransportsConfig(),
+		NAT:                       DefaultNATConfig(),
+		PeerScoring:               DefaultPeerScoringConfig(),
+		BlockCacheSize:            defaultBlockCacheSize,
+		ResourceLimits:            DefaultResourceLimitsConfig(),
+		StarvationTimeout:         defaultStarvationTimeout,
+		ReconnectPeers:            defaultReconnectPeers,
+		BootstrapProbeInterval:    defaultBootstrapProbe,
+		ActiveBootstrappers:       defaultActiveBootstrappers,
 	}
 }
 
@@ -80,5 +126,39 @@ func (cfg *Config) Validate() error {
 		cfg.RoutingTableRefreshPeriod = defaultRoutingRefreshPeriod
 		log.Warnf("routingTableRefreshPeriod is not valid. restoring to default value: %d", cfg.RoutingTableRefreshPeriod)
 	}
-	return nil
+	if strings.ContainsAny(cfg.NetworkID, "/ \t\n") {
+		return fmt.Errorf("p2p: network ID must not contain slashes or whitespaces: %q", cfg.NetworkID)
+	}
+	if cfg.StarvationTimeout < 0 {
+		return fmt.Errorf("p2p: starvation timeout must not be negative: %s", cfg.StarvationTimeout)
+	}
+	if cfg.ReconnectPeers < 0 {
+		return fmt.Errorf("p2p: amount of peers to reconnect must not be negative: %d", cfg.ReconnectPeers)
+	}
+	if cfg.BootstrapProbeInterval < 0 {
+		return fmt.Errorf("p2p: bootstrap probe interval must not be negative: %s", cfg.BootstrapProbeInterval)
+	}
+	if cfg.ActiveBootstrappers < 0 {
+		return fmt.Errorf("p2p: amount of active bootstrappers must not be negative: %d", cfg.ActiveBootstrappers)
+	}
+	if cfg.BlockCacheSize < 0 {
+		return fmt.Errorf("p2p: block cache size must not be negative: %d", cfg.BlockCacheSize)
+	}
+	err := cfg.ConnManager.Validate()
+	if err != nil {
+		return err
+	}
+	err = cfg.Transports.Validate()
+	if err != nil {
+		return err
+	}
+	err = cfg.PeerScoring.Validate()
+	if err != nil {
+		return err
+	}
+	err = cfg.ResourceLimits.Validate()
+	if err != nil {
+		return err
+	}
+	return cfg.NAT.Validate()
 }
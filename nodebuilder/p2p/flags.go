@@ -14,8 +14,18 @@ import (
 const EnvCustomNetwork = "CELESTIA_CUSTOM"
 
 const (
-	networkFlag = "p2p.network"
-	mutualFlag  = "p2p.mutual"
+	networkFlag      = "p2p.network"
+	mutualFlag       = "p2p.mutual"
+	reachabilityFlag = "p2p.reachability"
+	relaysFlag       = "p2p.relays"
+	listenFlag       = "p2p.listen"
+	tcpFlag          = "p2p.tcp"
+	quicFlag         = "p2p.quic"
+	websocketFlag    = "p2p.websocket"
+	mdnsFlag         = "p2p.mdns"
+	networkIDFlag    = "p2p.network-id"
+	announceFlag     = "p2p.announce"
+	noAnnounceFlag   = "p2p.no-announce"
 )
 
 // Flags gives a set of p2p flags.
@@ -37,6 +47,61 @@ Peers must bidirectionally point to each other. (Format: multiformats.io/multiad
 			listProvidedNetworks()+
 			". Must be passed on both init and start to take effect.",
 	)
+	flags.String(
+		reachabilityFlag,
+		"",
+		"Overrides the reachability of the node detected by AutoNAT: public or private",
+	)
+	flags.StringSlice(
+		relaysFlag,
+		nil,
+		`Comma-separated multiaddresses of circuit relays to reserve slots on, when the node is not
+publicly reachable. Defaults to the bootstrappers of the network. (Format: multiformats.io/multiaddr)`,
+	)
+	flags.StringSlice(
+		listenFlag,
+		nil,
+		`Comma-separated multiaddresses to listen on for inbound connections, e.g. /ip4/0.0.0.0/tcp/2122/ws
+for WebSocket. Addresses of disabled transports are skipped. (Format: multiformats.io/multiaddr)`,
+	)
+	flags.StringSlice(
+		announceFlag,
+		nil,
+		`Comma-separated multiaddresses to advertise to peers in addition to the listen ones, e.g. the public
+address of a load balancer or a static IP the node is reachable at. (Format: multiformats.io/multiaddr)`,
+	)
+	flags.StringSlice(
+		noAnnounceFlag,
+		nil,
+		`Comma-separated multiaddresses never to advertise to peers. Addresses with the ipcidr component, e.g.
+/ip4/10.0.0.0/ipcidr/8, filter out the whole network. (Format: multiformats.io/multiaddr)`,
+	)
+	flags.Bool(
+		tcpFlag,
+		true,
+		"Enables the TCP transport",
+	)
+	flags.Bool(
+		quicFlag,
+		true,
+		"Enables the QUIC transport",
+	)
+	flags.Bool(
+		websocketFlag,
+		true,
+		"Enables the WebSocket transport, which browser-embedded light clients can connect over",
+	)
+	flags.Bool(
+		mdnsFlag,
+		false,
+		"Enables the discovery of peers in the local network over mDNS. Ignored on public networks",
+	)
+	flags.String(
+		networkIDFlag,
+		"",
+		`Overrides the identifier the gossipsub topics and libp2p protocols are namespaced with, so that
+sovereign forks and test networks don't mix their traffic with the network of the same chain ID`,
+	)
 
 	return flags
 }
@@ -61,7 +126,75 @@ func ParseFlags(
 	if len(mutualPeers) != 0 {
 		cfg.MutualPeers = mutualPeers
 	}
-	return nil
+
+	if networkID := cmd.Flag(networkIDFlag).Value.String(); networkID != "" {
+		cfg.NetworkID = networkID
+	}
+
+	reachability := cmd.Flag(reachabilityFlag).Value.String()
+	if reachability != "" {
+		cfg.NAT.Reachability = reachability
+	}
+	relays, err := cmd.Flags().GetStringSlice(relaysFlag)
+	if err != nil {
+		return err
+	}
+	if len(relays) != 0 {
+		cfg.NAT.StaticRelays = relays
+	}
+	err = cfg.NAT.Validate()
+	if err != nil {
+		return err
+	}
+
+	listen, err := cmd.Flags().GetStringSlice(listenFlag)
+	if err != nil {
+		return err
+	}
+	for _, addr := range listen {
+		_, err = multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			return fmt.Errorf("cmd: while parsing '%s': %w", listenFlag, err)
+		}
+	}
+	if len(listen) != 0 {
+		cfg.ListenAddresses = listen
+	}
+
+	for name, addrs := range map[string]*[]string{
+		announceFlag:   &cfg.AnnounceAddresses,
+		noAnnounceFlag: &cfg.NoAnnounceAddresses,
+	} {
+		parsed, err := cmd.Flags().GetStringSlice(name)
+		if err != nil {
+			return err
+		}
+		for _, addr := range parsed {
+			_, err = multiaddr.NewMultiaddr(addr)
+			if err != nil {
+				return fmt.Errorf("cmd: while parsing '%s': %w", name, err)
+			}
+		}
+		if len(parsed) != 0 {
+			*addrs = parsed
+		}
+	}
+
+	for name, enabled := range map[string]*bool{
+		mdnsFlag:      &cfg.MDNS,
+		tcpFlag:       &cfg.Transports.TCP,
+		quicFlag:      &cfg.Transports.QUIC,
+		websocketFlag: &cfg.Transports.WebSocket,
+	} {
+		if !cmd.Flag(name).Changed {
+			continue
+		}
+		*enabled, err = cmd.Flags().GetBool(name)
+		if err != nil {
+			return err
+		}
+	}
+	return cfg.Transports.Validate()
 }
 
 // ParseNetwork tries to parse the network from the flags and environment,
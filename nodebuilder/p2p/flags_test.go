@@ -69,3 +69,22 @@ func createCmdWithNetworkFlag() *cobra.Command {
 	cmd.Flags().AddFlagSet(flags)
 	return cmd
 }
+
+// TestParseFlags_networkID checks that the network ID overrides the chain ID of the network as the
+// namespace of the node's traffic.
+func TestParseFlags_networkID(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().AddFlagSet(Flags())
+	cfg := DefaultConfig()
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.Equal(t, NetworkID(Arabica), networkID(cfg, Arabica))
+
+	err := cmd.Flags().Set(networkIDFlag, "arabica-fork")
+	require.NoError(t, err)
+	require.NoError(t, ParseFlags(cmd, &cfg))
+	assert.Equal(t, NetworkID("arabica-fork"), networkID(cfg, Arabica))
+	assert.Equal(t, "/celestia/arabica-fork", string(networkID(cfg, Arabica).ProtocolPrefix()))
+
+	cfg.NetworkID = "arabica/fork"
+	assert.Error(t, cfg.Validate())
+}
@@ -2,7 +2,6 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/connmgr"
@@ -29,6 +28,11 @@ func RoutedHost(base HostBase, r routing.PeerRouting) host.Host {
 
 // Host returns constructor for Host.
 func Host(cfg Config, params hostParams, bw *metrics.BandwidthCounter, rm network.ResourceManager) (HostBase, error) {
+	natOpts, err := natOptions(cfg.NAT, params.Tp, params.Bootstrappers)
+	if err != nil {
+		return nil, err
+	}
+
 	opts := []libp2p.Option{
 		libp2p.NoListenAddrs, // do not listen automatically
 		libp2p.AddrsFactory(params.AddrF),
@@ -36,21 +40,17 @@ func Host(cfg Config, params hostParams, bw *metrics.BandwidthCounter, rm networ
 		libp2p.Peerstore(params.PStore),
 		libp2p.ConnectionManager(params.ConnMngr),
 		libp2p.ConnectionGater(params.ConnGater),
-		libp2p.UserAgent(fmt.Sprintf("celestia-%s", params.Net)),
+		libp2p.UserAgent(userAgent(params.Net)),
 		libp2p.NATPortMap(), // enables upnp
-		libp2p.DisableRelay(),
 		libp2p.BandwidthReporter(bw),
 		libp2p.ResourceManager(rm),
 		// to clearly define what defaults we rely upon
 		libp2p.DefaultSecurity,
-		libp2p.DefaultTransports,
 		libp2p.DefaultMuxers,
 	}
 
-	// All node types except light (bridge, full) will enable NATService
-	if params.Tp != node.Light {
-		opts = append(opts, libp2p.EnableNATService())
-	}
+	opts = append(opts, cfg.Transports.options()...)
+	opts = append(opts, natOpts...)
 
 	h, err := libp2p.NewWithoutDefaults(opts...)
 	if err != nil {
@@ -64,6 +64,32 @@ func Host(cfg Config, params hostParams, bw *metrics.BandwidthCounter, rm networ
 	return h, nil
 }
 
+// offlineHost constructs a Host without any transports, which can neither dial nor be dialed by
+// any peers, for the node running purely from its existing store.
+func offlineHost(params hostParams, rm network.ResourceManager) (HostBase, error) {
+	h, err := libp2p.NewWithoutDefaults(
+		libp2p.NoTransports,
+		libp2p.NoListenAddrs,
+		libp2p.Identity(params.Key),
+		libp2p.Peerstore(params.PStore),
+		libp2p.ConnectionManager(params.ConnMngr),
+		libp2p.ConnectionGater(params.ConnGater),
+		libp2p.UserAgent(userAgent(params.Net)),
+		libp2p.ResourceManager(rm),
+		libp2p.DefaultSecurity,
+		libp2p.DefaultMuxers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Lc.Append(fx.Hook{OnStop: func(context.Context) error {
+		return h.Close()
+	}})
+
+	return h, nil
+}
+
 type HostBase host.Host
 
 type hostParams struct {
@@ -77,6 +103,8 @@ type hostParams struct {
 	PStore    peerstore.Peerstore
 	ConnMngr  connmgr.ConnManager
 	ConnGater *conngater.BasicConnectionGater
+	// Bootstrappers are used as relays, unless others are configured
+	Bootstrappers Bootstrappers
 
 	Tp node.Type
 }
@@ -0,0 +1,67 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"go.uber.org/fx"
+)
+
+// mdnsConnectTimeout is the time given to connect to a peer discovered over mDNS.
+var mdnsConnectTimeout = time.Second * 10
+
+// mdnsDiscovery starts the discovery of the peers of the same Network in the local network over
+// mDNS, if enabled. It is never started on public networks.
+func mdnsDiscovery(cfg Config, net Network, lc fx.Lifecycle, h HostBase) {
+	if !cfg.MDNS {
+		return
+	}
+	if net.isPublic() {
+		log.Warnw("mDNS discovery is disabled on public networks", "network", net)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := mdns.NewMdnsService(h, mdnsServiceName(net), &mdnsNotifee{ctx: ctx, host: h})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			return srv.Start()
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return srv.Close()
+		},
+	})
+}
+
+// mdnsServiceName namespaces the mDNS service with the Network, so that nodes of different
+// networks do not discover each other.
+func mdnsServiceName(net Network) string {
+	return fmt.Sprintf("_celestia-%s._udp", net)
+}
+
+// mdnsNotifee connects to the peers discovered over mDNS.
+type mdnsNotifee struct {
+	ctx  context.Context
+	host host.Host
+}
+
+func (n *mdnsNotifee) HandlePeerFound(info peer.AddrInfo) {
+	if info.ID == n.host.ID() {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(n.ctx, mdnsConnectTimeout)
+		defer cancel()
+		err := n.host.Connect(ctx, info)
+		if err != nil {
+			log.Debugw("connecting to peer discovered over mDNS", "peer", info.ID, "err", err)
+			return
+		}
+		log.Debugw("connected to peer discovered over mDNS", "peer", info.ID)
+	}()
+}
@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMDNSNotifee_ConnectsToFoundPeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	h, peer := net.Hosts()[0], net.Hosts()[1]
+
+	notifee := &mdnsNotifee{ctx: ctx, host: h}
+	notifee.HandlePeerFound(*host.InfoFromHost(h))
+	notifee.HandlePeerFound(*host.InfoFromHost(peer))
+	require.Eventually(t, func() bool {
+		return h.Network().Connectedness(peer.ID()) == network.Connected
+	}, time.Second*5, time.Millisecond*10)
+}
+
+// TestNetwork_isPublic ensures mDNS discovery can't be enabled on the long-running networks.
+func TestNetwork_isPublic(t *testing.T) {
+	for _, net := range []Network{Arabica, Mamaki} {
+		assert.True(t, net.isPublic(), net)
+	}
+	assert.False(t, Private.isPublic())
+	assert.False(t, Network("custom").isPublic())
+}
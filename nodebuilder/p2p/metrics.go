@@ -0,0 +1,96 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+var meter = global.MeterProvider().Meter("p2p")
+
+// WithMetrics enables Otel metrics to monitor the reachability of the node detected by AutoNAT,
+// the bandwidth consumed per protocol and per connected peer, the hits of the resource limits and
+// the versions run by the connected peers.
+func WithMetrics(host HostBase, bw *metrics.BandwidthCounter, hits *limitHits) {
+	reachabilityG, _ := meter.AsyncInt64().Gauge(
+		"nat_reachability",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Reachability of the node detected by AutoNAT: 0 - unknown, 1 - public, 2 - private"),
+	)
+	protocolBytes, _ := meter.AsyncInt64().Counter(
+		"p2p_protocol_bytes",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Bytes sent and received over each protocol"),
+	)
+	peerBytes, _ := meter.AsyncInt64().Counter(
+		"p2p_peer_bytes",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("Bytes sent to and received from each connected peer"),
+	)
+	limitHitsC, _ := meter.AsyncInt64().Counter(
+		"p2p_resource_limit_hits",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Resource reservations blocked by the resource manager per scope and protocol"),
+	)
+	peerVersionsG, _ := meter.AsyncInt64().Gauge(
+		"p2p_peer_versions",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Connected peers per version they run"),
+	)
+	outdatedPeersG, _ := meter.AsyncInt64().Gauge(
+		"p2p_outdated_peers",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Connected peers running older versions than the node"),
+	)
+
+	err := meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			reachabilityG,
+			protocolBytes,
+			peerBytes,
+			limitHitsC,
+			peerVersionsG,
+			outdatedPeersG,
+		},
+		func(ctx context.Context) {
+			hits.forEach(func(scope string, proto protocol.ID, count int64) {
+				limitHitsC.Observe(ctx, count,
+					attribute.String("scope", scope), attribute.String("protocol", string(proto)))
+			})
+			for proto, stats := range bw.GetBandwidthByProtocol() {
+				protocolBytes.Observe(ctx, stats.TotalIn,
+					attribute.String("protocol", string(proto)), attribute.String("direction", "in"))
+				protocolBytes.Observe(ctx, stats.TotalOut,
+					attribute.String("protocol", string(proto)), attribute.String("direction", "out"))
+			}
+			for _, id := range host.Network().Peers() {
+				stats := bw.GetBandwidthForPeer(id)
+				peerBytes.Observe(ctx, stats.TotalIn,
+					attribute.String("peer", id.String()), attribute.String("direction", "in"))
+				peerBytes.Observe(ctx, stats.TotalOut,
+					attribute.String("peer", id.String()), attribute.String("direction", "out"))
+			}
+
+			versions := versionStats(host.Peerstore(), host.Network().Peers(), localVersion())
+			for version, count := range versions.Peers {
+				peerVersionsG.Observe(ctx, int64(count), attribute.String("version", version))
+			}
+			outdatedPeersG.Observe(ctx, int64(versions.Outdated), attribute.String("version", versions.Version))
+
+			status, err := reachability(host)
+			if err != nil {
+				reachabilityG.Observe(ctx, 0, attribute.String("err", err.Error()))
+				return
+			}
+			reachabilityG.Observe(ctx, int64(status))
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
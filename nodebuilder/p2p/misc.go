@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ipfs/go-datastore"
@@ -11,6 +12,9 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 )
 
+// bootstrapTag protects the connections with bootstrappers from being pruned by ConnManager.
+const bootstrapTag = "protected-bootstrap"
+
 // ConnManagerConfig configures connection manager.
 type ConnManagerConfig struct {
 	// Low and High are watermarks governing the number of connections that'll be maintained.
@@ -29,6 +33,17 @@ func DefaultConnManagerConfig() ConnManagerConfig {
 	}
 }
 
+// Validate performs basic validation of the config.
+func (cfg *ConnManagerConfig) Validate() error {
+	if cfg.Low < 0 || cfg.High <= 0 || cfg.Low > cfg.High {
+		return fmt.Errorf("p2p: invalid connection manager watermarks: low %d, high %d", cfg.Low, cfg.High)
+	}
+	if cfg.GracePeriod < 0 {
+		return fmt.Errorf("p2p: connection manager grace period must not be negative")
+	}
+	return nil
+}
+
 // ConnectionManager provides a constructor for ConnectionManager.
 func ConnectionManager(cfg Config, bpeers Bootstrappers) (coreconnmgr.ConnManager, error) {
 	fpeers, err := cfg.mutualPeers()
@@ -44,10 +59,10 @@ func ConnectionManager(cfg Config, bpeers Bootstrappers) (coreconnmgr.ConnManage
 		return nil, err
 	}
 	for _, info := range fpeers {
-		cm.Protect(info.ID, "protected-mutual")
+		cm.Protect(info.ID, mutualPeersTag)
 	}
 	for _, info := range bpeers {
-		cm.Protect(info.ID, "protected-bootstrap")
+		cm.Protect(info.ID, bootstrapTag)
 	}
 
 	return cm, nil
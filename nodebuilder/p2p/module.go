@@ -1,10 +1,10 @@
 package p2p
 
 import (
+	"context"
+
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p-core/metrics"
-	"github.com/libp2p/go-libp2p-core/network"
-	rcmgr "github.com/libp2p/go-libp2p-resource-manager"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
@@ -13,39 +13,63 @@ import (
 var log = logging.Logger("module/p2p")
 
 // ConstructModule collects all the components and services related to p2p.
-func ConstructModule(tp node.Type, cfg *Config) fx.Option {
+// If 'readOnly', the Host has no transports, so that no peers are ever connected, and the lack of
+// peers is not watched.
+func ConstructModule(tp node.Type, cfg *Config, readOnly bool) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
 
 	baseComponents := fx.Options(
 		fx.Supply(*cfg),
 		fx.Error(cfgErr),
+		fx.Provide(networkID),
 		fx.Provide(Key),
 		fx.Provide(ID),
 		fx.Provide(PeerStore),
 		fx.Provide(ConnectionManager),
 		fx.Provide(ConnectionGater),
-		fx.Provide(Host),
 		fx.Provide(RoutedHost),
 		fx.Provide(PubSub),
+		fx.Provide(Blockstore),
 		fx.Provide(DataExchange),
 		fx.Provide(BlockService),
 		fx.Provide(PeerRouting),
 		fx.Provide(ContentRouting),
 		fx.Provide(AddrsFactory(cfg.AnnounceAddresses, cfg.NoAnnounceAddresses)),
 		fx.Provide(metrics.NewBandwidthCounter),
-		fx.Provide(func() (network.ResourceManager, error) {
-			return rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(rcmgr.DefaultLimits.AutoScale()))
-		}),
+		fx.Provide(newLimitHits),
+		fx.Provide(resourceManager),
+		fx.Provide(fx.Annotate(
+			newBootstrapMonitor,
+			fx.OnStart(func(ctx context.Context, bm *bootstrapMonitor) error {
+				return bm.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, bm *bootstrapMonitor) error {
+				return bm.Stop(ctx)
+			}),
+		)),
 		fx.Provide(newModule),
-		fx.Invoke(Listen(cfg.ListenAddresses)),
+		fx.Invoke(Listen(cfg.ListenAddresses, cfg.Transports)),
+		fx.Invoke(registerReloadable),
+		fx.Invoke(mdnsDiscovery),
+		fx.Invoke(persistPeers),
+	)
+
+	hostComponents := fx.Options(
+		fx.Provide(Host),
+		fx.Invoke(watchStarvation),
 	)
+	if readOnly {
+		// never connected to any peers, so never starving either
+		hostComponents = fx.Provide(offlineHost)
+	}
 
 	switch tp {
 	case node.Light, node.Full, node.Bridge:
 		return fx.Module(
 			"p2p",
 			baseComponents,
+			hostComponents,
 		)
 	default:
 		panic("invalid node type")
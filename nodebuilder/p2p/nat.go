@@ -0,0 +1,132 @@
+package p2p
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+const (
+	reachabilityPublic  = "public"
+	reachabilityPrivate = "private"
+)
+
+// NATConfig configures the traversal of NATs, so nodes behind them can be reached by peers.
+type NATConfig struct {
+	// Reachability overrides the reachability of the node detected by AutoNAT, either "public" or
+	// "private". If empty, AutoNAT detects it by asking peers to dial the node back.
+	Reachability string
+	// AutoNATService enables Full and Bridge nodes to dial peers back, so they can detect their
+	// reachability.
+	AutoNATService bool
+	// HolePunching enables DCUtR hole punching, upgrading relayed connections to direct ones.
+	HolePunching bool
+	// AutoRelay enables the node to reserve slots on circuit relay v2 relays and to advertise the
+	// relayed addresses once it detects it's not publicly reachable.
+	AutoRelay bool
+	// StaticRelays are the multiaddresses of the relays used by AutoRelay.
+	// If empty, the bootstrappers of the network are used.
+	StaticRelays []string
+	// RelayService enables Full and Bridge nodes to serve as circuit relay v2 relays, once they
+	// detect they are publicly reachable.
+	RelayService bool
+}
+
+// DefaultNATConfig returns the default NATConfig.
+func DefaultNATConfig() NATConfig {
+	return NATConfig{
+		AutoNATService: true,
+		HolePunching:   true,
+		AutoRelay:      true,
+		StaticRelays:   []string{},
+		RelayService:   true,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *NATConfig) Validate() error {
+	switch cfg.Reachability {
+	case "", reachabilityPublic, reachabilityPrivate:
+	default:
+		return fmt.Errorf("p2p: invalid reachability: %s", cfg.Reachability)
+	}
+	_, err := cfg.staticRelays(nil)
+	return err
+}
+
+// staticRelays returns the relays used by AutoRelay, falling back to the given bootstrappers.
+func (cfg *NATConfig) staticRelays(bpeers Bootstrappers) ([]peer.AddrInfo, error) {
+	if len(cfg.StaticRelays) == 0 {
+		return bpeers, nil
+	}
+	relays, err := parseAddrInfos(cfg.StaticRelays)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: invalid static relays: %w", err)
+	}
+	return relays, nil
+}
+
+// natOptions returns the options of the libp2p Host traversing NATs according to the given
+// config.
+func natOptions(cfg NATConfig, tp node.Type, bpeers Bootstrappers) ([]libp2p.Option, error) {
+	var opts []libp2p.Option
+	switch cfg.Reachability {
+	case reachabilityPublic:
+		opts = append(opts, libp2p.ForceReachabilityPublic())
+	case reachabilityPrivate:
+		opts = append(opts, libp2p.ForceReachabilityPrivate())
+	}
+
+	// All node types except light (bridge, full) can help peers to traverse their NATs
+	if tp != node.Light {
+		if cfg.AutoNATService {
+			opts = append(opts, libp2p.EnableNATService())
+		}
+		if cfg.RelayService {
+			opts = append(opts, libp2p.EnableRelayService())
+		}
+	}
+
+	relays, err := cfg.staticRelays(bpeers)
+	if err != nil {
+		return nil, err
+	}
+	// AutoRelay has no other way to find relays
+	autoRelay := cfg.AutoRelay && len(relays) > 0
+	if autoRelay {
+		opts = append(opts, libp2p.EnableAutoRelay(autorelay.WithStaticRelays(relays)))
+	}
+	if cfg.HolePunching {
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+
+	// both AutoRelay and hole punching rely on the relay transport
+	if autoRelay || cfg.HolePunching {
+		opts = append(opts, libp2p.EnableRelay())
+	} else {
+		opts = append(opts, libp2p.DisableRelay())
+	}
+	return opts, nil
+}
+
+// reachability reports the reachability of the Host detected by AutoNAT.
+func reachability(h host.Host) (network.Reachability, error) {
+	// AutoRelay wraps the BasicHost
+	if relayed, ok := h.(*autorelay.AutoRelayHost); ok {
+		h = relayed.Host
+	}
+	basic, ok := h.(*basichost.BasicHost)
+	if !ok {
+		return 0, fmt.Errorf("unexpected implementation of host.Host, expected %s, got %T",
+			reflect.TypeOf(&basichost.BasicHost{}).String(), h)
+	}
+	return basic.GetAutoNat().Status(), nil
+}
@@ -0,0 +1,63 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestNATConfig_Validate(t *testing.T) {
+	cfg := DefaultNATConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Reachability = "unreachable"
+	require.Error(t, cfg.Validate())
+
+	cfg = DefaultNATConfig()
+	cfg.StaticRelays = []string{"/ip4/127.0.0.1/tcp/2121"}
+	require.Error(t, cfg.Validate())
+}
+
+// TestNATOptions_AutoRelay ensures the reachability of the host is still reported once it
+// is wrapped by AutoRelay.
+func TestNATOptions_AutoRelay(t *testing.T) {
+	relay, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, relay.Close())
+	})
+	relayInfo := peer.AddrInfo{ID: relay.ID(), Addrs: relay.Addrs()}
+
+	cfg := DefaultNATConfig()
+	cfg.Reachability = reachabilityPrivate
+	opts, err := natOptions(cfg, node.Light, Bootstrappers{relayInfo})
+	require.NoError(t, err)
+	h, err := libp2p.New(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+	assert.IsType(t, &autorelay.AutoRelayHost{}, h)
+
+	status, err := reachability(h)
+	require.NoError(t, err)
+	assert.Equal(t, network.ReachabilityPrivate, status)
+
+	// without relays, AutoRelay can't be enabled
+	opts, err = natOptions(cfg, node.Light, nil)
+	require.NoError(t, err)
+	h, err = libp2p.New(opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+	_, ok := h.(*autorelay.AutoRelayHost)
+	assert.False(t, ok)
+}
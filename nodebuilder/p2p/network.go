@@ -2,12 +2,15 @@ package p2p
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
 )
 
 // NOTE: Every time we add a new long-running network, it has to be added here.
+// The value of each Network is the chain ID of the Celestia consensus network it belongs to.
 const (
 	// DefaultNetwork is the default network of the current build.
 	DefaultNetwork = Arabica
@@ -25,6 +28,40 @@ const (
 // Network is a type definition for DA network run by Celestia Node.
 type Network string
 
+// ChainID reports the chain ID of the consensus network the Network belongs to.
+func (n Network) ChainID() string {
+	return string(n)
+}
+
+// NetworkID is the identifier the gossipsub topics and libp2p protocols of the node are namespaced
+// with, so that nodes of different networks never talk to each other. It defaults to the chain ID of
+// the Network, but can be overridden through the Config, e.g. by sovereign forks and test networks
+// sharing a chain ID with another network.
+type NetworkID string
+
+// ProtocolPrefix reports the prefix the DHT and Bitswap protocols are namespaced with.
+func (id NetworkID) ProtocolPrefix() protocol.ID {
+	return protocol.ID(fmt.Sprintf("/celestia/%s", id))
+}
+
+// networkID provides the NetworkID of the node on the given Network.
+func networkID(cfg Config, net Network) NetworkID {
+	if cfg.NetworkID != "" {
+		return NetworkID(cfg.NetworkID)
+	}
+	return NetworkID(net.ChainID())
+}
+
+// isPublic reports whether the Network is a long-running public one.
+func (n Network) isPublic() bool {
+	switch n {
+	case Arabica, Mamaki:
+		return true
+	default:
+		return false
+	}
+}
+
 // Bootstrappers is a type definition for nodes that will be used as bootstrappers.
 type Bootstrappers []peer.AddrInfo
 
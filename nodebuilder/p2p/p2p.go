@@ -3,7 +3,6 @@ package p2p
 import (
 	"context"
 	"fmt"
-	"reflect"
 
 	libhost "github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/metrics"
@@ -12,8 +11,9 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	rcmgr "github.com/libp2p/go-libp2p-resource-manager"
-	basichost "github.com/libp2p/go-libp2p/p2p/host/basic"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 // Module represents all accessible methods related to the node's p2p
@@ -22,57 +22,70 @@ import (
 //nolint:dupl
 type Module interface {
 	// Info returns address information about the host.
-	Info() peer.AddrInfo
+	Info(context.Context) peer.AddrInfo
 	// Peers returns all peer IDs used across all inner stores.
-	Peers() []peer.ID
+	Peers(context.Context) []peer.ID
 	// PeerInfo returns a small slice of information Peerstore has on the
 	// given peer.
-	PeerInfo(id peer.ID) peer.AddrInfo
+	PeerInfo(ctx context.Context, id peer.ID) peer.AddrInfo
 
 	// Connect ensures there is a connection between this host and the peer with
 	// given peer.
 	Connect(ctx context.Context, pi peer.AddrInfo) error
 	// ClosePeer closes the connection to a given peer.
-	ClosePeer(id peer.ID) error
+	ClosePeer(ctx context.Context, id peer.ID) error
 	// Connectedness returns a state signaling connection capabilities.
-	Connectedness(id peer.ID) network.Connectedness
+	Connectedness(ctx context.Context, id peer.ID) network.Connectedness
 	// NATStatus returns the current NAT status.
-	NATStatus() (network.Reachability, error)
+	NATStatus(context.Context) (network.Reachability, error)
 
-	// BlockPeer adds a peer to the set of blocked peers.
-	BlockPeer(p peer.ID) error
+	// BlockPeer adds a peer to the set of blocked peers and closes the connections to it.
+	BlockPeer(ctx context.Context, p peer.ID) error
 	// UnblockPeer removes a peer from the set of blocked peers.
-	UnblockPeer(p peer.ID) error
+	UnblockPeer(ctx context.Context, p peer.ID) error
 	// ListBlockedPeers returns a list of blocked peers.
-	ListBlockedPeers() []peer.ID
+	ListBlockedPeers(context.Context) []peer.ID
 	// Protect adds a peer to the list of peers who have a bidirectional
 	// peering agreement that they are protected from being trimmed, dropped
 	// or negatively scored.
-	Protect(id peer.ID, tag string)
+	Protect(ctx context.Context, id peer.ID, tag string) error
 	// Unprotect removes a peer from the list of peers who have a bidirectional
 	// peering agreement that they are protected from being trimmed, dropped
 	// or negatively scored, returning a bool representing whether the given
 	// peer is protected or not.
-	Unprotect(id peer.ID, tag string) bool
+	Unprotect(ctx context.Context, id peer.ID, tag string) (bool, error)
 	// IsProtected returns whether the given peer is protected.
-	IsProtected(id peer.ID, tag string) bool
+	IsProtected(ctx context.Context, id peer.ID, tag string) bool
 
 	// BandwidthStats returns a Stats struct with bandwidth metrics for all
 	// data sent/received by the local peer, regardless of protocol or remote
 	// peer IDs.
-	BandwidthStats() metrics.Stats
+	BandwidthStats(context.Context) metrics.Stats
 	// BandwidthForPeer returns a Stats struct with bandwidth metrics associated with the given peer.ID.
 	// The metrics returned include all traffic sent / received for the peer, regardless of protocol.
-	BandwidthForPeer(id peer.ID) metrics.Stats
+	BandwidthForPeer(ctx context.Context, id peer.ID) metrics.Stats
 	// BandwidthForProtocol returns a Stats struct with bandwidth metrics associated with the given protocol.ID.
-	BandwidthForProtocol(proto protocol.ID) metrics.Stats
+	BandwidthForProtocol(ctx context.Context, proto protocol.ID) metrics.Stats
+	// BandwidthByPeer returns the bandwidth metrics of all the currently connected peers, by
+	// peer.ID.
+	BandwidthByPeer(context.Context) map[peer.ID]metrics.Stats
+	// BandwidthByProtocol returns the bandwidth metrics of all the protocols the local peer
+	// has exchanged data over, by protocol.ID.
+	BandwidthByProtocol(context.Context) map[protocol.ID]metrics.Stats
 
 	// ResourceState returns the state of the resource manager.
-	ResourceState() (rcmgr.ResourceManagerStat, error)
+	ResourceState(context.Context) (rcmgr.ResourceManagerStat, error)
 
 	// PubSubPeers returns the peer IDs of the peers joined on
 	// the given topic.
-	PubSubPeers(topic string) []peer.ID
+	PubSubPeers(ctx context.Context, topic string) []peer.ID
+
+	// PeerVersions returns the distribution of the software versions the connected peers advertise,
+	// so that the share of the peers running outdated ones is known.
+	PeerVersions(context.Context) VersionStats
+	// BootstrapperStatus returns the health of the bootstrappers of the network, as probed by the
+	// node, and which of them are active.
+	BootstrapperStatus(context.Context) ([]BootstrapperStatus, error)
 }
 
 // module contains all components necessary to access information and
@@ -83,6 +96,8 @@ type module struct {
 	connGater *conngater.BasicConnectionGater
 	bw        *metrics.BandwidthCounter
 	rm        network.ResourceManager
+	events    *events.Bus
+	bootstrap *bootstrapMonitor
 }
 
 func newModule(
@@ -91,6 +106,8 @@ func newModule(
 	cg *conngater.BasicConnectionGater,
 	bw *metrics.BandwidthCounter,
 	rm network.ResourceManager,
+	bus *events.Bus,
+	bm *bootstrapMonitor,
 ) Module {
 	return &module{
 		host:      host,
@@ -98,18 +115,20 @@ func newModule(
 		connGater: cg,
 		bw:        bw,
 		rm:        rm,
+		events:    bus,
+		bootstrap: bm,
 	}
 }
 
-func (m *module) Info() peer.AddrInfo {
+func (m *module) Info(context.Context) peer.AddrInfo {
 	return *libhost.InfoFromHost(m.host)
 }
 
-func (m *module) Peers() []peer.ID {
+func (m *module) Peers(context.Context) []peer.ID {
 	return m.host.Peerstore().Peers()
 }
 
-func (m *module) PeerInfo(id peer.ID) peer.AddrInfo {
+func (m *module) PeerInfo(_ context.Context, id peer.ID) peer.AddrInfo {
 	return m.host.Peerstore().PeerInfo(id)
 }
 
@@ -117,60 +136,75 @@ func (m *module) Connect(ctx context.Context, pi peer.AddrInfo) error {
 	return m.host.Connect(ctx, pi)
 }
 
-func (m *module) ClosePeer(id peer.ID) error {
+func (m *module) ClosePeer(_ context.Context, id peer.ID) error {
 	return m.host.Network().ClosePeer(id)
 }
 
-func (m *module) Connectedness(id peer.ID) network.Connectedness {
+func (m *module) Connectedness(_ context.Context, id peer.ID) network.Connectedness {
 	return m.host.Network().Connectedness(id)
 }
 
-func (m *module) NATStatus() (network.Reachability, error) {
-	basic, ok := m.host.(*basichost.BasicHost)
-	if !ok {
-		return 0, fmt.Errorf("unexpected implementation of host.Host, expected %s, got %T",
-			reflect.TypeOf(&basichost.BasicHost{}).String(), m.host)
-	}
-	return basic.GetAutoNat().Status(), nil
+func (m *module) NATStatus(context.Context) (network.Reachability, error) {
+	return reachability(m.host)
 }
 
-func (m *module) BlockPeer(p peer.ID) error {
-	return m.connGater.BlockPeer(p)
+func (m *module) BlockPeer(_ context.Context, p peer.ID) error {
+	err := m.connGater.BlockPeer(p)
+	if err != nil {
+		return err
+	}
+	m.events.Publish(events.Event{Type: events.PeerBanned, Peer: p})
+	// the gater only intercepts new connections
+	return m.host.Network().ClosePeer(p)
 }
 
-func (m *module) UnblockPeer(p peer.ID) error {
+func (m *module) UnblockPeer(_ context.Context, p peer.ID) error {
 	return m.connGater.UnblockPeer(p)
 }
 
-func (m *module) ListBlockedPeers() []peer.ID {
+func (m *module) ListBlockedPeers(context.Context) []peer.ID {
 	return m.connGater.ListBlockedPeers()
 }
 
-func (m *module) Protect(id peer.ID, tag string) {
+func (m *module) Protect(_ context.Context, id peer.ID, tag string) error {
 	m.host.ConnManager().Protect(id, tag)
+	return nil
 }
 
-func (m *module) Unprotect(id peer.ID, tag string) bool {
-	return m.host.ConnManager().Unprotect(id, tag)
+func (m *module) Unprotect(_ context.Context, id peer.ID, tag string) (bool, error) {
+	return m.host.ConnManager().Unprotect(id, tag), nil
 }
 
-func (m *module) IsProtected(id peer.ID, tag string) bool {
+func (m *module) IsProtected(_ context.Context, id peer.ID, tag string) bool {
 	return m.host.ConnManager().IsProtected(id, tag)
 }
 
-func (m *module) BandwidthStats() metrics.Stats {
+func (m *module) BandwidthStats(context.Context) metrics.Stats {
 	return m.bw.GetBandwidthTotals()
 }
 
-func (m *module) BandwidthForPeer(id peer.ID) metrics.Stats {
+func (m *module) BandwidthForPeer(_ context.Context, id peer.ID) metrics.Stats {
 	return m.bw.GetBandwidthForPeer(id)
 }
 
-func (m *module) BandwidthForProtocol(proto protocol.ID) metrics.Stats {
+func (m *module) BandwidthForProtocol(_ context.Context, proto protocol.ID) metrics.Stats {
 	return m.bw.GetBandwidthForProtocol(proto)
 }
 
-func (m *module) ResourceState() (rcmgr.ResourceManagerStat, error) {
+func (m *module) BandwidthByPeer(context.Context) map[peer.ID]metrics.Stats {
+	// the reporter keeps the stats of disconnected peers, which are not worth exposing
+	stats := make(map[peer.ID]metrics.Stats)
+	for _, id := range m.host.Network().Peers() {
+		stats[id] = m.bw.GetBandwidthForPeer(id)
+	}
+	return stats
+}
+
+func (m *module) BandwidthByProtocol(context.Context) map[protocol.ID]metrics.Stats {
+	return m.bw.GetBandwidthByProtocol()
+}
+
+func (m *module) ResourceState(context.Context) (rcmgr.ResourceManagerStat, error) {
 	rms, ok := m.rm.(rcmgr.ResourceManagerState)
 	if !ok {
 		return rcmgr.ResourceManagerStat{}, fmt.Errorf("network.ResourceManager does not implement " +
@@ -179,31 +213,133 @@ func (m *module) ResourceState() (rcmgr.ResourceManagerStat, error) {
 	return rms.Stat(), nil
 }
 
-func (m *module) PubSubPeers(topic string) []peer.ID {
+func (m *module) PubSubPeers(_ context.Context, topic string) []peer.ID {
 	return m.ps.ListPeers(topic)
 }
 
+func (m *module) PeerVersions(context.Context) VersionStats {
+	return versionStats(m.host.Peerstore(), m.host.Network().Peers(), localVersion())
+}
+
+func (m *module) BootstrapperStatus(context.Context) ([]BootstrapperStatus, error) {
+	return m.bootstrap.Statuses(), nil
+}
+
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 //
 //nolint:dupl
 type API struct {
-	Info                 func() peer.AddrInfo
-	Peers                func() []peer.ID
-	PeerInfo             func(id peer.ID) peer.AddrInfo
-	Connect              func(ctx context.Context, pi peer.AddrInfo) error
-	ClosePeer            func(id peer.ID) error
-	Connectedness        func(id peer.ID) network.Connectedness
-	NATStatus            func() (network.Reachability, error)
-	BlockPeer            func(p peer.ID) error
-	UnblockPeer          func(p peer.ID) error
-	ListBlockedPeers     func() []peer.ID
-	Protect              func(id peer.ID, tag string)
-	Unprotect            func(id peer.ID, tag string) bool
-	IsProtected          func(id peer.ID, tag string) bool
-	BandwidthStats       func() metrics.Stats
-	BandwidthForPeer     func(id peer.ID) metrics.Stats
-	BandwidthForProtocol func(proto protocol.ID) metrics.Stats
-	ResourceState        func() (rcmgr.ResourceManagerStat, error)
-	PubSubPeers          func(topic string) []peer.ID
+	Internal struct {
+		Info                 func(context.Context) peer.AddrInfo                             `perm:"read"`
+		Peers                func(context.Context) []peer.ID                                 `perm:"read"`
+		PeerInfo             func(ctx context.Context, id peer.ID) peer.AddrInfo             `perm:"read"`
+		Connect              func(ctx context.Context, pi peer.AddrInfo) error               `perm:"admin"`
+		ClosePeer            func(ctx context.Context, id peer.ID) error                     `perm:"admin"`
+		Connectedness        func(ctx context.Context, id peer.ID) network.Connectedness     `perm:"read"`
+		NATStatus            func(context.Context) (network.Reachability, error)             `perm:"read"`
+		BlockPeer            func(ctx context.Context, p peer.ID) error                      `perm:"admin"`
+		UnblockPeer          func(ctx context.Context, p peer.ID) error                      `perm:"admin"`
+		ListBlockedPeers     func(context.Context) []peer.ID                                 `perm:"read"`
+		Protect              func(ctx context.Context, id peer.ID, tag string) error         `perm:"admin"`
+		Unprotect            func(ctx context.Context, id peer.ID, tag string) (bool, error) `perm:"admin"`
+		IsProtected          func(ctx context.Context, id peer.ID, tag string) bool          `perm:"read"`
+		BandwidthStats       func(context.Context) metrics.Stats                             `perm:"read"`
+		BandwidthForPeer     func(ctx context.Context, id peer.ID) metrics.Stats             `perm:"read"`
+		BandwidthForProtocol func(ctx context.Context, proto protocol.ID) metrics.Stats      `perm:"read"`
+		BandwidthByPeer      func(context.Context) map[peer.ID]metrics.Stats                 `perm:"read"`
+		BandwidthByProtocol  func(context.Context) map[protocol.ID]metrics.Stats             `perm:"read"`
+		ResourceState        func(context.Context) (rcmgr.ResourceManagerStat, error)        `perm:"read"`
+		PubSubPeers          func(ctx context.Context, topic string) []peer.ID               `perm:"read"`
+		PeerVersions         func(context.Context) VersionStats                              `perm:"read"`
+		BootstrapperStatus   func(context.Context) ([]BootstrapperStatus, error)             `perm:"read"`
+	}
+}
+
+func (api *API) Info(ctx context.Context) peer.AddrInfo {
+	return api.Internal.Info(ctx)
+}
+
+func (api *API) Peers(ctx context.Context) []peer.ID {
+	return api.Internal.Peers(ctx)
+}
+
+func (api *API) PeerInfo(ctx context.Context, id peer.ID) peer.AddrInfo {
+	return api.Internal.PeerInfo(ctx, id)
+}
+
+func (api *API) Connect(ctx context.Context, pi peer.AddrInfo) error {
+	return api.Internal.Connect(ctx, pi)
+}
+
+func (api *API) ClosePeer(ctx context.Context, id peer.ID) error {
+	return api.Internal.ClosePeer(ctx, id)
+}
+
+func (api *API) Connectedness(ctx context.Context, id peer.ID) network.Connectedness {
+	return api.Internal.Connectedness(ctx, id)
+}
+
+func (api *API) NATStatus(ctx context.Context) (network.Reachability, error) {
+	return api.Internal.NATStatus(ctx)
+}
+
+func (api *API) BlockPeer(ctx context.Context, p peer.ID) error {
+	return api.Internal.BlockPeer(ctx, p)
+}
+
+func (api *API) UnblockPeer(ctx context.Context, p peer.ID) error {
+	return api.Internal.UnblockPeer(ctx, p)
+}
+
+func (api *API) ListBlockedPeers(ctx context.Context) []peer.ID {
+	return api.Internal.ListBlockedPeers(ctx)
+}
+
+func (api *API) Protect(ctx context.Context, id peer.ID, tag string) error {
+	return api.Internal.Protect(ctx, id, tag)
+}
+
+func (api *API) Unprotect(ctx context.Context, id peer.ID, tag string) (bool, error) {
+	return api.Internal.Unprotect(ctx, id, tag)
+}
+
+func (api *API) IsProtected(ctx context.Context, id peer.ID, tag string) bool {
+	return api.Internal.IsProtected(ctx, id, tag)
+}
+
+func (api *API) BandwidthStats(ctx context.Context) metrics.Stats {
+	return api.Internal.BandwidthStats(ctx)
+}
+
+func (api *API) BandwidthForPeer(ctx context.Context, id peer.ID) metrics.Stats {
+	return api.Internal.BandwidthForPeer(ctx, id)
+}
+
+func (api *API) BandwidthForProtocol(ctx context.Context, proto protocol.ID) metrics.Stats {
+	return api.Internal.BandwidthForProtocol(ctx, proto)
+}
+
+func (api *API) BandwidthByPeer(ctx context.Context) map[peer.ID]metrics.Stats {
+	return api.Internal.BandwidthByPeer(ctx)
+}
+
+func (api *API) BandwidthByProtocol(ctx context.Context) map[protocol.ID]metrics.Stats {
+	return api.Internal.BandwidthByProtocol(ctx)
+}
+
+func (api *API) ResourceState(ctx context.Context) (rcmgr.ResourceManagerStat, error) {
+	return api.Internal.ResourceState(ctx)
+}
+
+func (api *API) PubSubPeers(ctx context.Context, topic string) []peer.ID {
+	return api.Internal.PubSubPeers(ctx, topic)
+}
+
+func (api *API) PeerVersions(ctx context.Context) VersionStats {
+	return api.Internal.PeerVersions(ctx)
+}
+
+func (api *API) BootstrapperStatus(ctx context.Context) ([]BootstrapperStatus, error) {
+	return api.Internal.BootstrapperStatus(ctx)
 }
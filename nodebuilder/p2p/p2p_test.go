@@ -18,25 +18,29 @@ import (
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
 )
 
 // TestP2PModule_Host tests P2P Module methods on
 // the instance of Host.
 func TestP2PModule_Host(t *testing.T) {
+	ctx := context.Background()
+
 	net, err := mocknet.FullMeshConnected(2)
 	require.NoError(t, err)
 	host, peer := net.Hosts()[0], net.Hosts()[1]
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
 	// test all methods on `manager.host`
-	assert.Equal(t, []libpeer.ID(host.Peerstore().Peers()), mgr.Peers())
-	assert.Equal(t, libhost.InfoFromHost(peer).ID, mgr.PeerInfo(peer.ID()).ID)
+	assert.Equal(t, []libpeer.ID(host.Peerstore().Peers()), mgr.Peers(ctx))
+	assert.Equal(t, libhost.InfoFromHost(peer).ID, mgr.PeerInfo(ctx, peer.ID()).ID)
 
-	assert.Equal(t, host.Network().Connectedness(peer.ID()), mgr.Connectedness(peer.ID()))
+	assert.Equal(t, host.Network().Connectedness(peer.ID()), mgr.Connectedness(ctx, peer.ID()))
 	// now disconnect using manager and check for connectedness match again
-	assert.NoError(t, mgr.ClosePeer(peer.ID()))
-	assert.Equal(t, host.Network().Connectedness(peer.ID()), mgr.Connectedness(peer.ID()))
+	assert.NoError(t, mgr.ClosePeer(ctx, peer.ID()))
+	assert.Equal(t, host.Network().Connectedness(peer.ID()), mgr.Connectedness(ctx, peer.ID()))
 }
 
 // TestP2PModule_ConnManager tests P2P Module methods on
@@ -51,7 +55,7 @@ func TestP2PModule_ConnManager(t *testing.T) {
 	peer, err := libp2p.New()
 	require.NoError(t, err)
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -59,21 +63,25 @@ func TestP2PModule_ConnManager(t *testing.T) {
 	err = mgr.Connect(ctx, *libhost.InfoFromHost(peer))
 	require.NoError(t, err)
 
-	mgr.Protect(peer.ID(), "test")
-	assert.True(t, mgr.IsProtected(peer.ID(), "test"))
-	mgr.Unprotect(peer.ID(), "test")
-	assert.False(t, mgr.IsProtected(peer.ID(), "test"))
+	err = mgr.Protect(ctx, peer.ID(), "test")
+	require.NoError(t, err)
+	assert.True(t, mgr.IsProtected(ctx, peer.ID(), "test"))
+	_, err = mgr.Unprotect(ctx, peer.ID(), "test")
+	require.NoError(t, err)
+	assert.False(t, mgr.IsProtected(ctx, peer.ID(), "test"))
 }
 
 // TestP2PModule_Autonat tests P2P Module methods on
 // the node's instance of AutoNAT.
 func TestP2PModule_Autonat(t *testing.T) {
+	ctx := context.Background()
+
 	host, err := libp2p.New(libp2p.EnableNATService())
 	require.NoError(t, err)
 
-	mgr := newModule(host, nil, nil, nil, nil)
+	mgr := newModule(host, nil, nil, nil, nil, nil, nil)
 
-	status, err := mgr.NATStatus()
+	status, err := mgr.NATStatus(ctx)
 	assert.NoError(t, err)
 	assert.Equal(t, network.ReachabilityUnknown, status)
 }
@@ -103,7 +111,7 @@ func TestP2PModule_Bandwidth(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	mgr := newModule(host, nil, nil, bw, nil)
+	mgr := newModule(host, nil, nil, bw, nil, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -112,10 +120,10 @@ func TestP2PModule_Bandwidth(t *testing.T) {
 	err = mgr.Connect(ctx, *libhost.InfoFromHost(peer))
 	require.NoError(t, err)
 	// check to ensure they're actually connected
-	require.Equal(t, network.Connected, mgr.Connectedness(peer.ID()))
+	require.Equal(t, network.Connected, mgr.Connectedness(ctx, peer.ID()))
 
 	// open stream with host
-	stream, err := peer.NewStream(ctx, mgr.Info().ID, protoID)
+	stream, err := peer.NewStream(ctx, mgr.Info(ctx).ID, protoID)
 	require.NoError(t, err)
 
 	// write to stream to increase bandwidth usage get some substantive
@@ -133,14 +141,20 @@ func TestP2PModule_Bandwidth(t *testing.T) {
 	// in the background process
 	time.Sleep(time.Second * 2)
 
-	stats := mgr.BandwidthStats()
+	stats := mgr.BandwidthStats(ctx)
 	assert.NotNil(t, stats)
-	peerStat := mgr.BandwidthForPeer(peer.ID())
+	peerStat := mgr.BandwidthForPeer(ctx, peer.ID())
 	assert.NotZero(t, peerStat.TotalIn)
 	assert.Greater(t, int(peerStat.TotalIn), bufSize) // should be slightly more than buf size due negotiations, etc
-	protoStat := mgr.BandwidthForProtocol(protoID)
+	protoStat := mgr.BandwidthForProtocol(ctx, protoID)
 	assert.NotZero(t, protoStat.TotalIn)
 	assert.Greater(t, int(protoStat.TotalIn), bufSize) // should be slightly more than buf size due negotiations, etc
+
+	byPeer := mgr.BandwidthByPeer(ctx)
+	require.Len(t, byPeer, 1)
+	assert.Greater(t, int(byPeer[peer.ID()].TotalIn), bufSize)
+	byProto := mgr.BandwidthByProtocol(ctx)
+	assert.Greater(t, int(byProto[protoID].TotalIn), bufSize)
 }
 
 // TestP2PModule_Pubsub tests P2P Module methods on
@@ -157,7 +171,7 @@ func TestP2PModule_Pubsub(t *testing.T) {
 	gs, err := pubsub.NewGossipSub(ctx, host)
 	require.NoError(t, err)
 
-	mgr := newModule(host, gs, nil, nil, nil)
+	mgr := newModule(host, gs, nil, nil, nil, nil, nil)
 
 	topicStr := "test-topic"
 
@@ -182,32 +196,44 @@ func TestP2PModule_Pubsub(t *testing.T) {
 	// anywhere where gossipsub is used in tests)
 	time.Sleep(1 * time.Second)
 
-	assert.Equal(t, len(topic.ListPeers()), len(mgr.PubSubPeers(topicStr)))
+	assert.Equal(t, len(topic.ListPeers()), len(mgr.PubSubPeers(ctx, topicStr)))
 }
 
 // TestP2PModule_ConnGater tests P2P Module methods on
 // the instance of ConnectionGater.
 func TestP2PModule_ConnGater(t *testing.T) {
+	ctx := context.Background()
+
 	gater, err := ConnectionGater(datastore.NewMapDatastore())
 	require.NoError(t, err)
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	host, peer := net.Hosts()[0], net.Hosts()[1]
 
-	mgr := newModule(nil, nil, gater, nil, nil)
-
-	assert.NoError(t, mgr.BlockPeer("badpeer"))
-	assert.Len(t, mgr.ListBlockedPeers(), 1)
-	assert.NoError(t, mgr.UnblockPeer("badpeer"))
-	assert.Len(t, mgr.ListBlockedPeers(), 0)
+	bus := events.NewBus()
+	banned := bus.Subscribe(events.PeerBanned)
+	mgr := newModule(host, nil, gater, nil, nil, bus, nil)
+
+	// blocking also closes the existing connections
+	assert.NoError(t, mgr.BlockPeer(ctx, peer.ID()))
+	assert.Equal(t, peer.ID(), (<-banned.Out()).Peer)
+	assert.Len(t, mgr.ListBlockedPeers(ctx), 1)
+	assert.Equal(t, network.NotConnected, mgr.Connectedness(ctx, peer.ID()))
+	assert.NoError(t, mgr.UnblockPeer(ctx, peer.ID()))
+	assert.Len(t, mgr.ListBlockedPeers(ctx), 0)
 }
 
 // TestP2PModule_ResourceManager tests P2P Module methods on
 // the ResourceManager.
 func TestP2PModule_ResourceManager(t *testing.T) {
+	ctx := context.Background()
+
 	rm, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(rcmgr.DefaultLimits.AutoScale()))
 	require.NoError(t, err)
 
-	mgr := newModule(nil, nil, nil, nil, rm)
+	mgr := newModule(nil, nil, nil, nil, rm, nil, nil)
 
-	state, err := mgr.ResourceState()
+	state, err := mgr.ResourceState(ctx)
 	require.NoError(t, err)
 
 	assert.NotNil(t, state)
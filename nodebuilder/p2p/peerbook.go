@@ -0,0 +1,347 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p-core/event"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
+	"go.uber.org/fx"
+)
+
+var (
+	// peerBookPrefix namespaces the records of the known peers in the datastore.
+	peerBookPrefix = datastore.NewKey("peerbook")
+	// peerBookFlushInterval is the period of time between the changed records are persisted.
+	peerBookFlushInterval = time.Minute
+)
+
+const (
+	// maxPeerRecords bounds the amount of the known peers kept. The ones with the lowest scores are
+	// forgotten first.
+	maxPeerRecords = 1000
+	// maxPeerRecordAge is the period of time a peer not seen for is forgotten after.
+	maxPeerRecordAge = 7 * 24 * time.Hour
+	// peerScoreDecay is the weight of the history in the score of a peer on every connection attempt.
+	peerScoreDecay = 0.8
+	// reconnectTimeout is the timeout of reconnecting to a known peer on start.
+	reconnectTimeout = 10 * time.Second
+)
+
+// peerRecord is the persisted record of a known peer.
+type peerRecord struct {
+	Addrs     []string
+	Protocols []string
+	// Score is the decaying rate of the successful connections with the peer in the range [0, 1].
+	Score    float64
+	LastSeen time.Time
+}
+
+// observe accounts the outcome of a connection attempt with the peer in its score.
+func (r *peerRecord) observe(connected bool) {
+	r.Score *= peerScoreDecay
+	if connected {
+		r.Score += 1 - peerScoreDecay
+		r.LastSeen = time.Now()
+	}
+}
+
+// peerBook persists the records of the peers the node connected to along with the history of the
+// connections with them, so that a restarted node reconnects to the known good peers right away,
+// instead of rediscovering them from the bootstrappers.
+type peerBook struct {
+	host HostBase
+	ds   datastore.Batching
+
+	lk      sync.Mutex
+	records map[peer.ID]*peerRecord
+	dirty   map[peer.ID]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newPeerBook(h HostBase, ds datastore.Batching) *peerBook {
+	return &peerBook{
+		host:    h,
+		ds:      namespace.Wrap(ds, peerBookPrefix),
+		records: make(map[peer.ID]*peerRecord),
+		dirty:   make(map[peer.ID]struct{}),
+	}
+}
+
+// persistPeers keeps the records of the known peers across restarts and reconnects to the best
+// of them on start, if enabled.
+func persistPeers(cfg Config, lc fx.Lifecycle, h HostBase, ds datastore.Batching) {
+	if cfg.ReconnectPeers == 0 {
+		return
+	}
+
+	pb := newPeerBook(h, ds)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return pb.Start(ctx, cfg.ReconnectPeers)
+		},
+		OnStop: pb.Stop,
+	})
+}
+
+// Start loads the records of the known peers and reconnects to the 'reconnect' best of them in
+// the background, while tracking the connections with the peers.
+func (pb *peerBook) Start(ctx context.Context, reconnect int) error {
+	err := pb.load(ctx)
+	if err != nil {
+		return err
+	}
+	sub, err := pb.host.EventBus().Subscribe([]interface{}{
+		&event.EvtPeerConnectednessChanged{},
+		&event.EvtPeerIdentificationCompleted{},
+	})
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	pb.cancel, pb.done = cancel, make(chan struct{})
+	go func() {
+		defer close(pb.done)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pb.reconnect(runCtx, reconnect)
+		}()
+
+		pb.run(runCtx, sub)
+		if err := sub.Close(); err != nil {
+			log.Error(err)
+		}
+		wg.Wait()
+	}()
+	return nil
+}
+
+// Stop stops tracking the peers and persists the changed records.
+func (pb *peerBook) Stop(ctx context.Context) error {
+	pb.cancel()
+	select {
+	case <-pb.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return pb.flush(ctx)
+}
+
+func (pb *peerBook) run(ctx context.Context, sub event.Subscription) {
+	ticker := time.NewTicker(peerBookFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case e := <-sub.Out():
+			switch e := e.(type) {
+			case event.EvtPeerConnectednessChanged:
+				pb.update(e.Peer, e.Connectedness == network.Connected)
+			case event.EvtPeerIdentificationCompleted:
+				pb.update(e.Peer, false)
+			}
+		case <-ticker.C:
+			if err := pb.flush(ctx); err != nil {
+				log.Errorw("persisting known peers", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// update records the current addresses and protocols of the peer, accounting a new connection
+// with it, if 'connected'. Only the peers connected to once are recorded.
+func (pb *peerBook) update(p peer.ID, connected bool) {
+	pstore := pb.host.Peerstore()
+	var addrs []string
+	for _, addr := range pstore.Addrs(p) {
+		addrs = append(addrs, addr.String())
+	}
+	if len(addrs) == 0 {
+		// the addresses of the peer are not identified yet
+		for _, conn := range pb.host.Network().ConnsToPeer(p) {
+			addrs = append(addrs, conn.RemoteMultiaddr().String())
+		}
+	}
+	protocols, err := pstore.GetProtocols(p)
+	if err != nil {
+		log.Debugw("getting protocols of peer", "peer", p, "err", err)
+	}
+
+	pb.lk.Lock()
+	defer pb.lk.Unlock()
+	record, ok := pb.records[p]
+	switch {
+	case !ok && !connected:
+		return
+	case !ok:
+		record = &peerRecord{}
+		pb.records[p] = record
+	}
+	if connected {
+		record.observe(true)
+	} else {
+		record.LastSeen = time.Now()
+	}
+	if len(addrs) > 0 {
+		record.Addrs = addrs
+	}
+	if len(protocols) > 0 {
+		record.Protocols = protocols
+	}
+	pb.dirty[p] = struct{}{}
+}
+
+// reconnect connects to the 'limit' known peers with the highest scores. The failures are accounted
+// in their scores right away, while the connections are once the connectedness changes.
+func (pb *peerBook) reconnect(ctx context.Context, limit int) {
+	pb.lk.Lock()
+	infos := make([]peer.AddrInfo, 0, len(pb.records))
+	for p, record := range pb.records {
+		info := peer.AddrInfo{ID: p}
+		for _, addr := range record.Addrs {
+			maddr, err := ma.NewMultiaddr(addr)
+			if err == nil {
+				info.Addrs = append(info.Addrs, maddr)
+			}
+		}
+		if len(info.Addrs) > 0 && p != pb.host.ID() {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return pb.records[infos[i].ID].Score > pb.records[infos[j].ID].Score
+	})
+	pb.lk.Unlock()
+	if len(infos) > limit {
+		infos = infos[:limit]
+	}
+
+	var wg sync.WaitGroup
+	for _, info := range infos {
+		wg.Add(1)
+		go func(info peer.AddrInfo) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, reconnectTimeout)
+			defer cancel()
+
+			pb.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+			err := pb.host.Connect(ctx, info)
+			if err == nil {
+				return
+			}
+			log.Debugw("reconnecting to known peer", "peer", info.ID, "err", err)
+			pb.lk.Lock()
+			if record, ok := pb.records[info.ID]; ok {
+				record.observe(false)
+				pb.dirty[info.ID] = struct{}{}
+			}
+			pb.lk.Unlock()
+		}(info)
+	}
+	wg.Wait()
+	log.Infow("reconnected to known peers", "attempted", len(infos))
+}
+
+// load loads the persisted records of the known peers.
+func (pb *peerBook) load(ctx context.Context) error {
+	res, err := pb.ds.Query(ctx, query.Query{})
+	if err != nil {
+		return err
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		return err
+	}
+
+	pb.lk.Lock()
+	defer pb.lk.Unlock()
+	for _, entry := range entries {
+		p, err := peer.Decode(datastore.RawKey(entry.Key).BaseNamespace())
+		if err != nil {
+			log.Warnw("decoding known peer", "key", entry.Key, "err", err)
+			continue
+		}
+		record := &peerRecord{}
+		if err = json.Unmarshal(entry.Value, record); err != nil {
+			log.Warnw("decoding record of known peer", "peer", p, "err", err)
+			continue
+		}
+		pb.records[p] = record
+	}
+	return nil
+}
+
+// flush persists the changed records and drops the ones of the peers forgotten.
+func (pb *peerBook) flush(ctx context.Context) error {
+	pb.lk.Lock()
+	defer pb.lk.Unlock()
+
+	batch, err := pb.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for p := range pb.forget() {
+		if err = batch.Delete(ctx, datastore.NewKey(p.String())); err != nil {
+			return err
+		}
+	}
+	for p := range pb.dirty {
+		bs, err := json.Marshal(pb.records[p])
+		if err != nil {
+			return err
+		}
+		if err = batch.Put(ctx, datastore.NewKey(p.String()), bs); err != nil {
+			return err
+		}
+	}
+	if err = batch.Commit(ctx); err != nil {
+		return err
+	}
+	pb.dirty = make(map[peer.ID]struct{})
+	return nil
+}
+
+// forget drops the records of the peers not seen for too long and of the ones with the lowest
+// scores beyond maxPeerRecords, returning the forgotten peers. It must be called under the lock.
+func (pb *peerBook) forget() map[peer.ID]struct{} {
+	forgotten := make(map[peer.ID]struct{})
+	for p, record := range pb.records {
+		if time.Since(record.LastSeen) > maxPeerRecordAge {
+			forgotten[p] = struct{}{}
+		}
+	}
+	if excess := len(pb.records) - len(forgotten) - maxPeerRecords; excess > 0 {
+		peers := make([]peer.ID, 0, len(pb.records))
+		for p := range pb.records {
+			if _, ok := forgotten[p]; !ok {
+				peers = append(peers, p)
+			}
+		}
+		sort.Slice(peers, func(i, j int) bool {
+			return pb.records[peers[i]].Score < pb.records[peers[j]].Score
+		})
+		for _, p := range peers[:excess] {
+			forgotten[p] = struct{}{}
+		}
+	}
+	for p := range forgotten {
+		delete(pb.records, p)
+		delete(pb.dirty, p)
+	}
+	return forgotten
+}
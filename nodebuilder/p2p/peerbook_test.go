@@ -0,0 +1,77 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/test"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerBook_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	net, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	host, known := net.Hosts()[0], net.Hosts()[1]
+	ds := dssync.MutexWrap(datastore.NewMapDatastore())
+
+	pb := newPeerBook(host, ds)
+	require.NoError(t, pb.Start(ctx, 0))
+	_, err = net.ConnectPeers(host.ID(), known.ID())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		pb.lk.Lock()
+		defer pb.lk.Unlock()
+		record, ok := pb.records[known.ID()]
+		return ok && len(record.Addrs) > 0
+	}, time.Second, time.Millisecond*10)
+	require.NoError(t, pb.Stop(ctx))
+
+	// the restarted node reconnects to the known peer right away
+	restarted, err := net.GenPeer()
+	require.NoError(t, err)
+	require.NoError(t, net.LinkAll())
+	pb = newPeerBook(restarted, ds)
+	require.NoError(t, pb.Start(ctx, 1))
+	t.Cleanup(func() {
+		require.NoError(t, pb.Stop(ctx))
+	})
+	assert.Eventually(t, func() bool {
+		return restarted.Network().Connectedness(known.ID()) == network.Connected
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestPeerBook_Forget(t *testing.T) {
+	pb := newPeerBook(nil, datastore.NewMapDatastore())
+
+	stale, err := test.RandPeerID()
+	require.NoError(t, err)
+	pb.records[stale] = &peerRecord{LastSeen: time.Now().Add(-maxPeerRecordAge - time.Hour)}
+	for i := 0; i < maxPeerRecords+1; i++ {
+		p, err := test.RandPeerID()
+		require.NoError(t, err)
+		// all the peers are recent, but only one never connected
+		record := &peerRecord{LastSeen: time.Now()}
+		record.observe(i > 0)
+		pb.records[p] = record
+	}
+
+	forgotten := pb.forget()
+	assert.Len(t, forgotten, 2)
+	assert.Contains(t, forgotten, stale)
+	assert.Len(t, pb.records, maxPeerRecords)
+	for p := range forgotten {
+		assert.NotContains(t, pb.records, p)
+	}
+	for _, record := range pb.records {
+		assert.Greater(t, record.Score, 0.0)
+	}
+}
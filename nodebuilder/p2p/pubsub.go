@@ -19,17 +19,18 @@ func PubSub(cfg Config, params pubSubParams) (*pubsub.PubSub, error) {
 
 	// TODO(@Wondertan) for PubSub options:
 	//  * Hash-based MsgId function.
-	//  * Validate default peer scoring params for our use-case.
 	//  * Strict subscription filter
 	//  * For different network types(mainnet/testnet/devnet) we should have different network topic
-	// names.  * Hardcode positive score for bootstrap peers
+	// names.
 	//  * Bootstrappers should only gossip and PX
-	//  * Peers should trust boostrappers, so peerscore for them should always be high.
 	opts := []pubsub.Option{
 		pubsub.WithPeerExchange(cfg.PeerExchange || cfg.Bootstrapper),
 		pubsub.WithDirectPeers(fpeers),
 		pubsub.WithMessageIdFn(hashMsgID),
 	}
+	if cfg.PeerScoring.Enabled {
+		opts = append(opts, peerScoreOption(cfg.PeerScoring, params.NetworkID, params.Bootstrappers))
+	}
 
 	return pubsub.NewGossipSub(
 		params.Ctx,
@@ -46,6 +47,8 @@ func hashMsgID(m *pubsub_pb.Message) string {
 type pubSubParams struct {
 	fx.In
 
-	Ctx  context.Context
-	Host host.Host
+	Ctx           context.Context
+	Host          host.Host
+	NetworkID     NetworkID
+	Bootstrappers Bootstrappers
 }
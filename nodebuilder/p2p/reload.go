@@ -0,0 +1,54 @@
+package p2p
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+
+	"github.com/celestiaorg/celestia-node/libs/reload"
+)
+
+const mutualPeersTag = "protected-mutual"
+
+// registerReloadable declares the p2p settings which can be changed without restarting the node.
+func registerReloadable(r *reload.Registry, host HostBase) error {
+	return r.Register("P2P.MutualPeers", func(ctx context.Context, old, new interface{}) error {
+		return updateMutualPeers(ctx, host, old.([]string), new.([]string))
+	})
+}
+
+// updateMutualPeers protects and connects to the newly added mutual peers and unprotects the
+// removed ones.
+// NOTE: Direct peering of PubSub is not updated until the node is restarted.
+func updateMutualPeers(ctx context.Context, host HostBase, old, new []string) error {
+	oldPeers, err := (&Config{MutualPeers: old}).mutualPeers()
+	if err != nil {
+		return err
+	}
+	newPeers, err := (&Config{MutualPeers: new}).mutualPeers()
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[peer.ID]bool, len(newPeers))
+	for _, info := range newPeers {
+		keep[info.ID] = true
+	}
+	for _, info := range oldPeers {
+		if !keep[info.ID] {
+			host.ConnManager().Unprotect(info.ID, mutualPeersTag)
+		}
+	}
+
+	for _, info := range newPeers {
+		host.ConnManager().Protect(info.ID, mutualPeersTag)
+		host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+		// failing to connect is not critical, as the connection is retried by the peers on their own
+		err = host.Connect(ctx, info)
+		if err != nil {
+			log.Warnw("connecting to mutual peer", "peer", info.ID, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	bsnet "github.com/ipfs/go-bitswap/network"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	rcmgr "github.com/libp2p/go-libp2p-resource-manager"
+
+	"github.com/celestiaorg/celestia-node/fraud"
+	headp2p "github.com/celestiaorg/celestia-node/header/p2p"
+)
+
+// ResourceLimit limits the inbound streams and the memory in bytes reserved for them.
+// Zero values keep the default limits of libp2p, scaled to the resources of the system.
+type ResourceLimit struct {
+	StreamsInbound int
+	Memory         int64
+}
+
+// ProtocolResourceLimits limits the resources of a protocol in total and per peer.
+type ProtocolResourceLimits struct {
+	Total   ResourceLimit
+	PerPeer ResourceLimit
+}
+
+// ResourceLimitsConfig configures the limits the resource manager puts on the protocols of the
+// node, so that a flood of inbound streams can't exhaust its memory.
+type ResourceLimitsConfig struct {
+	// PerPeer limits the resources of each peer over all the protocols.
+	PerPeer        ResourceLimit
+	HeaderExchange ProtocolResourceLimits
+	Fraud          ProtocolResourceLimits
+	Bitswap        ProtocolResourceLimits
+	Gossip         ProtocolResourceLimits
+}
+
+// DefaultResourceLimitsConfig returns the default limits of the protocols.
+func DefaultResourceLimitsConfig() ResourceLimitsConfig {
+	return ResourceLimitsConfig{
+		HeaderExchange: ProtocolResourceLimits{
+			Total:   ResourceLimit{StreamsInbound: 256, Memory: 64 << 20},
+			PerPeer: ResourceLimit{StreamsInbound: 16, Memory: 8 << 20},
+		},
+		Fraud: ProtocolResourceLimits{
+			Total:   ResourceLimit{StreamsInbound: 64, Memory: 16 << 20},
+			PerPeer: ResourceLimit{StreamsInbound: 4, Memory: 4 << 20},
+		},
+		Bitswap: ProtocolResourceLimits{
+			Total:   ResourceLimit{StreamsInbound: 1024},
+			PerPeer: ResourceLimit{StreamsInbound: 64},
+		},
+		Gossip: ProtocolResourceLimits{
+			// every peer opens a single long-lived stream to gossip over
+			Total:   ResourceLimit{StreamsInbound: 512, Memory: 64 << 20},
+			PerPeer: ResourceLimit{StreamsInbound: 4, Memory: 8 << 20},
+		},
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *ResourceLimitsConfig) Validate() error {
+	for name, limit := range map[string]ResourceLimit{
+		"per peer":                 cfg.PerPeer,
+		"header exchange":          cfg.HeaderExchange.Total,
+		"header exchange per peer": cfg.HeaderExchange.PerPeer,
+		"fraud":                    cfg.Fraud.Total,
+		"fraud per peer":           cfg.Fraud.PerPeer,
+		"bitswap":                  cfg.Bitswap.Total,
+		"bitswap per peer":         cfg.Bitswap.PerPeer,
+		"gossip":                   cfg.Gossip.Total,
+		"gossip per peer":          cfg.Gossip.PerPeer,
+	} {
+		if limit.StreamsInbound < 0 || limit.Memory < 0 {
+			return fmt.Errorf("p2p: %s resource limits must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// limitConfig applies the configured limits of the protocols of the given network on top of the
+// default limits of libp2p.
+func (cfg *ResourceLimitsConfig) limitConfig(netID NetworkID) rcmgr.LimitConfig {
+	limits := rcmgr.DefaultLimits.AutoScale()
+	if limits.Protocol == nil {
+		limits.Protocol = make(map[protocol.ID]rcmgr.BaseLimit)
+	}
+	if limits.ProtocolPeer == nil {
+		limits.ProtocolPeer = make(map[protocol.ID]rcmgr.BaseLimit)
+	}
+	limits.PeerDefault = cfg.PerPeer.apply(limits.PeerDefault)
+
+	prefix := netID.ProtocolPrefix()
+	for _, proto := range []struct {
+		limits ProtocolResourceLimits
+		ids    []protocol.ID
+	}{
+		{cfg.HeaderExchange, []protocol.ID{headp2p.ProtocolID(string(netID))}},
+		{cfg.Fraud, []protocol.ID{fraud.ProtocolID(string(netID))}},
+		{cfg.Bitswap, []protocol.ID{
+			prefix + bsnet.ProtocolBitswap,
+			prefix + bsnet.ProtocolBitswapOneOne,
+			prefix + bsnet.ProtocolBitswapOneZero,
+			prefix + bsnet.ProtocolBitswapNoVers,
+		}},
+		{cfg.Gossip, []protocol.ID{pubsub.GossipSubID_v11, pubsub.GossipSubID_v10, pubsub.FloodSubID}},
+	} {
+		for _, id := range proto.ids {
+			limits.Protocol[id] = proto.limits.Total.apply(limits.ProtocolDefault)
+			limits.ProtocolPeer[id] = proto.limits.PerPeer.apply(limits.ProtocolPeerDefault)
+		}
+	}
+	return limits
+}
+
+// apply overrides the given limit with the non-zero values of the ResourceLimit.
+func (l ResourceLimit) apply(limit rcmgr.BaseLimit) rcmgr.BaseLimit {
+	if l.StreamsInbound != 0 {
+		limit.StreamsInbound = l.StreamsInbound
+	}
+	if l.Memory != 0 {
+		limit.Memory = l.Memory
+	}
+	return limit
+}
+
+// resourceManager constructs the resource manager limiting the resources of the protocols of the
+// network as configured.
+func resourceManager(cfg Config, netID NetworkID, hits *limitHits) (network.ResourceManager, error) {
+	limits := cfg.ResourceLimits.limitConfig(netID)
+	return rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(limits), rcmgr.WithMetrics(hits))
+}
+
+// limitHits counts the resource reservations blocked by the resource manager for exceeding the
+// limits, per scope and protocol, to be reported as metrics.
+type limitHits struct {
+	lk     sync.Mutex
+	counts map[limitHitKey]*int64
+}
+
+type limitHitKey struct {
+	scope    string
+	protocol protocol.ID
+}
+
+func newLimitHits() *limitHits {
+	return &limitHits{counts: make(map[limitHitKey]*int64)}
+}
+
+func (lh *limitHits) hit(scope string, proto protocol.ID) {
+	key := limitHitKey{scope: scope, protocol: proto}
+	lh.lk.Lock()
+	count, ok := lh.counts[key]
+	if !ok {
+		count = new(int64)
+		lh.counts[key] = count
+	}
+	lh.lk.Unlock()
+	atomic.AddInt64(count, 1)
+}
+
+// forEach calls the given func with the count of every scope and protocol.
+func (lh *limitHits) forEach(f func(scope string, proto protocol.ID, count int64)) {
+	lh.lk.Lock()
+	defer lh.lk.Unlock()
+	for key, count := range lh.counts {
+		f(key.scope, key.protocol, atomic.LoadInt64(count))
+	}
+}
+
+func (lh *limitHits) BlockConn(network.Direction, bool)      { lh.hit("conn", "") }
+func (lh *limitHits) BlockStream(peer.ID, network.Direction) { lh.hit("stream", "") }
+func (lh *limitHits) BlockPeer(peer.ID)                      { lh.hit("peer", "") }
+func (lh *limitHits) BlockProtocol(proto protocol.ID)        { lh.hit("protocol", proto) }
+func (lh *limitHits) BlockProtocolPeer(proto protocol.ID, _ peer.ID) {
+	lh.hit("protocol_peer", proto)
+}
+func (lh *limitHits) BlockService(string)                    { lh.hit("service", "") }
+func (lh *limitHits) BlockServicePeer(string, peer.ID)       { lh.hit("service_peer", "") }
+func (lh *limitHits) BlockMemory(int)                        { lh.hit("memory", "") }
+func (lh *limitHits) AllowConn(network.Direction, bool)      {}
+func (lh *limitHits) AllowStream(peer.ID, network.Direction) {}
+func (lh *limitHits) AllowPeer(peer.ID)                      {}
+func (lh *limitHits) AllowProtocol(protocol.ID)              {}
+func (lh *limitHits) AllowService(string)                    {}
+func (lh *limitHits) AllowMemory(int)                        {}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	headp2p "github.com/celestiaorg/celestia-node/header/p2p"
+)
+
+func TestResourceLimitsConfig(t *testing.T) {
+	cfg := DefaultResourceLimitsConfig()
+	require.NoError(t, cfg.Validate())
+
+	limits := cfg.limitConfig(NetworkID(Private))
+	headerEx := headp2p.ProtocolID(string(Private))
+	assert.Equal(t, cfg.HeaderExchange.Total.StreamsInbound, limits.Protocol[headerEx].StreamsInbound)
+	assert.Equal(t, cfg.HeaderExchange.PerPeer.Memory, limits.ProtocolPeer[headerEx].Memory)
+	// the unset limits keep the defaults
+	assert.Equal(t, limits.ProtocolDefault.StreamsOutbound, limits.Protocol[headerEx].StreamsOutbound)
+	assert.Equal(t, limits.ProtocolPeerDefault.Memory,
+		limits.ProtocolPeer["/celestia/private/ipfs/bitswap/1.2.0"].Memory)
+
+	cfg.Gossip.PerPeer.StreamsInbound = -1
+	assert.Error(t, cfg.Validate())
+}
+
+// TestResourceManager_LimitsInboundStreams ensures the inbound streams of a peer over the limit of
+// the protocol are rejected and counted.
+func TestResourceManager_LimitsInboundStreams(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	cfg := DefaultConfig()
+	cfg.ResourceLimits.HeaderExchange.PerPeer.StreamsInbound = 1
+	hits := newLimitHits()
+	rm, err := resourceManager(cfg, NetworkID(Private), hits)
+	require.NoError(t, err)
+
+	server, err := libp2p.New(libp2p.ResourceManager(rm), libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, server.Close())
+	})
+	proto := headp2p.ProtocolID(string(Private))
+	server.SetStreamHandler(proto, func(stream network.Stream) {
+		// hold the stream open
+		_, _ = stream.Read(make([]byte, 1))
+	})
+
+	client, err := libp2p.New(libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	err = client.Connect(ctx, peer.AddrInfo{ID: server.ID(), Addrs: server.Addrs()})
+	require.NoError(t, err)
+
+	// open reports whether the opened stream is held open by the server
+	open := func() bool {
+		stream, err := client.NewStream(ctx, server.ID(), proto)
+		if err != nil {
+			return false
+		}
+		_, err = stream.Write([]byte{1})
+		if err != nil {
+			return false
+		}
+		_ = stream.SetReadDeadline(time.Now().Add(time.Millisecond * 500))
+		_, err = stream.Read(make([]byte, 1))
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+	assert.True(t, open())
+	// the second stream exceeds the limit, so it is reset by the server
+	assert.False(t, open())
+
+	var blocked int64
+	hits.forEach(func(scope string, p protocol.ID, count int64) {
+		if scope == "protocol_peer" && p == proto {
+			blocked = count
+		}
+	})
+	assert.EqualValues(t, 1, blocked)
+}
@@ -2,10 +2,8 @@ package p2p
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ipfs/go-datastore"
-	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/libp2p/go-libp2p-core/routing"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"go.uber.org/fx"
@@ -23,7 +21,7 @@ func PeerRouting(cfg Config, params routingParams) (routing.PeerRouting, error)
 	opts := []dht.Option{
 		dht.Mode(dht.ModeAuto),
 		dht.BootstrapPeers(params.Peers...),
-		dht.ProtocolPrefix(protocol.ID(fmt.Sprintf("/celestia/%s", params.Net))),
+		dht.ProtocolPrefix(params.NetID.ProtocolPrefix()),
 		dht.Datastore(params.DataStore),
 		dht.RoutingTableRefreshPeriod(cfg.RoutingTableRefreshPeriod),
 	}
@@ -55,7 +53,7 @@ type routingParams struct {
 	fx.In
 
 	Ctx       context.Context
-	Net       Network
+	NetID     NetworkID
 	Peers     Bootstrappers
 	Lc        fx.Lifecycle
 	Host      HostBase
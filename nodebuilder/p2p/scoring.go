@@ -0,0 +1,161 @@
+package p2p
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+
+	"github.com/celestiaorg/celestia-node/fraud"
+	headp2p "github.com/celestiaorg/celestia-node/header/p2p"
+)
+
+// bootstrapperScore is the application-specific score of the bootstrappers, which lets them
+// exceed the AcceptPXThreshold, so that peers accept peer exchange from them.
+const bootstrapperScore = 2500
+
+var (
+	loopbackIPv4 = &net.IPNet{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)}
+	loopbackIPv6 = &net.IPNet{IP: net.IPv6loopback, Mask: net.CIDRMask(128, 128)}
+)
+
+// PeerScoringConfig configures the gossipsub v1.1 peer scoring on the header and fraud topics.
+// Peers delivering invalid headers or fraud proofs are penalized and, once their score drops below
+// the thresholds, excluded from gossip, publishing and, eventually, from message processing.
+type PeerScoringConfig struct {
+	// Enabled enables the peer scoring.
+	Enabled bool
+	// GossipThreshold is the score below which gossip to and from a peer is suppressed.
+	GossipThreshold float64
+	// PublishThreshold is the score below which messages are not published to a peer.
+	PublishThreshold float64
+	// GraylistThreshold is the score below which all the messages of a peer are ignored.
+	GraylistThreshold float64
+	// AcceptPXThreshold is the score above which peer exchange from a pruning peer is accepted.
+	// Only the bootstrappers attain it by default.
+	AcceptPXThreshold float64
+	// OpportunisticGraftThreshold is the median score of the mesh below which peers with higher
+	// scores are grafted to it.
+	OpportunisticGraftThreshold float64
+	// InvalidMessageWeight is the weight of the squared number of invalid messages a peer
+	// delivered on a topic.
+	InvalidMessageWeight float64
+	// InvalidMessageDecay is the time the number of invalid messages of a peer takes to decay to
+	// zero.
+	InvalidMessageDecay time.Duration
+	// IPColocationThreshold is the number of peers sharing an IP above which they are all
+	// penalized, mitigating Sybil attacks.
+	IPColocationThreshold int
+}
+
+// DefaultPeerScoringConfig returns the default PeerScoringConfig.
+// With the defaults, a peer is excluded from gossip after 3 invalid messages and graylisted after
+// 6 of them.
+func DefaultPeerScoringConfig() PeerScoringConfig {
+	return PeerScoringConfig{
+		Enabled:                     true,
+		GossipThreshold:             -500,
+		PublishThreshold:            -1000,
+		GraylistThreshold:           -2500,
+		AcceptPXThreshold:           1000,
+		OpportunisticGraftThreshold: 3.5,
+		InvalidMessageWeight:        -100,
+		InvalidMessageDecay:         time.Hour,
+		IPColocationThreshold:       10,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *PeerScoringConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.GossipThreshold > 0 || cfg.PublishThreshold > cfg.GossipThreshold ||
+		cfg.GraylistThreshold > cfg.PublishThreshold {
+		return errors.New("p2p: peer scoring thresholds must satisfy Graylist <= Publish <= Gossip <= 0")
+	}
+	if cfg.AcceptPXThreshold < 0 || cfg.OpportunisticGraftThreshold < 0 {
+		return errors.New("p2p: peer scoring AcceptPX and OpportunisticGraft thresholds must not be negative")
+	}
+	if cfg.InvalidMessageWeight > 0 {
+		return errors.New("p2p: peer scoring invalid message weight must not be positive")
+	}
+	if cfg.InvalidMessageDecay <= 0 {
+		return errors.New("p2p: peer scoring invalid message decay must be positive")
+	}
+	if cfg.IPColocationThreshold < 1 {
+		return errors.New("p2p: peer scoring IP colocation threshold must be at least 1")
+	}
+	return nil
+}
+
+// peerScoreOption returns the option of PubSub scoring the peers on the header and fraud topics of
+// the given network.
+func peerScoreOption(cfg PeerScoringConfig, netID NetworkID, bpeers Bootstrappers) pubsub.Option {
+	topics := map[string]*pubsub.TopicScoreParams{
+		headp2p.PubSubTopicID(string(netID)): headerTopicScoreParams(cfg),
+	}
+	for _, proofType := range fraud.RegisteredProofTypes() {
+		topics[fraud.PubSubTopicID(proofType, string(netID))] = fraudTopicScoreParams(cfg)
+	}
+
+	trusted := make(map[peer.ID]struct{}, len(bpeers))
+	for _, bpeer := range bpeers {
+		trusted[bpeer.ID] = struct{}{}
+	}
+
+	params := &pubsub.PeerScoreParams{
+		Topics: topics,
+		AppSpecificScore: func(id peer.ID) float64 {
+			if _, ok := trusted[id]; ok {
+				return bootstrapperScore
+			}
+			return 0
+		},
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -100,
+		IPColocationFactorThreshold: cfg.IPColocationThreshold,
+		// local test networks run all their nodes on the same IP
+		IPColocationFactorWhitelist: []*net.IPNet{loopbackIPv4, loopbackIPv6},
+		BehaviourPenaltyWeight:      -10,
+		BehaviourPenaltyThreshold:   6,
+		BehaviourPenaltyDecay:       pubsub.ScoreParameterDecay(time.Hour),
+		DecayInterval:               pubsub.DefaultDecayInterval,
+		DecayToZero:                 pubsub.DefaultDecayToZero,
+		RetainScore:                 time.Hour,
+	}
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             cfg.GossipThreshold,
+		PublishThreshold:            cfg.PublishThreshold,
+		GraylistThreshold:           cfg.GraylistThreshold,
+		AcceptPXThreshold:           cfg.AcceptPXThreshold,
+		OpportunisticGraftThreshold: cfg.OpportunisticGraftThreshold,
+	}
+	return pubsub.WithPeerScore(params, thresholds)
+}
+
+// headerTopicScoreParams rewards peers for being the first to deliver new headers, besides
+// penalizing invalid ones.
+func headerTopicScoreParams(cfg PeerScoringConfig) *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                    1,
+		TimeInMeshQuantum:              time.Second,
+		FirstMessageDeliveriesWeight:   1,
+		FirstMessageDeliveriesDecay:    pubsub.ScoreParameterDecay(time.Hour),
+		FirstMessageDeliveriesCap:      10,
+		InvalidMessageDeliveriesWeight: cfg.InvalidMessageWeight,
+		InvalidMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(cfg.InvalidMessageDecay),
+	}
+}
+
+// fraudTopicScoreParams only penalizes invalid fraud proofs, as valid ones are rare.
+func fraudTopicScoreParams(cfg PeerScoringConfig) *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                    1,
+		TimeInMeshQuantum:              time.Second,
+		InvalidMessageDeliveriesWeight: cfg.InvalidMessageWeight,
+		InvalidMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(cfg.InvalidMessageDecay),
+	}
+}
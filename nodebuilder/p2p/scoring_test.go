@@ -0,0 +1,45 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScoringConfig_Validate(t *testing.T) {
+	cfg := DefaultPeerScoringConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.PublishThreshold = cfg.GossipThreshold + 1
+	require.Error(t, cfg.Validate())
+
+	cfg = DefaultPeerScoringConfig()
+	cfg.InvalidMessageWeight = 1
+	require.Error(t, cfg.Validate())
+
+	cfg = DefaultPeerScoringConfig()
+	cfg.InvalidMessageDecay = 0
+	require.Error(t, cfg.Validate())
+
+	// disabled scoring is not validated
+	cfg.Enabled = false
+	require.NoError(t, cfg.Validate())
+}
+
+// TestPeerScoreOption ensures the default scoring parameters are accepted by gossipsub.
+func TestPeerScoreOption(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	host := net.Hosts()[0]
+	bpeer := net.Hosts()[1]
+	bpeers := Bootstrappers{host.Peerstore().PeerInfo(bpeer.ID())}
+	_, err = pubsub.NewGossipSub(ctx, host, peerScoreOption(DefaultPeerScoringConfig(), NetworkID(Private), bpeers))
+	require.NoError(t, err)
+}
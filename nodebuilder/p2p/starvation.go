@@ -0,0 +1,70 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+// starvationCheckInterval is the period of time between the checks of the amount of peers.
+var starvationCheckInterval = time.Second * 10
+
+// watchStarvation publishes a PeerStarvation event once the node stays without connected peers for
+// the configured StarvationTimeout, if set. The event is published once per starvation.
+func watchStarvation(cfg Config, lc fx.Lifecycle, h HostBase, bus *events.Bus) {
+	if cfg.StarvationTimeout == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				watchPeers(ctx, h, bus, cfg.StarvationTimeout)
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+}
+
+func watchPeers(ctx context.Context, h HostBase, bus *events.Bus, timeout time.Duration) {
+	ticker := time.NewTicker(starvationCheckInterval)
+	defer ticker.Stop()
+
+	since, starved := time.Now(), false
+	for {
+		select {
+		case <-ticker.C:
+			if len(h.Network().Peers()) > 0 {
+				since, starved = time.Now(), false
+				continue
+			}
+			if starved || time.Since(since) < timeout {
+				continue
+			}
+			starved = true
+			log.Warnw("no connected peers", "for", time.Since(since).Truncate(time.Second))
+			bus.Publish(events.Event{
+				Type:    events.PeerStarvation,
+				Message: fmt.Sprintf("no connected peers for %s", time.Since(since).Truncate(time.Second)),
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
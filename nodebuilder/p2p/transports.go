@@ -0,0 +1,70 @@
+package p2p
+
+import (
+	"errors"
+
+	"github.com/libp2p/go-libp2p"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ws "github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// TransportsConfig enables the transports of the p2p host.
+// The host listens only on the ListenAddresses of the enabled transports.
+type TransportsConfig struct {
+	// TCP enables the TCP transport.
+	TCP bool
+	// QUIC enables the QUIC transport, which is preferred by mobile nodes roaming between networks.
+	QUIC bool
+	// WebSocket enables the WebSocket transport, which browser-embedded light clients can connect
+	// over, e.g. to a "/ip4/0.0.0.0/tcp/2122/ws" listen address.
+	WebSocket bool
+}
+
+// DefaultTransportsConfig returns the default TransportsConfig with all the transports enabled.
+func DefaultTransportsConfig() TransportsConfig {
+	return TransportsConfig{
+		TCP:       true,
+		QUIC:      true,
+		WebSocket: true,
+	}
+}
+
+// Validate performs basic validation of the config.
+func (cfg *TransportsConfig) Validate() error {
+	if !cfg.TCP && !cfg.QUIC && !cfg.WebSocket {
+		return errors.New("p2p: at least one transport must be enabled")
+	}
+	return nil
+}
+
+// options returns the options of the libp2p Host enabling the configured transports.
+func (cfg *TransportsConfig) options() []libp2p.Option {
+	var opts []libp2p.Option
+	if cfg.TCP {
+		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport))
+	}
+	if cfg.QUIC {
+		opts = append(opts, libp2p.Transport(quic.NewTransport))
+	}
+	if cfg.WebSocket {
+		opts = append(opts, libp2p.Transport(ws.New))
+	}
+	return opts
+}
+
+// enabled reports whether the transport of the given address is enabled.
+func (cfg *TransportsConfig) enabled(addr ma.Multiaddr) bool {
+	for _, p := range addr.Protocols() {
+		switch p.Code {
+		case ma.P_QUIC:
+			return cfg.QUIC
+		case ma.P_WS, ma.P_WSS:
+			return cfg.WebSocket
+		}
+	}
+	// plain TCP addresses
+	_, err := addr.ValueForProtocol(ma.P_TCP)
+	return err == nil && cfg.TCP
+}
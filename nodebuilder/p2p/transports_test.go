@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportsConfig_Enabled(t *testing.T) {
+	cfg := TransportsConfig{TCP: true}
+	for addr, enabled := range map[string]bool{
+		"/ip4/0.0.0.0/tcp/2121":         true,
+		"/ip4/0.0.0.0/udp/2121/quic":    false,
+		"/ip4/0.0.0.0/tcp/2122/ws":      false,
+		"/dns4/example.com/tcp/443/wss": false,
+	} {
+		assert.Equal(t, enabled, cfg.enabled(ma.StringCast(addr)), addr)
+	}
+
+	cfg = TransportsConfig{}
+	require.Error(t, cfg.Validate())
+}
+
+func TestListen_SkipsDisabledTransports(t *testing.T) {
+	cfg := TransportsConfig{TCP: true, WebSocket: true}
+	h, err := libp2p.NewWithoutDefaults(append(cfg.options(),
+		libp2p.NoListenAddrs,
+		libp2p.DefaultSecurity,
+		libp2p.DefaultMuxers,
+		libp2p.DefaultPeerstore,
+		libp2p.RandomIdentity,
+	)...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, h.Close())
+	})
+
+	err = Listen([]string{
+		"/ip4/127.0.0.1/tcp/0",
+		"/ip4/127.0.0.1/udp/0/quic",
+		"/ip4/127.0.0.1/tcp/0/ws",
+	}, cfg)(h)
+	require.NoError(t, err)
+
+	var quic, websocket bool
+	for _, addr := range h.Network().ListenAddresses() {
+		_, err := addr.ValueForProtocol(ma.P_QUIC)
+		quic = quic || err == nil
+		_, err = addr.ValueForProtocol(ma.P_WS)
+		websocket = websocket || err == nil
+	}
+	assert.False(t, quic)
+	assert.True(t, websocket)
+	assert.Len(t, h.Network().ListenAddresses(), 2)
+}
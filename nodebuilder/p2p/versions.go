@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	"golang.org/x/mod/semver"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+const (
+	// agentPrefix starts the user agents of the nodes advertising their versions.
+	agentPrefix = "celestia-node"
+	// UnknownVersion is the version of the peers not advertising theirs, e.g. running the software
+	// released before the versions were advertised.
+	UnknownVersion = "unknown"
+)
+
+// VersionStats is the distribution of the software versions run by the connected peers.
+type VersionStats struct {
+	// Version is the version run by the node itself.
+	Version string
+	// Peers is the amount of connected peers per version they run.
+	Peers map[string]int
+	// Outdated is the amount of connected peers running older versions than the node itself,
+	// including the ones of UnknownVersion.
+	Outdated int
+}
+
+// userAgent returns the user agent the Host advertises to its peers over identify, so they know
+// the network and the version of the node.
+func userAgent(net Network) string {
+	return fmt.Sprintf("%s/%s/%s", agentPrefix, net, localVersion())
+}
+
+// localVersion returns the version run by the node itself.
+func localVersion() string {
+	version := node.GetBuildInfo().SemanticVersion
+	if version == "" {
+		return UnknownVersion
+	}
+	return version
+}
+
+// agentVersion parses the version of the node from its user agent.
+func agentVersion(agent string) string {
+	parts := strings.SplitN(agent, "/", 3)
+	if len(parts) != 3 || parts[0] != agentPrefix || parts[2] == "" {
+		return UnknownVersion
+	}
+	return parts[2]
+}
+
+// versionStats aggregates the versions advertised by the given peers against the local one.
+func versionStats(pstore peerstore.Peerstore, peers []peer.ID, local string) VersionStats {
+	stats := VersionStats{
+		Version: local,
+		Peers:   make(map[string]int),
+	}
+	for _, id := range peers {
+		// the agent is unknown until identify completes with the peer
+		agent, _ := pstore.Get(id, "AgentVersion")
+		str, _ := agent.(string)
+		version := agentVersion(str)
+		stats.Peers[version]++
+		if isOutdated(version, stats.Version) {
+			stats.Outdated++
+		}
+	}
+	return stats
+}
+
+// isOutdated reports whether the version is older than the local one. Only the semantic versions
+// are compared, the others are never outdated, unless they are unknown.
+func isOutdated(version, local string) bool {
+	local = releaseVersion(local)
+	if !semver.IsValid(local) {
+		return false
+	}
+	if version == UnknownVersion {
+		return true
+	}
+	version = releaseVersion(version)
+	return semver.IsValid(version) && semver.Compare(version, local) < 0
+}
+
+// describeSuffix ends the versions of the builds made past a release, as `git describe` reports
+// them, e.g. "v0.5.0-3-g1a2b3c4" 3 commits past v0.5.0, or "-dev" for the uncommitted changes.
+var describeSuffix = regexp.MustCompile(`(-[0-9]+-g[0-9a-f]+)?(-dev)?$`)
+
+// releaseVersion returns the release the given version is built from, as the suffixes of the builds
+// made past it would be compared as pre-releases, i.e. older than the release itself.
+func releaseVersion(version string) string {
+	return describeSuffix.ReplaceAllString(version, "")
+}
@@ -0,0 +1,52 @@
+package p2p
+
+import (
+	"testing"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionStats(t *testing.T) {
+	net, err := mocknet.FullMeshConnected(5)
+	require.NoError(t, err)
+	host, peers := net.Hosts()[0], net.Hosts()[1:]
+
+	agents := []string{
+		"celestia-node/private/v0.5.0",
+		"celestia-node/private/v0.4.1",
+		// advertised by the nodes released before the versions
+		"celestia-private",
+		"celestia-node/private/v0.6.0-rc1",
+	}
+	for i, agent := range agents {
+		require.NoError(t, host.Peerstore().Put(peers[i].ID(), "AgentVersion", agent))
+	}
+
+	stats := versionStats(host.Peerstore(), host.Network().Peers(), "v0.5.0")
+	assert.Equal(t, VersionStats{
+		Version: "v0.5.0",
+		Peers: map[string]int{
+			"v0.5.0":       1,
+			"v0.4.1":       1,
+			UnknownVersion: 1,
+			"v0.6.0-rc1":   1,
+		},
+		Outdated: 2,
+	}, stats)
+
+	// the builds made past a release are compared as the release
+	stats = versionStats(host.Peerstore(), host.Network().Peers(), "v0.5.0-3-g1a2b3c4-dev")
+	assert.Equal(t, 2, stats.Outdated)
+	assert.False(t, isOutdated("v0.5.0-12-g1a2b3c4", "v0.5.0"))
+	assert.False(t, isOutdated("v0.6.0-rc1-2-g1a2b3c4", "v0.6.0-rc1"))
+	assert.True(t, isOutdated("v0.4.1-7-g1a2b3c4", "v0.5.0"))
+
+	// nothing is outdated in comparison to the builds without a semantic version
+	stats = versionStats(host.Peerstore(), host.Network().Peers(), UnknownVersion)
+	assert.Zero(t, stats.Outdated)
+
+	// the tests are built without a semantic version
+	assert.Equal(t, UnknownVersion, agentVersion(userAgent(Private)))
+}
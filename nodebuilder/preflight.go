@@ -0,0 +1,260 @@
+package nodebuilder
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+
+	"github.com/celestiaorg/celestia-node/core"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/ntp"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+var (
+	// preflightDialTimeout bounds the network checks.
+	preflightDialTimeout = 5 * time.Second
+	// minOpenFiles is the recommended limit of the open files, as every connection and every table
+	// of the datastore takes a file descriptor.
+	minOpenFiles uint64 = 8192
+)
+
+// PreflightIssue is a problem found by Preflight.
+type PreflightIssue struct {
+	// Check names the check that found the issue.
+	Check string
+	Err   error
+	// Hint suggests how to fix the issue.
+	Hint string
+	// Fatal issues would leave the node half-broken, so it must not be started, while the other ones
+	// are only warned about.
+	Fatal bool
+}
+
+func (i PreflightIssue) String() string {
+	return fmt.Sprintf("%s: %s\n\t-> %s", i.Check, i.Err, i.Hint)
+}
+
+// Preflight checks the environment of the node before it starts, so that the misconfigurations
+// are reported with actionable errors, instead of the services failing midway. It checks:
+//   - the skew of the system clock against NTP
+//   - whether the ports to listen on are free
+//   - whether the store is writable
+//   - the limit of the open files
+//   - whether the Core endpoints are reachable
+func Preflight(ctx context.Context, tp node.Type, cfg *Config, store Store) []PreflightIssue {
+	var issues []PreflightIssue
+	issues = append(issues, checkClock(ctx, cfg)...)
+	issues = append(issues, checkPorts(cfg)...)
+	issues = append(issues, checkStore(store)...)
+	issues = append(issues, checkOpenFiles()...)
+	issues = append(issues, checkCore(ctx, tp, cfg)...)
+	return issues
+}
+
+func checkClock(ctx context.Context, cfg *Config) []PreflightIssue {
+	if len(cfg.Header.NTPServers) == 0 {
+		return nil
+	}
+	skew, err := ntp.Estimate(ctx, cfg.Header.NTPServers, preflightDialTimeout)
+	if err != nil {
+		return []PreflightIssue{{
+			Check: "clock",
+			Err:   fmt.Errorf("querying NTP servers: %w", err),
+			Hint:  "ensure the system clock is synchronized, e.g. with chrony or systemd-timesyncd",
+		}}
+	}
+	drift := cfg.Header.ClockDrift
+	if skew > drift || skew < -drift {
+		// the clock behind is tolerated by the drift widened by the estimated offset of the clock
+		tolerated := cfg.Header.ClockSyncInterval != 0 && skew > 0 && skew <= drift+header.MaxClockOffset
+		return []PreflightIssue{{
+			Check: "clock",
+			Err:   fmt.Errorf("system clock is off by %s", skew.Truncate(time.Millisecond)),
+			Hint: fmt.Sprintf("synchronize the system clock, e.g. with chrony or systemd-timesyncd, as "+
+				"headers are rejected with the clock off by more than Header.ClockDrift of %s, unless "+
+				"Header.ClockSyncInterval is set to tolerate the clock behind by up to %s more",
+				drift, header.MaxClockOffset),
+			Fatal: !tolerated,
+		}}
+	}
+	return nil
+}
+
+func checkPorts(cfg *Config) []PreflightIssue {
+	addrs := map[string]string{
+		"RPC": net.JoinHostPort(cfg.RPC.Address, cfg.RPC.Port),
+	}
+	if cfg.Gateway.Enabled {
+		addrs["Gateway"] = net.JoinHostPort(cfg.Gateway.Address, cfg.Gateway.Port)
+	}
+	if cfg.GRPC.Enabled {
+		addrs["GRPC"] = net.JoinHostPort(cfg.GRPC.Address, cfg.GRPC.Port)
+	}
+
+	var issues []PreflightIssue
+	for name, addr := range addrs {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			issues = append(issues, PreflightIssue{
+				Check: "ports",
+				Err:   fmt.Errorf("can't listen on %s: %w", addr, err),
+				Hint:  fmt.Sprintf("stop the process using the port or change %s.Address and %s.Port", name, name),
+				Fatal: true,
+			})
+			continue
+		}
+		ln.Close()
+	}
+
+	if cfg.Node.ReadOnly {
+		// read-only nodes don't listen for peers
+		return issues
+	}
+	for _, addr := range cfg.P2P.ListenAddresses {
+		err := checkListenAddr(addr)
+		if err != nil {
+			issues = append(issues, PreflightIssue{
+				Check: "ports",
+				Err:   fmt.Errorf("can't listen on %s: %w", addr, err),
+				Hint:  "stop the process using the port or change P2P.ListenAddresses",
+				Fatal: true,
+			})
+		}
+	}
+	return issues
+}
+
+// checkListenAddr checks whether the transport address the given multiaddr is based on is free.
+func checkListenAddr(addr string) error {
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	// the IP and the TCP or UDP port, stripping the protocols on top, e.g. QUIC
+	parts := ma.Split(maddr)
+	if len(parts) < 2 {
+		return nil
+	}
+	netAddr, err := manet.ToNetAddr(ma.Join(parts[0], parts[1]))
+	if err != nil {
+		// not an IP address, e.g. of a DNS name, so can't be checked
+		return nil //nolint:nilerr
+	}
+
+	switch addr := netAddr.(type) {
+	case *net.TCPAddr:
+		if addr.Port == 0 {
+			return nil
+		}
+		ln, err := net.ListenTCP("tcp", addr)
+		if err != nil {
+			return err
+		}
+		return ln.Close()
+	case *net.UDPAddr:
+		if addr.Port == 0 {
+			return nil
+		}
+		conn, err := net.ListenUDP("udp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	default:
+		return nil
+	}
+}
+
+func checkStore(store Store) []PreflightIssue {
+	path := store.Path()
+	if path == "" {
+		// the in-memory stores
+		return nil
+	}
+	f, err := os.CreateTemp(path, ".preflight-*")
+	if err == nil {
+		name := f.Name()
+		f.Close()
+		err = os.Remove(name)
+	}
+	if err != nil {
+		return []PreflightIssue{{
+			Check: "store",
+			Err:   fmt.Errorf("store %s is not writable: %w", filepath.Clean(path), err),
+			Hint:  "grant the user running the node the write permission on the store or free up the disk",
+			Fatal: true,
+		}}
+	}
+	return nil
+}
+
+func checkOpenFiles() []PreflightIssue {
+	limit, err := openFilesLimit()
+	if err != nil {
+		return []PreflightIssue{{
+			Check: "ulimit",
+			Err:   fmt.Errorf("getting limit of open files: %w", err),
+			Hint:  fmt.Sprintf("ensure at least %d files can be open, e.g. with 'ulimit -n %d'", minOpenFiles, minOpenFiles),
+		}}
+	}
+	if limit != 0 && limit < minOpenFiles {
+		return []PreflightIssue{{
+			Check: "ulimit",
+			Err:   fmt.Errorf("limit of open files is %d", limit),
+			Hint: fmt.Sprintf("raise the limit to at least %d, e.g. with 'ulimit -n %d' or LimitNOFILE of "+
+				"the systemd unit, or the node may fail with 'too many open files'", minOpenFiles, minOpenFiles),
+		}}
+	}
+	return nil
+}
+
+func checkCore(ctx context.Context, tp node.Type, cfg *Config) []PreflightIssue {
+	if cfg.Core.RPCPort == "0" && cfg.Core.GRPCPort == "0" {
+		// Core is not configured
+		return nil
+	}
+
+	var (
+		issues    []PreflightIssue
+		reachable bool
+	)
+	for _, endpoint := range cfg.Core.Endpoints() {
+		err := dialCore(ctx, endpoint)
+		if err != nil {
+			issues = append(issues, PreflightIssue{
+				Check: "core",
+				Err:   err,
+				Hint:  "ensure the Core node is running and its RPC and gRPC are reachable from this host",
+			})
+			continue
+		}
+		reachable = true
+	}
+	if !reachable && tp == node.Bridge {
+		// bridge nodes get the blocks from Core only
+		for i := range issues {
+			issues[i].Fatal = true
+		}
+	}
+	return issues
+}
+
+func dialCore(ctx context.Context, endpoint core.Endpoint) error {
+	dialer := net.Dialer{Timeout: preflightDialTimeout}
+	for _, port := range []string{endpoint.RPCPort, endpoint.GRPCPort} {
+		addr := net.JoinHostPort(endpoint.IP, port)
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("can't reach Core at %s: %w", addr, err)
+		}
+		conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+//go:build js && wasm
+
+package nodebuilder
+
+// openFilesLimit returns the soft limit of the open files of the process.
+// The browser limits nothing, so zero is returned.
+func openFilesLimit() (uint64, error) {
+	return 0, nil
+}
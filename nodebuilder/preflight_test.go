@@ -0,0 +1,99 @@
+package nodebuilder
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestPreflight(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	// occupy the port of the RPC
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ln.Close()
+	})
+
+	cfg := DefaultConfig(node.Bridge)
+	cfg.Header.NTPServers = []string{fakeNTPServer(t, time.Minute)}
+	cfg.Header.ClockSyncInterval = 0
+	cfg.RPC.Address = "127.0.0.1"
+	cfg.RPC.Port = strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+	cfg.P2P.ListenAddresses = []string{"/ip4/127.0.0.1/udp/0/quic", "/ip4/127.0.0.1/tcp/0"}
+	// nothing listens on the discard port
+	cfg.Core.IP, cfg.Core.RPCPort, cfg.Core.GRPCPort = "127.0.0.1", "9", "9"
+
+	issues := Preflight(ctx, node.Bridge, cfg, MockStore(t, cfg))
+	checks := make(map[string]PreflightIssue)
+	for _, issue := range issues {
+		checks[issue.Check] = issue
+	}
+	require.Contains(t, checks, "clock")
+	assert.True(t, checks["clock"].Fatal)
+	require.Contains(t, checks, "ports")
+	assert.True(t, checks["ports"].Fatal)
+	require.Contains(t, checks, "core")
+	assert.True(t, checks["core"].Fatal)
+	assert.NotContains(t, checks, "store")
+
+	// the skew beyond the capped offset is not compensated by the estimated one
+	cfg.Header.ClockSyncInterval = time.Minute
+	for _, issue := range checkClock(ctx, cfg) {
+		assert.True(t, issue.Fatal)
+	}
+	// while the slight one is
+	cfg.Header.NTPServers = []string{fakeNTPServer(t, cfg.Header.ClockDrift+time.Second*2)}
+	for _, issue := range checkClock(ctx, cfg) {
+		assert.False(t, issue.Fatal)
+	}
+
+	// Core is optional for the light nodes
+	cfg = DefaultConfig(node.Light)
+	cfg.Core.IP, cfg.Core.RPCPort, cfg.Core.GRPCPort = "127.0.0.1", "9", "9"
+	for _, issue := range checkCore(ctx, node.Light, cfg) {
+		assert.False(t, issue.Fatal)
+	}
+}
+
+// ntpEpochOffset is the amount of seconds between the NTP and the Unix epochs.
+const ntpEpochOffset = 2208988800
+
+// fakeNTPServer serves the time off by the given skew over SNTP and returns its address.
+func fakeNTPServer(t *testing.T, skew time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	go func() {
+		buf := make([]byte, 48)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp := make([]byte, 48)
+			now := time.Now().Add(skew)
+			secs := uint32(now.Unix() + ntpEpochOffset)
+			frac := uint32((uint64(now.Nanosecond()) << 32) / 1e9)
+			for _, off := range []int{32, 40} {
+				binary.BigEndian.PutUint32(resp[off:], secs)
+				binary.BigEndian.PutUint32(resp[off+4:], frac)
+			}
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
@@ -0,0 +1,15 @@
+//go:build darwin || freebsd || linux
+
+package nodebuilder
+
+import "syscall"
+
+// openFilesLimit returns the soft limit of the open files of the process.
+func openFilesLimit() (uint64, error) {
+	var limit syscall.Rlimit
+	err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(limit.Cur), nil //nolint:unconvert // the type of the field differs between the platforms
+}
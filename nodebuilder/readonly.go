@@ -0,0 +1,35 @@
+package nodebuilder
+
+import (
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	exchange "github.com/ipfs/go-ipfs-exchange-interface"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+)
+
+// readOnlyComponents cut the node off the network, so that it runs purely from its existing store.
+// The blocks of shares are only got from the local blockstore and no peers are bootstrapped from.
+func readOnlyComponents() fx.Option {
+	return fx.Options(
+		fx.Replace(p2p.Bootstrappers{}),
+		fx.Decorate(func(bs blockstore.Blockstore) exchange.Interface {
+			return offline.Exchange(bs)
+		}),
+	)
+}
+
+// readOnlyP2PConfig returns the copy of the given Config, which neither listens nor connects to
+// any peers, including the known ones and the bootstrappers, nor watches the connections.
+func readOnlyP2PConfig(cfg p2p.Config) *p2p.Config {
+	cfg.ListenAddresses = nil
+	cfg.AnnounceAddresses = nil
+	cfg.MutualPeers = nil
+	cfg.MDNS = false
+	cfg.NAT = p2p.NATConfig{}
+	cfg.ReconnectPeers = 0
+	cfg.StarvationTimeout = 0
+	cfg.BootstrapProbeInterval = 0
+	return &cfg
+}
@@ -0,0 +1,134 @@
+package nodebuilder
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+
+	"github.com/celestiaorg/celestia-node/libs/fslock"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+// InitDefault initializes the Node FileSystem Store for the given Node Type 'tp' in the directory
+// under 'path' with the default Config.
+func InitDefault(path string, tp node.Type) error {
+	return Init(*DefaultConfig(tp), path, tp)
+}
+
+// Reset erases all the data of the Node FileSystem Store under the given 'path', while its Config
+// and keys are kept, so the Node starts over as a freshly initialized one.
+func Reset(path string) error {
+	path, err := storePath(path)
+	if err != nil {
+		return err
+	}
+
+	flock, err := fslock.Lock(lockPath(path))
+	if err != nil {
+		if err == fslock.ErrLocked {
+			return ErrOpened
+		}
+		return err
+	}
+	defer flock.Unlock() //nolint: errcheck
+
+	if !IsInit(path) {
+		return ErrNotInited
+	}
+	log.Infof("Resetting Node Store over '%s'", path)
+
+	err = os.RemoveAll(dataPath(path))
+	if err != nil {
+		return fmt.Errorf("node: can't erase Datastore: %w", err)
+	}
+	err = initDir(dataPath(path))
+	if err != nil {
+		return err
+	}
+	log.Info("Node Store reset")
+	return nil
+}
+
+// PurgeBlockstore deletes all the blocks of the Node FileSystem Store under the given 'path',
+// while the rest of the data is kept, e.g. to reclaim the disk space of a Full Node without
+// resyncing the headers.
+func PurgeBlockstore(ctx context.Context, path string) (err error) {
+	store, err := OpenStore(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := store.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	ds, err := store.Datastore()
+	if err != nil {
+		return err
+	}
+	log.Infof("Purging Blockstore of Node Store over '%s'", store.Path())
+
+	n, err := deletePrefix(ctx, ds, blockstore.BlockPrefix)
+	if err != nil {
+		return fmt.Errorf("node: can't purge Blockstore: %w", err)
+	}
+	log.Infow("Blockstore purged", "blocks", n)
+	return nil
+}
+
+// RestoreDatastore replaces the data of the Node FileSystem Store under the given 'path' with the
+// backup under the 'backup' path, which is relative to the configured Node.BackupDir, unless
+// absolute. It fails with ErrOpened, while the Node is running.
+func RestoreDatastore(ctx context.Context, path, backup string) (err error) {
+	store, err := OpenStore(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := store.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	cfg, err := store.Config()
+	if err != nil {
+		return err
+	}
+	ds, err := store.Datastore()
+	if err != nil {
+		return err
+	}
+	log.Infof("Restoring Datastore of Node Store over '%s'", store.Path())
+	return node.RestoreDatastore(ctx, cfg.Node, ds, backup)
+}
+
+// deletePrefix deletes all the entries under the given prefix, reporting their amount.
+func deletePrefix(ctx context.Context, ds datastore.Batching, prefix datastore.Key) (int, error) {
+	res, err := ds.Query(ctx, dsq.Query{Prefix: prefix.String(), KeysOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+
+	batch, err := ds.Batch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, r.Error
+		}
+		err = batch.Delete(ctx, datastore.NewKey(r.Key))
+		if err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, batch.Commit(ctx)
+}
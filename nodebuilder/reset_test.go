@@ -0,0 +1,137 @@
+package nodebuilder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/dsbackup"
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+)
+
+func TestReset(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	err := Reset(dir)
+	require.ErrorIs(t, err, ErrNotInited)
+
+	err = InitDefault(dir, node.Full)
+	require.NoError(t, err)
+	putStoreData(t, dir, "key")
+
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	err = Reset(dir)
+	require.ErrorIs(t, err, ErrOpened)
+	require.NoError(t, store.Close())
+
+	err = Reset(dir)
+	require.NoError(t, err)
+
+	// the keys are kept, while the data is erased
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	ks, err := store.Keystore()
+	require.NoError(t, err)
+	_, err = ks.Get("key")
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	empty, err := isEmpty(ctx, ds)
+	require.NoError(t, err)
+	assert.True(t, empty)
+	require.NoError(t, store.Close())
+}
+
+func TestPurgeBlockstore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	err := InitDefault(dir, node.Full)
+	require.NoError(t, err)
+	putStoreData(t, dir, "key")
+
+	err = PurgeBlockstore(ctx, dir)
+	require.NoError(t, err)
+
+	// only the blocks are deleted
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	has, err := ds.Has(ctx, blockstore.BlockPrefix.ChildString("block"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = ds.Has(ctx, datastore.NewKey("other"))
+	require.NoError(t, err)
+	assert.True(t, has)
+	require.NoError(t, store.Close())
+}
+
+func TestRestoreDatastore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	err := InitDefault(dir, node.Full)
+	require.NoError(t, err)
+	putStoreData(t, dir, "key")
+
+	// back up the data and change it afterwards
+	backup := filepath.Join(t.TempDir(), "datastore.bak")
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	f, err := os.Create(backup)
+	require.NoError(t, err)
+	_, err = dsbackup.Backup(ctx, ds, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	err = ds.Delete(ctx, datastore.NewKey("other"))
+	require.NoError(t, err)
+
+	// the data is never restored under the running node
+	err = RestoreDatastore(ctx, dir, backup)
+	require.ErrorIs(t, err, ErrOpened)
+	require.NoError(t, store.Close())
+
+	err = RestoreDatastore(ctx, dir, backup)
+	require.NoError(t, err)
+
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	ds, err = store.Datastore()
+	require.NoError(t, err)
+	has, err := ds.Has(ctx, datastore.NewKey("other"))
+	require.NoError(t, err)
+	assert.True(t, has)
+	require.NoError(t, store.Close())
+}
+
+// putStoreData stores a key, a block and some other data in the Store under the given 'path'.
+func putStoreData(t *testing.T, path string, key keystore.KeyName) {
+	ctx := context.Background()
+	store, err := OpenStore(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	ks, err := store.Keystore()
+	require.NoError(t, err)
+	err = ks.Put(key, keystore.PrivKey{Body: []byte("private_key")})
+	require.NoError(t, err)
+
+	ds, err := store.Datastore()
+	require.NoError(t, err)
+	err = ds.Put(ctx, blockstore.BlockPrefix.ChildString("block"), []byte("block"))
+	require.NoError(t, err)
+	err = ds.Put(ctx, datastore.NewKey("other"), []byte("other"))
+	require.NoError(t, err)
+}
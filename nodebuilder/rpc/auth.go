@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/filecoin-project/go-jsonrpc/auth"
+	"github.com/gbrlsnchs/jwt/v3"
+
+	"github.com/celestiaorg/celestia-node/api/rpc/perms"
+	"github.com/celestiaorg/celestia-node/libs/authtoken"
+	"github.com/celestiaorg/celestia-node/libs/keystore"
+)
+
+const (
+	secretName = "jwt-secret"
+	secretSize = 32
+)
+
+// Secret provides the node's secret the RPC auth tokens are signed with,
+// generating and storing a new one in the Keystore if none exists yet.
+func Secret(ks keystore.Keystore) (jwt.Algorithm, error) {
+	sk, err := ks.Get(secretName)
+	if err != nil {
+		if !errors.Is(err, keystore.ErrNotFound) {
+			return nil, err
+		}
+
+		log.Infow("no JWT secret found in keystore, generating new one", "path", ks.Path())
+		body := make([]byte, secretSize)
+		_, err = io.ReadFull(rand.Reader, body)
+		if err != nil {
+			return nil, err
+		}
+
+		sk = keystore.PrivKey{Body: body}
+		err = ks.Put(secretName, sk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jwt.NewHS256(sk.Body), nil
+}
+
+// NewToken mints a new auth token granting the given permission tier, signed
+// with the secret held in the Keystore.
+func NewToken(ks keystore.Keystore, perm auth.Permission) (string, error) {
+	return NewRestrictedToken(ks, perm, authtoken.Restrictions{})
+}
+
+// NewRestrictedToken mints a new auth token granting the given permission tier narrowed down by
+// the Restrictions, e.g. for a tenant of a node shared with others.
+func NewRestrictedToken(ks keystore.Keystore, perm auth.Permission, r authtoken.Restrictions) (string, error) {
+	permissions, err := perms.With(perm)
+	if err != nil {
+		return "", err
+	}
+	signer, err := Secret(ks)
+	if err != nil {
+		return "", err
+	}
+	return authtoken.NewRestrictedJWT(signer, permissions, r)
+}
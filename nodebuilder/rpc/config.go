@@ -3,20 +3,30 @@ package rpc
 import (
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/celestiaorg/celestia-node/libs/httpsrv"
 	"github.com/celestiaorg/celestia-node/libs/utils"
 )
 
 type Config struct {
 	Address string
 	Port    string
+	// DrainTimeout is the time the server waits for in-flight requests to be served on shutdown.
+	DrainTimeout time.Duration
+	// EnableDiagnostics serves the runtime profiles and GC stats of the node to admin clients.
+	EnableDiagnostics bool
+	// the HTTP server settings, e.g. to run the RPC behind a reverse proxy
+	httpsrv.Config
 }
 
 func DefaultConfig() Config {
 	return Config{
 		Address: "0.0.0.0",
 		// do NOT expose the same port as celestia-core by default so that both can run on the same machine
-		Port: "26658",
+		Port:         "26658",
+		DrainTimeout: time.Second * 5,
+		Config:       httpsrv.DefaultConfig(),
 	}
 }
 
@@ -31,5 +41,11 @@ func (cfg *Config) Validate() error {
 	if err != nil {
 		return fmt.Errorf("service/rpc: invalid port: %s", err.Error())
 	}
+	if cfg.DrainTimeout <= 0 {
+		return fmt.Errorf("service/rpc: drain timeout must be positive")
+	}
+	if err = cfg.Config.Validate(); err != nil {
+		return fmt.Errorf("service/rpc: %w", err)
+	}
 	return nil
 }
@@ -1,33 +1,50 @@
 package rpc
 
 import (
+	"github.com/gbrlsnchs/jwt/v3"
+
 	"github.com/celestiaorg/celestia-node/api/rpc"
+	"github.com/celestiaorg/celestia-node/nodebuilder/blob"
+	"github.com/celestiaorg/celestia-node/nodebuilder/da"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
 	"github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/keystore"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 	"github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/state"
 )
 
 // RegisterEndpoints registers the given services on the rpc.
+// NOTE: The keystore exposes the private keys, so all of its methods require the admin
+// permission, and it must never be registered on a server not authenticating the requests.
 func RegisterEndpoints(
-	state state.Module,
-	share share.Module,
-	fraud fraud.Module,
-	header header.Module,
-	daser das.Module,
-	p2p p2p.Module,
+	stateMod state.Module,
+	shareMod share.Module,
+	blobMod blob.Module,
+	daMod da.Module,
+	fraudMod fraud.Module,
+	headerMod header.Module,
+	daserMod das.Module,
+	p2pMod p2p.Module,
+	keystoreMod keystore.Module,
+	nodeMod node.Module,
 	serv *rpc.Server,
 ) {
-	serv.RegisterService("state", state)
-	serv.RegisterService("share", share)
-	serv.RegisterService("fraud", fraud)
-	serv.RegisterService("header", header)
-	serv.RegisterService("das", daser)
-	serv.RegisterService("p2p", p2p)
+	serv.RegisterService("state", stateMod, &state.API{})
+	serv.RegisterService("share", shareMod, &share.API{})
+	serv.RegisterService("blob", blobMod, &blob.API{})
+	serv.RegisterService("da", daMod, &da.API{})
+	serv.RegisterService("fraud", fraudMod, &fraud.API{})
+	serv.RegisterService("header", headerMod, &header.API{})
+	serv.RegisterService("das", daserMod, &das.API{})
+	serv.RegisterService("p2p", p2pMod, &p2p.API{})
+	serv.RegisterService("keystore", keystoreMod, &keystore.API{})
+	serv.RegisterService("node", nodeMod, &node.API{})
 }
 
-func Server(cfg *Config) *rpc.Server {
-	return rpc.NewServer(cfg.Address, cfg.Port)
+func Server(cfg *Config, signer jwt.Algorithm) (*rpc.Server, error) {
+	srv := rpc.NewServer(cfg.Address, cfg.Port, signer)
+	return srv, srv.Configure(cfg.Config)
 }
@@ -6,8 +6,11 @@ import (
 )
 
 var (
-	addrFlag = "rpc.addr"
-	portFlag = "rpc.port"
+	addrFlag        = "rpc.addr"
+	portFlag        = "rpc.port"
+	diagnosticsFlag = "rpc.diagnostics"
+	corsFlag        = "rpc.cors-origins"
+	proxiesFlag     = "rpc.trusted-proxies"
 )
 
 // Flags gives a set of hardcoded node/rpc package flags.
@@ -24,6 +27,21 @@ func Flags() *flag.FlagSet {
 		"",
 		"Set a custom RPC port (default: 26658)",
 	)
+	flags.Bool(
+		diagnosticsFlag,
+		false,
+		"Serves runtime profiles and GC stats of the node to admin RPC clients",
+	)
+	flags.StringSlice(
+		corsFlag,
+		nil,
+		"Comma-separated origins allowed to make RPC requests from browsers, e.g. https://example.com, or * for any",
+	)
+	flags.StringSlice(
+		proxiesFlag,
+		nil,
+		"Comma-separated IPs or CIDRs of reverse proxies trusted to report the client address in X-Forwarded-For",
+	)
 
 	return flags
 }
@@ -38,4 +56,13 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if port != "" {
 		cfg.Port = port
 	}
+	if cmd.Flag(diagnosticsFlag).Changed {
+		cfg.EnableDiagnostics = true
+	}
+	if origins, err := cmd.Flags().GetStringSlice(corsFlag); err == nil && len(origins) > 0 {
+		cfg.CORSOrigins = origins
+	}
+	if proxies, err := cmd.Flags().GetStringSlice(proxiesFlag); err == nil && len(proxies) > 0 {
+		cfg.TrustedProxies = proxies
+	}
 }
@@ -3,12 +3,15 @@ package rpc
 import (
 	"context"
 
+	logging "github.com/ipfs/go-log/v2"
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/api/rpc"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
+var log = logging.Logger("module/rpc")
+
 func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
@@ -16,12 +19,15 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 	baseComponents := fx.Options(
 		fx.Supply(cfg),
 		fx.Error(cfgErr),
+		fx.Provide(Secret),
 		fx.Provide(fx.Annotate(
 			Server,
 			fx.OnStart(func(ctx context.Context, server *rpc.Server) error {
 				return server.Start(ctx)
 			}),
 			fx.OnStop(func(ctx context.Context, server *rpc.Server) error {
+				ctx, cancel := context.WithTimeout(ctx, cfg.DrainTimeout)
+				defer cancel()
 				return server.Stop(ctx)
 			}),
 		)),
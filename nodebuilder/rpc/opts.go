@@ -0,0 +1,14 @@
+package rpc
+
+import (
+	"github.com/gbrlsnchs/jwt/v3"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/fxutil"
+)
+
+// WithSecret overrides the secret the RPC auth tokens are signed with,
+// which is otherwise loaded from or generated into the node's Keystore.
+func WithSecret(signer jwt.Algorithm) fx.Option {
+	return fxutil.ReplaceAs(signer, new(jwt.Algorithm))
+}
@@ -3,8 +3,10 @@ package nodebuilder
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	exchange "github.com/ipfs/go-ipfs-exchange-interface"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/metric/global"
@@ -17,9 +19,15 @@ import (
 
 	"github.com/celestiaorg/celestia-node/fraud"
 	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/libs/health"
 	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/metrics"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
+	modshare "github.com/celestiaorg/celestia-node/nodebuilder/share"
+	modwatchdog "github.com/celestiaorg/celestia-node/nodebuilder/watchdog"
+	"github.com/celestiaorg/celestia-node/replay"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 	"github.com/celestiaorg/celestia-node/state"
 )
 
@@ -43,6 +51,12 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 		fx.Invoke(header.WithMetrics),
 		fx.Invoke(state.WithMetrics),
 		fx.Invoke(fraud.WithMetrics),
+		fx.Invoke(health.WithMetrics),
+		fx.Invoke(p2p.WithMetrics),
+		fx.Invoke(ipld.WithMetrics),
+		fx.Invoke(modshare.WithMetrics),
+		fx.Invoke(modwatchdog.WithMetrics),
+		fx.Invoke(node.WithMetrics),
 	)
 
 	var opts fx.Option
@@ -64,6 +78,49 @@ func WithMetrics(metricOpts []otlpmetrichttp.Option, nodeType node.Type) fx.Opti
 	return opts
 }
 
+// WithPrometheusMetrics exposes the node's metrics for Prometheus on the given listen address.
+func WithPrometheusMetrics(address string, nodeType node.Type) fx.Option {
+	return metrics.ConstructModule(nodeType, address)
+}
+
+// WithTraceRecording records the headers and the blocks of shares the node gets from the network
+// to the trace file under the given path, so that they can be replayed with WithTraceReplay.
+func WithTraceRecording(path string) fx.Option {
+	return fx.Options(
+		fx.Provide(func(lc fx.Lifecycle) (*replay.Recorder, error) {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("opening trace: %w", err)
+			}
+			lc.Append(fx.Hook{
+				OnStop: func(context.Context) error {
+					return f.Close()
+				},
+			})
+			return replay.NewRecorder(f), nil
+		}),
+		fx.Decorate(func(rec *replay.Recorder, ex header.Exchange) header.Exchange {
+			return rec.RecordHeaders(ex)
+		}),
+		fx.Decorate(func(rec *replay.Recorder, ex exchange.Interface) exchange.Interface {
+			return rec.RecordBlocks(ex)
+		}),
+	)
+}
+
+// WithTraceReplay makes the node get the headers and the blocks of shares from the given Trace
+// instead of the network.
+func WithTraceReplay(trace *replay.Trace) fx.Option {
+	return fx.Decorate(
+		func(header.Exchange) header.Exchange {
+			return trace.ReplayHeaders()
+		},
+		func(exchange.Interface) exchange.Interface {
+			return trace.ReplayBlocks()
+		},
+	)
+}
+
 // initializeMetrics initializes the global meter provider.
 func initializeMetrics(
 	ctx context.Context,
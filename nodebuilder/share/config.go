@@ -4,10 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/service"
 )
 
 var (
-	ErrNegativeInterval = errors.New("interval must be positive")
+	ErrNegativeInterval   = errors.New("interval must be positive")
+	ErrInvalidSampleCount = errors.New("sample amount must be positive")
+	ErrInvalidConfidence  = errors.New("target confidence must be in the [0, 1) range")
+	ErrNegativeCacheSize  = errors.New("proof cache size must not be negative")
+	ErrNegativeBudget     = errors.New("retrieval budget must not be negative")
+	ErrNegativeQuota      = errors.New("serve quota must not be negative")
+	ErrInvalidBanDuration = errors.New("serve ban duration must be positive")
+	ErrNoRemoteBucket     = errors.New("remote bucket must be set along with the remote endpoint")
+	ErrNegativeOffload    = errors.New("offload age must not be negative")
 )
 
 type Config struct {
@@ -15,9 +27,44 @@ type Config struct {
 	PeersLimit uint
 	// DiscoveryInterval is an interval between discovery sessions.
 	DiscoveryInterval time.Duration
-	// AdvertiseInterval is a interval between advertising sessions.
+	// AdvertiseInterval is an interval between attempts to advertise after a failed one.
+	// Successful advertisements are renewed shortly before they expire.
 	// NOTE: only full and bridge can advertise themselves.
 	AdvertiseInterval time.Duration
+	// SampleAmount is the amount of shares sampled from every square.
+	// NOTE: only light nodes sample.
+	SampleAmount int
+	// TargetConfidence is the probability of availability sampling has to reach for every square.
+	// The amount of samples is raised above SampleAmount to reach it, if needed. 0 disables it.
+	TargetConfidence float64
+	// ProofCacheSize is the size in bytes of the proofs cached for the served shares, so that the
+	// proofs for popular namespaces are not recomputed on every request. 0 disables caching.
+	ProofCacheSize int
+	// RetrievalBudget is the total time given to retrieve the shares of a square, e.g. for
+	// GetSharesByNamespace or GetEDS, split across the sources of the shares and the attempts to
+	// them. The retrievals exceeding it fail with a timeout error. 0 disables it.
+	RetrievalBudget time.Duration
+	// AccessLog enables logging every block of shares served to the peers along with the peer, the
+	// namespace, the size and the latency of serving it under the "share/access" logger, so that
+	// the load is attributed to the rollups requesting it.
+	AccessLog bool
+	// ServeRequestsPerMinute is the quota of the requests for the blocks of shares served to every
+	// remote peer per minute. 0 disables it.
+	ServeRequestsPerMinute int
+	// ServeBytesPerHour is the quota of the bytes of the blocks of shares served to every remote
+	// peer per hour. 0 disables it.
+	ServeBytesPerHour uint64
+	// ServeBanDuration is the duration the requests of the peers exceeding any of the serve quotas
+	// are denied for.
+	ServeBanDuration time.Duration
+	// Remote is the S3 compatible object storage the old squares are offloaded to from the local
+	// disk, and fetched back from lazily. It is disabled, if its Endpoint is empty.
+	// NOTE: only full and bridge nodes store the squares, and they keep them in a separate store
+	// only if the Remote is enabled.
+	Remote eds.S3Config
+	// OffloadAfter is the age of the stored squares after which they are offloaded to the Remote.
+	// 0 disables the offloading.
+	OffloadAfter time.Duration
 }
 
 func DefaultConfig() Config {
@@ -25,6 +72,11 @@ func DefaultConfig() Config {
 		PeersLimit:        3,
 		DiscoveryInterval: time.Second * 30,
 		AdvertiseInterval: time.Second * 30,
+		SampleAmount:      light.DefaultSampleAmount,
+		ProofCacheSize:    service.DefaultProofCacheSize,
+		RetrievalBudget:   time.Minute,
+		ServeBanDuration:  time.Minute * 10,
+		OffloadAfter:      time.Hour * 24 * 30,
 	}
 }
 
@@ -33,5 +85,29 @@ func (cfg *Config) Validate() error {
 	if cfg.DiscoveryInterval <= 0 || cfg.AdvertiseInterval <= 0 {
 		return fmt.Errorf("nodebuilder/share: %s", ErrNegativeInterval)
 	}
+	if cfg.SampleAmount <= 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrInvalidSampleCount)
+	}
+	if cfg.TargetConfidence < 0 || cfg.TargetConfidence >= 1 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrInvalidConfidence)
+	}
+	if cfg.ProofCacheSize < 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrNegativeCacheSize)
+	}
+	if cfg.RetrievalBudget < 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrNegativeBudget)
+	}
+	if cfg.ServeRequestsPerMinute < 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrNegativeQuota)
+	}
+	if (cfg.ServeRequestsPerMinute > 0 || cfg.ServeBytesPerHour > 0) && cfg.ServeBanDuration <= 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrInvalidBanDuration)
+	}
+	if cfg.Remote.Endpoint != "" && cfg.Remote.Bucket == "" {
+		return fmt.Errorf("nodebuilder/share: %s", ErrNoRemoteBucket)
+	}
+	if cfg.OffloadAfter < 0 {
+		return fmt.Errorf("nodebuilder/share: %s", ErrNegativeOffload)
+	}
 	return nil
 }
@@ -2,17 +2,26 @@ package share
 
 import (
 	"context"
+	"path/filepath"
 
 	"github.com/ipfs/go-blockservice"
 	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/routing"
 	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"go.uber.org/fx"
 
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/cache"
 	disc "github.com/celestiaorg/celestia-node/share/availability/discovery"
+	"github.com/celestiaorg/celestia-node/share/availability/full"
+	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/getters"
 	"github.com/celestiaorg/celestia-node/share/service"
 )
 
@@ -31,6 +40,107 @@ func discovery(cfg Config) func(routing.ContentRouting, host.Host) *disc.Discove
 	}
 }
 
+// edsStorePrefix namespaces the keys of the eds.Store in the node's datastore.
+var edsStorePrefix = datastore.NewKey("eds")
+
+// edsStore constructs the eds.Store keeping the squares of the node under the 'storePath', if the
+// Remote is configured to offload the old squares to. Otherwise, the squares are only kept in the
+// blockstore, so that they are not stored twice on local disk.
+func edsStore(
+	cfg Config,
+	storePath string,
+) func(fx.Lifecycle, datastore.Batching, blockstore.Blockstore) (*eds.Store, error) {
+	return func(lc fx.Lifecycle, ds datastore.Batching, bs blockstore.Blockstore) (*eds.Store, error) {
+		if storePath == "" || cfg.Remote.Endpoint == "" {
+			return nil, nil
+		}
+
+		remote, err := eds.NewS3Remote(cfg.Remote)
+		if err != nil {
+			return nil, err
+		}
+		opts := []eds.Option{
+			eds.WithRemote(remote),
+			eds.WithOffloadAfter(cfg.OffloadAfter),
+			// the offloaded squares leave the blockstore as well
+			eds.WithBlockstore(bs),
+		}
+
+		store, err := eds.NewStore(filepath.Join(storePath, "eds"), namespace.Wrap(ds, edsStorePrefix), opts...)
+		if err != nil {
+			return nil, err
+		}
+		lc.Append(fx.Hook{
+			OnStart: store.Start,
+			OnStop: func(context.Context) error {
+				return store.Stop()
+			},
+		})
+		return store, nil
+	}
+}
+
+// lightAvailability constructs light availability sampling as configured.
+func lightAvailability(
+	cfg Config,
+	bServ blockservice.BlockService,
+	disc *disc.Discovery,
+) *light.ShareAvailability {
+	return light.NewShareAvailability(
+		bServ,
+		disc,
+		light.WithSampleAmount(cfg.SampleAmount),
+		light.WithTargetConfidence(cfg.TargetConfidence),
+	)
+}
+
+// fullAvailability constructs full availability sampling for the given node type. Full nodes
+// pause storing the reconstructed squares in the protective mode, while bridge nodes keep storing
+// the squares they are the source of.
+func fullAvailability(tp node.Type) func(
+	*eds.Store,
+	blockservice.BlockService,
+	*disc.Discovery,
+	node.DiskSpace,
+) *full.ShareAvailability {
+	return func(
+		store *eds.Store,
+		bServ blockservice.BlockService,
+		disc *disc.Discovery,
+		disk node.DiskSpace,
+	) *full.ShareAvailability {
+		var opts []full.Option
+		if tp == node.Full {
+			opts = append(opts, full.WithStorePaused(disk.Protective))
+		}
+		return full.NewShareAvailability(store, bServ, disc, opts...)
+	}
+}
+
+// lightGetter constructs the cascade of share.Getters retrieving the shares for light nodes, which
+// keep no squares, so they are only retrieved over IPLD.
+// TODO: Put the tier of direct peer protocols before IPLD, once those are available to the node.
+func lightGetter(cfg Config, bServ blockservice.BlockService) *getters.CascadeGetter {
+	return getters.NewCascadeGetter(cfg.RetrievalBudget, ipldTier(bServ))
+}
+
+// fullGetter constructs the cascade of share.Getters retrieving the shares for full and bridge
+// nodes, which read the squares from their eds.Store, unless nil, before retrieving them over IPLD.
+// TODO: Put the tier of direct peer protocols before IPLD, once those are available to the node.
+func fullGetter(cfg Config, store *eds.Store, bServ blockservice.BlockService) *getters.CascadeGetter {
+	var tiers []getters.Tier
+	if store != nil {
+		tiers = append(tiers, getters.Tier{Name: "store", Getter: getters.NewStoreGetter(store)})
+	}
+	tiers = append(tiers, ipldTier(bServ))
+	return getters.NewCascadeGetter(cfg.RetrievalBudget, tiers...)
+}
+
+func ipldTier(bServ blockservice.BlockService) getters.Tier {
+	// Bitswap sessions get stuck on unresponsive peers at times, so they are restarted once
+	return getters.Tier{Name: "ipld", Getter: getters.NewIPLDGetter(bServ), Attempts: 2}
+}
+
 // cacheAvailability wraps either Full or Light availability with a cache for result sampling.
 func cacheAvailability[A share.Availability](lc fx.Lifecycle, ds datastore.Batching, avail A) share.Availability {
 	ca := cache.NewShareAvailability(avail, ds)
@@ -40,8 +150,17 @@ func cacheAvailability[A share.Availability](lc fx.Lifecycle, ds datastore.Batch
 	return ca
 }
 
-func newModule(lc fx.Lifecycle, bServ blockservice.BlockService, avail share.Availability) Module {
-	serv := service.NewShareService(bServ, avail)
+func newModule(
+	lc fx.Lifecycle,
+	tp node.Type,
+	cfg Config,
+	bServ blockservice.BlockService,
+	avail share.Availability,
+	getter share.Getter,
+	store header.Store,
+	poisoned *share.PoisonList,
+) Module {
+	serv := service.NewShareService(bServ, avail, service.WithProofCacheSize(cfg.ProofCacheSize))
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			return serv.Start(ctx)
@@ -50,5 +169,11 @@ func newModule(lc fx.Lifecycle, bServ blockservice.BlockService, avail share.Ava
 			return serv.Stop(ctx)
 		},
 	})
-	return serv
+	return &Service{
+		ShareService: serv,
+		shares:       getter,
+		getter:       store,
+		poisoned:     poisoned,
+		light:        tp == node.Light,
+	}
 }
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	da "github.com/celestiaorg/celestia-app/pkg/da"
+	share "github.com/celestiaorg/celestia-node/share"
 	namespace "github.com/celestiaorg/nmt/namespace"
 )
 
@@ -37,6 +38,36 @@ func (m *MockModule) EXPECT() *MockModuleMockRecorder {
 	return m.recorder
 }
 
+// GetEDS mocks base method.
+func (m *MockModule) GetEDS(arg0 context.Context, arg1 uint64) ([][][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEDS", arg0, arg1)
+	ret0, _ := ret[0].([][][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEDS indicates an expected call of GetEDS.
+func (mr *MockModuleMockRecorder) GetEDS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEDS", reflect.TypeOf((*MockModule)(nil).GetEDS), arg0, arg1)
+}
+
+// GetRow mocks base method.
+func (m *MockModule) GetRow(arg0 context.Context, arg1 uint64, arg2 int) (*share.Row, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRow", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*share.Row)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRow indicates an expected call of GetRow.
+func (mr *MockModuleMockRecorder) GetRow(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRow", reflect.TypeOf((*MockModule)(nil).GetRow), arg0, arg1, arg2)
+}
+
 // GetShare mocks base method.
 func (m *MockModule) GetShare(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2, arg3 int) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -82,18 +113,47 @@ func (mr *MockModuleMockRecorder) GetSharesByNamespace(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespace", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespace), arg0, arg1, arg2)
 }
 
+// GetSharesByNamespaceWithProof mocks base method.
+func (m *MockModule) GetSharesByNamespaceWithProof(arg0 context.Context, arg1 *da.DataAvailabilityHeader, arg2 namespace.ID) ([]*share.NamespacedRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharesByNamespaceWithProof", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*share.NamespacedRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharesByNamespaceWithProof indicates an expected call of GetSharesByNamespaceWithProof.
+func (mr *MockModuleMockRecorder) GetSharesByNamespaceWithProof(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharesByNamespaceWithProof", reflect.TypeOf((*MockModule)(nil).GetSharesByNamespaceWithProof), arg0, arg1, arg2)
+}
+
+// PoisonedHeights mocks base method.
+func (m *MockModule) PoisonedHeights(arg0 context.Context) []uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoisonedHeights", arg0)
+	ret0, _ := ret[0].([]uint64)
+	return ret0
+}
+
+// PoisonedHeights indicates an expected call of PoisonedHeights.
+func (mr *MockModuleMockRecorder) PoisonedHeights(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoisonedHeights", reflect.TypeOf((*MockModule)(nil).PoisonedHeights), arg0)
+}
+
 // ProbabilityOfAvailability mocks base method.
-func (m *MockModule) ProbabilityOfAvailability() float64 {
+func (m *MockModule) ProbabilityOfAvailability(arg0 context.Context) float64 {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProbabilityOfAvailability")
+	ret := m.ctrl.Call(m, "ProbabilityOfAvailability", arg0)
 	ret0, _ := ret[0].(float64)
 	return ret0
 }
 
 // ProbabilityOfAvailability indicates an expected call of ProbabilityOfAvailability.
-func (mr *MockModuleMockRecorder) ProbabilityOfAvailability() *gomock.Call {
+func (mr *MockModuleMockRecorder) ProbabilityOfAvailability(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbabilityOfAvailability", reflect.TypeOf((*MockModule)(nil).ProbabilityOfAvailability))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbabilityOfAvailability", reflect.TypeOf((*MockModule)(nil).ProbabilityOfAvailability), arg0)
 }
 
 // SharesAvailable mocks base method.
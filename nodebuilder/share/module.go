@@ -6,13 +6,17 @@ import (
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/full"
 	"github.com/celestiaorg/celestia-node/share/availability/light"
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
 
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 )
 
-func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option {
+// ConstructModule collects all the components and services related to the shares. Full and bridge
+// nodes keep the squares under the 'storePath', unless empty.
+func ConstructModule(tp node.Type, cfg *Config, storePath string, options ...fx.Option) fx.Option {
 	// sanitize config values before constructing module
 	cfgErr := cfg.Validate()
 
@@ -22,6 +26,17 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		fx.Options(options...),
 		fx.Invoke(share.EnsureEmptySquareExists),
 		fx.Provide(discovery(*cfg)),
+		fx.Provide(poisonList),
+		fx.Provide(func(cfg Config) *ipld.ServeTracer {
+			return ipld.NewServeTracer(cfg.AccessLog)
+		}),
+		fx.Provide(func(cfg Config) *ipld.ServeQuota {
+			return ipld.NewServeQuota(cfg.ServeRequestsPerMinute, cfg.ServeBytesPerHour, cfg.ServeBanDuration)
+		}),
+		fx.Invoke(registerReloadable),
+		fx.Provide(func(cg *getters.CascadeGetter, pl *share.PoisonList) share.Getter {
+			return getters.NewPoisonGetter(cg, pl)
+		}),
 		fx.Provide(newModule),
 	)
 
@@ -30,8 +45,9 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		return fx.Module(
 			"share",
 			baseComponents,
+			fx.Provide(lightGetter),
 			fx.Provide(fx.Annotate(
-				light.NewShareAvailability,
+				lightAvailability,
 				fx.OnStart(func(ctx context.Context, avail *light.ShareAvailability) error {
 					return avail.Start(ctx)
 				}),
@@ -47,8 +63,10 @@ func ConstructModule(tp node.Type, cfg *Config, options ...fx.Option) fx.Option
 		return fx.Module(
 			"share",
 			baseComponents,
+			fx.Provide(edsStore(*cfg, storePath)),
+			fx.Provide(fullGetter),
 			fx.Provide(fx.Annotate(
-				full.NewShareAvailability,
+				fullAvailability(tp),
 				fx.OnStart(func(ctx context.Context, avail *full.ShareAvailability) error {
 					return avail.Start(ctx)
 				}),
@@ -0,0 +1,18 @@
+package share
+
+import (
+	"github.com/celestiaorg/celestia-node/share/getters"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+// WithMetrics is a utility function that is expected to be
+// "invoked" by the fx lifecycle.
+func WithMetrics(cg *getters.CascadeGetter, st *ipld.ServeTracer, q *ipld.ServeQuota) error {
+	if err := cg.InitMetrics(); err != nil {
+		return err
+	}
+	if err := st.InitMetrics(); err != nil {
+		return err
+	}
+	return q.InitMetrics()
+}
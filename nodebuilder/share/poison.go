@@ -0,0 +1,92 @@
+package share
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/fraud"
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+var log = logging.Logger("module/share")
+
+// poisonList constructs the share.PoisonList of the blocks proven to be incorrectly encoded by the
+// stored Bad Encoding Fraud Proofs, and keeps it updated with the ones arriving later on.
+// The locally stored IPLD blocks of the poisoned squares are poisoned as well, so that they are
+// not served over Bitswap.
+func poisonList(
+	lc fx.Lifecycle,
+	fservice fraud.Service,
+	getter header.Store,
+	bs blockstore.Blockstore,
+) *share.PoisonList {
+	pl := share.NewPoisonList()
+	stored := blockservice.New(bs, offline.Exchange(bs))
+	poison := func(ctx context.Context, proof fraud.Proof) {
+		h, err := getter.GetByHeight(ctx, proof.Height())
+		if err != nil {
+			log.Errorw("poisoning height", "height", proof.Height(), "err", err)
+			return
+		}
+		pl.Poison(proof.Height(), h.DAH)
+		for _, root := range append(h.DAH.RowsRoots, h.DAH.ColumnRoots...) {
+			cids, err := ipld.GetStoredCIDs(ctx, stored, ipld.MustCidFromNamespacedSha256(root))
+			if err != nil {
+				log.Errorw("poisoning blocks", "height", proof.Height(), "err", err)
+				continue
+			}
+			pl.PoisonBlocks(cids...)
+		}
+		log.Warnw("height proven fraudulent, its data won't be sampled nor served",
+			"height", proof.Height(), "data root", h.DAH.Hash())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(startCtx context.Context) error {
+			// subscribe before checking the stored proofs, so that no proof arriving in between is missed
+			sub, err := fservice.Subscribe(fraud.BadEncoding)
+			if err != nil {
+				return err
+			}
+			proofs, err := fservice.Get(startCtx, fraud.BadEncoding)
+			if err != nil && !errors.Is(err, datastore.ErrNotFound) {
+				sub.Cancel()
+				return err
+			}
+			for _, proof := range proofs {
+				poison(startCtx, proof)
+			}
+
+			go func() {
+				defer sub.Cancel()
+				for {
+					// at this point we receive already verified fraud proofs
+					proof, err := sub.Proof(ctx)
+					if err != nil {
+						if !errors.Is(err, context.Canceled) {
+							log.Errorw("reading next proof failed", "err", err)
+						}
+						return
+					}
+					poison(ctx, proof)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+	return pl
+}
@@ -0,0 +1,35 @@
+package share
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/celestia-node/libs/reload"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+// registerReloadable declares the serve quotas, which can be changed without restarting the node.
+func registerReloadable(r *reload.Registry, q *ipld.ServeQuota) error {
+	return multierr.Combine(
+		r.Register("Share.ServeRequestsPerMinute", func(_ context.Context, _, new interface{}) error {
+			if new.(int) < 0 {
+				return ErrNegativeQuota
+			}
+			q.SetRequestsPerMinute(new.(int))
+			return nil
+		}),
+		r.Register("Share.ServeBytesPerHour", func(_ context.Context, _, new interface{}) error {
+			q.SetBytesPerHour(new.(uint64))
+			return nil
+		}),
+		r.Register("Share.ServeBanDuration", func(_ context.Context, _, new interface{}) error {
+			if new.(time.Duration) <= 0 {
+				return ErrInvalidBanDuration
+			}
+			q.SetBanDuration(new.(time.Duration))
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,116 @@
+package share
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/nmt/namespace"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/service"
+)
+
+var _ Module = (*Service)(nil)
+
+// ErrLightNode is returned on attempt to get whole squares or rows of them from a light node, as
+// light nodes only sample the squares.
+var ErrLightNode = errors.New("share: light nodes don't serve whole squares and rows")
+
+// Service is an implementation of Module that uses service.ShareService as a backend. It
+// additionally resolves the heights of the requested blocks to their Roots with the header.Getter,
+// and retrieves whole squares and the shares of namespaces with the share.Getter. The requests for
+// the blocks kept in the share.PoisonList are refused with share.ErrPoisoned.
+type Service struct {
+	*service.ShareService
+	shares   share.Getter
+	getter   header.Getter
+	poisoned *share.PoisonList
+	// light reports whether the Service runs on a light node, which refuses the requests for whole
+	// squares and rows with ErrLightNode
+	light bool
+}
+
+func (s *Service) SharesAvailable(ctx context.Context, root *share.Root) error {
+	if s.poisoned.IsRootPoisoned(root) {
+		return share.ErrPoisoned
+	}
+	return s.ShareService.SharesAvailable(ctx, root)
+}
+
+func (s *Service) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	if s.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return s.ShareService.GetShare(ctx, root, row, col)
+}
+
+func (s *Service) GetShares(ctx context.Context, root *share.Root) ([][]share.Share, error) {
+	if s.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return s.ShareService.GetShares(ctx, root)
+}
+
+func (s *Service) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]share.Share, error) {
+	if s.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return s.shares.GetSharesByNamespace(ctx, root, nID)
+}
+
+func (s *Service) GetSharesByNamespaceWithProof(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]*share.NamespacedRow, error) {
+	if s.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return s.ShareService.GetSharesByNamespaceWithProof(ctx, root, nID)
+}
+
+func (s *Service) GetEDS(ctx context.Context, height uint64) ([][]share.Share, error) {
+	if s.light {
+		return nil, ErrLightNode
+	}
+	if s.poisoned.IsHeightPoisoned(height) {
+		return nil, share.ErrPoisoned
+	}
+	h, err := s.getter.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	eds, err := s.shares.GetEDS(ctx, h.DAH)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]share.Share, eds.Width())
+	for i := range rows {
+		rows[i] = eds.Row(uint(i))
+	}
+	return rows, nil
+}
+
+func (s *Service) GetRow(ctx context.Context, height uint64, row int) (*share.Row, error) {
+	if s.light {
+		return nil, ErrLightNode
+	}
+	if s.poisoned.IsHeightPoisoned(height) {
+		return nil, share.ErrPoisoned
+	}
+	h, err := s.getter.GetByHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetRowWithProof(ctx, h.DAH, row)
+}
+
+func (s *Service) PoisonedHeights(context.Context) []uint64 {
+	return s.poisoned.Heights()
+}
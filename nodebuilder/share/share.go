@@ -28,19 +28,95 @@ type Module interface {
 	SharesAvailable(context.Context, *share.Root) error
 	// ProbabilityOfAvailability calculates the probability of the data square
 	// being available based on the number of samples collected.
-	ProbabilityOfAvailability() float64
+	ProbabilityOfAvailability(context.Context) float64
 	GetShare(ctx context.Context, dah *share.Root, row, col int) (share.Share, error)
 	GetShares(ctx context.Context, root *share.Root) ([][]share.Share, error)
 	// GetSharesByNamespace iterates over a square's row roots and accumulates the found shares in the given namespace.ID.
 	GetSharesByNamespace(ctx context.Context, root *share.Root, namespace namespace.ID) ([]share.Share, error)
+	// GetSharesByNamespaceWithProof returns the shares in the given namespace.ID from every row which
+	// may contain them, along with the proofs of the namespace being complete in the row.
+	GetSharesByNamespaceWithProof(
+		ctx context.Context,
+		root *share.Root,
+		namespace namespace.ID,
+	) ([]*share.NamespacedRow, error)
+	// GetEDS returns all the shares of the extended data square of the block at the given height,
+	// by rows. Light nodes refuse it with ErrLightNode.
+	GetEDS(ctx context.Context, height uint64) ([][]share.Share, error)
+	// GetRow returns the row with the given index of the extended data square of the block at the
+	// given height, along with the proof of its inclusion into the data root of the block.
+	// Light nodes refuse it with ErrLightNode.
+	GetRow(ctx context.Context, height uint64, row int) (*share.Row, error)
+	// PoisonedHeights returns the heights of the blocks proven to be incorrectly encoded by the Bad
+	// Encoding Fraud Proofs. The data of such blocks is neither sampled nor served, and the requests
+	// for it fail with share.ErrPoisoned.
+	PoisonedHeights(context.Context) []uint64
 }
 
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
-	SharesAvailable           func(context.Context, *share.Root) error
-	ProbabilityOfAvailability func() float64
-	GetShare                  func(ctx context.Context, dah *share.Root, row, col int) (share.Share, error)
-	GetShares                 func(ctx context.Context, root *share.Root) ([][]share.Share, error)
-	GetSharesByNamespace      func(ctx context.Context, root *share.Root, namespace namespace.ID) ([]share.Share, error)
+	Internal struct {
+		SharesAvailable           func(context.Context, *share.Root) error                                      `perm:"read"`
+		ProbabilityOfAvailability func(context.Context) float64                                                 `perm:"read"`
+		GetShare                  func(ctx context.Context, dah *share.Root, row, col int) (share.Share, error) `perm:"read"`
+		GetShares                 func(ctx context.Context, root *share.Root) ([][]share.Share, error)          `perm:"read"`
+		GetSharesByNamespace      func(
+			ctx context.Context,
+			root *share.Root,
+			namespace namespace.ID,
+		) ([]share.Share, error) `perm:"read"`
+		GetSharesByNamespaceWithProof func(
+			ctx context.Context,
+			root *share.Root,
+			namespace namespace.ID,
+		) ([]*share.NamespacedRow, error) `perm:"read"`
+		GetEDS          func(ctx context.Context, height uint64) ([][]share.Share, error)     `perm:"read"`
+		GetRow          func(ctx context.Context, height uint64, row int) (*share.Row, error) `perm:"read"`
+		PoisonedHeights func(context.Context) []uint64                                        `perm:"read"`
+	}
+}
+
+func (api *API) SharesAvailable(ctx context.Context, root *share.Root) error {
+	return api.Internal.SharesAvailable(ctx, root)
+}
+
+func (api *API) ProbabilityOfAvailability(ctx context.Context) float64 {
+	return api.Internal.ProbabilityOfAvailability(ctx)
+}
+
+func (api *API) GetShare(ctx context.Context, dah *share.Root, row, col int) (share.Share, error) {
+	return api.Internal.GetShare(ctx, dah, row, col)
+}
+
+func (api *API) GetShares(ctx context.Context, root *share.Root) ([][]share.Share, error) {
+	return api.Internal.GetShares(ctx, root)
+}
+
+func (api *API) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	namespace namespace.ID,
+) ([]share.Share, error) {
+	return api.Internal.GetSharesByNamespace(ctx, root, namespace)
+}
+
+func (api *API) GetSharesByNamespaceWithProof(
+	ctx context.Context,
+	root *share.Root,
+	namespace namespace.ID,
+) ([]*share.NamespacedRow, error) {
+	return api.Internal.GetSharesByNamespaceWithProof(ctx, root, namespace)
+}
+
+func (api *API) GetEDS(ctx context.Context, height uint64) ([][]share.Share, error) {
+	return api.Internal.GetEDS(ctx, height)
+}
+
+func (api *API) GetRow(ctx context.Context, height uint64, row int) (*share.Row, error) {
+	return api.Internal.GetRow(ctx, height, row)
+}
+
+func (api *API) PoisonedHeights(ctx context.Context) []uint64 {
+	return api.Internal.PoisonedHeights(ctx)
 }
@@ -1,18 +1,35 @@
 package state
 
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+)
+
 // Config contains configuration parameters for constructing
 // the node's keyring signer.
 type Config struct {
 	KeyringAccName string
+	// KeyringBackend is the backend of the keyring holding the node's keys.
+	// Supported backends: test, file, os.
+	KeyringBackend string
 }
 
 func DefaultConfig() Config {
 	return Config{
 		KeyringAccName: "",
+		KeyringBackend: keyring.BackendTest,
 	}
 }
 
 // Validate performs basic validation of the config.
 func (cfg *Config) Validate() error {
+	switch cfg.KeyringBackend {
+	case "":
+		cfg.KeyringBackend = keyring.BackendTest
+	case keyring.BackendTest, keyring.BackendFile, keyring.BackendOS:
+	default:
+		return fmt.Errorf("nodebuilder/state: unsupported keyring backend: %s", cfg.KeyringBackend)
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package state
 
 import (
 	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/celestia-node/header"
 	"github.com/celestiaorg/celestia-node/header/sync"
 	"github.com/celestiaorg/celestia-node/nodebuilder/core"
 	"github.com/celestiaorg/celestia-node/state"
@@ -13,6 +14,15 @@ func CoreAccessor(
 	corecfg core.Config,
 	signer *apptypes.KeyringSigner,
 	sync *sync.Syncer,
-) *state.CoreAccessor {
-	return state.NewCoreAccessor(signer, sync, corecfg.IP, corecfg.RPCPort, corecfg.GRPCPort)
+	sub header.Subscriber,
+	store header.Store,
+) (*state.CoreAccessor, error) {
+	opts, err := corecfg.GRPCDialOptions()
+	if err != nil {
+		return nil, err
+	}
+	return state.NewCoreAccessor(signer, sync, sub, corecfg.Endpoints(),
+		state.WithGRPCOptions(opts...),
+		state.WithHeaderGetter(store),
+	), nil
 }
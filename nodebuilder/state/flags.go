@@ -5,7 +5,10 @@ import (
 	flag "github.com/spf13/pflag"
 )
 
-var keyringAccNameFlag = "keyring.accname"
+var (
+	keyringAccNameFlag = "keyring.accname"
+	keyringBackendFlag = "keyring.backend"
+)
 
 // Flags gives a set of hardcoded State flags.
 func Flags() *flag.FlagSet {
@@ -13,6 +16,8 @@ func Flags() *flag.FlagSet {
 
 	flags.String(keyringAccNameFlag, "", "Directs node's keyring signer to use the key prefixed with the "+
 		"given string.")
+	flags.String(keyringBackendFlag, "", "Directs node's keyring signer to use the given keyring backend. "+
+		"Supported backends: test, file, os (default: test)")
 	return flags
 }
 
@@ -22,4 +27,8 @@ func ParseFlags(cmd *cobra.Command, cfg *Config) {
 	if keyringAccName != "" {
 		cfg.KeyringAccName = keyringAccName
 	}
+	keyringBackend := cmd.Flag(keyringBackendFlag).Value.String()
+	if keyringBackend != "" {
+		cfg.KeyringBackend = keyringBackend
+	}
 }
@@ -1,8 +1,12 @@
 package state
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
@@ -18,14 +22,31 @@ import (
 func Keyring(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes.KeyringSigner, error) {
 	// TODO @renaynay: Include option for setting custom `userInput` parameter with
 	//  implementation of https://github.com/celestiaorg/celestia-node/issues/415.
-	// TODO @renaynay @Wondertan: ensure that keyring backend from config is passed
-	//  here instead of hardcoded `BackendTest`:
-	// https://github.com/celestiaorg/celestia-node/issues/603.
 	encConf := encoding.MakeConfig(app.ModuleEncodingRegisters...)
-	ring, err := keyring.New(app.Name, keyring.BackendTest, ks.Path(), os.Stdin, encConf.Codec)
+	backend, input := cfg.KeyringBackend, io.Reader(os.Stdin)
+	passphrase, encrypted := keystore.Passphrase(ks)
+	if encrypted && backend != keyring.BackendOS {
+		// the keys of the encrypted keystore are kept in the file backend under a password derived
+		// from the same passphrase, as the backend requires long passwords, which it prompts for
+		// twice when the keyring is created
+		backend = keyring.BackendFile
+		digest := sha256.Sum256(passphrase)
+		input = strings.NewReader(strings.Repeat(hex.EncodeToString(digest[:])+"\n", 2))
+	}
+	ring, err := keyring.New(app.Name, backend, ks.Path(), input, encConf.Codec)
 	if err != nil {
 		return nil, err
 	}
+	if encrypted && cfg.KeyringBackend == keyring.BackendTest {
+		plain, err := keyring.New(app.Name, keyring.BackendTest, ks.Path(), nil, encConf.Codec)
+		if err != nil {
+			return nil, err
+		}
+		err = migrateKeyring(plain, ring, string(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("encrypting keyring: %w", err)
+		}
+	}
 
 	var info *keyring.Record
 	// if custom keyringAccName provided, find key for that name
@@ -64,10 +85,56 @@ func Keyring(cfg Config, ks keystore.Keystore, net p2p.Network) (*apptypes.Keyri
 		}
 	}
 	// construct signer using the default key found / generated above
-	signer := apptypes.NewKeyringSigner(ring, info.Name, string(net))
+	signer := apptypes.NewKeyringSigner(ring, info.Name, net.ChainID())
 	signerInfo := signer.GetSignerInfo()
-	log.Infow("constructed keyring signer", "backend", keyring.BackendTest, "path", ks.Path(),
-		"key name", signerInfo.Name, "chain-id", string(net))
+	log.Infow("constructed keyring signer", "backend", ring.Backend(), "path", ks.Path(),
+		"key name", signerInfo.Name, "chain-id", net.ChainID())
 
 	return signer, nil
 }
+
+// migrateKeyring moves the keys of the plaintext keyring to the encrypted one. Every key is
+// imported and checked before it is deleted from the plaintext keyring, so that no key is lost if
+// the migration is interrupted.
+func migrateKeyring(from, to keyring.Keyring, passphrase string) error {
+	records, err := from.List()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if _, err = to.Key(record.Name); err != nil {
+			armor, err := from.ExportPrivKeyArmor(record.Name, passphrase)
+			if err != nil {
+				return err
+			}
+			err = to.ImportPrivKey(record.Name, armor, passphrase)
+			if err != nil {
+				return err
+			}
+		}
+
+		imported, err := to.Key(record.Name)
+		if err != nil {
+			return err
+		}
+		addr, err := record.GetAddress()
+		if err != nil {
+			return err
+		}
+		importedAddr, err := imported.GetAddress()
+		if err != nil {
+			return err
+		}
+		if !addr.Equals(importedAddr) {
+			return fmt.Errorf("key %s does not match once imported", record.Name)
+		}
+
+		err = from.Delete(record.Name)
+		if err != nil {
+			return err
+		}
+		log.Infow("moved key to the encrypted keyring", "name", record.Name)
+	}
+	return nil
+}
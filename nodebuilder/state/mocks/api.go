@@ -14,6 +14,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	types1 "github.com/tendermint/tendermint/types"
 
+	state "github.com/celestiaorg/celestia-node/state"
 	namespace "github.com/celestiaorg/nmt/namespace"
 )
 
@@ -130,18 +131,33 @@ func (mr *MockModuleMockRecorder) Delegate(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delegate", reflect.TypeOf((*MockModule)(nil).Delegate), arg0, arg1, arg2, arg3)
 }
 
+// EstimateGas mocks base method.
+func (m *MockModule) EstimateGas(arg0 context.Context, arg1 namespace.ID, arg2 []byte) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateGas", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateGas indicates an expected call of EstimateGas.
+func (mr *MockModuleMockRecorder) EstimateGas(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGas", reflect.TypeOf((*MockModule)(nil).EstimateGas), arg0, arg1, arg2)
+}
+
 // IsStopped mocks base method.
-func (m *MockModule) IsStopped() bool {
+func (m *MockModule) IsStopped(arg0 context.Context) bool {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsStopped")
+	ret := m.ctrl.Call(m, "IsStopped", arg0)
 	ret0, _ := ret[0].(bool)
 	return ret0
 }
 
 // IsStopped indicates an expected call of IsStopped.
-func (mr *MockModuleMockRecorder) IsStopped() *gomock.Call {
+func (mr *MockModuleMockRecorder) IsStopped(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStopped", reflect.TypeOf((*MockModule)(nil).IsStopped))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStopped", reflect.TypeOf((*MockModule)(nil).IsStopped), arg0)
 }
 
 // QueryDelegation mocks base method.
@@ -189,6 +205,21 @@ func (mr *MockModuleMockRecorder) QueryUnbonding(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryUnbonding", reflect.TypeOf((*MockModule)(nil).QueryUnbonding), arg0, arg1)
 }
 
+// QueryWithProof mocks base method.
+func (m *MockModule) QueryWithProof(arg0 context.Context, arg1 string, arg2 []byte, arg3 uint64) (*state.QueryResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryWithProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*state.QueryResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryWithProof indicates an expected call of QueryWithProof.
+func (mr *MockModuleMockRecorder) QueryWithProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryWithProof", reflect.TypeOf((*MockModule)(nil).QueryWithProof), arg0, arg1, arg2, arg3)
+}
+
 // SubmitPayForData mocks base method.
 func (m *MockModule) SubmitPayForData(arg0 context.Context, arg1 namespace.ID, arg2 []byte, arg3 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -219,6 +250,21 @@ func (mr *MockModuleMockRecorder) SubmitTx(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTx", reflect.TypeOf((*MockModule)(nil).SubmitTx), arg0, arg1)
 }
 
+// SubscribeAccountEvents mocks base method.
+func (m *MockModule) SubscribeAccountEvents(arg0 context.Context) (<-chan *state.AccountEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeAccountEvents", arg0)
+	ret0, _ := ret[0].(<-chan *state.AccountEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeAccountEvents indicates an expected call of SubscribeAccountEvents.
+func (mr *MockModuleMockRecorder) SubscribeAccountEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAccountEvents", reflect.TypeOf((*MockModule)(nil).SubscribeAccountEvents), arg0)
+}
+
 // Transfer mocks base method.
 func (m *MockModule) Transfer(arg0 context.Context, arg1 types.AccAddress, arg2 math.Int, arg3 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +280,21 @@ func (mr *MockModuleMockRecorder) Transfer(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockModule)(nil).Transfer), arg0, arg1, arg2, arg3)
 }
 
+// TxStatus mocks base method.
+func (m *MockModule) TxStatus(arg0 context.Context, arg1 string) (*state.TxStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxStatus", arg0, arg1)
+	ret0, _ := ret[0].(*state.TxStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxStatus indicates an expected call of TxStatus.
+func (mr *MockModuleMockRecorder) TxStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxStatus", reflect.TypeOf((*MockModule)(nil).TxStatus), arg0, arg1)
+}
+
 // Undelegate mocks base method.
 func (m *MockModule) Undelegate(arg0 context.Context, arg1 types.ValAddress, arg2 math.Int, arg3 uint64) (*types.TxResponse, error) {
 	m.ctrl.T.Helper()
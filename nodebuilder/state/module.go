@@ -7,6 +7,7 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/celestiaorg/celestia-node/fraud"
+	"github.com/celestiaorg/celestia-node/libs/health"
 	fraudServ "github.com/celestiaorg/celestia-node/nodebuilder/fraud"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
 	"github.com/celestiaorg/celestia-node/state"
@@ -37,6 +38,9 @@ func ConstructModule(tp node.Type, cfg *Config) fx.Option {
 		fx.Provide(func(ca *state.CoreAccessor) Module {
 			return ca
 		}),
+		fx.Invoke(func(r *health.Registry, ca *state.CoreAccessor) error {
+			return r.Register("state", ca)
+		}),
 	)
 
 	switch tp {
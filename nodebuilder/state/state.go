@@ -18,7 +18,7 @@ import (
 //go:generate mockgen -destination=mocks/api.go -package=mocks . Module
 type Module interface {
 	// IsStopped checks if the Module's context has been stopped
-	IsStopped() bool
+	IsStopped(ctx context.Context) bool
 
 	// AccountAddress retrieves the address of the node's account/signer
 	AccountAddress(ctx context.Context) (state.Address, error)
@@ -32,6 +32,11 @@ type Module interface {
 	// the node's current head (head-1). This is due to the fact that for block N, the block's
 	// `AppHash` is the result of applying the previous block's transaction list.
 	BalanceForAddress(ctx context.Context, addr state.Address) (*state.Balance, error)
+	// QueryWithProof reads the value of the key from the store of the application state with the
+	// given key, e.g. "bank", as of the given height, or of the latest verifiable one (head-1) if
+	// zero. The value, or its absence, is verified against the `AppHash` of the locally verified
+	// header at the following height.
+	QueryWithProof(ctx context.Context, storeKey string, key []byte, height uint64) (*state.QueryResult, error)
 
 	// Transfer sends the given amount of coins from default wallet of the node to the given account
 	// address.
@@ -41,7 +46,18 @@ type Module interface {
 	// a block.
 	SubmitTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error)
 	// SubmitPayForData builds, signs and submits a PayForData transaction.
+	// If the gas limit is zero, it is estimated. The fee is set according to the gas price
+	// estimated over the latest blocks.
 	SubmitPayForData(ctx context.Context, nID namespace.ID, data []byte, gasLim uint64) (*state.TxResponse, error)
+	// EstimateGas estimates the gas a PayForData transaction carrying the given blob uses.
+	EstimateGas(ctx context.Context, nID namespace.ID, blob []byte) (uint64, error)
+	// TxStatus reports the latest known stage (broadcast, pending, committed or failed) of the
+	// transaction with the given hex-encoded hash. Only transactions submitted through the node
+	// are tracked.
+	TxStatus(ctx context.Context, hash string) (*state.TxStatus, error)
+	// SubscribeAccountEvents subscribes to the changes of the balance of the node's account made by
+	// the committed transactions, i.e. the received and sent funds and the withdrawn rewards.
+	SubscribeAccountEvents(ctx context.Context) (<-chan *state.AccountEvent, error)
 
 	// CancelUnbondingDelegation cancels a user's pending undelegation from a validator.
 	CancelUnbondingDelegation(
@@ -60,9 +76,9 @@ type Module interface {
 		gasLim uint64,
 	) (*state.TxResponse, error)
 	// Undelegate undelegates a user's delegated tokens, unbonding them from the current validator.
-	Undelegate(ctx context.Context, delAddr state.ValAddress, amount state.Int, gasLim uint64) (*state.TxResponse, error)
+	Undelegate(ctx context.Context, valAddr state.ValAddress, amount state.Int, gasLim uint64) (*state.TxResponse, error)
 	// Delegate sends a user's liquid tokens to a validator for delegation.
-	Delegate(ctx context.Context, delAddr state.ValAddress, amount state.Int, gasLim uint64) (*state.TxResponse, error)
+	Delegate(ctx context.Context, valAddr state.ValAddress, amount state.Int, gasLim uint64) (*state.TxResponse, error)
 
 	// QueryDelegation retrieves the delegation information between a delegator and a validator.
 	QueryDelegation(ctx context.Context, valAddr state.ValAddress) (*types.QueryDelegationResponse, error)
@@ -79,47 +95,187 @@ type Module interface {
 // API is a wrapper around Module for the RPC.
 // TODO(@distractedm1nd): These structs need to be autogenerated.
 type API struct {
-	IsStopped         func() bool
-	Balance           func(ctx context.Context) (*state.Balance, error)
-	BalanceForAddress func(ctx context.Context, addr state.Address) (*state.Balance, error)
-	Transfer          func(
-		ctx context.Context,
-		to state.AccAddress,
-		amount math.Int,
-		gasLimit uint64,
-	) (*state.TxResponse, error)
-	SubmitTx         func(ctx context.Context, tx state.Tx) (*state.TxResponse, error)
-	SubmitPayForData func(ctx context.Context, nID namespace.ID, data []byte, gasLim uint64) (
-		*state.TxResponse,
-		error,
-	)
-	CancelUnbondingDelegation func(
-		ctx context.Context,
-		valAddr state.ValAddress,
-		amount,
-		height state.Int,
-		gasLim uint64,
-	) (*state.TxResponse, error)
-	BeginRedelegate func(
-		ctx context.Context,
-		srcValAddr,
-		dstValAddr state.ValAddress,
-		amount state.Int,
-		gasLim uint64,
-	) (*state.TxResponse, error)
-	Undelegate func(ctx context.Context, delAddr state.ValAddress, amount state.Int, gasLim uint64) (
-		*state.TxResponse,
-		error,
-	)
-	Delegate func(ctx context.Context, delAddr state.ValAddress, amount state.Int, gasLim uint64) (
-		*state.TxResponse,
-		error,
-	)
-	QueryDelegation    func(ctx context.Context, valAddr state.ValAddress) (*types.QueryDelegationResponse, error)
-	QueryUnbonding     func(ctx context.Context, valAddr state.ValAddress) (*types.QueryUnbondingDelegationResponse, error)
-	QueryRedelegations func(
-		ctx context.Context,
-		srcValAddr,
-		dstValAddr state.ValAddress,
-	) (*types.QueryRedelegationsResponse, error)
+	Internal struct {
+		IsStopped         func(ctx context.Context) bool                                        `perm:"read"`
+		AccountAddress    func(ctx context.Context) (state.Address, error)                      `perm:"read"`
+		Balance           func(ctx context.Context) (*state.Balance, error)                     `perm:"read"`
+		BalanceForAddress func(ctx context.Context, addr state.Address) (*state.Balance, error) `perm:"read"`
+		QueryWithProof    func(
+			ctx context.Context,
+			storeKey string,
+			key []byte,
+			height uint64,
+		) (*state.QueryResult, error) `perm:"read"`
+		Transfer func(
+			ctx context.Context,
+			to state.AccAddress,
+			amount math.Int,
+			gasLimit uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		SubmitTx         func(ctx context.Context, tx state.Tx) (*state.TxResponse, error) `perm:"write"`
+		SubmitPayForData func(
+			ctx context.Context,
+			nID namespace.ID,
+			data []byte,
+			gasLim uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		EstimateGas               func(ctx context.Context, nID namespace.ID, blob []byte) (uint64, error) `perm:"read"`
+		TxStatus                  func(ctx context.Context, hash string) (*state.TxStatus, error)          `perm:"read"`
+		SubscribeAccountEvents    func(ctx context.Context) (<-chan *state.AccountEvent, error)            `perm:"read"`
+		CancelUnbondingDelegation func(
+			ctx context.Context,
+			valAddr state.ValAddress,
+			amount,
+			height state.Int,
+			gasLim uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		BeginRedelegate func(
+			ctx context.Context,
+			srcValAddr,
+			dstValAddr state.ValAddress,
+			amount state.Int,
+			gasLim uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		Undelegate func(
+			ctx context.Context,
+			valAddr state.ValAddress,
+			amount state.Int,
+			gasLim uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		Delegate func(
+			ctx context.Context,
+			valAddr state.ValAddress,
+			amount state.Int,
+			gasLim uint64,
+		) (*state.TxResponse, error) `perm:"write"`
+		QueryDelegation func(
+			ctx context.Context,
+			valAddr state.ValAddress,
+		) (*types.QueryDelegationResponse, error) `perm:"read"`
+		QueryUnbonding func(
+			ctx context.Context,
+			valAddr state.ValAddress,
+		) (*types.QueryUnbondingDelegationResponse, error) `perm:"read"`
+		QueryRedelegations func(
+			ctx context.Context,
+			srcValAddr,
+			dstValAddr state.ValAddress,
+		) (*types.QueryRedelegationsResponse, error) `perm:"read"`
+	}
+}
+
+func (api *API) IsStopped(ctx context.Context) bool {
+	return api.Internal.IsStopped(ctx)
+}
+
+func (api *API) AccountAddress(ctx context.Context) (state.Address, error) {
+	return api.Internal.AccountAddress(ctx)
+}
+
+func (api *API) Balance(ctx context.Context) (*state.Balance, error) {
+	return api.Internal.Balance(ctx)
+}
+
+func (api *API) BalanceForAddress(ctx context.Context, addr state.Address) (*state.Balance, error) {
+	return api.Internal.BalanceForAddress(ctx, addr)
+}
+
+func (api *API) QueryWithProof(
+	ctx context.Context,
+	storeKey string,
+	key []byte,
+	height uint64,
+) (*state.QueryResult, error) {
+	return api.Internal.QueryWithProof(ctx, storeKey, key, height)
+}
+
+func (api *API) Transfer(
+	ctx context.Context,
+	to state.AccAddress,
+	amount math.Int,
+	gasLimit uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.Transfer(ctx, to, amount, gasLimit)
+}
+
+func (api *API) SubmitTx(ctx context.Context, tx state.Tx) (*state.TxResponse, error) {
+	return api.Internal.SubmitTx(ctx, tx)
+}
+
+func (api *API) SubmitPayForData(
+	ctx context.Context,
+	nID namespace.ID,
+	data []byte,
+	gasLim uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.SubmitPayForData(ctx, nID, data, gasLim)
+}
+
+func (api *API) EstimateGas(ctx context.Context, nID namespace.ID, blob []byte) (uint64, error) {
+	return api.Internal.EstimateGas(ctx, nID, blob)
+}
+
+func (api *API) TxStatus(ctx context.Context, hash string) (*state.TxStatus, error) {
+	return api.Internal.TxStatus(ctx, hash)
+}
+
+func (api *API) SubscribeAccountEvents(ctx context.Context) (<-chan *state.AccountEvent, error) {
+	return api.Internal.SubscribeAccountEvents(ctx)
+}
+
+func (api *API) CancelUnbondingDelegation(
+	ctx context.Context,
+	valAddr state.ValAddress,
+	amount,
+	height state.Int,
+	gasLim uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.CancelUnbondingDelegation(ctx, valAddr, amount, height, gasLim)
+}
+
+func (api *API) BeginRedelegate(
+	ctx context.Context,
+	srcValAddr,
+	dstValAddr state.ValAddress,
+	amount state.Int,
+	gasLim uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.BeginRedelegate(ctx, srcValAddr, dstValAddr, amount, gasLim)
+}
+
+func (api *API) Undelegate(
+	ctx context.Context,
+	valAddr state.ValAddress,
+	amount state.Int,
+	gasLim uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.Undelegate(ctx, valAddr, amount, gasLim)
+}
+
+func (api *API) Delegate(
+	ctx context.Context,
+	valAddr state.ValAddress,
+	amount state.Int,
+	gasLim uint64,
+) (*state.TxResponse, error) {
+	return api.Internal.Delegate(ctx, valAddr, amount, gasLim)
+}
+
+func (api *API) QueryDelegation(ctx context.Context, valAddr state.ValAddress) (*types.QueryDelegationResponse, error) {
+	return api.Internal.QueryDelegation(ctx, valAddr)
+}
+
+func (api *API) QueryUnbonding(
+	ctx context.Context,
+	valAddr state.ValAddress,
+) (*types.QueryUnbondingDelegationResponse, error) {
+	return api.Internal.QueryUnbonding(ctx, valAddr)
+}
+
+func (api *API) QueryRedelegations(
+	ctx context.Context,
+	srcValAddr,
+	dstValAddr state.ValAddress,
+) (*types.QueryRedelegationsResponse, error) {
+	return api.Internal.QueryRedelegations(ctx, srcValAddr, dstValAddr)
 }
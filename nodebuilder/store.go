@@ -1,14 +1,13 @@
 package nodebuilder
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"sync"
 
-	"github.com/dgraph-io/badger/v2/options"
 	"github.com/ipfs/go-datastore"
-	dsbadger "github.com/ipfs/go-ds-badger2"
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/celestiaorg/celestia-node/libs/fslock"
@@ -104,17 +103,53 @@ func (f *fsStore) Keystore() (_ keystore.Keystore, err error) {
 	}
 	f.lock.RUnlock()
 
+	cfg, err := f.Config()
+	if err != nil {
+		return nil, err
+	}
+
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
-	f.keys, err = keystore.NewFSKeystore(keysPath(f.path))
+	keys, err := openKeystore(f.path, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("node: can't open Keystore: %w", err)
+		return nil, err
 	}
 
+	f.keys = keys
 	return f.keys, nil
 }
 
+// OpenKeystore opens the Keystore of the Store under the given 'path' without locking the Store,
+// e.g. to mint the RPC auth tokens while the node is running. The keys are decrypted the same way
+// the node does.
+func OpenKeystore(path string) (keystore.Keystore, error) {
+	path, err := storePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadConfig(configPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("node: can't load Config: %w", err)
+	}
+	return openKeystore(path, cfg)
+}
+
+func openKeystore(path string, cfg *Config) (keystore.Keystore, error) {
+	keys, err := keystore.NewFSKeystore(keysPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("node: can't open Keystore: %w", err)
+	}
+	if cfg.Node.EncryptStore {
+		passphrase, err := storePassphrase()
+		if err != nil {
+			return nil, err
+		}
+		keys = keystore.NewEncryptedKeystore(keys, passphrase)
+	}
+	return keys, nil
+}
+
 func (f *fsStore) Datastore() (_ datastore.Batching, err error) {
 	f.lock.RLock()
 	if f.data != nil {
@@ -123,44 +158,45 @@ func (f *fsStore) Datastore() (_ datastore.Batching, err error) {
 	}
 	f.lock.RUnlock()
 
+	cfg, err := f.Config()
+	if err != nil {
+		return nil, err
+	}
+	// the Datastore encryption key is kept in the Keystore
+	keys, err := f.Keystore()
+	if err != nil {
+		return nil, err
+	}
+
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
-	opts := dsbadger.DefaultOptions // this should be copied
-
-	// Badger sets ValueThreshold to 1K by default and this makes shares being stored in LSM tree
-	// instead of the value log, so we change the value to be lower than share size,
-	// so shares are store in value log. For value log and LSM definitions
-	opts.ValueThreshold = 128
-	// We always write unique values to Badger transaction so there is no need to detect conflicts.
-	opts.DetectConflicts = false
-	// Use MemoryMap for better performance
-	opts.ValueLogLoadingMode = options.MemoryMap
-	opts.TableLoadingMode = options.MemoryMap
-	// Truncate set to true will truncate corrupted data on start if there is any.
-	// If we don't truncate, the node will refuse to start and will beg for recovering, etc.
-	// If we truncate, the node will start with any uncorrupted data and reliably sync again what was
-	// corrupted in most cases.
-	opts.Truncate = true
-	// MaxTableSize defines in memory and on disk size of LSM tree
-	// Bigger values constantly takes more RAM
-	// TODO(@Wondertan): Make configurable with more conservative defaults for Light Node
-	opts.MaxTableSize = 64 << 20
-	// Remove GC as long as we don't have pruning of data to be GCed.
-	// Currently, we only append data on disk without removing.
-	// TODO(@Wondertan): Find good enough default, once pruning is shipped.
-	opts.GcInterval = 0
-
-	f.data, err = dsbadger.NewDatastore(dataPath(f.path), &opts)
+	data, err := openDatastore(dataPath(f.path), cfg.Node.DatastoreBackend)
 	if err != nil {
-		return nil, fmt.Errorf("node: can't open Badger Datastore: %w", err)
+		return nil, fmt.Errorf("node: can't open Datastore: %w", err)
+	}
+
+	if cfg.Node.EncryptStore {
+		encrypted, err := encryptDatastore(context.Background(), data, keys)
+		if err != nil {
+			data.Close() //nolint: errcheck
+			return nil, err
+		}
+		data = encrypted
+	} else if err = ensurePlaintext(keys); err != nil {
+		data.Close() //nolint: errcheck
+		return nil, err
 	}
 
+	f.data = data
 	return f.data, nil
 }
 
 func (f *fsStore) Close() error {
 	defer f.dirLock.Unlock() //nolint: errcheck
+	if f.data == nil {
+		return nil
+	}
 	return f.data.Close()
 }
 
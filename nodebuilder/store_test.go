@@ -1,13 +1,17 @@
 package nodebuilder
 
 import (
+	"context"
 	"strconv"
 	"testing"
 
+	"github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/libs/keystore"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/p2p"
 )
 
 func TestRepo(t *testing.T) {
@@ -50,3 +54,146 @@ func TestRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestRepo_DatastoreBackends(t *testing.T) {
+	ctx := context.Background()
+	key := datastore.NewKey("key")
+
+	for _, backend := range []node.DatastoreBackend{
+		node.BadgerBackend, node.LevelDBBackend, node.PebbleBackend, node.MemoryBackend,
+	} {
+		t.Run(string(backend), func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := DefaultConfig(node.Full)
+			cfg.Node.DatastoreBackend = backend
+			err := Init(*cfg, dir, node.Full)
+			require.NoError(t, err)
+
+			store, err := OpenStore(dir)
+			require.NoError(t, err)
+			data, err := store.Datastore()
+			require.NoError(t, err)
+			err = data.Put(ctx, key, []byte("value"))
+			require.NoError(t, err)
+			require.NoError(t, store.Close())
+
+			store, err = OpenStore(dir)
+			require.NoError(t, err)
+			data, err = store.Datastore()
+			require.NoError(t, err)
+			has, err := data.Has(ctx, key)
+			require.NoError(t, err)
+			// only the persistent backends keep the data over restarts
+			assert.Equal(t, backend != node.MemoryBackend, has)
+			require.NoError(t, store.Close())
+		})
+	}
+}
+
+func TestRepo_ChangedDatastoreBackend(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig(node.Full)
+	err := Init(*cfg, dir, node.Full)
+	require.NoError(t, err)
+
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	_, err = store.Datastore()
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	cfg.Node.DatastoreBackend = node.PebbleBackend
+	err = SaveConfig(configPath(dir), cfg)
+	require.NoError(t, err)
+
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	_, err = store.Datastore()
+	require.ErrorContains(t, err, "badger")
+}
+
+func TestRepo_EncryptStore(t *testing.T) {
+	ctx := context.Background()
+	key, value := datastore.NewKey("key"), []byte("value")
+	t.Setenv(EnvStorePassphrase, "passphrase")
+
+	dir := t.TempDir()
+	cfg := DefaultConfig(node.Full)
+	cfg.Node.EncryptStore = true
+	err := Init(*cfg, dir, node.Full)
+	require.NoError(t, err)
+
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	data, err := store.Datastore()
+	require.NoError(t, err)
+	err = data.Put(ctx, key, value)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// the data is readable with the same passphrase only
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	data, err = store.Datastore()
+	require.NoError(t, err)
+	got, err := data.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+	require.NoError(t, store.Close())
+
+	t.Setenv(EnvStorePassphrase, "wrong")
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	_, err = store.Datastore()
+	require.ErrorIs(t, err, keystore.ErrWrongPassphrase)
+	require.NoError(t, store.Close())
+
+	// the encryption can't be disabled once enabled
+	cfg.Node.EncryptStore = false
+	err = SaveConfig(configPath(dir), cfg)
+	require.NoError(t, err)
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	_, err = store.Datastore()
+	require.ErrorContains(t, err, "encrypted")
+	require.NoError(t, store.Close())
+}
+
+func TestRepo_EncryptPlaintextStore(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv(EnvStorePassphrase, "passphrase")
+
+	dir := t.TempDir()
+	cfg := DefaultConfig(node.Full)
+	err := Init(*cfg, dir, node.Full)
+	require.NoError(t, err)
+
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	data, err := store.Datastore()
+	require.NoError(t, err)
+	err = data.Put(ctx, datastore.NewKey("key"), []byte("value"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	cfg.Node.EncryptStore = true
+	err = SaveConfig(configPath(dir), cfg)
+	require.NoError(t, err)
+
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	_, err = store.Datastore()
+	require.ErrorContains(t, err, "plaintext")
+}
+
+func TestEnsureNetwork(t *testing.T) {
+	ctx := context.Background()
+	ds := datastore.NewMapDatastore()
+
+	err := ensureNetwork(ctx, ds, p2p.Private)
+	require.NoError(t, err)
+	err = ensureNetwork(ctx, ds, p2p.Private)
+	require.NoError(t, err)
+	err = ensureNetwork(ctx, ds, p2p.Arabica)
+	require.ErrorIs(t, err, ErrWrongNetwork)
+}
@@ -47,6 +47,8 @@ func TestNodeWithConfig(t *testing.T, tp node.Type, cfg *Config, opts ...fx.Opti
 	cfg.Core.IP = ip
 	cfg.Core.RPCPort = port
 	cfg.RPC.Port = "0"
+	// no need to query NTP servers for the tests
+	cfg.Header.ClockSyncInterval = 0
 
 	opts = append(opts,
 		state.WithKeyringSigner(TestKeyringSigner(t)),
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/nodebuilder"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/nodebuilder/tests/swamp"
+)
+
+/*
+Test-Case: Light Node keeps syncing over a slow link and recovers after dropping out of the network
+Steps:
+1. Create a Bridge Node(BN)
+2. Start a BN
+3. Create a Light Node(LN) with a trusted peer
+4. Start a LN with a defined connection to the BN
+5. Check LN is synced to height 10
+6. Delay the streams between LN and BN
+7. Check LN is synced to height 15 and DASed it
+8. Isolate LN
+9. Check LN is not synced to height 25, while the chain is
+10. Reconnect LN to BN
+11. Check LN is synced to height 30
+*/
+func TestSyncLightWithFaults(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), swamp.DefaultTestTimeout)
+	t.Cleanup(cancel)
+
+	sw := swamp.NewSwamp(t, swamp.WithBlockTime(btime))
+	fillDn := sw.FillBlocks(ctx, bsize, blocks)
+
+	bridge := sw.NewBridgeNode()
+	err := bridge.Start(ctx)
+	require.NoError(t, err)
+
+	addrs, err := peer.AddrInfoToP2pAddrs(host.InfoFromHost(bridge.Host))
+	require.NoError(t, err)
+
+	cfg := nodebuilder.DefaultConfig(node.Light)
+	cfg.Header.TrustedPeers = append(cfg.Header.TrustedPeers, addrs[0].String())
+	light := sw.NewNodeWithConfig(node.Light, cfg)
+	err = light.Start(ctx)
+	require.NoError(t, err)
+
+	_, err = light.HeaderServ.GetByHeight(ctx, 10)
+	require.NoError(t, err)
+
+	sw.Delay(t, light.Host.ID(), bridge.Host.ID(), time.Millisecond*50)
+	h, err := light.HeaderServ.GetByHeight(ctx, 15)
+	require.NoError(t, err)
+	err = light.ShareServ.SharesAvailable(ctx, h.DAH)
+	require.NoError(t, err)
+
+	sw.Isolate(t, light.Host.ID())
+	sw.WaitTillHeight(ctx, 25)
+	head, err := light.HeaderServ.Head(ctx)
+	require.NoError(t, err)
+	assert.Less(t, head.Height, int64(25))
+
+	sw.Connect(t, light.Host.ID(), bridge.Host.ID())
+	h, err = light.HeaderServ.GetByHeight(ctx, 30)
+	require.NoError(t, err)
+	assert.EqualValues(t, h.Commit.BlockID.Hash, sw.GetCoreBlockHashByHeight(ctx, 30))
+	require.NoError(t, <-fillDn)
+}
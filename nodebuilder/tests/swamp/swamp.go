@@ -175,7 +175,13 @@ func (s *Swamp) createPeer(ks keystore.Keystore) host.Host {
 	host, err := s.Network.AddPeer(key, a)
 	require.NoError(s.t, err)
 
-	require.NoError(s.t, s.Network.LinkAll())
+	// only the new peer is linked, as linking all the peers again would undo the injected faults
+	for _, p := range s.Network.Peers() {
+		if p != host.ID() {
+			_, err = s.Network.LinkPeers(host.ID(), p)
+			require.NoError(s.t, err)
+		}
+	}
 	return host
 }
 
@@ -258,6 +264,8 @@ func (s *Swamp) newNode(t node.Type, store nodebuilder.Store, options ...fx.Opti
 	// default that are set here
 	cfg, _ := store.Config()
 	cfg.Header.TrustedHash = s.trustedHash
+	// the clocks of the swamp are the same one
+	cfg.Header.ClockSyncInterval = 0
 	cfg.RPC.Port = "0"
 	options = append(options,
 		p2p.WithHost(s.createPeer(ks)),
@@ -324,3 +332,27 @@ func (s *Swamp) Disconnect(t *testing.T, peerA, peerB peer.ID) {
 	require.NoError(t, s.Network.UnlinkPeers(peerA, peerB))
 	require.NoError(t, s.Network.DisconnectPeers(peerA, peerB))
 }
+
+// Isolate breaks the connections of the peer with all the other peers, as if it dropped out of
+// the network. The peers created afterwards are still linked to it.
+// In order to reconnect the peer again, please use swamp.Connect
+func (s *Swamp) Isolate(t *testing.T, p peer.ID) {
+	for _, other := range s.Network.Peers() {
+		if other == p || len(s.Network.LinksBetweenPeers(p, other)) == 0 {
+			continue
+		}
+		s.Disconnect(t, p, other)
+	}
+}
+
+// Delay delays every write to the streams between two peers by the given latency, simulating
+// a slow network link. Zero latency removes the delay.
+func (s *Swamp) Delay(t *testing.T, peerA, peerB peer.ID, latency time.Duration) {
+	links := s.Network.LinksBetweenPeers(peerA, peerB)
+	require.NotEmpty(t, links, "peers are not linked")
+	for _, l := range links {
+		opts := l.Options()
+		opts.Latency = latency
+		l.SetOptions(opts)
+	}
+}
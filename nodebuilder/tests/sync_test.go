@@ -12,7 +12,9 @@ import (
 
 	"github.com/celestiaorg/celestia-node/nodebuilder"
 	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	shareServ "github.com/celestiaorg/celestia-node/nodebuilder/share"
 	"github.com/celestiaorg/celestia-node/nodebuilder/tests/swamp"
+	"github.com/celestiaorg/celestia-node/share"
 )
 
 // Common consts for tests producing filled blocks
@@ -61,12 +63,19 @@ func TestSyncLightWithBridge(t *testing.T) {
 	err = light.Start(ctx)
 	require.NoError(t, err)
 
+	err = light.HeaderServ.SyncWait(ctx)
+	require.NoError(t, err)
+
 	h, err = light.HeaderServ.GetByHeight(ctx, 30)
 	require.NoError(t, err)
 
 	err = light.ShareServ.SharesAvailable(ctx, h.DAH)
 	assert.NoError(t, err)
 
+	// light nodes don't serve whole squares
+	_, err = light.ShareServ.GetEDS(ctx, 30)
+	assert.ErrorIs(t, err, shareServ.ErrLightNode)
+
 	err = light.DASer.WaitCatchUp(ctx)
 	require.NoError(t, err)
 
@@ -183,6 +192,21 @@ func TestSyncFullWithBridge(t *testing.T) {
 	err = full.DASer.WaitCatchUp(ctx)
 	require.NoError(t, err)
 
+	eds, err := full.ShareServ.GetEDS(ctx, 30)
+	require.NoError(t, err)
+	assert.Len(t, eds, len(h.DAH.RowsRoots))
+	row, err := full.ShareServ.GetRow(ctx, 30, 0)
+	require.NoError(t, err)
+	assert.Equal(t, eds[0], row.Shares)
+	assert.NoError(t, row.Verify(h.DataHash))
+	nID := share.ID(eds[0][0])
+	nsRows, err := full.ShareServ.GetSharesByNamespaceWithProof(ctx, h.DAH, nID)
+	require.NoError(t, err)
+	require.NotEmpty(t, nsRows)
+	for _, nsRow := range nsRows {
+		assert.NoError(t, nsRow.Verify(h.DAH.RowsRoots[nsRow.Index], nID))
+	}
+
 	assert.EqualValues(t, h.Commit.BlockID.Hash, sw.GetCoreBlockHashByHeight(ctx, 30))
 	require.NoError(t, <-fillDn)
 }
@@ -0,0 +1,23 @@
+package watchdog
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/watchdog"
+)
+
+// Config contains configuration parameters for the Watchdog alerting on the stalled chain heads.
+type Config watchdog.Parameters
+
+func DefaultConfig() Config {
+	return Config(watchdog.DefaultParameters())
+}
+
+// Validate performs basic validation of the config.
+func (cfg *Config) Validate() error {
+	err := (*watchdog.Parameters)(cfg).Validate()
+	if err != nil {
+		return fmt.Errorf("modwatchdog misconfiguration: %w", err)
+	}
+	return nil
+}
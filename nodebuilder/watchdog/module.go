@@ -0,0 +1,58 @@
+package watchdog
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+	"github.com/celestiaorg/celestia-node/libs/health"
+	"github.com/celestiaorg/celestia-node/nodebuilder/das"
+	"github.com/celestiaorg/celestia-node/nodebuilder/header"
+	"github.com/celestiaorg/celestia-node/nodebuilder/node"
+	"github.com/celestiaorg/celestia-node/watchdog"
+)
+
+// ConstructModule collects the Watchdog alerting once the synced or, for the nodes sampling, the
+// sampled chain head stalls behind the network head. If 'readOnly', nothing is synced and thus
+// nothing is watched.
+func ConstructModule(tp node.Type, cfg *Config, readOnly bool) fx.Option {
+	// sanitize config values before constructing module
+	cfgErr := cfg.Validate()
+	if readOnly || !(*watchdog.Parameters)(cfg).Enabled() {
+		return fx.Options()
+	}
+
+	var newWatchdog interface{}
+	switch tp {
+	case node.Light, node.Full:
+		newWatchdog = func(cfg Config, hdr header.Module, daser das.Module, bus *events.Bus) *watchdog.Watchdog {
+			return watchdog.NewWatchdog(watchdog.Parameters(cfg), hdr, daser, bus)
+		}
+	case node.Bridge:
+		// bridge nodes don't sample
+		newWatchdog = func(cfg Config, hdr header.Module, bus *events.Bus) *watchdog.Watchdog {
+			return watchdog.NewWatchdog(watchdog.Parameters(cfg), hdr, nil, bus)
+		}
+	default:
+		panic("invalid node type")
+	}
+
+	return fx.Module(
+		"watchdog",
+		fx.Supply(*cfg),
+		fx.Error(cfgErr),
+		fx.Provide(fx.Annotate(
+			newWatchdog,
+			fx.OnStart(func(ctx context.Context, w *watchdog.Watchdog) error {
+				return w.Start(ctx)
+			}),
+			fx.OnStop(func(ctx context.Context, w *watchdog.Watchdog) error {
+				return w.Stop(ctx)
+			}),
+		)),
+		fx.Invoke(func(r *health.Registry, w *watchdog.Watchdog) error {
+			return r.Register("watchdog", w)
+		}),
+	)
+}
@@ -0,0 +1,23 @@
+package watchdog
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/celestiaorg/celestia-node/watchdog"
+)
+
+type metricsParams struct {
+	fx.In
+
+	// Watchdog is absent when disabled
+	Watchdog *watchdog.Watchdog `optional:"true"`
+}
+
+// WithMetrics is a utility function that is expected to be
+// "invoked" by the fx lifecycle.
+func WithMetrics(p metricsParams) error {
+	if p.Watchdog == nil {
+		return nil
+	}
+	return watchdog.WithMetrics(p.Watchdog)
+}
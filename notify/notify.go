@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+var log = logging.Logger("notify")
+
+// SignatureHeader is the header carrying the signature of the payload, if the Webhook has a Secret.
+const SignatureHeader = "X-Celestia-Signature"
+
+var (
+	// deliveryTimeout bounds a single attempt to deliver a Notification.
+	deliveryTimeout = 10 * time.Second
+	// deliveryAttempts is the amount of attempts to deliver a Notification to a Webhook.
+	deliveryAttempts = 3
+	// retryBackoff is the period of time before the first retry, doubled for every next one.
+	retryBackoff = time.Second
+)
+
+// Webhook is an endpoint the Notifications are POSTed to as JSON.
+type Webhook struct {
+	URL string
+	// Secret signs the payloads with HMAC-SHA256, if set. The hex-encoded signature is sent in the
+	// SignatureHeader as "sha256=<signature>", so that the receivers verify the sender.
+	Secret string
+}
+
+// Notification is the payload delivered to the Webhooks.
+type Notification struct {
+	events.Event
+	// Node is the peer ID of the node sending the Notification.
+	Node peer.ID `json:"node"`
+}
+
+// Notifier delivers the Events of the given types published on the Bus to the Webhooks.
+type Notifier struct {
+	node     peer.ID
+	webhooks []Webhook
+	types    []events.Type
+	bus      *events.Bus
+	client   *http.Client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewNotifier creates a new Notifier delivering the Events of the given types to the Webhooks.
+func NewNotifier(node peer.ID, bus *events.Bus, webhooks []Webhook, types []events.Type) *Notifier {
+	return &Notifier{
+		node:     node,
+		webhooks: webhooks,
+		types:    types,
+		bus:      bus,
+		client:   &http.Client{Timeout: deliveryTimeout},
+	}
+}
+
+// Start subscribes to the Events and starts delivering them.
+func (n *Notifier) Start(context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cancel, n.done = cancel, make(chan struct{})
+	sub := n.bus.Subscribe(n.types...)
+	go n.deliverLoop(ctx, sub)
+	return nil
+}
+
+// Stop stops delivering the Events and waits for the ongoing delivery to finish.
+func (n *Notifier) Stop(ctx context.Context) error {
+	if n.cancel == nil {
+		return nil
+	}
+
+	n.cancel()
+	select {
+	case <-n.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) deliverLoop(ctx context.Context, sub *events.Subscription) {
+	defer close(n.done)
+	defer sub.Cancel()
+
+	for {
+		select {
+		case e := <-sub.Out():
+			body, err := json.Marshal(Notification{Event: e, Node: n.node})
+			if err != nil {
+				log.Errorw("marshaling notification", "type", e.Type, "err", err)
+				continue
+			}
+			for _, hook := range n.webhooks {
+				err = n.deliver(ctx, hook, body)
+				if err != nil {
+					log.Errorw("delivering notification", "type", e.Type, "webhook", hook.URL, "err", err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deliver POSTs the payload to the Webhook, retrying the failed attempts with the backoff.
+func (n *Notifier) deliver(ctx context.Context, hook Webhook, body []byte) (err error) {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err = n.post(ctx, hook, body)
+		if err == nil || attempt == deliveryAttempts {
+			return err
+		}
+		log.Debugw("retrying notification", "webhook", hook.URL, "attempt", attempt, "err", err)
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, hook Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hook.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign([]byte(hook.Secret), body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of the payload with the given secret, as sent in the
+// SignatureHeader.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
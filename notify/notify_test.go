@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+func TestNotifier(t *testing.T) {
+	retryBackoff = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	secret := "secret"
+	received := make(chan Notification, 1)
+	var attempts int
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// the failed attempts are retried
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256="+Sign([]byte(secret), body), r.Header.Get(SignatureHeader))
+
+		var n Notification
+		assert.NoError(t, json.Unmarshal(body, &n))
+		received <- n
+	}))
+	t.Cleanup(hook.Close)
+
+	node, err := test.RandPeerID()
+	require.NoError(t, err)
+	bus := events.NewBus()
+	n := NewNotifier(node, bus, []Webhook{{URL: hook.URL, Secret: secret}}, []events.Type{events.FraudProof})
+	require.NoError(t, n.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, n.Stop(ctx))
+	})
+
+	// not of the notified types
+	bus.Publish(events.Event{Type: events.NewHead, Height: 1})
+	bus.Publish(events.Event{Type: events.FraudProof, Height: 2, Message: "befp"})
+
+	select {
+	case got := <-received:
+		assert.Equal(t, events.FraudProof, got.Type)
+		assert.EqualValues(t, 2, got.Height)
+		assert.Equal(t, "befp", got.Message)
+		assert.Equal(t, node, got.Node)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+	assert.Equal(t, 2, attempts)
+}
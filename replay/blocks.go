@@ -0,0 +1,112 @@
+package replay
+
+import (
+	"context"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	exchange "github.com/ipfs/go-ipfs-exchange-interface"
+)
+
+// RecordBlocks wraps the exchange.Interface to record all the blocks it fetches.
+func (r *Recorder) RecordBlocks(ex exchange.Interface) exchange.Interface {
+	return &blocksRecorder{fetcher: ex, ex: ex, rec: r}
+}
+
+// ReplayBlocks provides the exchange.Interface serving the recorded blocks.
+func (t *Trace) ReplayBlocks() exchange.Interface {
+	return &blocksReplayer{trace: t}
+}
+
+type blocksRecorder struct {
+	fetcher exchange.Fetcher
+	ex      exchange.Interface
+	rec     *Recorder
+}
+
+func (br *blocksRecorder) GetBlock(ctx context.Context, id cid.Cid) (blocks.Block, error) {
+	blk, err := br.fetcher.GetBlock(ctx, id)
+	if err != nil {
+		br.rec.record(Record{Kind: Blocks, Request: id.String(), Error: err.Error()})
+		return nil, err
+	}
+	br.record(blk)
+	return blk, nil
+}
+
+func (br *blocksRecorder) GetBlocks(ctx context.Context, ids []cid.Cid) (<-chan blocks.Block, error) {
+	in, err := br.fetcher.GetBlocks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan blocks.Block)
+	go func() {
+		defer close(out)
+		for blk := range in {
+			br.record(blk)
+			select {
+			case out <- blk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// NewSession records the blocks fetched within the session of the wrapped exchange, so that the
+// recording does not deprive the block service of the sessions.
+func (br *blocksRecorder) NewSession(ctx context.Context) exchange.Fetcher {
+	sesEx, ok := br.ex.(exchange.SessionExchange)
+	if !ok {
+		return br
+	}
+	return &blocksRecorder{fetcher: sesEx.NewSession(ctx), ex: br.ex, rec: br.rec}
+}
+
+func (br *blocksRecorder) NotifyNewBlocks(ctx context.Context, blks ...blocks.Block) error {
+	return br.ex.NotifyNewBlocks(ctx, blks...)
+}
+
+func (br *blocksRecorder) Close() error {
+	return br.ex.Close()
+}
+
+func (br *blocksRecorder) record(blk blocks.Block) {
+	br.rec.record(Record{Kind: Blocks, Request: blk.Cid().String(), Response: [][]byte{blk.RawData()}})
+}
+
+type blocksReplayer struct {
+	trace *Trace
+}
+
+func (br *blocksReplayer) GetBlock(_ context.Context, id cid.Cid) (blocks.Block, error) {
+	br.trace.lk.Lock()
+	defer br.trace.lk.Unlock()
+	blk, ok := br.trace.blocks[id]
+	if !ok {
+		return nil, ErrNotRecorded
+	}
+	return blk, nil
+}
+
+func (br *blocksReplayer) GetBlocks(ctx context.Context, ids []cid.Cid) (<-chan blocks.Block, error) {
+	out := make(chan blocks.Block, len(ids))
+	defer close(out)
+	for _, id := range ids {
+		// the blocks missing in the Trace are never sent, as with the network not having them
+		if blk, err := br.GetBlock(ctx, id); err == nil {
+			out <- blk
+		}
+	}
+	return out, nil
+}
+
+func (br *blocksReplayer) NotifyNewBlocks(context.Context, ...blocks.Block) error {
+	return nil
+}
+
+func (br *blocksReplayer) Close() error {
+	return nil
+}
@@ -0,0 +1,121 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// RecordHeaders wraps the header.Exchange to record all its requests.
+func (r *Recorder) RecordHeaders(ex header.Exchange) header.Exchange {
+	return &headersRecorder{ex: ex, rec: r}
+}
+
+// ReplayHeaders provides the header.Exchange replaying the recorded requests.
+func (t *Trace) ReplayHeaders() header.Exchange {
+	return &headersReplayer{trace: t}
+}
+
+type headersRecorder struct {
+	ex  header.Exchange
+	rec *Recorder
+}
+
+func (hr *headersRecorder) Head(ctx context.Context) (*header.ExtendedHeader, error) {
+	h, err := hr.ex.Head(ctx)
+	hr.record(headRequest(), err, h)
+	return h, err
+}
+
+func (hr *headersRecorder) Get(ctx context.Context, hash tmbytes.HexBytes) (*header.ExtendedHeader, error) {
+	h, err := hr.ex.Get(ctx, hash)
+	hr.record(hashRequest(hash), err, h)
+	return h, err
+}
+
+func (hr *headersRecorder) GetByHeight(ctx context.Context, height uint64) (*header.ExtendedHeader, error) {
+	h, err := hr.ex.GetByHeight(ctx, height)
+	hr.record(heightRequest(height), err, h)
+	return h, err
+}
+
+func (hr *headersRecorder) GetRangeByHeight(
+	ctx context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	hs, err := hr.ex.GetRangeByHeight(ctx, from, amount)
+	hr.record(rangeRequest(from, amount), err, hs...)
+	return hs, err
+}
+
+func (hr *headersRecorder) record(req string, err error, hs ...*header.ExtendedHeader) {
+	rec := Record{Kind: Headers, Request: req}
+	if err != nil {
+		rec.Error = err.Error()
+		hr.rec.record(rec)
+		return
+	}
+
+	for _, h := range hs {
+		data, err := header.MarshalExtendedHeader(h)
+		if err != nil {
+			log.Errorw("marshaling header", "request", req, "err", err)
+			return
+		}
+		rec.Response = append(rec.Response, data)
+	}
+	hr.rec.record(rec)
+}
+
+type headersReplayer struct {
+	trace *Trace
+}
+
+func (hr *headersReplayer) Head(context.Context) (*header.ExtendedHeader, error) {
+	return hr.single(headRequest())
+}
+
+func (hr *headersReplayer) Get(_ context.Context, hash tmbytes.HexBytes) (*header.ExtendedHeader, error) {
+	return hr.single(hashRequest(hash))
+}
+
+func (hr *headersReplayer) GetByHeight(_ context.Context, height uint64) (*header.ExtendedHeader, error) {
+	return hr.single(heightRequest(height))
+}
+
+func (hr *headersReplayer) GetRangeByHeight(
+	_ context.Context,
+	from, amount uint64,
+) ([]*header.ExtendedHeader, error) {
+	return hr.trace.nextHeaders(rangeRequest(from, amount))
+}
+
+func (hr *headersReplayer) single(req string) (*header.ExtendedHeader, error) {
+	hs, err := hr.trace.nextHeaders(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(hs) != 1 {
+		return nil, fmt.Errorf("replay: %s: expected single header, got %d", req, len(hs))
+	}
+	return hs[0], nil
+}
+
+func headRequest() string {
+	return "head"
+}
+
+func hashRequest(hash tmbytes.HexBytes) string {
+	return "hash/" + hash.String()
+}
+
+func heightRequest(height uint64) string {
+	return fmt.Sprintf("height/%d", height)
+}
+
+func rangeRequest(from, amount uint64) string {
+	return fmt.Sprintf("range/%d/%d", from, amount)
+}
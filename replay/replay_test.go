@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/header/local"
+	"github.com/celestiaorg/celestia-node/header/store"
+)
+
+func TestReplayHeaders(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	suite := header.NewTestSuite(t, 3)
+	hstore := store.NewTestStore(ctx, t, suite.Head())
+	_, err := hstore.Append(ctx, suite.GenExtendedHeaders(10)...)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	ex := NewRecorder(buf).RecordHeaders(local.NewExchange(hstore))
+
+	// the head changes in between the requests
+	firstHead, err := ex.Head(ctx)
+	require.NoError(t, err)
+	_, err = hstore.Append(ctx, suite.GenExtendedHeaders(1)...)
+	require.NoError(t, err)
+	secondHead, err := ex.Head(ctx)
+	require.NoError(t, err)
+
+	h, err := ex.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	byHash, err := ex.Get(ctx, h.Hash())
+	require.NoError(t, err)
+	hs, err := ex.GetRangeByHeight(ctx, 2, 5)
+	require.NoError(t, err)
+	_, err = ex.Get(ctx, header.RandExtendedHeader(t).Hash())
+	require.ErrorIs(t, err, header.ErrNotFound)
+
+	trace, err := LoadTrace(buf)
+	require.NoError(t, err)
+	replayed := trace.ReplayHeaders()
+
+	// the responses are replayed in the recorded order, repeating the last one
+	for _, want := range []*header.ExtendedHeader{firstHead, secondHead, secondHead} {
+		got, err := replayed.Head(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash(), got.Hash())
+	}
+
+	got, err := replayed.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, h.Hash(), got.Hash())
+	got, err = replayed.Get(ctx, h.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, byHash.Hash(), got.Hash())
+
+	gotRange, err := replayed.GetRangeByHeight(ctx, 2, 5)
+	require.NoError(t, err)
+	require.Len(t, gotRange, len(hs))
+	for i := range hs {
+		assert.Equal(t, hs[i].Hash(), gotRange[i].Hash())
+	}
+
+	_, err = replayed.Get(ctx, header.RandExtendedHeader(t).Hash())
+	assert.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestReplayBlocks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(ds.NewMapDatastore()))
+	blks := []blocks.Block{
+		blocks.NewBlock([]byte("first")),
+		blocks.NewBlock([]byte("second")),
+	}
+	require.NoError(t, bs.PutMany(ctx, blks))
+	missing := blocks.NewBlock([]byte("missing"))
+
+	buf := &bytes.Buffer{}
+	ex := NewRecorder(buf).RecordBlocks(offline.Exchange(bs))
+	_, err := ex.GetBlock(ctx, blks[0].Cid())
+	require.NoError(t, err)
+	ch, err := ex.GetBlocks(ctx, []cid.Cid{blks[1].Cid()})
+	require.NoError(t, err)
+	for range ch {
+	}
+	_, err = ex.GetBlock(ctx, missing.Cid())
+	require.Error(t, err)
+
+	trace, err := LoadTrace(buf)
+	require.NoError(t, err)
+	replayed := trace.ReplayBlocks()
+
+	for _, blk := range blks {
+		got, err := replayed.GetBlock(ctx, blk.Cid())
+		require.NoError(t, err)
+		assert.Equal(t, blk.RawData(), got.RawData())
+	}
+	_, err = replayed.GetBlock(ctx, missing.Cid())
+	assert.ErrorIs(t, err, ErrNotRecorded)
+
+	ch, err = replayed.GetBlocks(ctx, []cid.Cid{blks[0].Cid(), missing.Cid(), blks[1].Cid()})
+	require.NoError(t, err)
+	var amount int
+	for range ch {
+		amount++
+	}
+	assert.Equal(t, len(blks), amount)
+}
+
+func TestLoadTrace_Invalid(t *testing.T) {
+	_, err := LoadTrace(strings.NewReader(`{"kind":"unknown","request":"head"}`))
+	assert.Error(t, err)
+
+	// the data must match the CID
+	blk := blocks.NewBlock([]byte("block"))
+	_, err = LoadTrace(strings.NewReader(`{"kind":"blocks","request":"` + blk.Cid().String() + `","response":["AAAA"]}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+// Package replay records the headers and the blocks of shares a node gets from the network to a
+// trace and replays them into another node, so that an issue observed on a live network can be
+// reproduced deterministically offline, e.g. in tests.
+//
+// A trace is a stream of JSON encoded Records, one per line.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+var log = logging.Logger("replay")
+
+// ErrNotRecorded is returned by the replayed exchanges for the requests missing in the Trace.
+var ErrNotRecorded = errors.New("replay: request not recorded")
+
+// Kind is the kind of the exchange a Record belongs to.
+type Kind string
+
+const (
+	// Headers is the kind of the Records of the header exchange.
+	Headers Kind = "headers"
+	// Blocks is the kind of the Records of the block exchange serving the shares.
+	Blocks Kind = "blocks"
+)
+
+// Record is a single request recorded along with its response.
+type Record struct {
+	Kind Kind `json:"kind"`
+	// Request identifies the request, e.g. "height/10" for headers or the CID for blocks.
+	Request string `json:"request"`
+	// Response holds the protobuf encoded headers or the raw data of the block.
+	Response [][]byte `json:"response,omitempty"`
+	// Error is the error message the request failed with, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Recorder writes the Records of the exchanges it wraps to a trace.
+// It is safe for concurrent use.
+type Recorder struct {
+	lk  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewRecorder creates a new Recorder writing the trace to the given writer.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// record writes the Record to the trace. The failures are only logged not to break the exchanges.
+func (r *Recorder) record(rec Record) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if err := r.enc.Encode(rec); err != nil {
+		log.Errorw("recording", "kind", rec.Kind, "request", rec.Request, "err", err)
+	}
+}
+
+// Trace keeps the recorded Records to be replayed.
+type Trace struct {
+	lk sync.Mutex
+	// headers keeps the responses to every request not yet replayed, in the recorded order
+	headers map[string][]Record
+	blocks  map[cid.Cid]blocks.Block
+}
+
+// LoadTrace reads the trace from the given reader.
+func LoadTrace(r io.Reader) (*Trace, error) {
+	t := &Trace{
+		headers: make(map[string][]Record),
+		blocks:  make(map[cid.Cid]blocks.Block),
+	}
+
+	scanner := bufio.NewScanner(r)
+	// the lines carry whole ranges of headers
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("replay: line %d: %w", line, err)
+		}
+		if err := t.add(rec); err != nil {
+			return nil, fmt.Errorf("replay: line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("replay: reading trace: %w", err)
+	}
+	return t, nil
+}
+
+// OpenTrace reads the trace from the file under the given path.
+func OpenTrace(path string) (*Trace, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay: opening trace: %w", err)
+	}
+	defer f.Close()
+	return LoadTrace(f)
+}
+
+func (t *Trace) add(rec Record) error {
+	switch rec.Kind {
+	case Headers:
+		t.headers[rec.Request] = append(t.headers[rec.Request], rec)
+	case Blocks:
+		if rec.Error != "" {
+			// the block is served by the content, so the failures are not replayed
+			return nil
+		}
+		id, err := cid.Decode(rec.Request)
+		if err != nil {
+			return err
+		}
+		if len(rec.Response) != 1 {
+			return fmt.Errorf("block %s: expected single response, got %d", id, len(rec.Response))
+		}
+		// the trace may come from an untrusted bug report, so the data is checked to match the CID
+		sum, err := id.Prefix().Sum(rec.Response[0])
+		if err != nil {
+			return err
+		}
+		if !sum.Equals(id) {
+			return fmt.Errorf("block %s: data does not match", id)
+		}
+		blk, err := blocks.NewBlockWithCid(rec.Response[0], id)
+		if err != nil {
+			return err
+		}
+		t.blocks[id] = blk
+	default:
+		return fmt.Errorf("unknown kind %q", rec.Kind)
+	}
+	return nil
+}
+
+// nextHeaders replays the next response to the request. Once all the recorded responses are
+// replayed, the last one is repeated.
+func (t *Trace) nextHeaders(req string) ([]*header.ExtendedHeader, error) {
+	t.lk.Lock()
+	recs := t.headers[req]
+	if len(recs) == 0 {
+		t.lk.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, req)
+	}
+	rec := recs[0]
+	if len(recs) > 1 {
+		t.headers[req] = recs[1:]
+	}
+	t.lk.Unlock()
+
+	if rec.Error != "" {
+		return nil, replayedError(rec.Error)
+	}
+	hs := make([]*header.ExtendedHeader, len(rec.Response))
+	for i, data := range rec.Response {
+		h, err := header.UnmarshalExtendedHeader(data)
+		if err != nil {
+			return nil, fmt.Errorf("replay: %s: %w", req, err)
+		}
+		hs[i] = h
+	}
+	return hs, nil
+}
+
+// replayedError recreates the recorded error, keeping the identity of the well-known ones.
+func replayedError(msg string) error {
+	for _, err := range []error{header.ErrNotFound, header.ErrNoHead} {
+		if msg == err.Error() {
+			return err
+		}
+	}
+	return errors.New(msg)
+}
@@ -28,5 +28,16 @@ type Availability interface {
 	// ProbabilityOfAvailability calculates the probability of the data square
 	// being available based on the number of samples collected.
 	// TODO(@Wondertan): Merge with SharesAvailable method, eventually
-	ProbabilityOfAvailability() float64
+	ProbabilityOfAvailability(context.Context) float64
+	// ProbabilityOfAvailabilityFor calculates the probability of the data square committed to the
+	// given Root being available, once SharesAvailable succeeds for it.
+	ProbabilityOfAvailabilityFor(context.Context, *Root) float64
+}
+
+// Prefetcher is implemented by the Availabilities able to fetch the Shares they sample ahead, so
+// that SharesAvailable for the same Root completes sooner.
+type Prefetcher interface {
+	// Prefetch fetches the Shares to be sampled over the given Root to local storage, blocking
+	// until done or the context is done. The errors are not reported, as SharesAvailable retries.
+	Prefetch(context.Context, *Root)
 }
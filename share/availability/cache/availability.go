@@ -81,8 +81,29 @@ func (ca *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Ro
 	return err
 }
 
-func (ca *ShareAvailability) ProbabilityOfAvailability() float64 {
-	return ca.avail.ProbabilityOfAvailability()
+// Prefetch implements share.Prefetcher, prefetching over the given Root with the wrapped
+// share.Availability, if it supports prefetching and the Root has not been sampled yet.
+func (ca *ShareAvailability) Prefetch(ctx context.Context, root *share.Root) {
+	prefetcher, ok := ca.avail.(share.Prefetcher)
+	if !ok || isMinRoot(root) {
+		return
+	}
+
+	ca.dsLk.RLock()
+	exists, err := ca.ds.Has(ctx, rootKey(root))
+	ca.dsLk.RUnlock()
+	if err != nil || exists {
+		return
+	}
+	prefetcher.Prefetch(ctx, root)
+}
+
+func (ca *ShareAvailability) ProbabilityOfAvailability(ctx context.Context) float64 {
+	return ca.avail.ProbabilityOfAvailability(ctx)
+}
+
+func (ca *ShareAvailability) ProbabilityOfAvailabilityFor(ctx context.Context, root *share.Root) float64 {
+	return ca.avail.ProbabilityOfAvailabilityFor(ctx, root)
 }
 
 // Close flushes all queued writes to disk.
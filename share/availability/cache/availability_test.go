@@ -134,6 +134,10 @@ func (da *dummyAvailability) SharesAvailable(_ context.Context, root *share.Root
 	return nil
 }
 
-func (da *dummyAvailability) ProbabilityOfAvailability() float64 {
+func (da *dummyAvailability) ProbabilityOfAvailability(context.Context) float64 {
+	return 0
+}
+
+func (da *dummyAvailability) ProbabilityOfAvailabilityFor(context.Context, *share.Root) float64 {
 	return 0
 }
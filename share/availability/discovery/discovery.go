@@ -15,11 +15,10 @@ import (
 var log = logging.Logger("share/discovery")
 
 const (
-	// peerWeight is a weight of discovered peers.
-	// peerWeight is a number that will be assigned to all discovered full nodes,
-	// so ConnManager will not break a connection with them.
-	peerWeight = 1000
-	topic      = "full"
+	topic = "full"
+	// protectedTag protects the connections with the discovered full nodes serving shares from being
+	// pruned by ConnManager.
+	protectedTag = "protected-full"
 )
 
 // waitF calculates time to restart announcing.
@@ -79,13 +78,13 @@ func (d *Discovery) handlePeerFound(ctx context.Context, topic string, peer peer
 		return
 	}
 	log.Debugw("added peer to set", "id", peer.ID)
-	// add tag to protect peer of being killed by ConnManager
-	d.host.ConnManager().TagPeer(peer.ID, topic, peerWeight)
+	// protect peer of being killed by ConnManager
+	d.host.ConnManager().Protect(peer.ID, protectedTag)
 }
 
 // EnsurePeers ensures we always have 'peerLimit' connected peers.
-// It starts peer discovery every 30 seconds until peer cache reaches peersLimit.
-// Discovery is restarted if any previously connected peers disconnect.
+// It starts peer discovery right away and then every discovery interval until peer cache reaches
+// peersLimit. Discovery is restarted if any previously connected peers disconnect.
 func (d *Discovery) EnsurePeers(ctx context.Context) {
 	if d.peersLimit == 0 {
 		log.Warn("peers limit is set to 0. Skipping discovery...")
@@ -99,6 +98,8 @@ func (d *Discovery) EnsurePeers(ctx context.Context) {
 	}
 	go d.connector.GC(ctx)
 
+	// do not wait a whole interval to find the first peers, as sampling depends on them
+	d.findPeers(ctx)
 	t := time.NewTicker(d.discoveryInterval)
 	defer func() {
 		t.Stop()
@@ -116,14 +117,7 @@ func (d *Discovery) EnsurePeers(ctx context.Context) {
 				t.Stop()
 				continue
 			}
-			peers, err := d.disc.FindPeers(ctx, topic)
-			if err != nil {
-				log.Error(err)
-				continue
-			}
-			for p := range peers {
-				go d.handlePeerFound(ctx, topic, p)
-			}
+			d.findPeers(ctx)
 		case e := <-sub.Out():
 			// listen to disconnect event to remove peer from set and reset backoff time
 			// reset timer in order to restart the discovery, once stored peer is disconnected
@@ -132,7 +126,7 @@ func (d *Discovery) EnsurePeers(ctx context.Context) {
 				if d.set.Contains(connStatus.Peer) {
 					d.connector.RestartBackoff(connStatus.Peer)
 					d.set.Remove(connStatus.Peer)
-					d.host.ConnManager().UntagPeer(connStatus.Peer, topic)
+					d.host.ConnManager().Unprotect(connStatus.Peer, protectedTag)
 					t.Reset(d.discoveryInterval)
 				}
 			}
@@ -140,32 +134,45 @@ func (d *Discovery) EnsurePeers(ctx context.Context) {
 	}
 }
 
+// findPeers starts a discovery session, connecting to the found peers.
+func (d *Discovery) findPeers(ctx context.Context) {
+	peers, err := d.disc.FindPeers(ctx, topic)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	for p := range peers {
+		go d.handlePeerFound(ctx, topic, p)
+	}
+}
+
 // Advertise is a utility function that persistently advertises a service through an Advertiser.
+// The advertisement is renewed shortly before its TTL expires, while failed attempts are retried
+// every advertise interval.
 func (d *Discovery) Advertise(ctx context.Context) {
-	timer := time.NewTimer(d.advertiseInterval)
+	timer := time.NewTimer(0)
 	defer timer.Stop()
 	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
 		ttl, err := d.disc.Advertise(ctx, topic)
 		if err != nil {
 			log.Debugf("Error advertising %s: %s", topic, err.Error())
 			if ctx.Err() != nil {
 				return
 			}
-
-			select {
-			case <-timer.C:
-				timer.Reset(d.advertiseInterval)
-				continue
-			case <-ctx.Done():
-				return
-			}
+			timer.Reset(d.advertiseInterval)
+			continue
 		}
-
-		select {
-		case <-timer.C:
-			timer.Reset(waitF(ttl))
-		case <-ctx.Done():
-			return
+		log.Debugw("advertised", "topic", topic, "ttl", ttl)
+		wait := waitF(ttl)
+		if wait <= 0 {
+			wait = d.advertiseInterval
 		}
+		timer.Reset(wait)
 	}
 }
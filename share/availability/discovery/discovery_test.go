@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	core "github.com/libp2p/go-libp2p-core/discovery"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscovery_EnsurePeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	m, err := mocknet.FullMeshLinked(3)
+	require.NoError(t, err)
+
+	disc := &testDiscovery{peers: []peer.AddrInfo{
+		*host.InfoFromHost(m.Hosts()[1]),
+		*host.InfoFromHost(m.Hosts()[2]),
+	}}
+	// the interval is long enough to ensure peers are found right away
+	d := NewDiscovery(m.Hosts()[0], disc, 2, time.Hour, time.Hour)
+	go d.EnsurePeers(ctx)
+
+	require.Eventually(t, func() bool {
+		return d.set.Size() == 2
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestDiscovery_Advertise(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	t.Cleanup(cancel)
+	m, err := mocknet.FullMeshLinked(1)
+	require.NoError(t, err)
+
+	// the advertisement is renewed before its TTL expires, regardless of the advertise interval
+	disc := &testDiscovery{ttl: time.Millisecond * 80}
+	d := NewDiscovery(m.Hosts()[0], disc, 0, time.Hour, time.Hour)
+	go d.Advertise(ctx)
+
+	require.Eventually(t, func() bool {
+		return disc.advertised() >= 3
+	}, time.Second*5, time.Millisecond*10)
+}
+
+// testDiscovery finds the given peers and counts the advertisements.
+type testDiscovery struct {
+	peers []peer.AddrInfo
+	ttl   time.Duration
+
+	lk             sync.Mutex
+	advertisements int
+}
+
+func (d *testDiscovery) Advertise(context.Context, string, ...core.Option) (time.Duration, error) {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	d.advertisements++
+	return d.ttl, nil
+}
+
+func (d *testDiscovery) FindPeers(context.Context, string, ...core.Option) (<-chan peer.AddrInfo, error) {
+	peers := make(chan peer.AddrInfo, len(d.peers))
+	for _, p := range d.peers {
+		peers <- p
+	}
+	close(peers)
+	return peers, nil
+}
+
+func (d *testDiscovery) advertised() int {
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	return d.advertisements
+}
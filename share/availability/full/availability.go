@@ -19,18 +19,32 @@ var log = logging.Logger("share/full")
 // recovery technique. It is considered "full" because it is required
 // to download enough shares to fully reconstruct the data square.
 type ShareAvailability struct {
-	rtrv *eds.Retriever
-	disc *discovery.Discovery
+	rtrv  *eds.Retriever
+	disc  *discovery.Discovery
+	store *eds.Store
+	// storePaused reports whether storing the squares is paused, if set
+	storePaused func() bool
 
 	cancel context.CancelFunc
 }
 
 // NewShareAvailability creates a new full ShareAvailability.
-func NewShareAvailability(bServ blockservice.BlockService, disc *discovery.Discovery) *ShareAvailability {
-	return &ShareAvailability{
-		rtrv: eds.NewRetriever(bServ),
-		disc: disc,
+// The reconstructed squares are kept in the given eds.Store, unless it is nil.
+func NewShareAvailability(
+	store *eds.Store,
+	bServ blockservice.BlockService,
+	disc *discovery.Discovery,
+	options ...Option,
+) *ShareAvailability {
+	fa := &ShareAvailability{
+		rtrv:  eds.NewRetriever(bServ),
+		disc:  disc,
+		store: store,
 	}
+	for _, applyOpt := range options {
+		applyOpt(fa)
+	}
+	return fa
 }
 
 func (fa *ShareAvailability) Start(context.Context) error {
@@ -60,7 +74,7 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Ro
 		panic(err)
 	}
 
-	_, err := fa.rtrv.Retrieve(ctx, root)
+	square, err := fa.rtrv.Retrieve(ctx, root)
 	if err != nil {
 		log.Errorw("availability validation failed", "root", root.Hash(), "err", err)
 		if ipldFormat.IsNotFound(err) || errors.Is(err, context.DeadlineExceeded) {
@@ -69,9 +83,25 @@ func (fa *ShareAvailability) SharesAvailable(ctx context.Context, root *share.Ro
 
 		return err
 	}
-	return err
+
+	switch {
+	case fa.store == nil:
+	case fa.storePaused != nil && fa.storePaused():
+		log.Debugw("skipping storing square, as storing is paused", "root", root.Hash())
+	default:
+		// the square is available regardless of whether it is stored
+		err = fa.store.Put(ctx, *root, square)
+		if err != nil {
+			log.Errorw("storing square", "root", root.Hash(), "err", err)
+		}
+	}
+	return nil
+}
+
+func (fa *ShareAvailability) ProbabilityOfAvailability(context.Context) float64 {
+	return 1
 }
 
-func (fa *ShareAvailability) ProbabilityOfAvailability() float64 {
+func (fa *ShareAvailability) ProbabilityOfAvailabilityFor(context.Context, *share.Root) float64 {
 	return 1
 }
@@ -6,9 +6,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	mdutils "github.com/ipfs/go-merkledag/test"
+	routinghelpers "github.com/libp2p/go-libp2p-routing-helpers"
+	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/celestiaorg/celestia-node/share/availability/discovery"
 	availability_test "github.com/celestiaorg/celestia-node/share/availability/test"
+	"github.com/celestiaorg/celestia-node/share/eds"
 )
 
 func init() {
@@ -38,3 +46,35 @@ func TestSharesAvailable_Full(t *testing.T) {
 	err := service.SharesAvailable(ctx, dah)
 	assert.NoError(t, err)
 }
+
+// TestSharesAvailable_StorePaused verifies that the reconstructed squares are not stored while
+// storing is paused.
+func TestSharesAvailable_StorePaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	bServ := mdutils.Bserv()
+	root := availability_test.RandFillBS(t, 16, bServ)
+	disc := discovery.NewDiscovery(nil, routing.NewRoutingDiscovery(routinghelpers.Null{}), 0, time.Second, time.Second)
+	paused := true
+	fa := NewShareAvailability(store, bServ, disc, WithStorePaused(func() bool {
+		return paused
+	}))
+
+	require.NoError(t, fa.SharesAvailable(ctx, root))
+	has, _ := store.Has(ctx, *root)
+	assert.False(t, has)
+
+	paused = false
+	require.NoError(t, fa.SharesAvailable(ctx, root))
+	has, err = store.Has(ctx, *root)
+	require.NoError(t, err)
+	assert.True(t, has)
+}
@@ -0,0 +1,13 @@
+package full
+
+// Option is the functional option that is applied to the ShareAvailability instance
+// to configure it.
+type Option func(*ShareAvailability)
+
+// WithStorePaused makes the ShareAvailability skip storing the reconstructed squares while the
+// given function reports true, e.g. while the node is low on disk space.
+func WithStorePaused(paused func() bool) Option {
+	return func(fa *ShareAvailability) {
+		fa.storePaused = paused
+	}
+}
@@ -37,5 +37,5 @@ func Node(dn *availability_test.TestDagNet) *availability_test.TestNode {
 
 func TestAvailability(bServ blockservice.BlockService) *ShareAvailability {
 	disc := discovery.NewDiscovery(nil, routing.NewRoutingDiscovery(routinghelpers.Null{}), 0, time.Second, time.Second)
-	return NewShareAvailability(bServ, disc)
+	return NewShareAvailability(nil, bServ, disc)
 }
@@ -2,20 +2,31 @@ package light
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"math"
+	"sync"
 
 	"github.com/celestiaorg/celestia-node/share/ipld"
 
 	"github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
 	ipldFormat "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/availability/discovery"
 )
 
-var log = logging.Logger("share/light")
+// maxPrefetched bounds the amount of the Roots the prefetched samples are kept for until sampled.
+const maxPrefetched = 64
+
+var (
+	log    = logging.Logger("share/light")
+	tracer = otel.Tracer("share/light")
+)
 
 // ShareAvailability implements share.Availability using Data Availability Sampling technique.
 // It is light because it does not require the downloading of all the data to verify
@@ -27,16 +38,37 @@ type ShareAvailability struct {
 	// it is not allowed to call advertise for light nodes (Full nodes only).
 	disc   *discovery.Discovery
 	cancel context.CancelFunc
+
+	// sampleAmount is the amount of Shares sampled from every square.
+	sampleAmount int
+	// targetConfidence is the probability of availability sampling has to reach for every square.
+	targetConfidence float64
+
+	// prefetched keeps the samples fetched ahead per Root, so that sampling of the Root reuses them
+	// and finds their Shares in local storage. prefetchedOrder is the order the Roots are evicted in.
+	prefetchLk      sync.Mutex
+	prefetched      map[string][]sampleLeaf
+	prefetchedOrder []string
 }
 
 // NewShareAvailability creates a new light Availability.
 func NewShareAvailability(
 	bserv blockservice.BlockService,
 	disc *discovery.Discovery,
+	options ...Option,
 ) *ShareAvailability {
 	la := &ShareAvailability{
-		bserv: bserv,
-		disc:  disc,
+		bserv:        bserv,
+		disc:         disc,
+		sampleAmount: DefaultSampleAmount,
+		prefetched:   make(map[string][]sampleLeaf),
+	}
+	for _, applyOpt := range options {
+		applyOpt(la)
+	}
+	// sample more, if the configured amount is not enough to reach the target confidence
+	if amount := SampleAmountFor(la.targetConfidence); amount > la.sampleAmount {
+		la.sampleAmount = amount
 	}
 	return la
 }
@@ -54,7 +86,7 @@ func (la *ShareAvailability) Stop(context.Context) error {
 	return nil
 }
 
-// SharesAvailable randomly samples DefaultSampleAmount amount of Shares committed to the given
+// SharesAvailable randomly samples the configured amount of Shares committed to the given
 // Root. This way SharesAvailable subjectively verifies that Shares are available.
 func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Root) error {
 	log.Debugw("Validate availability", "root", dah.Hash())
@@ -65,11 +97,120 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 			"err", err)
 		panic(err)
 	}
-	samples, err := SampleSquare(len(dah.RowsRoots), DefaultSampleAmount)
+	samples, prefetched := la.takePrefetched(dah)
+	if !prefetched {
+		var err error
+		samples, err = sampleLeaves(dah, la.sampleAmount)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, span := tracer.Start(ctx, "sample-shares")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int("size", len(dah.RowsRoots)),
+		attribute.Int("samples", len(samples)),
+		attribute.Bool("prefetched", prefetched),
+		attribute.String("data_hash", hex.EncodeToString(dah.Hash())),
+	)
+
+	err := la.fetch(ctx, dah, samples)
+	if err != nil {
+		span.RecordError(err)
+		if !errors.Is(err, context.Canceled) {
+			log.Errorw("availability validation failed", "root", dah.Hash(), "err", err)
+		}
+		if ipldFormat.IsNotFound(err) || errors.Is(err, context.DeadlineExceeded) {
+			return share.ErrNotAvailable
+		}
+	}
+	return err
+}
+
+// Prefetch implements share.Prefetcher. It chooses the samples of the given Root and fetches their
+// Shares ahead, so that SharesAvailable for the Root samples the same Shares, but finds them in
+// local storage. Opening the session ahead also resolves the peers providing the Shares.
+func (la *ShareAvailability) Prefetch(ctx context.Context, dah *share.Root) {
+	if err := dah.ValidateBasic(); err != nil {
+		return
+	}
+	samples, err := sampleLeaves(dah, la.sampleAmount)
+	if err != nil {
+		return
+	}
+
+	key := string(dah.Hash())
+	la.prefetchLk.Lock()
+	if _, ok := la.prefetched[key]; ok {
+		// already prefetched or being prefetched
+		la.prefetchLk.Unlock()
+		return
+	}
+	if len(la.prefetchedOrder) >= maxPrefetched {
+		delete(la.prefetched, la.prefetchedOrder[0])
+		la.prefetchedOrder = la.prefetchedOrder[1:]
+	}
+	la.prefetched[key] = samples
+	la.prefetchedOrder = append(la.prefetchedOrder, key)
+	la.prefetchLk.Unlock()
+
+	ctx, span := tracer.Start(ctx, "prefetch-shares")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Int("size", len(dah.RowsRoots)),
+		attribute.Int("samples", len(samples)),
+		attribute.String("data_hash", hex.EncodeToString(dah.Hash())),
+	)
+
+	if err = la.fetch(ctx, dah, samples); err != nil {
+		span.RecordError(err)
+		log.Debugw("prefetching shares", "root", dah.Hash(), "err", err)
+	}
+}
+
+// takePrefetched returns the samples prefetched for the given Root, if any, and forgets them.
+func (la *ShareAvailability) takePrefetched(dah *share.Root) ([]sampleLeaf, bool) {
+	key := string(dah.Hash())
+	la.prefetchLk.Lock()
+	defer la.prefetchLk.Unlock()
+	samples, ok := la.prefetched[key]
+	if !ok {
+		return nil, false
+	}
+	delete(la.prefetched, key)
+	for i, k := range la.prefetchedOrder {
+		if k == key {
+			la.prefetchedOrder = append(la.prefetchedOrder[:i], la.prefetchedOrder[i+1:]...)
+			break
+		}
+	}
+	return samples, true
+}
+
+// sampleLeaf is a sample translated to the leaf of either the row or the column tree it is in.
+type sampleLeaf struct {
+	root cid.Cid
+	leaf int
+}
+
+// sampleLeaves randomly picks the given amount of samples from the square committed to the Root and
+// translates them to the leaves of the trees.
+func sampleLeaves(dah *share.Root, amount int) ([]sampleLeaf, error) {
+	samples, err := SampleSquare(len(dah.RowsRoots), amount)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	leaves := make([]sampleLeaf, len(samples))
+	for i, s := range samples {
+		leaves[i].root, leaves[i].leaf = ipld.Translate(dah, s.Row, s.Col)
+	}
+	return leaves, nil
+}
 
+// fetch fetches the Shares of the given samples of the Root to local storage within a single
+// session.
+func (la *ShareAvailability) fetch(ctx context.Context, dah *share.Root, samples []sampleLeaf) error {
 	ctx, cancel := context.WithTimeout(ctx, share.AvailabilityTimeout)
 	defer cancel()
 
@@ -77,13 +218,11 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 	ses := blockservice.NewSession(ctx, la.bserv)
 	errs := make(chan error, len(samples))
 	for _, s := range samples {
-		go func(s Sample) {
-			root, leaf := ipld.Translate(dah, s.Row, s.Col)
-
-			log.Debugw("fetching share", "root", dah.Hash(), "leaf CID", leaf)
-			_, err := share.GetShare(ctx, ses, root, leaf, len(dah.RowsRoots))
+		go func(s sampleLeaf) {
+			log.Debugw("fetching share", "root", dah.Hash(), "leaf CID", s.leaf)
+			_, err := share.GetShare(ctx, ses, s.root, s.leaf, len(dah.RowsRoots))
 			if err != nil {
-				log.Debugw("error fetching share", "root", dah.Hash(), "leaf CID", leaf)
+				log.Debugw("error fetching share", "root", dah.Hash(), "leaf CID", s.leaf)
 			}
 			// we don't really care about Share bodies at this point
 			// it also means we now saved the Share in local storage
@@ -101,15 +240,7 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 		case <-ctx.Done():
 			err = ctx.Err()
 		}
-
 		if err != nil {
-			if !errors.Is(err, context.Canceled) {
-				log.Errorw("availability validation failed", "root", dah.Hash(), "err", err)
-			}
-			if ipldFormat.IsNotFound(err) || errors.Is(err, context.DeadlineExceeded) {
-				return share.ErrNotAvailable
-			}
-
 			return err
 		}
 	}
@@ -118,10 +249,37 @@ func (la *ShareAvailability) SharesAvailable(ctx context.Context, dah *share.Roo
 }
 
 // ProbabilityOfAvailability calculates the probability that the
-// data square is available based on the amount of samples collected
-// (DefaultSampleAmount).
+// data square is available based on the amount of samples collected.
 //
 // Formula: 1 - (0.75 ** amount of samples)
-func (la *ShareAvailability) ProbabilityOfAvailability() float64 {
-	return 1 - math.Pow(0.75, float64(DefaultSampleAmount))
+func (la *ShareAvailability) ProbabilityOfAvailability(context.Context) float64 {
+	return 1 - math.Pow(0.75, float64(la.sampleAmount))
+}
+
+// ProbabilityOfAvailabilityFor calculates the probability that the data square committed to the
+// given Root is available once SharesAvailable succeeds for it.
+//
+// Unlike ProbabilityOfAvailability, it accounts for the actual width of the square: the square is
+// unrecoverable only when at least (k+1)^2 of its (2k)^2 shares are withheld, so the probability
+// is the one of at least one of the unique samples hitting a withheld share of such square.
+func (la *ShareAvailability) ProbabilityOfAvailabilityFor(_ context.Context, root *share.Root) float64 {
+	return probabilityOfAvailability(len(root.RowsRoots), la.sampleAmount)
+}
+
+func probabilityOfAvailability(width, samples int) float64 {
+	total := width * width
+	withheld := (width/2 + 1) * (width/2 + 1)
+	if samples > total {
+		samples = total
+	}
+
+	// the probability of all the samples missing the withheld shares
+	missed := 1.0
+	for i := 0; i < samples; i++ {
+		if total-withheld-i <= 0 {
+			return 1
+		}
+		missed *= float64(total-withheld-i) / float64(total-i)
+	}
+	return 1 - missed
 }
@@ -50,6 +50,35 @@ func TestSharesAvailableFailed(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestProbabilityOfAvailability(t *testing.T) {
+	avail := TestAvailability(nil)
+	ctx := context.Background()
+	assert.InDelta(t, 1-math.Pow(0.75, float64(DefaultSampleAmount)), avail.ProbabilityOfAvailability(ctx), 1e-9)
+
+	// the more samples, the higher the guarantee
+	more := NewShareAvailability(nil, nil, WithSampleAmount(DefaultSampleAmount*2))
+	assert.Greater(t, more.ProbabilityOfAvailability(ctx), avail.ProbabilityOfAvailability(ctx))
+
+	// the amount of samples is raised to reach the target confidence
+	confident := NewShareAvailability(nil, nil, WithSampleAmount(1), WithTargetConfidence(0.999))
+	assert.GreaterOrEqual(t, confident.ProbabilityOfAvailability(ctx), 0.999)
+
+	// the guarantee for a square accounts for its width and is never lower than the general one
+	root := da.NewDataAvailabilityHeader(share.RandEDS(t, 16))
+	guarantee := avail.ProbabilityOfAvailabilityFor(ctx, &root)
+	assert.GreaterOrEqual(t, guarantee, avail.ProbabilityOfAvailability(ctx))
+	assert.Less(t, guarantee, 1.0)
+	// for the smallest square, any sample detects withholding of an unrecoverable amount of shares
+	assert.Equal(t, 1.0, probabilityOfAvailability(2, 1))
+}
+
+func TestSampleAmountFor(t *testing.T) {
+	assert.Equal(t, 0, SampleAmountFor(0))
+	assert.Equal(t, 0, SampleAmountFor(1))
+	assert.Equal(t, 17, SampleAmountFor(0.99))
+	assert.Equal(t, 1, SampleAmountFor(0.25))
+}
+
 func TestShareAvailableOverMocknet_Light(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -63,6 +92,27 @@ func TestShareAvailableOverMocknet_Light(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSharesAvailable_Prefetched(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	net := availability_test.NewTestDAGNet(ctx, t)
+	full, root := RandNode(net, 16)
+	nd := Node(net)
+	net.ConnectAll()
+
+	avail := TestAvailability(nd.BlockService)
+	avail.Prefetch(ctx, root)
+	assert.Len(t, avail.prefetched, 1)
+
+	// the prefetched samples are sampled from local storage
+	net.Disconnect(full.ID(), nd.ID())
+	err := avail.SharesAvailable(ctx, root)
+	assert.NoError(t, err)
+	assert.Len(t, avail.prefetched, 0)
+	assert.Len(t, avail.prefetchedOrder, 0)
+}
+
 func TestGetShare(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -152,6 +202,65 @@ func TestGetShares(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetExtendedSquare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serv, dah := RandServiceWithSquare(t, 16)
+	eds, err := serv.GetExtendedSquare(ctx, dah)
+	require.NoError(t, err)
+
+	gotDAH := da.NewDataAvailabilityHeader(eds)
+	require.True(t, dah.Equals(&gotDAH))
+}
+
+func TestGetRowWithProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serv, dah := RandServiceWithSquare(t, 16)
+	for i := range dah.RowsRoots {
+		row, err := serv.GetRowWithProof(ctx, dah, i)
+		require.NoError(t, err)
+		assert.Len(t, row.Shares, len(dah.RowsRoots))
+		require.NoError(t, row.Verify(dah.Hash()))
+	}
+
+	_, err := serv.GetRowWithProof(ctx, dah, len(dah.RowsRoots))
+	require.Error(t, err)
+}
+
+func TestService_GetSharesByNamespaceWithProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	serv, bServ := RandService()
+	n := 16 * 16
+	randShares := share.RandShares(t, n)
+	idx1, idx2 := (n-1)/2, n/2
+	// make it so that two rows have the same namespace ID
+	copy(randShares[idx2][:8], randShares[idx1][:8])
+	root := availability_test.FillBS(t, bServ, randShares)
+	randNID := randShares[idx1][:8]
+
+	rows, err := serv.GetSharesByNamespaceWithProof(ctx, root, randNID)
+	require.NoError(t, err)
+	var shares []share.Share
+	for _, row := range rows {
+		require.NoError(t, row.Verify(root.RowsRoots[row.Index], randNID))
+		shares = append(shares, row.Shares...)
+	}
+	assert.Equal(t, []share.Share{randShares[idx1], randShares[idx2]}, shares)
+
+	// repeated requests are served from the cache
+	cached, err := serv.GetSharesByNamespaceWithProof(ctx, root, randNID)
+	require.NoError(t, err)
+	assert.Equal(t, rows, cached)
+
+	_, err = serv.GetSharesByNamespaceWithProof(ctx, root, randNID[:4])
+	require.Error(t, err)
+}
+
 func TestService_GetSharesByNamespaceNotFound(t *testing.T) {
 	serv, root := RandServiceWithSquare(t, 1)
 	root.RowsRoots = nil
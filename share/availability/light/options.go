@@ -0,0 +1,20 @@
+package light
+
+// Option is the functional option that is applied to the ShareAvailability instance
+// to configure its sampling.
+type Option func(*ShareAvailability)
+
+// WithSampleAmount sets the amount of Shares sampled from every square.
+func WithSampleAmount(amount int) Option {
+	return func(la *ShareAvailability) {
+		la.sampleAmount = amount
+	}
+}
+
+// WithTargetConfidence sets the probability of availability sampling has to reach for every
+// square. The amount of samples is raised to the one needed to reach it, if not enough.
+func WithTargetConfidence(confidence float64) Option {
+	return func(la *ShareAvailability) {
+		la.targetConfidence = confidence
+	}
+}
@@ -3,6 +3,7 @@ package light
 
 import (
 	crand "crypto/rand"
+	"math"
 	"math/big"
 )
 
@@ -10,6 +11,15 @@ import (
 // ShareAvailability.
 var DefaultSampleAmount = 16
 
+// SampleAmountFor returns the amount of samples needed to reach the given probability of
+// availability over squares of any width. It returns 0 for confidence out of the (0, 1) range.
+func SampleAmountFor(confidence float64) int {
+	if confidence <= 0 || confidence >= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log(1-confidence) / math.Log(0.75)))
+}
+
 // Sample is a point in 2D space over square.
 type Sample struct {
 	Row, Col int
@@ -41,7 +51,7 @@ func newSquareSampler(squareWidth int, expectedSamples int) *squareSampler {
 // generateSample randomly picks unique point on a 2D spaces.
 func (ss *squareSampler) generateSample(num int) error {
 	if num > ss.squareWidth*ss.squareWidth {
-		num = ss.squareWidth
+		num = ss.squareWidth * ss.squareWidth
 	}
 
 	done := 0
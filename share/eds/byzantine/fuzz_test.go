@@ -0,0 +1,40 @@
+package byzantine
+
+import (
+	"testing"
+
+	core "github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-node/header"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine/pb"
+)
+
+func FuzzBadEncodingProof(f *testing.F) {
+	dah := header.EmptyDAH()
+	h := &header.ExtendedHeader{
+		RawHeader: core.Header{Height: 1},
+		DAH:       &dah,
+	}
+
+	seed, err := (&pb.BadEncoding{
+		Height: 1,
+		Shares: []*pb.Share{
+			{Data: make([]byte, 16), Proof: &pb.MerkleProof{End: 1, Nodes: [][]byte{dah.RowsRoots[0]}}},
+			{Data: make([]byte, 4), Proof: &pb.MerkleProof{Start: 1, End: 2}},
+		},
+	}).Marshal()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// malformed proofs must be rejected with an error, rather than panic
+		proof := new(BadEncodingProof)
+		if err := proof.UnmarshalBinary(data); err != nil {
+			return
+		}
+		proof.Validate(h) //nolint:errcheck
+	})
+}
@@ -42,6 +42,11 @@ func NewShareWithProof(index int, share share.Share, pathToLeaf []cid.Cid) *Shar
 
 // Validate validates inclusion of the share under the given root CID.
 func (s *ShareWithProof) Validate(root cid.Cid) bool {
+	// the share or its proof may be malformed, e.g. received from a malicious peer, so ensure the
+	// proof covers the single share, as NMT panics otherwise
+	if len(s.Share) < share.NamespaceSize || s.Proof.Start() < 0 || s.Proof.End()-s.Proof.Start() != 1 {
+		return false
+	}
 	return s.Proof.VerifyInclusion(
 		sha256.New(), // TODO(@Wondertan): This should be defined somewhere globally
 		share.ID(s.Share),
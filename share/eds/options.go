@@ -0,0 +1,81 @@
+package eds
+
+import (
+	"fmt"
+	"time"
+
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+)
+
+// Option is the functional option that is applied to the store instance
+// to configure store parameters.
+type Option func(*Parameters)
+
+// Parameters is the set of parameters that must be configured for the store.
+type Parameters struct {
+	// Remote is the remote tier the CAR files are offloaded to. If nil, all the CAR files are kept
+	// on local disk.
+	Remote Remote
+
+	// OffloadAfter defines the age of the CAR files after which they are offloaded to the Remote.
+	// Zero disables the automatic offloading, though the CAR files can still be offloaded with
+	// Store.Offload.
+	OffloadAfter time.Duration
+
+	// OffloadInterval defines how often the CAR files older than OffloadAfter are offloaded and the
+	// local copies of the CAR files fetched from the Remote are evicted.
+	OffloadInterval time.Duration
+
+	// Blockstore is the blockstore the squares are kept in as IPLD blocks as well, e.g. to be served
+	// over Bitswap. The blocks of the offloaded CAR files are deleted from it, so that the squares
+	// leave the local disk entirely.
+	Blockstore blockstore.Blockstore
+}
+
+// DefaultParameters returns the default params to configure the store.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		OffloadInterval: time.Hour,
+	}
+}
+
+func (p *Parameters) Validate() error {
+	if p.OffloadAfter < 0 {
+		return fmt.Errorf("invalid offload age: value should not be negative")
+	}
+	if p.OffloadInterval <= 0 {
+		return fmt.Errorf("invalid offload interval: value should be positive and non-zero")
+	}
+	return nil
+}
+
+// WithRemote is a functional option that configures the `Remote` parameter.
+func WithRemote(remote Remote) Option {
+	return func(p *Parameters) {
+		p.Remote = remote
+	}
+}
+
+// WithOffloadAfter is a functional option that configures the
+// `OffloadAfter` parameter.
+func WithOffloadAfter(age time.Duration) Option {
+	return func(p *Parameters) {
+		p.OffloadAfter = age
+	}
+}
+
+// WithOffloadInterval is a functional option that configures the
+// `OffloadInterval` parameter.
+func WithOffloadInterval(interval time.Duration) Option {
+	return func(p *Parameters) {
+		p.OffloadInterval = interval
+	}
+}
+
+// WithBlockstore is a functional option that configures the
+// `Blockstore` parameter.
+func WithBlockstore(bs blockstore.Blockstore) Option {
+	return func(p *Parameters) {
+		p.Blockstore = bs
+	}
+}
@@ -0,0 +1,94 @@
+package eds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/filecoin-project/dagstore/mount"
+)
+
+const remoteScheme = "remote"
+
+// ErrNotOffloaded is returned by the Remote when it doesn't hold the requested CAR file.
+var ErrNotOffloaded = errors.New("eds: CAR file is not offloaded")
+
+// Remote is the remote tier of the Store, e.g. an object storage, the old CAR files are offloaded
+// to.
+type Remote interface {
+	// Put uploads the CAR file of the given size under the given key.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get downloads the CAR file under the given key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Stat reports the size of the CAR file under the given key.
+	Stat(ctx context.Context, key string) (int64, error)
+	// Delete deletes the CAR file under the given key.
+	Delete(ctx context.Context, key string) error
+}
+
+// remoteMount is the DAGStore mount of a CAR file offloaded to the Remote.
+// It only supports sequential reads, so that DAGStore caches the CAR file in a local transient
+// copy once fetched.
+type remoteMount struct {
+	remote Remote
+	Key    string
+}
+
+var _ mount.Mount = (*remoteMount)(nil)
+
+func (m *remoteMount) Close() error {
+	return nil
+}
+
+func (m *remoteMount) Fetch(ctx context.Context) (mount.Reader, error) {
+	r, err := m.remote.Get(ctx, m.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &sequentialReader{ReadCloser: r}, nil
+}
+
+func (m *remoteMount) Info() mount.Info {
+	return mount.Info{
+		Kind:             mount.KindRemote,
+		AccessSequential: true,
+	}
+}
+
+func (m *remoteMount) Stat(ctx context.Context) (mount.Stat, error) {
+	size, err := m.remote.Stat(ctx, m.Key)
+	if errors.Is(err, ErrNotOffloaded) {
+		return mount.Stat{Exists: false}, nil
+	}
+	if err != nil {
+		return mount.Stat{}, err
+	}
+	return mount.Stat{Exists: true, Size: size}, nil
+}
+
+func (m *remoteMount) Serialize() *url.URL {
+	return &url.URL{Scheme: remoteScheme, Host: m.Key}
+}
+
+func (m *remoteMount) Deserialize(u *url.URL) error {
+	if u.Host == "" {
+		return fmt.Errorf("invalid host")
+	}
+	m.Key = u.Host
+	return nil
+}
+
+// sequentialReader is the mount.Reader of the CAR files streamed from the Remote.
+type sequentialReader struct {
+	io.ReadCloser
+}
+
+func (r *sequentialReader) ReadAt([]byte, int64) (int, error) {
+	return 0, mount.ErrRandomAccessUnsupported
+}
+
+func (r *sequentialReader) Seek(int64, int) (int64, error) {
+	return 0, mount.ErrSeekUnsupported
+}
@@ -0,0 +1,87 @@
+package eds
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config configures the S3Remote.
+type S3Config struct {
+	// Endpoint is the host of the S3 compatible object storage, e.g. "s3.amazonaws.com" or
+	// "storage.googleapis.com".
+	Endpoint string
+	// Bucket is the bucket the CAR files are stored in.
+	Bucket string
+	// Prefix is prepended to the keys of the CAR files in the bucket.
+	Prefix string
+	// AccessKey and SecretKey are the credentials of the object storage.
+	AccessKey, SecretKey string
+	// Insecure disables TLS, e.g. for local deployments.
+	Insecure bool
+}
+
+// S3Remote is the Remote storing the CAR files in an S3 compatible object storage, including
+// Google Cloud Storage with HMAC keys.
+type S3Remote struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Remote creates a new S3Remote.
+func NewS3Remote(cfg S3Config) (*S3Remote, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: !cfg.Insecure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("eds: creating S3 client: %w", err)
+	}
+	return &S3Remote{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+	}, nil
+}
+
+func (r *S3Remote) Put(ctx context.Context, key string, reader io.Reader, size int64) error {
+	_, err := r.client.PutObject(ctx, r.bucket, r.prefix+key, reader, size, minio.PutObjectOptions{})
+	return err
+}
+
+func (r *S3Remote) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := r.client.GetObject(ctx, r.bucket, r.prefix+key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// objects are requested lazily, so ensure it exists
+	_, err = obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, r.wrap(err)
+	}
+	return obj, nil
+}
+
+func (r *S3Remote) Stat(ctx context.Context, key string) (int64, error) {
+	info, err := r.client.StatObject(ctx, r.bucket, r.prefix+key, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, r.wrap(err)
+	}
+	return info.Size, nil
+}
+
+func (r *S3Remote) Delete(ctx context.Context, key string) error {
+	return r.client.RemoveObject(ctx, r.bucket, r.prefix+key, minio.RemoveObjectOptions{})
+}
+
+func (r *S3Remote) wrap(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %s", ErrNotOffloaded, err)
+	}
+	return err
+}
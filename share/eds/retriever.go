@@ -15,9 +15,11 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/celestiaorg/celestia-app/pkg/da"
 	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/celestia-node/libs/utils"
 	"github.com/celestiaorg/celestia-node/share"
 	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
 	"github.com/celestiaorg/celestia-node/share/ipld"
@@ -44,6 +46,8 @@ var (
 // until it is able to reconstruct the whole square.
 type Retriever struct {
 	bServ blockservice.BlockService
+	// inflight deduplicates the concurrent retrievals of the same data square
+	inflight singleflight.Group
 }
 
 // NewRetriever creates a new instance of the Retriever over IPLD BlockService and rmst2d.Codec
@@ -57,7 +61,17 @@ func NewRetriever(bServ blockservice.BlockService) *Retriever {
 // data square and reconstructs the other three quadrants (3/4). If the requested quadrant is not
 // available within RetrieveQuadrantTimeout, it starts requesting another quadrant until either the
 // data is reconstructed, context is canceled or ErrByzantine is generated.
+//
+// The concurrent retrievals of the same data square, e.g. from the DASer and the RPC, share a single
+// one keyed by the DataHash.
 func (r *Retriever) Retrieve(ctx context.Context, dah *da.DataAvailabilityHeader) (*rsmt2d.ExtendedDataSquare, error) {
+	return utils.DoShared(ctx, &r.inflight, string(dah.Hash()),
+		func(ctx context.Context) (*rsmt2d.ExtendedDataSquare, error) {
+			return r.retrieve(ctx, dah)
+		})
+}
+
+func (r *Retriever) retrieve(ctx context.Context, dah *da.DataAvailabilityHeader) (*rsmt2d.ExtendedDataSquare, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel() // cancels all the ongoing requests if reconstruction succeeds early
 
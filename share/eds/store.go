@@ -2,15 +2,19 @@ package eds
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/filecoin-project/dagstore"
 	"github.com/filecoin-project/dagstore/index"
 	"github.com/filecoin-project/dagstore/mount"
 	"github.com/filecoin-project/dagstore/shard"
 	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/ipld/go-car"
 
 	"github.com/celestiaorg/celestia-node/share"
 
@@ -23,10 +27,16 @@ const (
 	transientsPath = "/transients/"
 )
 
+// ErrNoRemote is returned on attempt to offload a CAR file without the Remote configured.
+var ErrNoRemote = errors.New("eds: remote tier is not configured")
+
 // Store maintains (via DAGStore) a top-level index enabling granular and efficient random access to
 // every share and/or Merkle proof over every registered CARv1 file. The EDSStore provides a custom
 // Blockstore interface implementation to achieve access. The main use-case is randomized sampling
 // over the whole chain of EDS block data and getting data by namespace.
+//
+// Optionally, the Store tiers the CAR files: the recent ones are kept on local disk, while the old
+// ones are offloaded to the Remote and fetched back lazily, with a local read-through cache.
 type Store struct {
 	dgstr  *dagstore.DAGStore
 	mounts *mount.Registry
@@ -35,11 +45,25 @@ type Store struct {
 	carIdx index.FullIndexRepo
 
 	basepath string
+
+	Params *Parameters
+
+	cancel context.CancelFunc
+	done   chan struct{}
 }
 
 // NewStore creates a new EDS Store under the given basepath and datastore.
-func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
-	err := setupPath(basepath)
+func NewStore(basepath string, ds datastore.Batching, opts ...Option) (*Store, error) {
+	params := DefaultParameters()
+	for _, opt := range opts {
+		opt(params)
+	}
+	err := params.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("eds: invalid store parameters: %w", err)
+	}
+
+	err = setupPath(basepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup eds.Store directories: %w", err)
 	}
@@ -49,6 +73,12 @@ func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to register FS mount on the registry: %w", err)
 	}
+	if params.Remote != nil {
+		err = r.Register(remoteScheme, &remoteMount{remote: params.Remote})
+		if err != nil {
+			return nil, fmt.Errorf("failed to register remote mount on the registry: %w", err)
+		}
+	}
 
 	fsRepo, err := index.NewFSRepo(basepath + indexPath)
 	if err != nil {
@@ -75,16 +105,33 @@ func NewStore(basepath string, ds datastore.Batching) (*Store, error) {
 		topIdx:   invertedRepo,
 		carIdx:   fsRepo,
 		mounts:   r,
+		Params:   params,
 	}, nil
 }
 
-// Start starts the underlying DAGStore.
+// Start starts the underlying DAGStore and, if the Remote is configured, the offloading of the old
+// CAR files.
 func (s *Store) Start(ctx context.Context) error {
-	return s.dgstr.Start(ctx)
+	err := s.dgstr.Start(ctx)
+	if err != nil {
+		return err
+	}
+	if s.Params.Remote == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+	go s.offloadLoop(ctx)
+	return nil
 }
 
 // Stop stops the underlying DAGStore.
 func (s *Store) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
 	return s.dgstr.Close()
 }
 
@@ -93,12 +140,22 @@ func (s *Store) Stop() error {
 // The square is verified on the Exchange level, and Put only stores the square, trusting it.
 // The resulting file stores all the shares and NMT Merkle Proofs of the EDS.
 // Additionally, the file gets indexed s.t. store.Blockstore can access them.
+// Put is a no-op for the squares already stored, including the offloaded ones.
 func (s *Store) Put(ctx context.Context, root share.Root, square *rsmt2d.ExtendedDataSquare) error {
+	has, err := s.Has(ctx, root)
+	if err != nil && !errors.Is(err, dagstore.ErrShardUnknown) {
+		return fmt.Errorf("failed to check shard presence: %w", err)
+	}
+	if has {
+		return nil
+	}
+
 	key := root.String()
 	f, err := os.OpenFile(s.basepath+blocksPath+key, os.O_CREATE|os.O_WRONLY, 0600)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	err = WriteEDS(ctx, square, f)
 	if err != nil {
@@ -179,12 +236,168 @@ func (s *Store) Remove(ctx context.Context, root share.Root) error {
 	}
 
 	err = os.Remove(s.basepath + blocksPath + key)
+	if errors.Is(err, os.ErrNotExist) && s.Params.Remote != nil {
+		// the CAR file was offloaded
+		err = s.Params.Remote.Delete(ctx, key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove CAR file: %w", err)
+	}
+	return nil
+}
+
+// Offload moves the CAR file of the EDS with the given share.Root from local disk to the Remote.
+// The CAR file is fetched back lazily on access and cached in a local transient copy, until the
+// next offloading round evicts it.
+func (s *Store) Offload(ctx context.Context, root share.Root) error {
+	if s.Params.Remote == nil {
+		return ErrNoRemote
+	}
+	return s.offload(ctx, root.String())
+}
+
+func (s *Store) offload(ctx context.Context, key string) error {
+	path := s.basepath + blocksPath + key
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open CAR file: %w", err)
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat CAR file: %w", err)
+	}
+	err = s.Params.Remote.Put(ctx, key, f, stat.Size())
+	if err != nil {
+		return fmt.Errorf("failed to upload CAR file: %w", err)
+	}
+
+	// re-register the shard under the remote mount, using the local file as its transient copy
+	// one last time, so that the shard is re-indexed without downloading the file
+	ch := make(chan dagstore.ShardResult, 1)
+	err = s.dgstr.DestroyShard(ctx, shard.KeyFromString(key), ch, dagstore.DestroyOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to initiate shard destruction: %w", err)
+	}
+	err = waitShard(ctx, ch)
+	if err != nil {
+		return fmt.Errorf("failed to destroy shard: %w", err)
+	}
+	err = s.dgstr.RegisterShard(ctx, shard.KeyFromString(key), &remoteMount{
+		remote: s.Params.Remote,
+		Key:    key,
+	}, ch, dagstore.RegisterOpts{ExistingTransient: path})
+	if err != nil {
+		return fmt.Errorf("failed to initiate shard registration: %w", err)
+	}
+	err = waitShard(ctx, ch)
+	if err != nil {
+		return fmt.Errorf("failed to register shard: %w", err)
+	}
+
+	if s.Params.Blockstore != nil {
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("failed to rewind CAR file: %w", err)
+		}
+		err = deleteBlocks(ctx, s.Params.Blockstore, f)
+		if err != nil {
+			return fmt.Errorf("failed to delete blocks of CAR file: %w", err)
+		}
+	}
+	err = os.Remove(path)
 	if err != nil {
 		return fmt.Errorf("failed to remove CAR file: %w", err)
 	}
+	log.Debugw("offloaded CAR file", "key", key)
 	return nil
 }
 
+// offloadLoop periodically offloads the CAR files older than OffloadAfter and evicts the local
+// copies of the offloaded CAR files which were fetched back.
+func (s *Store) offloadLoop(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.Params.OffloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.Params.OffloadAfter > 0 {
+				err := s.offloadOld(ctx)
+				if err != nil {
+					log.Errorw("offloading old CAR files", "err", err)
+				}
+			}
+			_, err := s.dgstr.GC(ctx)
+			if err != nil {
+				log.Errorw("evicting local copies of CAR files", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// offloadOld offloads all the local CAR files older than OffloadAfter.
+func (s *Store) offloadOld(ctx context.Context) error {
+	entries, err := os.ReadDir(s.basepath + blocksPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if time.Since(info.ModTime()) < s.Params.OffloadAfter {
+			continue
+		}
+		err = s.offload(ctx, entry.Name())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Errorw("offloading CAR file", "key", entry.Name(), "err", err)
+		}
+	}
+	return nil
+}
+
+// deleteBlocks deletes the blocks of the given CAR file from the Blockstore.
+// NOTE: The blocks shared with the squares still kept locally, e.g. of the padding shares, are
+// deleted as well, but those squares are read from the Store first, and the blocks missing from
+// the Blockstore are fetched from the network otherwise.
+func deleteBlocks(ctx context.Context, bs blockstore.Blockstore, r io.Reader) error {
+	carReader, err := car.NewCarReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		block, err := carReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = bs.DeleteBlock(ctx, block.Cid())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func waitShard(ctx context.Context, ch chan dagstore.ShardResult) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-ch:
+		return result.Error
+	}
+}
+
 // Get reads EDS out of Store by given DataRoot.
 //
 // It reads only one quadrant(1/4) of the EDS and verifies the integrity of the stored data by
@@ -194,6 +407,7 @@ func (s *Store) Get(ctx context.Context, root share.Root) (*rsmt2d.ExtendedDataS
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CAR file: %w", err)
 	}
+	defer f.Close()
 	eds, err := ReadEDS(ctx, f, root)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read EDS from CAR file: %w", err)
@@ -214,15 +428,15 @@ func (s *Store) Has(ctx context.Context, root share.Root) (bool, error) {
 
 func setupPath(basepath string) error {
 	perms := os.FileMode(0755)
-	err := os.Mkdir(basepath+blocksPath, perms)
+	err := os.MkdirAll(basepath+blocksPath, perms)
 	if err != nil {
 		return fmt.Errorf("failed to create blocks directory: %w", err)
 	}
-	err = os.Mkdir(basepath+transientsPath, perms)
+	err = os.MkdirAll(basepath+transientsPath, perms)
 	if err != nil {
 		return fmt.Errorf("failed to create transients directory: %w", err)
 	}
-	err = os.Mkdir(basepath+indexPath, perms)
+	err = os.MkdirAll(basepath+indexPath, perms)
 	if err != nil {
 		return fmt.Errorf("failed to create index directory: %w", err)
 	}
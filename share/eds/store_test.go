@@ -1,16 +1,22 @@
 package eds
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/filecoin-project/dagstore/shard"
+	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-datastore"
 	ds_sync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
 	"github.com/ipld/go-car"
 	"github.com/stretchr/testify/require"
 
@@ -44,6 +50,39 @@ func TestEDSStore_PutRegistersShard(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestEDSStore_PutTwice ensures that Putting a stored EDS again is a no-op and that the store
+// keeps the EDS once reopened.
+func TestEDSStore_PutTwice(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	dir := t.TempDir()
+	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
+	edsStore, err := NewStore(dir, ds)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah, eds)
+	require.NoError(t, err)
+	err = edsStore.Put(ctx, dah, eds)
+	require.NoError(t, err)
+	require.NoError(t, edsStore.Stop())
+
+	edsStore, err = NewStore(dir, ds)
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, edsStore.Stop())
+	})
+
+	got, err := edsStore.Get(ctx, dah)
+	require.NoError(t, err)
+	assert.Equal(t, eds.Flattened(), got.Flattened())
+}
+
 // TestEDSStore_PutIndexesEDS ensures that Putting an EDS indexes it into the car index
 func TestEDSStore_PutIndexesEDS(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -133,6 +172,106 @@ func TestEDSStore_Remove(t *testing.T) {
 	assert.ErrorContains(t, err, "no such file or directory")
 }
 
+func TestEDSStore_Offload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	remote := newMapRemote()
+	bs := blockstore.NewBlockstore(ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	edsStore, err := newStore(t, WithRemote(remote), WithBlockstore(bs))
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, edsStore.Stop())
+	})
+
+	eds, dah := randomEDS(t)
+	err = edsStore.Put(ctx, dah, eds)
+	require.NoError(t, err)
+	// keep the blocks of the square in the blockstore as well
+	f, err := os.Open(edsStore.basepath + blocksPath + dah.String())
+	require.NoError(t, err)
+	carReader, err := car.NewCarReader(f)
+	require.NoError(t, err)
+	var cids []cid.Cid
+	for {
+		block, err := carReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, bs.Put(ctx, block))
+		cids = append(cids, block.Cid())
+	}
+	require.NoError(t, f.Close())
+
+	err = edsStore.Offload(ctx, dah)
+	require.NoError(t, err)
+	_, err = os.Stat(edsStore.basepath + blocksPath + dah.String())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	// the blocks of the square left the blockstore along with the CAR file
+	for _, c := range cids {
+		has, err := bs.Has(ctx, c)
+		require.NoError(t, err)
+		assert.False(t, has)
+	}
+	_, err = remote.Stat(ctx, dah.String())
+	require.NoError(t, err)
+	// the shard was re-indexed from the local file
+	assert.Zero(t, remote.gets)
+
+	// the CAR file is fetched from the remote tier once and read from the local copy afterwards
+	for i := 0; i < 2; i++ {
+		retrievedEDS, err := edsStore.Get(ctx, dah)
+		require.NoError(t, err)
+		assert.Equal(t, eds.Flattened(), retrievedEDS.Flattened())
+	}
+	assert.Equal(t, 1, remote.gets)
+
+	err = edsStore.Remove(ctx, dah)
+	require.NoError(t, err)
+	_, err = remote.Stat(ctx, dah.String())
+	assert.ErrorIs(t, err, ErrNotOffloaded)
+}
+
+func TestEDSStore_OffloadOld(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	remote := newMapRemote()
+	edsStore, err := newStore(t, WithRemote(remote), WithOffloadAfter(time.Hour))
+	require.NoError(t, err)
+	err = edsStore.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, edsStore.Stop())
+	})
+
+	oldEDS, oldDAH := randomEDS(t)
+	err = edsStore.Put(ctx, oldDAH, oldEDS)
+	require.NoError(t, err)
+	old := time.Now().Add(-time.Hour * 2)
+	err = os.Chtimes(edsStore.basepath+blocksPath+oldDAH.String(), old, old)
+	require.NoError(t, err)
+	recentEDS, recentDAH := randomEDS(t)
+	err = edsStore.Put(ctx, recentDAH, recentEDS)
+	require.NoError(t, err)
+
+	err = edsStore.offloadOld(ctx)
+	require.NoError(t, err)
+	_, err = remote.Stat(ctx, oldDAH.String())
+	assert.NoError(t, err)
+	_, err = remote.Stat(ctx, recentDAH.String())
+	assert.ErrorIs(t, err, ErrNotOffloaded)
+
+	// without the remote tier, nothing is offloaded
+	edsStore, err = newStore(t)
+	require.NoError(t, err)
+	err = edsStore.Offload(ctx, recentDAH)
+	assert.ErrorIs(t, err, ErrNoRemote)
+}
+
 func TestEDSStore_Has(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
@@ -156,12 +295,62 @@ func TestEDSStore_Has(t *testing.T) {
 	assert.True(t, ok)
 }
 
-func newStore(t *testing.T) (*Store, error) {
+func newStore(t *testing.T, opts ...Option) (*Store, error) {
 	t.Helper()
 
 	tmpDir := t.TempDir()
 	ds := ds_sync.MutexWrap(datastore.NewMapDatastore())
-	return NewStore(tmpDir, ds)
+	return NewStore(tmpDir, ds, opts...)
+}
+
+// mapRemote is the Remote keeping the CAR files in memory.
+type mapRemote struct {
+	lk    sync.Mutex
+	files map[string][]byte
+	gets  int
+}
+
+func newMapRemote() *mapRemote {
+	return &mapRemote{files: make(map[string][]byte)}
+}
+
+func (r *mapRemote) Put(_ context.Context, key string, reader io.Reader, _ int64) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.files[key] = data
+	return nil
+}
+
+func (r *mapRemote) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	data, ok := r.files[key]
+	if !ok {
+		return nil, ErrNotOffloaded
+	}
+	r.gets++
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (r *mapRemote) Stat(_ context.Context, key string) (int64, error) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	data, ok := r.files[key]
+	if !ok {
+		return 0, ErrNotOffloaded
+	}
+	return int64(len(data)), nil
+}
+
+func (r *mapRemote) Delete(_ context.Context, key string) error {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	delete(r.files, key)
+	return nil
 }
 
 func randomEDS(t *testing.T) (*rsmt2d.ExtendedDataSquare, share.Root) {
@@ -0,0 +1,23 @@
+package share
+
+import (
+	"context"
+	"errors"
+
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+// ErrNotFound is returned by a Getter when the requested data is not found.
+var ErrNotFound = errors.New("share: data not found")
+
+// Getter provides access to the shares of extended data squares committed to the given Root.
+// Implementations fetch the shares from different sources, e.g. the local store or the network.
+type Getter interface {
+	// GetShare gets the Share at the given coordinates of the extended data square.
+	GetShare(ctx context.Context, root *Root, row, col int) (Share, error)
+	// GetEDS gets the whole extended data square.
+	GetEDS(context.Context, *Root) (*rsmt2d.ExtendedDataSquare, error)
+	// GetSharesByNamespace gets all the shares of the extended data square in the given namespace.ID.
+	GetSharesByNamespace(context.Context, *Root, namespace.ID) ([]Share, error)
+}
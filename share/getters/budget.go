@@ -0,0 +1,62 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is returned by the CascadeGetter when the retrieval budget of a request is
+// exhausted before any of the Tiers succeeds.
+type TimeoutError struct {
+	// Method is the getter method that timed out.
+	Method string
+	// Budget is the total time given to the request.
+	Budget time.Duration
+	// Err holds the errors of the Tiers tried within the budget.
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("getters: %s timed out after %s: %v", e.Method, e.Budget, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes the TimeoutError match context.DeadlineExceeded, so that the callers checking for the
+// latter keep working.
+func (e *TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Timeout reports that the error is a timeout, similarly to net.Error.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// withBudget bounds the context by the given budget, unless its own deadline is sooner.
+// 0 budget leaves the context as it is.
+func withBudget(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	if budget <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, budget)
+}
+
+// split gives the Tier its share of the time left until the deadline of the context, if any, so
+// that the remaining Tiers, including the given one, get equal shares of it. The time left unused
+// by the Tier is split among the following ones. The Timeout of the Tier caps its share.
+func split(ctx context.Context, tier Tier, remaining int) time.Duration {
+	timeout := tier.Timeout
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+	share := time.Until(deadline) / time.Duration(remaining)
+	if timeout <= 0 || share < timeout {
+		timeout = share
+	}
+	return timeout
+}
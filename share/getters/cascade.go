@@ -0,0 +1,150 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+	"go.uber.org/multierr"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds/byzantine"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+var log = logging.Logger("share/getters")
+
+var _ share.Getter = (*CascadeGetter)(nil)
+
+// Tier is a single source of shares the CascadeGetter tries.
+type Tier struct {
+	// Name identifies the Tier in logs and metrics.
+	Name   string
+	Getter share.Getter
+	// Timeout bounds the time given to the Tier, before moving on to the next one.
+	// 0 leaves the Tier bound only by its share of the retrieval budget, if any, and by the context
+	// of the request.
+	Timeout time.Duration
+	// Attempts is the number of times the Tier is tried within its time, as long as the attempts
+	// time out, e.g. to restart slow Bitswap sessions. 0 tries the Tier once.
+	Attempts int
+}
+
+// CascadeGetter is a share.Getter composing multiple Tiers, e.g. the local store, direct peer
+// protocols and Bitswap, from the cheapest to the most expensive one. A request is served by the
+// first Tier succeeding within its timeout, so that retrieval latency stays predictable.
+//
+// The total time of a request is bound by the retrieval budget, which is split across the Tiers
+// and their attempts. Once the budget is exhausted, the request fails with a TimeoutError instead
+// of hanging on slow Tiers.
+type CascadeGetter struct {
+	tiers   []Tier
+	budget  time.Duration
+	metrics *metrics
+}
+
+// NewCascadeGetter creates a new CascadeGetter trying the given Tiers in order, within the given
+// retrieval budget per request. 0 budget leaves the requests bound only by their contexts.
+func NewCascadeGetter(budget time.Duration, tiers ...Tier) *CascadeGetter {
+	return &CascadeGetter{tiers: tiers, budget: budget}
+}
+
+func (cg *CascadeGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	return cascade(ctx, cg, "get_share", func(ctx context.Context, getter share.Getter) (share.Share, error) {
+		return getter.GetShare(ctx, root, row, col)
+	})
+}
+
+func (cg *CascadeGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	get := func(ctx context.Context, getter share.Getter) (*rsmt2d.ExtendedDataSquare, error) {
+		return getter.GetEDS(ctx, root)
+	}
+	return cascade(ctx, cg, "get_eds", get)
+}
+
+func (cg *CascadeGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]share.Share, error) {
+	get := func(ctx context.Context, getter share.Getter) ([]share.Share, error) {
+		return getter.GetSharesByNamespace(ctx, root, nID)
+	}
+	return cascade(ctx, cg, "get_shares_by_namespace", get)
+}
+
+// cascade tries the Tiers in order until one of them succeeds within the retrieval budget.
+// Errors proving the data is malicious are returned right away, as no other Tier can do better.
+func cascade[V any](
+	ctx context.Context,
+	cg *CascadeGetter,
+	method string,
+	get func(context.Context, share.Getter) (V, error),
+) (V, error) {
+	var (
+		zero V
+		errs error
+	)
+	budgetCtx, cancel := withBudget(ctx, cg.budget)
+	defer cancel()
+	for i, tier := range cg.tiers {
+		tierCtx, cancel := budgetCtx, context.CancelFunc(func() {})
+		if timeout := split(budgetCtx, tier, len(cg.tiers)-i); timeout > 0 {
+			tierCtx, cancel = context.WithTimeout(budgetCtx, timeout)
+		}
+		v, err := attempt(tierCtx, cg, tier, method, get)
+		cancel()
+		if err == nil {
+			return v, nil
+		}
+
+		var byzErr *byzantine.ErrByzantine
+		if errors.As(err, &byzErr) {
+			return zero, err
+		}
+		if ctx.Err() != nil {
+			return zero, ctx.Err()
+		}
+		errs = multierr.Append(errs, err)
+		if budgetCtx.Err() != nil {
+			return zero, &TimeoutError{Method: method, Budget: cg.budget, Err: errs}
+		}
+		log.Debugw("tier failed, cascading", "tier", tier.Name, "method", method, "err", err)
+	}
+	if errs == nil {
+		return zero, share.ErrNotFound
+	}
+	return zero, errs
+}
+
+// attempt tries the Tier as many times as configured, while its attempts time out. The time given
+// to the Tier is split equally across the attempts.
+func attempt[V any](
+	ctx context.Context,
+	cg *CascadeGetter,
+	tier Tier,
+	method string,
+	get func(context.Context, share.Getter) (V, error),
+) (V, error) {
+	attempts := tier.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	for i := 0; ; i++ {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if timeout := split(ctx, Tier{}, attempts-i); timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+
+		start := time.Now()
+		v, err := get(attemptCtx, tier.Getter)
+		cancel()
+		cg.metrics.observe(ctx, tier.Name, method, time.Since(start), err)
+		if err == nil || i == attempts-1 || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+			return v, err
+		}
+		log.Debugw("tier attempt timed out, retrying", "tier", tier.Name, "method", method, "attempt", i+1)
+	}
+}
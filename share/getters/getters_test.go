@@ -0,0 +1,224 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	ds_sync "github.com/ipfs/go-datastore/sync"
+	mdutils "github.com/ipfs/go-merkledag/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+func TestStoreGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store, err := eds.NewStore(t.TempDir(), ds_sync.MutexWrap(datastore.NewMapDatastore()))
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(t, store.Stop())
+	})
+
+	square := share.RandEDS(t, 4)
+	root := da.NewDataAvailabilityHeader(square)
+	require.NoError(t, store.Put(ctx, root, square))
+
+	testGetter(ctx, t, NewStoreGetter(store), square, &root)
+
+	// unknown squares are not found
+	other := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	_, err = NewStoreGetter(store).GetEDS(ctx, &other)
+	assert.ErrorIs(t, err, share.ErrNotFound)
+}
+
+func TestIPLDGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	bServ := mdutils.Bserv()
+	square, err := share.AddShares(ctx, share.RandShares(t, 16), bServ)
+	require.NoError(t, err)
+	root := da.NewDataAvailabilityHeader(square)
+
+	testGetter(ctx, t, NewIPLDGetter(bServ), square, &root)
+}
+
+func TestCascadeGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	bServ := mdutils.Bserv()
+	square, err := share.AddShares(ctx, share.RandShares(t, 16), bServ)
+	require.NoError(t, err)
+	root := da.NewDataAvailabilityHeader(square)
+
+	failing := &mockGetter{err: share.ErrNotFound}
+	blocking := &mockGetter{block: true}
+	cg := NewCascadeGetter(
+		0,
+		Tier{Name: "failing", Getter: failing},
+		Tier{Name: "blocking", Getter: blocking, Timeout: time.Millisecond * 50},
+		Tier{Name: "ipld", Getter: NewIPLDGetter(bServ)},
+	)
+	require.NoError(t, cg.InitMetrics())
+
+	// failing and slow tiers are cascaded
+	testGetter(ctx, t, cg, square, &root)
+	assert.NotZero(t, failing.calls)
+	assert.NotZero(t, blocking.calls)
+
+	// errors of all the tiers are reported
+	cg = NewCascadeGetter(0, Tier{Name: "failing", Getter: failing})
+	_, err = cg.GetEDS(ctx, &root)
+	assert.ErrorIs(t, err, share.ErrNotFound)
+
+	// a canceled request is not cascaded further
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	failing.calls = 0
+	cg = NewCascadeGetter(0, Tier{Name: "blocking", Getter: blocking}, Tier{Name: "failing", Getter: failing})
+	_, err = cg.GetEDS(canceledCtx, &root)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, failing.calls)
+}
+
+func TestCascadeGetter_Budget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	root := da.NewDataAvailabilityHeader(share.RandEDS(t, 4))
+	first, second := &mockGetter{block: true}, &mockGetter{block: true}
+	cg := NewCascadeGetter(
+		time.Second,
+		Tier{Name: "first", Getter: first},
+		Tier{Name: "second", Getter: second, Attempts: 2},
+	)
+
+	// the budget is split across the tiers and attempts, instead of hanging on the first tier
+	start := time.Now()
+	_, err := cg.GetEDS(ctx, &root)
+	assert.Less(t, time.Since(start), time.Second*3)
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "get_eds", timeoutErr.Method)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 2, second.calls)
+
+	// the deadline of the request takes over the budget, when it's sooner
+	reqCtx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	cg = NewCascadeGetter(time.Minute, Tier{Name: "first", Getter: first})
+	_, err = cg.GetEDS(reqCtx, &root)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, errors.As(err, &timeoutErr))
+}
+
+func TestPoisonGetter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	bServ := mdutils.Bserv()
+	square, err := share.AddShares(ctx, share.RandShares(t, 16), bServ)
+	require.NoError(t, err)
+	root := da.NewDataAvailabilityHeader(square)
+
+	poisoned := share.NewPoisonList()
+	pg := NewPoisonGetter(NewIPLDGetter(bServ), poisoned)
+	testGetter(ctx, t, pg, square, &root)
+
+	// the data proven fraudulent is not served
+	poisoned.Poison(1, &root)
+	assert.Equal(t, []uint64{1}, poisoned.Heights())
+	_, err = pg.GetEDS(ctx, &root)
+	assert.ErrorIs(t, err, share.ErrPoisoned)
+	_, err = pg.GetShare(ctx, &root, 0, 0)
+	assert.ErrorIs(t, err, share.ErrPoisoned)
+	_, err = pg.GetSharesByNamespace(ctx, &root, share.ID(square.GetCell(0, 0)))
+	assert.ErrorIs(t, err, share.ErrPoisoned)
+
+	// so are the stored blocks of its square
+	for _, hash := range root.RowsRoots {
+		id := ipld.MustCidFromNamespacedSha256(hash)
+		require.True(t, poisoned.IsBlockPoisoned(id))
+		cids, err := ipld.GetStoredCIDs(ctx, bServ, id)
+		require.NoError(t, err)
+		// every node of the tree over the row
+		require.Len(t, cids, len(root.RowsRoots)*2-1)
+		poisoned.PoisonBlocks(cids...)
+		for _, id := range cids {
+			assert.True(t, poisoned.IsBlockPoisoned(id))
+		}
+	}
+	cids, err := ipld.GetStoredCIDs(ctx, bServ, ipld.RandNamespacedCID(t))
+	require.NoError(t, err)
+	assert.Empty(t, cids)
+}
+
+func testGetter(
+	ctx context.Context,
+	t *testing.T,
+	getter share.Getter,
+	square *rsmt2d.ExtendedDataSquare,
+	root *share.Root,
+) {
+	got, err := getter.GetEDS(ctx, root)
+	require.NoError(t, err)
+	gotRoot := da.NewDataAvailabilityHeader(got)
+	assert.True(t, root.Equals(&gotRoot))
+
+	shr, err := getter.GetShare(ctx, root, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, square.GetCell(1, 2), shr)
+	_, err = getter.GetShare(ctx, root, len(root.RowsRoots), 0)
+	assert.Error(t, err)
+
+	nID := namespace.ID(share.ID(square.GetCell(1, 0)))
+	shares, err := getter.GetSharesByNamespace(ctx, root, nID)
+	require.NoError(t, err)
+	assert.Equal(t, []share.Share{square.GetCell(1, 0)}, shares)
+	_, err = getter.GetSharesByNamespace(ctx, root, nID[:4])
+	assert.Error(t, err)
+}
+
+type mockGetter struct {
+	err   error
+	block bool
+	calls int
+}
+
+func (m *mockGetter) GetShare(ctx context.Context, _ *share.Root, _, _ int) (share.Share, error) {
+	return nil, m.get(ctx)
+}
+
+func (m *mockGetter) GetEDS(ctx context.Context, _ *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	return nil, m.get(ctx)
+}
+
+func (m *mockGetter) GetSharesByNamespace(ctx context.Context, _ *share.Root, _ namespace.ID) ([]share.Share, error) {
+	return nil, m.get(ctx)
+}
+
+func (m *mockGetter) get(ctx context.Context) error {
+	m.calls++
+	if m.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if m.err != nil {
+		return m.err
+	}
+	return errors.New("mock: not implemented")
+}
@@ -0,0 +1,91 @@
+package getters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-blockservice"
+	ipldFormat "github.com/ipfs/go-ipld-format"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/celestia-node/share/ipld"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+var _ share.Getter = (*IPLDGetter)(nil)
+
+// IPLDGetter is a share.Getter that retrieves the shares as IPLD blocks, either from the local
+// blockstore or from the network over Bitswap.
+type IPLDGetter struct {
+	rtrv  *eds.Retriever
+	bServ blockservice.BlockService
+}
+
+// NewIPLDGetter creates a new share.Getter over the given blockservice.BlockService.
+func NewIPLDGetter(bServ blockservice.BlockService) *IPLDGetter {
+	return &IPLDGetter{
+		rtrv:  eds.NewRetriever(bServ),
+		bServ: bServ,
+	}
+}
+
+func (ig *IPLDGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	if err := validateCoordinates(root, row, col); err != nil {
+		return nil, err
+	}
+	rootCid, leaf := ipld.Translate(root, row, col)
+	shr, err := share.GetShare(ctx, ig.bServ, rootCid, leaf, len(root.RowsRoots))
+	if ipldFormat.IsNotFound(err) {
+		return nil, share.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getters: getting share over IPLD: %w", err)
+	}
+	return shr, nil
+}
+
+func (ig *IPLDGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	// the Retriever propagates the errors as is, so that byzantine ones are not obscured
+	return ig.rtrv.Retrieve(ctx, root)
+}
+
+func (ig *IPLDGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]share.Share, error) {
+	if err := validateNamespace(nID); err != nil {
+		return nil, err
+	}
+	rows := rowsWithNamespace(root, nID)
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	errGroup, ctx := errgroup.WithContext(ctx)
+	shares := make([][]share.Share, len(rows))
+	for i, row := range rows {
+		// shadow loop variables, to ensure correct values are captured
+		i, rootCid := i, ipld.MustCidFromNamespacedSha256(root.RowsRoots[row])
+		errGroup.Go(func() (err error) {
+			shares[i], err = share.GetSharesByNamespace(ctx, ig.bServ, rootCid, nID, len(root.RowsRoots))
+			return
+		})
+	}
+	err := errGroup.Wait()
+	if ipldFormat.IsNotFound(err) {
+		return nil, share.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getters: getting shares by namespace over IPLD: %w", err)
+	}
+
+	var out []share.Share
+	for _, rowShares := range shares {
+		out = append(out, rowShares...)
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+var meter = global.MeterProvider().Meter("share/getters")
+
+type metrics struct {
+	requests syncint64.Counter
+	latency  syncfloat64.Histogram
+}
+
+// InitMetrics enables Otel metrics of the requests served by each Tier and their latency.
+func (cg *CascadeGetter) InitMetrics() error {
+	requests, err := meter.SyncInt64().Counter("getters_tier_requests_counter",
+		instrument.WithDescription("requests served by a tier of the getter cascade, by their status"))
+	if err != nil {
+		return err
+	}
+
+	latency, err := meter.SyncFloat64().Histogram("getters_tier_latency_hist",
+		instrument.WithDescription("duration of a request to a tier of the getter cascade"))
+	if err != nil {
+		return err
+	}
+
+	cg.metrics = &metrics{
+		requests: requests,
+		latency:  latency,
+	}
+	return nil
+}
+
+func (m *metrics) observe(ctx context.Context, tier, method string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	status := "ok"
+	switch {
+	case errors.Is(err, share.ErrNotFound):
+		status = "not_found"
+	case errors.Is(err, context.DeadlineExceeded):
+		status = "timeout"
+	case err != nil:
+		status = "failed"
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("tier", tier),
+		attribute.String("method", method),
+		attribute.String("status", status),
+	}
+	m.requests.Add(ctx, 1, attrs...)
+	m.latency.Record(ctx, d.Seconds(), attrs...)
+}
@@ -0,0 +1,48 @@
+package getters
+
+import (
+	"context"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+var _ share.Getter = (*PoisonGetter)(nil)
+
+// PoisonGetter is a share.Getter refusing to get the data of the blocks kept in the
+// share.PoisonList with share.ErrPoisoned.
+type PoisonGetter struct {
+	getter   share.Getter
+	poisoned *share.PoisonList
+}
+
+// NewPoisonGetter wraps the given share.Getter with the check against the share.PoisonList.
+func NewPoisonGetter(getter share.Getter, poisoned *share.PoisonList) *PoisonGetter {
+	return &PoisonGetter{getter: getter, poisoned: poisoned}
+}
+
+func (pg *PoisonGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	if pg.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return pg.getter.GetShare(ctx, root, row, col)
+}
+
+func (pg *PoisonGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	if pg.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return pg.getter.GetEDS(ctx, root)
+}
+
+func (pg *PoisonGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]share.Share, error) {
+	if pg.poisoned.IsRootPoisoned(root) {
+		return nil, share.ErrPoisoned
+	}
+	return pg.getter.GetSharesByNamespace(ctx, root, nID)
+}
@@ -0,0 +1,76 @@
+package getters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/filecoin-project/dagstore"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/celestia-node/share/eds"
+	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
+)
+
+var _ share.Getter = (*StoreGetter)(nil)
+
+// StoreGetter is a share.Getter that reads the extended data squares from the local eds.Store.
+type StoreGetter struct {
+	store *eds.Store
+}
+
+// NewStoreGetter creates a new share.Getter over the given eds.Store.
+func NewStoreGetter(store *eds.Store) *StoreGetter {
+	return &StoreGetter{store: store}
+}
+
+func (sg *StoreGetter) GetShare(ctx context.Context, root *share.Root, row, col int) (share.Share, error) {
+	if err := validateCoordinates(root, row, col); err != nil {
+		return nil, err
+	}
+	square, err := sg.GetEDS(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return square.GetCell(uint(row), uint(col)), nil
+}
+
+func (sg *StoreGetter) GetEDS(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	square, err := sg.store.Get(ctx, *root)
+	if errors.Is(err, dagstore.ErrShardUnknown) {
+		return nil, share.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getters: getting EDS from store: %w", err)
+	}
+	return square, nil
+}
+
+func (sg *StoreGetter) GetSharesByNamespace(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]share.Share, error) {
+	if err := validateNamespace(nID); err != nil {
+		return nil, err
+	}
+	rows := rowsWithNamespace(root, nID)
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	square, err := sg.GetEDS(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	var shares []share.Share
+	for _, row := range rows {
+		for _, shr := range square.Row(uint(row)) {
+			if nID.Equal(share.ID(shr)) {
+				shares = append(shares, shr)
+			}
+		}
+	}
+	return shares, nil
+}
@@ -0,0 +1,36 @@
+package getters
+
+import (
+	"fmt"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// rowsWithNamespace returns the indexes of the rows of the square, which may contain shares of the
+// given namespace.ID.
+func rowsWithNamespace(root *share.Root, nID namespace.ID) []int {
+	var rows []int
+	for i, row := range root.RowsRoots {
+		if !nID.Less(nmt.MinNamespace(row, nID.Size())) && nID.LessOrEqual(nmt.MaxNamespace(row, nID.Size())) {
+			rows = append(rows, i)
+		}
+	}
+	return rows
+}
+
+func validateNamespace(nID namespace.ID) error {
+	if len(nID) != share.NamespaceSize {
+		return fmt.Errorf("getters: expected namespace ID of size %d, got %d", share.NamespaceSize, len(nID))
+	}
+	return nil
+}
+
+func validateCoordinates(root *share.Root, row, col int) error {
+	width := len(root.RowsRoots)
+	if row < 0 || row >= width || col < 0 || col >= width {
+		return fmt.Errorf("getters: coordinates (%d, %d) out of square of width %d", row, col, width)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package ipld
+
+import (
+	"context"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+)
+
+// BlockCache is a read-through cache of blocks in front of a blockstore.Blockstore.
+// It keeps both the recently and frequently read NMT nodes in a 2Q cache, so that repeated
+// retrievals of the same data square, e.g. during reconstruction or namespace queries, are served
+// from memory rather than from disk.
+type BlockCache struct {
+	blockstore.Blockstore
+
+	// cache is nil if caching is disabled.
+	cache        *lru.TwoQueueCache
+	hits, misses int64
+}
+
+// NewBlockCache wraps the given blockstore.Blockstore with a cache of blocks of the given size in
+// bytes. Size of 0 disables caching.
+func NewBlockCache(bs blockstore.Blockstore, size int) (*BlockCache, error) {
+	bc := &BlockCache{Blockstore: bs}
+	// the amount of cached blocks is bound assuming all of them are of the largest size, i.e. leaves
+	if amount := size / leafNodeSize; amount > 0 {
+		cache, err := lru.New2Q(amount)
+		if err != nil {
+			return nil, err
+		}
+		bc.cache = cache
+	}
+	return bc, nil
+}
+
+// Get returns the block from the cache, if any, and reads it through from the blockstore
+// otherwise.
+func (bc *BlockCache) Get(ctx context.Context, id cid.Cid) (blocks.Block, error) {
+	if bc.cache == nil {
+		return bc.Blockstore.Get(ctx, id)
+	}
+	if b, ok := bc.cache.Get(id); ok {
+		atomic.AddInt64(&bc.hits, 1)
+		return b.(blocks.Block), nil
+	}
+
+	atomic.AddInt64(&bc.misses, 1)
+	b, err := bc.Blockstore.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// blocks other than NMT nodes are not expected, but are not cached to keep the size bound
+	if len(b.RawData()) <= leafNodeSize {
+		bc.cache.Add(id, b)
+	}
+	return b, nil
+}
+
+func (bc *BlockCache) GetSize(ctx context.Context, id cid.Cid) (int, error) {
+	if bc.cache != nil {
+		if b, ok := bc.cache.Peek(id); ok {
+			return len(b.(blocks.Block).RawData()), nil
+		}
+	}
+	return bc.Blockstore.GetSize(ctx, id)
+}
+
+func (bc *BlockCache) Has(ctx context.Context, id cid.Cid) (bool, error) {
+	if bc.cache != nil && bc.cache.Contains(id) {
+		return true, nil
+	}
+	return bc.Blockstore.Has(ctx, id)
+}
+
+func (bc *BlockCache) DeleteBlock(ctx context.Context, id cid.Cid) error {
+	if bc.cache != nil {
+		bc.cache.Remove(id)
+	}
+	return bc.Blockstore.DeleteBlock(ctx, id)
+}
+
+// Stats returns the amount of cache hits and misses since the BlockCache was created, along with
+// the amount of currently cached blocks.
+func (bc *BlockCache) Stats() (hits, misses int64, cached int) {
+	if bc.cache != nil {
+		cached = bc.cache.Len()
+	}
+	return atomic.LoadInt64(&bc.hits), atomic.LoadInt64(&bc.misses), cached
+}
@@ -0,0 +1,61 @@
+package ipld
+
+import (
+	"context"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bs := blockstore.NewBlockstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	bc, err := NewBlockCache(bs, 2*leafNodeSize)
+	require.NoError(t, err)
+
+	blks := make([]blocks.Block, 3)
+	for i := range blks {
+		blks[i] = blocks.NewBlock([]byte{byte(i)})
+		require.NoError(t, bc.Put(ctx, blks[i]))
+	}
+
+	// the first read goes through to the blockstore, while the next ones are cached
+	for i := 0; i < 2; i++ {
+		b, err := bc.Get(ctx, blks[0].Cid())
+		require.NoError(t, err)
+		assert.Equal(t, blks[0].RawData(), b.RawData())
+	}
+	hits, misses, cached := bc.Stats()
+	assert.EqualValues(t, 1, hits)
+	assert.EqualValues(t, 1, misses)
+	assert.Equal(t, 1, cached)
+
+	// deleted blocks are not served from the cache
+	require.NoError(t, bc.DeleteBlock(ctx, blks[0].Cid()))
+	_, err = bc.Get(ctx, blks[0].Cid())
+	require.Error(t, err)
+
+	// the cache does not grow over its size
+	for _, b := range blks[1:] {
+		_, err = bc.Get(ctx, b.Cid())
+		require.NoError(t, err)
+	}
+	_, _, cached = bc.Stats()
+	assert.LessOrEqual(t, cached, 2)
+
+	// 0 disables caching
+	bc, err = NewBlockCache(bs, 0)
+	require.NoError(t, err)
+	_, err = bc.Get(ctx, blks[1].Cid())
+	require.NoError(t, err)
+	hits, misses, cached = bc.Stats()
+	assert.Zero(t, hits+misses)
+	assert.Zero(t, cached)
+}
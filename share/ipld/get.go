@@ -387,3 +387,28 @@ type job struct {
 	pos int
 	ctx context.Context
 }
+
+// GetStoredCIDs walks the tree under the given root through the blocks the given BlockGetter has,
+// e.g. an offline one, and returns the CIDs of all the nodes found. The subtrees of the missing
+// nodes are skipped.
+func GetStoredCIDs(ctx context.Context, bGetter blockservice.BlockGetter, root cid.Cid) ([]cid.Cid, error) {
+	var cids []cid.Cid
+	queue := []cid.Cid{root}
+	for len(queue) > 0 {
+		id := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		nd, err := GetNode(ctx, bGetter, id)
+		if err != nil {
+			if ipld.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		cids = append(cids, id)
+		for _, lnk := range nd.Links() {
+			queue = append(queue, lnk.Cid)
+		}
+	}
+	return cids, nil
+}
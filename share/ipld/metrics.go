@@ -0,0 +1,47 @@
+package ipld
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+var meter = global.MeterProvider().Meter("ipld")
+
+// WithMetrics enables Otel metrics to monitor the efficiency of the BlockCache.
+func WithMetrics(bc *BlockCache) {
+	hitsC, _ := meter.AsyncInt64().Counter(
+		"ipld_block_cache_hits",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Amount of blocks read from the block cache"),
+	)
+	missesC, _ := meter.AsyncInt64().Counter(
+		"ipld_block_cache_misses",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Amount of blocks read through the block cache from the blockstore"),
+	)
+	cachedG, _ := meter.AsyncInt64().Gauge(
+		"ipld_block_cache_blocks",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("Amount of blocks currently kept in the block cache"),
+	)
+
+	err := meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			hitsC,
+			missesC,
+			cachedG,
+		},
+		func(ctx context.Context) {
+			hits, misses, cached := bc.Stats()
+			hitsC.Observe(ctx, hits)
+			missesC.Observe(ctx, misses)
+			cachedG.Observe(ctx, int64(cached))
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,228 @@
+package ipld
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bsmsg "github.com/ipfs/go-bitswap/message"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	// requestsWindow is the window the requests of a peer are counted in.
+	requestsWindow = time.Minute
+	// bytesWindow is the window the bytes served to a peer are counted in.
+	bytesWindow = time.Hour
+
+	requestsQuota = "requests"
+	bytesQuota    = "bytes"
+)
+
+// peerUsage is the usage of the quotas by a peer in the current windows.
+type peerUsage struct {
+	requests      int
+	requestsSince time.Time
+	bytes         uint64
+	bytesSince    time.Time
+	bannedUntil   time.Time
+}
+
+// ServeQuota enforces the quotas of the requests per minute and the bytes per hour on the blocks of
+// shares served to every remote peer over Bitswap, protecting the public nodes from scraping. The
+// peers exceeding any of the quotas are temporarily banned, i.e. their requests are denied.
+type ServeQuota struct {
+	requestsPerMinute int
+	bytesPerHour      uint64
+	banDuration       time.Duration
+
+	lk        sync.Mutex
+	peers     map[peer.ID]*peerUsage
+	lastSweep time.Time
+
+	metrics atomic.Pointer[quotaMetrics]
+}
+
+// NewServeQuota creates a new ServeQuota. Either of the quotas is disabled, if 0.
+func NewServeQuota(requestsPerMinute int, bytesPerHour uint64, banDuration time.Duration) *ServeQuota {
+	return &ServeQuota{
+		requestsPerMinute: requestsPerMinute,
+		bytesPerHour:      bytesPerHour,
+		banDuration:       banDuration,
+		peers:             make(map[peer.ID]*peerUsage),
+		lastSweep:         time.Now(),
+	}
+}
+
+// SetRequestsPerMinute changes the quota of the requests per minute, disabling it, if 0.
+// The current usages and bans of the peers are kept.
+func (q *ServeQuota) SetRequestsPerMinute(requestsPerMinute int) {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	q.requestsPerMinute = requestsPerMinute
+}
+
+// SetBytesPerHour changes the quota of the bytes per hour, disabling it, if 0.
+// The current usages and bans of the peers are kept.
+func (q *ServeQuota) SetBytesPerHour(bytesPerHour uint64) {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	q.bytesPerHour = bytesPerHour
+}
+
+// SetBanDuration changes the duration of the bans to come.
+func (q *ServeQuota) SetBanDuration(banDuration time.Duration) {
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	q.banDuration = banDuration
+}
+
+type quotaMetrics struct {
+	hits syncint64.Counter
+}
+
+// InitMetrics enables Otel metrics of the hits of the quotas and the currently banned peers.
+func (q *ServeQuota) InitMetrics() error {
+	hits, err := meter.SyncInt64().Counter("ipld_serve_quota_hits_counter",
+		instrument.WithDescription("peers banned for exceeding a quota of serving the blocks of shares"))
+	if err != nil {
+		return err
+	}
+	banned, err := meter.AsyncInt64().Gauge("ipld_serve_banned_peers",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("peers currently banned for exceeding a quota of serving the blocks of shares"))
+	if err != nil {
+		return err
+	}
+
+	err = meter.RegisterCallback(
+		[]instrument.Asynchronous{banned},
+		func(ctx context.Context) {
+			banned.Observe(ctx, int64(q.banned()))
+		},
+	)
+	if err != nil {
+		return err
+	}
+	q.metrics.Store(&quotaMetrics{hits: hits})
+	return nil
+}
+
+// Allow implements bitswap.PeerBlockRequestFilter, denying the requests for the blocks of shares
+// of the banned peers and of the ones exceeding the quota of requests.
+func (q *ServeQuota) Allow(p peer.ID, id cid.Cid) bool {
+	if id.Type() != nmtCodec {
+		return true
+	}
+
+	now := time.Now()
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	q.sweep(now)
+
+	usage := q.usage(p)
+	if now.Before(usage.bannedUntil) {
+		return false
+	}
+	if q.requestsPerMinute <= 0 {
+		return true
+	}
+	if now.Sub(usage.requestsSince) >= requestsWindow {
+		usage.requests, usage.requestsSince = 0, now
+	}
+	usage.requests++
+	if usage.requests > q.requestsPerMinute {
+		q.ban(p, usage, now, requestsQuota)
+		return false
+	}
+	return true
+}
+
+// MessageReceived implements bitswap.Tracer.
+func (q *ServeQuota) MessageReceived(peer.ID, bsmsg.BitSwapMessage) {}
+
+// MessageSent implements bitswap.Tracer, accounting the bytes of the blocks of shares served to the
+// peer and banning it once it exceeds the quota of bytes.
+func (q *ServeQuota) MessageSent(p peer.ID, msg bsmsg.BitSwapMessage) {
+	var size uint64
+	for _, block := range msg.Blocks() {
+		if block.Cid().Type() == nmtCodec {
+			size += uint64(len(block.RawData()))
+		}
+	}
+	if size == 0 {
+		return
+	}
+
+	now := time.Now()
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	if q.bytesPerHour == 0 {
+		return
+	}
+	usage := q.usage(p)
+	if now.Sub(usage.bytesSince) >= bytesWindow {
+		usage.bytes, usage.bytesSince = 0, now
+	}
+	usage.bytes += size
+	if usage.bytes > q.bytesPerHour && !now.Before(usage.bannedUntil) {
+		q.ban(p, usage, now, bytesQuota)
+	}
+}
+
+// usage returns the usage of the peer, creating it, if missing. It must be called under the lock.
+func (q *ServeQuota) usage(p peer.ID) *peerUsage {
+	usage, ok := q.peers[p]
+	if !ok {
+		usage = &peerUsage{}
+		q.peers[p] = usage
+	}
+	return usage
+}
+
+// ban bans the peer for exceeding the quota. It must be called under the lock.
+func (q *ServeQuota) ban(p peer.ID, usage *peerUsage, now time.Time, quota string) {
+	usage.bannedUntil = now.Add(q.banDuration)
+	log.Warnw("peer exceeded quota of serving shares, banning", "peer", p, "quota", quota,
+		"requests", usage.requests, "bytes", usage.bytes, "until", usage.bannedUntil)
+	if metrics := q.metrics.Load(); metrics != nil {
+		metrics.hits.Add(context.Background(), 1, attribute.String("quota", quota))
+	}
+}
+
+// sweep drops the usages of the peers with all the windows and bans expired, at most once per the
+// requests window, so that the usages of the peers gone don't pile up. It must be called under the
+// lock.
+func (q *ServeQuota) sweep(now time.Time) {
+	if now.Sub(q.lastSweep) < requestsWindow {
+		return
+	}
+	q.lastSweep = now
+	for p, usage := range q.peers {
+		if now.Sub(usage.requestsSince) >= requestsWindow &&
+			now.Sub(usage.bytesSince) >= bytesWindow &&
+			!now.Before(usage.bannedUntil) {
+			delete(q.peers, p)
+		}
+	}
+}
+
+// banned returns the amount of the currently banned peers.
+func (q *ServeQuota) banned() int {
+	now := time.Now()
+	q.lk.Lock()
+	defer q.lk.Unlock()
+	var banned int
+	for _, usage := range q.peers {
+		if now.Before(usage.bannedUntil) {
+			banned++
+		}
+	}
+	return banned
+}
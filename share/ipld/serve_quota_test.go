@@ -0,0 +1,73 @@
+package ipld
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	bsmsg "github.com/ipfs/go-bitswap/message"
+	"github.com/libp2p/go-libp2p-core/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeQuota_Requests(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+	other, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	ns := bytes.Repeat([]byte{1}, NamespaceSize)
+	block := testBlock(t, ns, ns)
+
+	q := NewServeQuota(2, 0, time.Minute)
+	require.NoError(t, q.InitMetrics())
+	assert.True(t, q.Allow(p, block.Cid()))
+	assert.True(t, q.Allow(p, block.Cid()))
+	// the peer is banned once it exceeds the quota
+	assert.False(t, q.Allow(p, block.Cid()))
+	assert.Equal(t, 1, q.banned())
+	// the other peers are not affected
+	assert.True(t, q.Allow(other, block.Cid()))
+
+	// the ban expires
+	q.peers[p].bannedUntil = time.Now()
+	q.peers[p].requestsSince = time.Now().Add(-requestsWindow)
+	assert.True(t, q.Allow(p, block.Cid()))
+}
+
+func TestServeQuota_Bytes(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	ns := bytes.Repeat([]byte{1}, NamespaceSize)
+	block := testBlock(t, ns, ns)
+
+	q := NewServeQuota(0, uint64(len(block.RawData())), time.Minute)
+	msg := bsmsg.New(false)
+	msg.AddBlock(block)
+
+	q.MessageSent(p, msg)
+	assert.True(t, q.Allow(p, block.Cid()))
+	q.MessageSent(p, msg)
+	assert.False(t, q.Allow(p, block.Cid()))
+}
+
+func TestServeQuota_Set(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	ns := bytes.Repeat([]byte{1}, NamespaceSize)
+	block := testBlock(t, ns, ns)
+
+	q := NewServeQuota(0, 0, time.Minute)
+	assert.True(t, q.Allow(p, block.Cid()))
+	// the quota enabled on reload applies right away
+	q.SetRequestsPerMinute(1)
+	assert.True(t, q.Allow(p, block.Cid()))
+	assert.False(t, q.Allow(p, block.Cid()))
+
+	q.SetRequestsPerMinute(0)
+	q.peers[p].bannedUntil = time.Now()
+	assert.True(t, q.Allow(p, block.Cid()))
+}
@@ -0,0 +1,190 @@
+package ipld
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bsmsg "github.com/ipfs/go-bitswap/message"
+	pb "github.com/ipfs/go-bitswap/message/pb"
+	"github.com/ipfs/go-cid"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// accessLog is the log of the blocks of shares served to the peers, if enabled.
+var accessLog = logging.Logger("share/access")
+
+const (
+	// maxPendingWants bounds the amount of the wanted blocks kept to measure the latency of serving
+	// them, as the peers may never cancel their wants.
+	maxPendingWants = 1 << 16
+	// maxNamespaces bounds the amount of the distinct namespaces the metrics are reported for, not
+	// to explode the cardinality of the metrics. The rest of the namespaces are reported as
+	// otherNamespace.
+	maxNamespaces = 1024
+
+	// mixedNamespace is reported for the inner nodes spanning multiple namespaces.
+	mixedNamespace = "mixed"
+	// otherNamespace is reported for the namespaces beyond maxNamespaces.
+	otherNamespace = "other"
+)
+
+type wantKey struct {
+	peer peer.ID
+	cid  cid.Cid
+}
+
+// ServeTracer traces the blocks of shares served over Bitswap, so that the load is attributed to
+// the namespaces, i.e. rollups, requesting it. It reports the requests, the bytes served and the
+// latency of serving them per namespace as metrics, once InitMetrics is called, and logs every
+// served block along with the requesting peer to the access log, if enabled.
+type ServeTracer struct {
+	accessLog bool
+
+	lk         sync.Mutex
+	wants      map[wantKey]time.Time
+	namespaces map[string]struct{}
+
+	// metrics are set concurrently with the tracing, as Bitswap serves right away once created
+	metrics atomic.Pointer[serveMetrics]
+}
+
+// NewServeTracer creates a new ServeTracer, logging the served blocks, if 'accessLog' is set.
+func NewServeTracer(accessLog bool) *ServeTracer {
+	return &ServeTracer{
+		accessLog:  accessLog,
+		wants:      make(map[wantKey]time.Time),
+		namespaces: make(map[string]struct{}),
+	}
+}
+
+type serveMetrics struct {
+	requests syncint64.Counter
+	bytes    syncint64.Counter
+	latency  syncfloat64.Histogram
+}
+
+// InitMetrics enables Otel metrics of the requests for the blocks of shares, the bytes served and
+// the latency of serving them per namespace.
+func (st *ServeTracer) InitMetrics() error {
+	requests, err := meter.SyncInt64().Counter("ipld_serve_requests_counter",
+		instrument.WithDescription("blocks of shares requested by the peers per namespace"))
+	if err != nil {
+		return err
+	}
+
+	bytes, err := meter.SyncInt64().Counter("ipld_serve_bytes_counter",
+		instrument.WithUnit(unit.Bytes),
+		instrument.WithDescription("bytes of the blocks of shares served to the peers per namespace"))
+	if err != nil {
+		return err
+	}
+
+	latency, err := meter.SyncFloat64().Histogram("ipld_serve_latency_hist",
+		instrument.WithDescription("duration between a block of shares is requested and served per namespace"))
+	if err != nil {
+		return err
+	}
+
+	st.metrics.Store(&serveMetrics{
+		requests: requests,
+		bytes:    bytes,
+		latency:  latency,
+	})
+	return nil
+}
+
+// MessageReceived implements bitswap.Tracer, accounting the blocks of shares wanted by the peer.
+func (st *ServeTracer) MessageReceived(p peer.ID, msg bsmsg.BitSwapMessage) {
+	now := time.Now()
+	for _, entry := range msg.Wantlist() {
+		if entry.Cid.Type() != nmtCodec {
+			continue
+		}
+
+		key := wantKey{peer: p, cid: entry.Cid}
+		st.lk.Lock()
+		if entry.Cancel {
+			delete(st.wants, key)
+			st.lk.Unlock()
+			continue
+		}
+		if entry.WantType != pb.Message_Wantlist_Block {
+			st.lk.Unlock()
+			continue
+		}
+		if _, ok := st.wants[key]; !ok && len(st.wants) < maxPendingWants {
+			st.wants[key] = now
+		}
+		ns := st.namespace(entry.Cid)
+		st.lk.Unlock()
+
+		if metrics := st.metrics.Load(); metrics != nil {
+			metrics.requests.Add(context.Background(), 1, attribute.String("namespace", ns))
+		}
+	}
+}
+
+// MessageSent implements bitswap.Tracer, accounting the blocks of shares served to the peer.
+func (st *ServeTracer) MessageSent(p peer.ID, msg bsmsg.BitSwapMessage) {
+	now := time.Now()
+	for _, block := range msg.Blocks() {
+		id := block.Cid()
+		if id.Type() != nmtCodec {
+			continue
+		}
+
+		key := wantKey{peer: p, cid: id}
+		st.lk.Lock()
+		wanted, ok := st.wants[key]
+		delete(st.wants, key)
+		ns := st.namespace(id)
+		st.lk.Unlock()
+
+		size := len(block.RawData())
+		var latency time.Duration
+		if ok {
+			latency = now.Sub(wanted)
+		}
+		if metrics := st.metrics.Load(); metrics != nil {
+			ctx, attr := context.Background(), attribute.String("namespace", ns)
+			metrics.bytes.Add(ctx, int64(size), attr)
+			if ok {
+				metrics.latency.Record(ctx, latency.Seconds(), attr)
+			}
+		}
+		if st.accessLog {
+			accessLog.Infow("served", "peer", p, "cid", id, "namespace", ns, "bytes", size, "latency", latency)
+		}
+	}
+}
+
+// namespace returns the namespace of the NMT node with the given CID, as reported in the metrics.
+// It must be called under the lock.
+func (st *ServeTracer) namespace(id cid.Cid) string {
+	hash := NamespacedSha256FromCID(id)
+	if len(hash) != nmtHashSize {
+		return otherNamespace
+	}
+	minNs, maxNs := hash[:NamespaceSize], hash[NamespaceSize:2*NamespaceSize]
+	if string(minNs) != string(maxNs) {
+		return mixedNamespace
+	}
+
+	ns := hex.EncodeToString(minNs)
+	if _, ok := st.namespaces[ns]; !ok {
+		if len(st.namespaces) >= maxNamespaces {
+			return otherNamespace
+		}
+		st.namespaces[ns] = struct{}{}
+	}
+	return ns
+}
@@ -0,0 +1,57 @@
+package ipld
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	bsmsg "github.com/ipfs/go-bitswap/message"
+	pb "github.com/ipfs/go-bitswap/message/pb"
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/libp2p/go-libp2p-core/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeTracer(t *testing.T) {
+	p, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	ns := bytes.Repeat([]byte{1}, NamespaceSize)
+	leaf := testBlock(t, ns, ns)
+	inner := testBlock(t, ns, bytes.Repeat([]byte{2}, NamespaceSize))
+
+	st := NewServeTracer(true)
+	require.NoError(t, st.InitMetrics())
+
+	want := bsmsg.New(false)
+	want.AddEntry(leaf.Cid(), 1, pb.Message_Wantlist_Block, false)
+	want.AddEntry(inner.Cid(), 1, pb.Message_Wantlist_Block, false)
+	st.MessageReceived(p, want)
+	assert.Len(t, st.wants, 2)
+
+	served := bsmsg.New(false)
+	served.AddBlock(leaf)
+	st.MessageSent(p, served)
+	assert.Len(t, st.wants, 1)
+
+	cancel := bsmsg.New(false)
+	cancel.Cancel(inner.Cid())
+	st.MessageReceived(p, cancel)
+	assert.Len(t, st.wants, 0)
+
+	assert.Equal(t, hex.EncodeToString(ns), st.namespace(leaf.Cid()))
+	assert.Equal(t, mixedNamespace, st.namespace(inner.Cid()))
+}
+
+// testBlock creates a block of an NMT node with the given namespace range.
+func testBlock(t *testing.T, minNs, maxNs []byte) blocks.Block {
+	digest := sha256.Sum256(append(minNs, maxNs...))
+	hash := append(append(append([]byte{}, minNs...), maxNs...), digest[:]...)
+	id, err := CidFromNamespacedSha256(hash)
+	require.NoError(t, err)
+	block, err := blocks.NewBlockWithCid(digest[:], id)
+	require.NoError(t, err)
+	return block
+}
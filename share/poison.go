@@ -0,0 +1,92 @@
+package share
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/celestiaorg/celestia-node/share/ipld"
+)
+
+// ErrPoisoned is returned for the data proven to be incorrectly encoded by a Bad Encoding Fraud
+// Proof.
+var ErrPoisoned = errors.New("share: data proven fraudulent")
+
+// PoisonList keeps the heights and the data roots of the blocks proven to be incorrectly encoded,
+// so that they are neither sampled nor served, along with the IPLD blocks of their squares, so
+// that those are not served over Bitswap either.
+type PoisonList struct {
+	lk      sync.RWMutex
+	heights map[uint64]struct{}
+	roots   map[string]struct{}
+	blocks  map[cid.Cid]struct{}
+}
+
+// NewPoisonList creates an empty PoisonList.
+func NewPoisonList() *PoisonList {
+	return &PoisonList{
+		heights: make(map[uint64]struct{}),
+		roots:   make(map[string]struct{}),
+		blocks:  make(map[cid.Cid]struct{}),
+	}
+}
+
+// Poison marks the block at the given height with the given Root as poisoned, along with the
+// IPLD blocks of the row and column roots of its square.
+func (pl *PoisonList) Poison(height uint64, root *Root) {
+	pl.lk.Lock()
+	defer pl.lk.Unlock()
+	pl.heights[height] = struct{}{}
+	pl.roots[string(root.Hash())] = struct{}{}
+	for _, hash := range append(root.RowsRoots, root.ColumnRoots...) {
+		pl.blocks[ipld.MustCidFromNamespacedSha256(hash)] = struct{}{}
+	}
+}
+
+// PoisonBlocks marks the IPLD blocks with the given CIDs, e.g. the inner nodes and the leaves of
+// a poisoned square, as poisoned.
+func (pl *PoisonList) PoisonBlocks(cids ...cid.Cid) {
+	pl.lk.Lock()
+	defer pl.lk.Unlock()
+	for _, id := range cids {
+		pl.blocks[id] = struct{}{}
+	}
+}
+
+// IsBlockPoisoned reports whether the IPLD block with the given CID is poisoned.
+func (pl *PoisonList) IsBlockPoisoned(id cid.Cid) bool {
+	pl.lk.RLock()
+	defer pl.lk.RUnlock()
+	_, ok := pl.blocks[id]
+	return ok
+}
+
+// IsHeightPoisoned reports whether the block at the given height is poisoned.
+func (pl *PoisonList) IsHeightPoisoned(height uint64) bool {
+	pl.lk.RLock()
+	defer pl.lk.RUnlock()
+	_, ok := pl.heights[height]
+	return ok
+}
+
+// IsRootPoisoned reports whether the block with the given Root is poisoned.
+func (pl *PoisonList) IsRootPoisoned(root *Root) bool {
+	pl.lk.RLock()
+	defer pl.lk.RUnlock()
+	_, ok := pl.roots[string(root.Hash())]
+	return ok
+}
+
+// Heights returns the ascending heights of all the poisoned blocks.
+func (pl *PoisonList) Heights() []uint64 {
+	pl.lk.RLock()
+	defer pl.lk.RUnlock()
+	heights := make([]uint64, 0, len(pl.heights))
+	for h := range pl.heights {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
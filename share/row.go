@@ -0,0 +1,137 @@
+package share
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/minio/sha256-simd"
+	"github.com/tendermint/tendermint/crypto/merkle"
+
+	"github.com/celestiaorg/celestia-app/pkg/wrapper"
+	"github.com/celestiaorg/nmt"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// Row is a row of an extended data square along with the proof of its inclusion into the data
+// root, i.e. the hash of the Root the square is committed to.
+type Row struct {
+	// Index is the index of the row in the extended data square.
+	Index int `json:"index"`
+	// Shares are all the shares of the row, including the ones of the extended half.
+	Shares []Share `json:"shares"`
+	// Root is the root of the NMT over the Shares.
+	Root []byte `json:"root"`
+	// Proof proves the inclusion of the Root into the data root.
+	Proof *merkle.Proof `json:"proof"`
+}
+
+// NewRow creates the Row with the given index and shares of the extended data square committed to
+// the given Root.
+func NewRow(root *Root, idx int, shares []Share) (*Row, error) {
+	if idx < 0 || idx >= len(root.RowsRoots) {
+		return nil, fmt.Errorf("share: row index %d out of range [0, %d)", idx, len(root.RowsRoots))
+	}
+	// the data root is the root of the merkle tree over the row roots followed by the column roots
+	roots := make([][]byte, 0, len(root.RowsRoots)+len(root.ColumnRoots))
+	roots = append(roots, root.RowsRoots...)
+	roots = append(roots, root.ColumnRoots...)
+	_, proofs := merkle.ProofsFromByteSlices(roots)
+	return &Row{
+		Index:  idx,
+		Shares: shares,
+		Root:   root.RowsRoots[idx],
+		Proof:  proofs[idx],
+	}, nil
+}
+
+// Verify checks that the Shares hash to the Root, and that the Root is included into the given
+// data root.
+func (r *Row) Verify(dataRoot []byte) error {
+	if len(r.Shares) == 0 || len(r.Shares)%2 != 0 {
+		return fmt.Errorf("share: invalid amount of shares in row: %d", len(r.Shares))
+	}
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(len(r.Shares)/2), uint(r.Index))
+	for _, shr := range r.Shares {
+		tree.Push(shr)
+	}
+	if !bytes.Equal(tree.Root(), r.Root) {
+		return fmt.Errorf("share: shares of row %d do not match its root", r.Index)
+	}
+	if r.Proof == nil || r.Proof.Index != int64(r.Index) {
+		return fmt.Errorf("share: missing proof of row %d", r.Index)
+	}
+	return r.Proof.Verify(dataRoot, r.Root)
+}
+
+// NamespacedRow holds the shares of a namespace within a row of an extended data square along with
+// the proof of their inclusion into the row root, or the proof of the namespace absence from it.
+type NamespacedRow struct {
+	// Index is the index of the row in the extended data square.
+	Index int `json:"index"`
+	// Shares are the shares of the namespace in the row. Empty, if the namespace is absent.
+	Shares []Share `json:"shares"`
+	// Proof proves the Shares are all the shares of the namespace in the row.
+	Proof *NamespaceProof `json:"proof"`
+}
+
+// NamespaceProof is the serializable form of the NMT proof of a namespace in a row.
+type NamespaceProof struct {
+	Start int      `json:"start"`
+	End   int      `json:"end"`
+	Nodes [][]byte `json:"nodes"`
+	// LeafHash is the hash of the leaf standing where the namespace would be, if it is absent.
+	LeafHash []byte `json:"leaf_hash,omitempty"`
+}
+
+// NewNamespacedRow computes the NamespacedRow for the namespace out of all the shares of the row with
+// the given index.
+func NewNamespacedRow(idx int, shares []Share, nID namespace.ID) (*NamespacedRow, error) {
+	if len(shares) == 0 || len(shares)%2 != 0 {
+		return nil, fmt.Errorf("share: invalid amount of shares in row: %d", len(shares))
+	}
+	tree := wrapper.NewErasuredNamespacedMerkleTree(uint64(len(shares)/2), uint(idx))
+	for _, shr := range shares {
+		tree.Push(shr)
+	}
+	proof, err := tree.Tree().ProveNamespace(nID)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &NamespacedRow{
+		Index: idx,
+		Proof: &NamespaceProof{
+			Start:    proof.Start(),
+			End:      proof.End(),
+			Nodes:    proof.Nodes(),
+			LeafHash: proof.LeafHash(),
+		},
+	}
+	if !proof.IsOfAbsence() {
+		row.Shares = shares[proof.Start():proof.End()]
+	}
+	return row, nil
+}
+
+// Verify checks that the Shares are all the shares of the namespace in the row with the given root.
+func (r *NamespacedRow) Verify(rowRoot []byte, nID namespace.ID) error {
+	if r.Proof == nil {
+		return fmt.Errorf("share: missing proof of namespace in row %d", r.Index)
+	}
+
+	var proof nmt.Proof
+	if len(r.Proof.LeafHash) != 0 {
+		proof = nmt.NewAbsenceProof(r.Proof.Start, r.Proof.End, r.Proof.Nodes, r.Proof.LeafHash, true)
+	} else {
+		proof = nmt.NewInclusionProof(r.Proof.Start, r.Proof.End, r.Proof.Nodes, true)
+	}
+	// leaves of the row tree are the shares prefixed with their namespace
+	leaves := make([][]byte, len(r.Shares))
+	for i, shr := range r.Shares {
+		leaves[i] = append(append(make([]byte, 0, len(nID)+len(shr)), nID...), shr...)
+	}
+	if !proof.VerifyNamespace(sha256.New(), nID, leaves, rowRoot) {
+		return fmt.Errorf("share: shares of namespace %s do not match root of row %d", nID, r.Index)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+func TestRow(t *testing.T) {
+	eds := RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+
+	for i := range dah.RowsRoots {
+		row, err := NewRow(&dah, i, eds.Row(uint(i)))
+		require.NoError(t, err)
+		assert.Len(t, row.Shares, 8)
+		require.NoError(t, row.Verify(dah.Hash()))
+	}
+
+	_, err := NewRow(&dah, len(dah.RowsRoots), nil)
+	require.Error(t, err)
+
+	// a row does not verify against another data root
+	row, err := NewRow(&dah, 1, eds.Row(1))
+	require.NoError(t, err)
+	other := da.NewDataAvailabilityHeader(RandEDS(t, 4))
+	require.Error(t, row.Verify(other.Hash()))
+
+	// nor with tampered shares
+	row.Shares[0] = row.Shares[1]
+	require.Error(t, row.Verify(dah.Hash()))
+}
+
+func TestNamespacedRow(t *testing.T) {
+	eds := RandEDS(t, 4)
+	dah := da.NewDataAvailabilityHeader(eds)
+
+	shares := eds.Row(1)
+	nID := ID(shares[2])
+	row, err := NewNamespacedRow(1, shares, nID)
+	require.NoError(t, err)
+	assert.Len(t, row.Shares, 1)
+	assert.Equal(t, shares[2], row.Shares[0])
+	require.NoError(t, row.Verify(dah.RowsRoots[1], nID))
+
+	// it does not verify against another row
+	require.Error(t, row.Verify(dah.RowsRoots[0], nID))
+	// nor with shares left out
+	row.Shares = nil
+	require.Error(t, row.Verify(dah.RowsRoots[1], nID))
+
+	// absence of a namespace within the range of the row is proven as well
+	absent := append(namespace.ID{}, nID...)
+	absent[len(absent)-1]++
+	row, err = NewNamespacedRow(1, shares, absent)
+	require.NoError(t, err)
+	assert.Empty(t, row.Shares)
+	require.NoError(t, row.Verify(dah.RowsRoots[1], absent))
+
+	_, err = NewNamespacedRow(1, shares[:3], nID)
+	require.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"math"
+	"sync"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+
+	"github.com/celestiaorg/celestia-node/share"
+	"github.com/celestiaorg/nmt/namespace"
+)
+
+// DefaultProofCacheSize is the default size in bytes of the proofs cached by the ShareService.
+var DefaultProofCacheSize = 64 << 20 // 64 MiB
+
+// proofCache caches the proven shares served for recent squares, so that proofs for hot
+// namespaces are not recomputed on every request. Once over its size in bytes, the least recently
+// used proofs are evicted first. A nil proofCache caches nothing.
+type proofCache struct {
+	lk      sync.Mutex
+	lru     *simplelru.LRU
+	size    int
+	maxSize int
+}
+
+// proofKey identifies the proof of shares of the square with the given DataHash either by their
+// namespace or by their coordinates.
+type proofKey struct {
+	dataHash  string
+	namespace string
+	row       int
+}
+
+func namespaceProofKey(root *share.Root, nID namespace.ID) proofKey {
+	return proofKey{dataHash: string(root.Hash()), namespace: string(nID), row: -1}
+}
+
+func rowProofKey(root *share.Root, row int) proofKey {
+	return proofKey{dataHash: string(root.Hash()), row: row}
+}
+
+type cachedProof struct {
+	value interface{}
+	size  int
+}
+
+func newProofCache(maxSize int) *proofCache {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	c := &proofCache{maxSize: maxSize}
+	// the amount of entries is not limited, as eviction is driven by their size
+	c.lru, _ = simplelru.NewLRU(math.MaxInt32, func(_, value interface{}) {
+		c.size -= value.(cachedProof).size
+	})
+	return c
+}
+
+func (c *proofCache) get(key proofKey) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	v, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return v.(cachedProof).value, true
+}
+
+func (c *proofCache) add(key proofKey, value interface{}, size int) {
+	if c == nil || size > c.maxSize {
+		return
+	}
+
+	c.lk.Lock()
+	defer c.lk.Unlock()
+	if old, ok := c.lru.Peek(key); ok {
+		c.size -= old.(cachedProof).size
+	}
+	c.lru.Add(key, cachedProof{value: value, size: size})
+	c.size += size
+	for c.size > c.maxSize {
+		c.lru.RemoveOldest()
+	}
+}
+
+func sizeOfRow(row *share.Row) int {
+	size := len(row.Root)
+	for _, shr := range row.Shares {
+		size += len(shr)
+	}
+	if row.Proof != nil {
+		size += len(row.Proof.LeafHash)
+		for _, aunt := range row.Proof.Aunts {
+			size += len(aunt)
+		}
+	}
+	return size
+}
+
+func sizeOfNamespacedRows(rows []*share.NamespacedRow) int {
+	var size int
+	for _, row := range rows {
+		for _, shr := range row.Shares {
+			size += len(shr)
+		}
+		size += len(row.Proof.LeafHash)
+		for _, nd := range row.Proof.Nodes {
+			size += len(nd)
+		}
+	}
+	return size
+}
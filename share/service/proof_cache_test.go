@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/celestiaorg/celestia-app/pkg/da"
+	"github.com/celestiaorg/celestia-node/share"
+)
+
+func TestProofCache(t *testing.T) {
+	dah := da.NewDataAvailabilityHeader(share.RandEDS(t, 2))
+	cache := newProofCache(10)
+
+	cache.add(rowProofKey(&dah, 0), 0, 4)
+	cache.add(rowProofKey(&dah, 1), 1, 4)
+	v, ok := cache.get(rowProofKey(&dah, 0))
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+
+	// the least recently used proof is evicted once over the size
+	cache.add(namespaceProofKey(&dah, share.ID(dah.RowsRoots[0])), 2, 4)
+	_, ok = cache.get(rowProofKey(&dah, 1))
+	assert.False(t, ok)
+	_, ok = cache.get(rowProofKey(&dah, 0))
+	assert.True(t, ok)
+	assert.Equal(t, 8, cache.size)
+
+	// replacing a proof accounts for its new size only
+	cache.add(rowProofKey(&dah, 0), 0, 2)
+	assert.Equal(t, 6, cache.size)
+
+	// proofs bigger than the cache are not cached
+	cache.add(rowProofKey(&dah, 2), 2, 11)
+	_, ok = cache.get(rowProofKey(&dah, 2))
+	assert.False(t, ok)
+
+	// nil cache caches nothing
+	cache = newProofCache(0)
+	cache.add(rowProofKey(&dah, 0), 0, 4)
+	_, ok = cache.get(rowProofKey(&dah, 0))
+	assert.False(t, ok)
+}
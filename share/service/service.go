@@ -13,6 +13,7 @@ import (
 	"github.com/celestiaorg/celestia-node/share/ipld"
 	"github.com/celestiaorg/nmt"
 	"github.com/celestiaorg/nmt/namespace"
+	"github.com/celestiaorg/rsmt2d"
 )
 
 // TODO(@Wondertan): Simple thread safety for Start and Stop would not hurt.
@@ -24,15 +25,33 @@ type ShareService struct {
 	// nodes, like shares prefer session over blockservice for fetching nodes.
 	session blockservice.BlockGetter
 	cancel  context.CancelFunc
+	// proofs caches the proven shares served by the service.
+	proofs *proofCache
+}
+
+// Option is the functional option that is applied to the ShareService instance.
+type Option func(*ShareService)
+
+// WithProofCacheSize sets the size in bytes of the proofs cached by the ShareService.
+// 0 disables caching.
+func WithProofCacheSize(size int) Option {
+	return func(s *ShareService) {
+		s.proofs = newProofCache(size)
+	}
 }
 
 // NewService creates a new basic share.Module.
-func NewShareService(bServ blockservice.BlockService, avail share.Availability) *ShareService {
-	return &ShareService{
+func NewShareService(bServ blockservice.BlockService, avail share.Availability, options ...Option) *ShareService {
+	s := &ShareService{
 		rtrv:         eds.NewRetriever(bServ),
 		Availability: avail,
 		bServ:        bServ,
+		proofs:       newProofCache(DefaultProofCacheSize),
 	}
+	for _, applyOpt := range options {
+		applyOpt(s)
+	}
+	return s
 }
 
 func (s *ShareService) Start(context.Context) error {
@@ -91,6 +110,99 @@ func (s *ShareService) GetShares(ctx context.Context, root *share.Root) ([][]sha
 	return shares, nil
 }
 
+// GetExtendedSquare retrieves the whole extended data square committed to the given Root.
+func (s *ShareService) GetExtendedSquare(ctx context.Context, root *share.Root) (*rsmt2d.ExtendedDataSquare, error) {
+	return s.rtrv.Retrieve(ctx, root)
+}
+
+// GetRowWithProof retrieves the row with the given index of the extended data square committed to
+// the given Root, along with the proof of its inclusion into the data root.
+func (s *ShareService) GetRowWithProof(ctx context.Context, root *share.Root, idx int) (*share.Row, error) {
+	if idx < 0 || idx >= len(root.RowsRoots) {
+		return nil, fmt.Errorf("share: row index %d out of range [0, %d)", idx, len(root.RowsRoots))
+	}
+
+	key := rowProofKey(root, idx)
+	if row, ok := s.proofs.get(key); ok {
+		return row.(*share.Row), nil
+	}
+
+	shares, err := s.getRow(ctx, root, idx)
+	if err != nil {
+		return nil, err
+	}
+	row, err := share.NewRow(root, idx, shares)
+	if err != nil {
+		return nil, err
+	}
+	s.proofs.add(key, row, sizeOfRow(row))
+	return row, nil
+}
+
+// GetSharesByNamespaceWithProof retrieves the shares of the given namespace.ID from every row of the
+// square which may contain it, along with the proofs that the row has no other shares of the
+// namespace.
+func (s *ShareService) GetSharesByNamespaceWithProof(
+	ctx context.Context,
+	root *share.Root,
+	nID namespace.ID,
+) ([]*share.NamespacedRow, error) {
+	if len(nID) != share.NamespaceSize {
+		return nil, fmt.Errorf("expected namespace ID of size %d, got %d", share.NamespaceSize, len(nID))
+	}
+
+	key := namespaceProofKey(root, nID)
+	if rows, ok := s.proofs.get(key); ok {
+		return rows.([]*share.NamespacedRow), nil
+	}
+
+	var idxs []int
+	for i, row := range root.RowsRoots {
+		if !nID.Less(nmt.MinNamespace(row, nID.Size())) && nID.LessOrEqual(nmt.MaxNamespace(row, nID.Size())) {
+			idxs = append(idxs, i)
+		}
+	}
+	if len(idxs) == 0 {
+		return nil, nil
+	}
+
+	// proving requires the whole row, as the proof consists of the nodes around the namespace
+	errGroup, ctx := errgroup.WithContext(ctx)
+	rows := make([]*share.NamespacedRow, len(idxs))
+	for i, idx := range idxs {
+		// shadow loop variables, to ensure correct values are captured
+		i, idx := i, idx
+		errGroup.Go(func() error {
+			shares, err := s.getRow(ctx, root, idx)
+			if err != nil {
+				return err
+			}
+			rows[i], err = share.NewNamespacedRow(idx, shares, nID)
+			return err
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+
+	s.proofs.add(key, rows, sizeOfNamespacedRows(rows))
+	return rows, nil
+}
+
+// getRow retrieves all the shares of the row with the given index.
+func (s *ShareService) getRow(ctx context.Context, root *share.Root, idx int) ([]share.Share, error) {
+	shares := make([]share.Share, len(root.RowsRoots))
+	rootCID := ipld.MustCidFromNamespacedSha256(root.RowsRoots[idx])
+	// this blocks until either all the shares are retrieved or the context is done
+	share.GetShares(ctx, s.bServ, rootCID, len(shares), func(i int, shr share.Share) {
+		shares[i] = shr
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
 // GetSharesByNamespace iterates over a square's row roots and accumulates the found shares in the
 // given namespace.ID.
 func (s *ShareService) GetSharesByNamespace(
@@ -0,0 +1,179 @@
+package state
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// AccountEventType identifies the kind of an AccountEvent.
+type AccountEventType string
+
+const (
+	// FundsReceived is emitted for every transfer to the account.
+	FundsReceived AccountEventType = "funds_received"
+	// FundsSent is emitted for every transfer from the account, including the paid fees.
+	FundsSent AccountEventType = "funds_sent"
+	// RewardsReceived is emitted for every withdrawal of the delegation rewards to the account.
+	RewardsReceived AccountEventType = "rewards_received"
+)
+
+// distributionAddr is the address of the account the delegation rewards are withdrawn from.
+var distributionAddr = authtypes.NewModuleAddress(distrtypes.ModuleName).String()
+
+// AccountEvent describes a change of the balance of an account made by a committed transaction.
+type AccountEvent struct {
+	Type   AccountEventType `json:"type"`
+	Height int64            `json:"height"`
+	// TxHash is the hex-encoded hash of the transaction making the change.
+	TxHash string `json:"tx_hash"`
+	// Counterparty is the address the funds are transferred from or to.
+	Counterparty string         `json:"counterparty"`
+	Amount       sdktypes.Coins `json:"amount"`
+}
+
+// SubscribeAccountEvents watches the committed blocks for the transactions changing the balance of
+// the node's account and emits the AccountEvents about them, until the given context is canceled.
+func (ca *CoreAccessor) SubscribeAccountEvents(ctx context.Context) (<-chan *AccountEvent, error) {
+	if ca.hsub == nil {
+		return nil, errors.New("state: account events require a header subscription")
+	}
+	addr, err := ca.signer.GetSignerInfo().GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	sub, err := ca.hsub.Subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *AccountEvent)
+	go func() {
+		defer close(events)
+		defer sub.Cancel()
+		for {
+			h, err := sub.NextHeader(ctx)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+					log.Errorw("account events: getting next header", "err", err)
+				}
+				return
+			}
+
+			evs, err := ca.blockAccountEvents(ctx, addr.String(), h.Height)
+			if errors.Is(err, ErrNotConnected) {
+				// the accessor is stopped, so no more events can be collected
+				log.Warnw("account events: stopping", "err", err)
+				return
+			}
+			if err != nil {
+				log.Errorw("account events: getting block results", "height", h.Height, "err", err)
+				continue
+			}
+			for _, ev := range evs {
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// blockAccountEvents collects the AccountEvents of the given address out of the block at the
+// given height.
+func (ca *CoreAccessor) blockAccountEvents(ctx context.Context, addr string, height int64) ([]*AccountEvent, error) {
+	cli := ca.rpcCli
+	if cli == nil {
+		return nil, ErrNotConnected
+	}
+	block, err := cli.Block(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := cli.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.TxsResults) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("mismatching amount of txs %d and their results %d",
+			len(block.Block.Txs), len(results.TxsResults))
+	}
+
+	var events []*AccountEvent
+	for i, res := range results.TxsResults {
+		if res.Code != abci.CodeTypeOK {
+			continue
+		}
+		events = append(events, accountEvents(addr, height, txHash(block.Block.Txs[i]), res.Events)...)
+	}
+	return events, nil
+}
+
+// accountEvents converts the transfers to and from the given address among the events of the
+// transaction into AccountEvents.
+func accountEvents(addr string, height int64, hash string, events []abci.Event) []*AccountEvent {
+	var accEvents []*AccountEvent
+	for _, ev := range events {
+		if ev.Type != banktypes.EventTypeTransfer {
+			continue
+		}
+
+		var recipient, sender, amount string
+		for _, attr := range ev.Attributes {
+			switch string(attr.Key) {
+			case banktypes.AttributeKeyRecipient:
+				recipient = string(attr.Value)
+			case banktypes.AttributeKeySender:
+				sender = string(attr.Value)
+			case sdktypes.AttributeKeyAmount:
+				amount = string(attr.Value)
+			}
+		}
+		coins, err := sdktypes.ParseCoinsNormalized(amount)
+		if err != nil {
+			log.Debugw("account events: parsing amount", "amount", amount, "err", err)
+			continue
+		}
+
+		accEvent := &AccountEvent{
+			Height: height,
+			TxHash: hash,
+			Amount: coins,
+		}
+		switch addr {
+		case recipient:
+			accEvent.Type, accEvent.Counterparty = FundsReceived, sender
+			if sender == distributionAddr {
+				accEvent.Type = RewardsReceived
+			}
+		case sender:
+			accEvent.Type, accEvent.Counterparty = FundsSent, recipient
+		default:
+			continue
+		}
+		accEvents = append(accEvents, accEvent)
+	}
+	return accEvents
+}
+
+// txHash computes the hex-encoded hash of the transaction as it was submitted.
+func txHash(tx coretypes.Tx) string {
+	// transactions paying for data are malleated by the block producer, but they are known by the
+	// hash of the original one
+	if malleated, ok := coretypes.UnwrapMalleatedTx(tx); ok {
+		return strings.ToUpper(hex.EncodeToString(malleated.OriginalTxHash))
+	}
+	return strings.ToUpper(hex.EncodeToString(tx.Hash()))
+}
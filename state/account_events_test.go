@@ -0,0 +1,56 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestAccountEvents(t *testing.T) {
+	addr, other := "celestia1addr", "celestia1other"
+	events := []abci.Event{
+		transferEvent(other, addr, "10utia"),
+		transferEvent(addr, other, "5utia"),
+		transferEvent(distributionAddr, addr, "1utia"),
+		// transfers between other accounts are ignored
+		transferEvent(distributionAddr, other, "1utia"),
+		{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("sender"), Value: []byte(addr)}}},
+	}
+
+	accEvents := accountEvents(addr, 10, "HASH", events)
+	require.Len(t, accEvents, 3)
+
+	assert.Equal(t, &AccountEvent{
+		Type:         FundsReceived,
+		Height:       10,
+		TxHash:       "HASH",
+		Counterparty: other,
+		Amount:       sdktypes.NewCoins(sdktypes.NewInt64Coin("utia", 10)),
+	}, accEvents[0])
+	assert.Equal(t, FundsSent, accEvents[1].Type)
+	assert.Equal(t, other, accEvents[1].Counterparty)
+	assert.Equal(t, RewardsReceived, accEvents[2].Type)
+	assert.Equal(t, sdktypes.NewCoins(sdktypes.NewInt64Coin("utia", 1)), accEvents[2].Amount)
+}
+
+func transferEvent(sender, recipient, amount string) abci.Event {
+	return abci.Event{
+		Type: "transfer",
+		Attributes: []abci.EventAttribute{
+			{Key: []byte("recipient"), Value: []byte(recipient)},
+			{Key: []byte("sender"), Value: []byte(sender)},
+			{Key: []byte("amount"), Value: []byte(amount)},
+		},
+	}
+}
+
+func TestBlockAccountEvents_NotStarted(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, nil, nil)
+
+	_, err := ca.blockAccountEvents(context.Background(), "celestia1addr", 1)
+	require.ErrorIs(t, err, ErrNotConnected)
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/api/tendermint/abci"
-	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -15,76 +14,139 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	"github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"go.uber.org/multierr"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 
 	"github.com/celestiaorg/celestia-app/app"
 	"github.com/celestiaorg/celestia-app/x/payment"
 	apptypes "github.com/celestiaorg/celestia-app/x/payment/types"
 	"github.com/celestiaorg/nmt/namespace"
 
+	"github.com/celestiaorg/celestia-node/core"
 	"github.com/celestiaorg/celestia-node/header"
 )
 
 var (
 	log              = logging.Logger("state")
 	ErrInvalidAmount = errors.New("state: amount must be greater than zero")
+	// ErrNotConnected is returned by the methods requiring a connection to celestia-core while the
+	// CoreAccessor is not started.
+	ErrNotConnected = errors.New("core-access: not connected to core endpoint")
 )
 
+// gasAdjustment is the factor the simulated gas usage is multiplied by for the estimation to
+// account for the state changing between the simulation and the execution.
+const gasAdjustment = 1.1
+
 // CoreAccessor implements service over a gRPC connection
 // with a celestia-core node.
 type CoreAccessor struct {
-	ctx    context.Context
-	cancel context.CancelFunc
+	// lifecycleLk guards Start and Stop, which may be called concurrently,
+	// e.g. on node shutdown and on a fraud proof arrival.
+	lifecycleLk sync.Mutex
+	ctx         context.Context
+	cancel      context.CancelFunc
 
 	signer *apptypes.KeyringSigner
 	getter header.Head
+	hsub   header.Subscriber
+	// hgetter retrieves the past headers proving the state of the past heights
+	hgetter header.Getter
 
 	queryCli   banktypes.QueryClient
 	stakingCli stakingtypes.QueryClient
-	rpcCli     rpcclient.ABCIClient
+	rpcCli     rpcclient.Client
+
+	fees      *feeEstimator
+	sequencer *txSequencer
+
+	txs *txTracker
+	// txsDone is closed once the routine tracking txs inclusion is finished
+	txsDone chan struct{}
 
-	coreConn *grpc.ClientConn
-	coreIP   string
-	rpcPort  string
-	grpcPort string
+	coreConn  *grpc.ClientConn
+	endpoints []core.Endpoint
+	grpcOpts  []grpc.DialOption
 
 	lastPayForData  int64
 	payForDataCount int64
 }
 
-// NewCoreAccessor dials the given celestia-core endpoint and
-// constructs and returns a new CoreAccessor (state service) with the active
-// connection.
+// Option configures the CoreAccessor.
+type Option func(*CoreAccessor)
+
+// WithGRPCOptions sets the options the gRPC endpoints of celestia-core are dialed with, e.g. to
+// secure the connection. By default, the connection is insecure.
+func WithGRPCOptions(opts ...grpc.DialOption) Option {
+	return func(ca *CoreAccessor) {
+		ca.grpcOpts = opts
+	}
+}
+
+// WithHeaderGetter sets the header.Getter retrieving the past headers, which enables the verified
+// state queries of the past heights.
+func WithHeaderGetter(getter header.Getter) Option {
+	return func(ca *CoreAccessor) {
+		ca.hgetter = getter
+	}
+}
+
+// NewCoreAccessor constructs and returns a new CoreAccessor (state service) over the given
+// celestia-core endpoints. The first endpoint is the primary one, while the rest are fallbacks
+// used whenever the active one becomes unavailable.
 func NewCoreAccessor(
 	signer *apptypes.KeyringSigner,
 	getter header.Head,
-	coreIP,
-	rpcPort string,
-	grpcPort string,
+	hsub header.Subscriber,
+	endpoints []core.Endpoint,
+	options ...Option,
 ) *CoreAccessor {
-	return &CoreAccessor{
-		signer:   signer,
-		getter:   getter,
-		coreIP:   coreIP,
-		rpcPort:  rpcPort,
-		grpcPort: grpcPort,
-	}
+	ca := &CoreAccessor{
+		signer:    signer,
+		getter:    getter,
+		hsub:      hsub,
+		endpoints: endpoints,
+		grpcOpts:  []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		txs:       newTxTracker(),
+	}
+	ca.fees = newFeeEstimator(blockGetterFunc(ca.block))
+	for _, opt := range options {
+		opt(ca)
+	}
+	return ca
 }
 
 func (ca *CoreAccessor) Start(ctx context.Context) error {
+	ca.lifecycleLk.Lock()
+	defer ca.lifecycleLk.Unlock()
 	if ca.coreConn != nil {
 		return fmt.Errorf("core-access: already connected to core endpoint")
 	}
+	if len(ca.endpoints) == 0 {
+		return fmt.Errorf("core-access: no core endpoints given")
+	}
 	ca.ctx, ca.cancel = context.WithCancel(context.Background())
 
-	// dial given celestia-core endpoint
-	endpoint := fmt.Sprintf("%s:%s", ca.coreIP, ca.grpcPort)
-	client, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// dial given celestia-core endpoints, gRPC switches over to the next endpoint
+	// in order once the connection to the current one breaks
+	addrs := make([]resolver.Address, len(ca.endpoints))
+	for i, endpoint := range ca.endpoints {
+		addrs[i] = resolver.Address{Addr: fmt.Sprintf("%s:%s", endpoint.IP, endpoint.GRPCPort)}
+	}
+	res := manual.NewBuilderWithScheme("core")
+	res.InitialState(resolver.State{Addresses: addrs})
+	opts := append([]grpc.DialOption{grpc.WithResolvers(res)}, ca.grpcOpts...)
+	client, err := grpc.DialContext(ctx, res.Scheme()+":///state", opts...)
 	if err != nil {
 		return err
 	}
 	ca.coreConn = client
+	ca.sequencer = newTxSequencer(ca.signer, ca.coreConn)
 	// create the query client
 	queryCli := banktypes.NewQueryClient(ca.coreConn)
 	ca.queryCli = queryCli
@@ -92,15 +154,40 @@ func (ca *CoreAccessor) Start(ctx context.Context) error {
 	stakingCli := stakingtypes.NewQueryClient(ca.coreConn)
 	ca.stakingCli = stakingCli
 	// create ABCI query client
-	cli, err := http.New(fmt.Sprintf("http://%s:%s", ca.coreIP, ca.rpcPort), "/websocket")
-	if err != nil {
-		return err
+	if len(ca.endpoints) == 1 {
+		endpoint := ca.endpoints[0]
+		cli, err := http.New(fmt.Sprintf("http://%s:%s", endpoint.IP, endpoint.RPCPort), "/websocket")
+		if err != nil {
+			return err
+		}
+		ca.rpcCli = cli
+	} else {
+		// multiple endpoints require the client to be running to health check them
+		cli, err := core.NewRemoteWithFailover(ca.endpoints)
+		if err != nil {
+			return err
+		}
+		err = cli.Start()
+		if err != nil {
+			return err
+		}
+		ca.rpcCli = cli
+	}
+	// watch new headers to track inclusion of submitted txs
+	if ca.hsub != nil {
+		sub, err := ca.hsub.Subscribe()
+		if err != nil {
+			return err
+		}
+		ca.txsDone = make(chan struct{})
+		go ca.trackTxs(ca.ctx, sub)
 	}
-	ca.rpcCli = cli
 	return nil
 }
 
-func (ca *CoreAccessor) Stop(context.Context) error {
+func (ca *CoreAccessor) Stop(ctx context.Context) error {
+	ca.lifecycleLk.Lock()
+	defer ca.lifecycleLk.Unlock()
 	if ca.cancel == nil {
 		log.Warn("core accessor already stopped")
 		return nil
@@ -111,15 +198,29 @@ func (ca *CoreAccessor) Stop(context.Context) error {
 	}
 	defer ca.cancelCtx()
 
-	// close out core connection
-	err := ca.coreConn.Close()
-	if err != nil {
-		return err
+	// stop tracking txs and wait for the header subscription to be released
+	var err error
+	if ca.txsDone != nil {
+		ca.cancel()
+		select {
+		case <-ca.txsDone:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		ca.txsDone = nil
+	}
+
+	// close out the connections regardless, as the accessor can't be stopped again
+	err = multierr.Append(err, ca.coreConn.Close())
+	if ca.rpcCli != nil && ca.rpcCli.IsRunning() {
+		err = multierr.Append(err, ca.rpcCli.Stop())
 	}
 
 	ca.coreConn = nil
 	ca.queryCli = nil
-	return nil
+	ca.rpcCli = nil
+	ca.sequencer = nil
+	return err
 }
 
 func (ca *CoreAccessor) cancelCtx() {
@@ -127,31 +228,70 @@ func (ca *CoreAccessor) cancelCtx() {
 	ca.cancel = nil
 }
 
-func (ca *CoreAccessor) constructSignedTx(
+// submitMsg signs a transaction carrying the given message with the next sequence of the account
+// and submits it.
+func (ca *CoreAccessor) submitMsg(
 	ctx context.Context,
 	msg sdktypes.Msg,
 	opts ...apptypes.TxBuilderOption,
-) ([]byte, error) {
-	// should be called first in order to make a valid tx
-	err := ca.signer.QueryAccountNumber(ctx, ca.coreConn)
-	if err != nil {
-		return nil, err
-	}
+) (*TxResponse, error) {
+	build := func() ([]byte, error) {
+		tx, err := ca.signer.BuildSignedTx(ca.signer.NewTxBuilder(opts...), msg)
+		if err != nil {
+			return nil, err
+		}
+		return ca.signer.EncodeTx(tx)
+	}
+	return ca.sequencer.submit(ctx, build, ca.SubmitTx)
+}
 
-	tx, err := ca.signer.BuildSignedTx(ca.signer.NewTxBuilder(opts...), msg)
-	if err != nil {
-		return nil, err
+// buildPayForData returns the buildFunc of the PayForData transaction carrying the given data.
+func (ca *CoreAccessor) buildPayForData(
+	nID namespace.ID,
+	data []byte,
+	opts ...apptypes.TxBuilderOption,
+) buildFunc {
+	return func() ([]byte, error) {
+		pfd, err := apptypes.NewWirePayForData(nID, data, apptypes.AllSquareSizes(len(data))...)
+		if err != nil {
+			return nil, err
+		}
+		// the commitments are signed with the sequence of the account as well
+		err = pfd.SignShareCommitments(ca.signer, opts...)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := payment.SignPayForData(ca.signer, pfd, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return ca.signer.EncodeTx(tx)
 	}
-	return ca.signer.EncodeTx(tx)
 }
 
+// SubmitPayForData builds, signs and submits a PayForData transaction carrying the given data.
+// The fee is set to cover the gas limit at the gas price estimated over the latest blocks, and
+// the gas limit is estimated as well, if not given.
 func (ca *CoreAccessor) SubmitPayForData(
 	ctx context.Context,
 	nID namespace.ID,
 	data []byte,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	response, err := payment.SubmitPayForData(ctx, ca.signer, ca.coreConn, nID, data, gasLim)
+	if gasLim == 0 {
+		var err error
+		gasLim, err = ca.EstimateGas(ctx, nID, data)
+		if err != nil {
+			return nil, fmt.Errorf("estimating gas: %w", err)
+		}
+	}
+	fee, err := ca.fees.Fee(ctx, gasLim)
+	if err != nil {
+		return nil, fmt.Errorf("estimating fee: %w", err)
+	}
+
+	build := ca.buildPayForData(nID, data, apptypes.SetGasLimit(gasLim), apptypes.SetFeeAmount(fee))
+	response, err := ca.sequencer.submit(ctx, build, ca.SubmitTx)
 	// metrics should only be counted on a successful PFD tx
 	if err == nil && response.Code == 0 {
 		ca.lastPayForData = time.Now().UnixMilli()
@@ -160,6 +300,27 @@ func (ca *CoreAccessor) SubmitPayForData(
 	return response, err
 }
 
+// EstimateGas estimates the gas a PayForData transaction carrying the given blob uses, by
+// simulating its execution.
+func (ca *CoreAccessor) EstimateGas(ctx context.Context, nID namespace.ID, blob []byte) (uint64, error) {
+	rawTx, err := ca.sequencer.build(ctx, ca.buildPayForData(nID, blob))
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := sdktx.NewServiceClient(ca.coreConn).Simulate(ctx, &sdktx.SimulateRequest{TxBytes: rawTx})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(float64(resp.GasInfo.GasUsed) * gasAdjustment), nil
+}
+
+// TxStatus reports the latest known stage of the transaction with the given hex-encoded hash
+// submitted through the node.
+func (ca *CoreAccessor) TxStatus(_ context.Context, hash string) (*TxStatus, error) {
+	return ca.txs.status(hash)
+}
+
 func (ca *CoreAccessor) AccountAddress(ctx context.Context) (Address, error) {
 	addr, err := ca.signer.GetSignerInfo().GetAddress()
 	if err != nil {
@@ -177,56 +338,23 @@ func (ca *CoreAccessor) Balance(ctx context.Context) (*Balance, error) {
 }
 
 func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*Balance, error) {
-	head, err := ca.getter.Head(ctx)
-	if err != nil {
-		return nil, err
-	}
-	// construct an ABCI query for the height at head-1 because
-	// the AppHash contained in the head is actually the state root
-	// after applying the transactions contained in the previous block.
-	// TODO @renaynay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use this method
-	// instead
 	prefixedAccountKey := append(banktypes.CreateAccountBalancesPrefix(addr.Bytes()), []byte(app.BondDenom)...)
-	abciReq := abci.RequestQuery{
-		// TODO @renayay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use const instead
-		Path:   fmt.Sprintf("store/%s/key", banktypes.StoreKey),
-		Height: head.Height - 1,
-		Data:   prefixedAccountKey,
-		Prove:  true,
-	}
-	opts := rpcclient.ABCIQueryOptions{
-		Height: abciReq.Height,
-		Prove:  abciReq.Prove,
-	}
-	result, err := ca.rpcCli.ABCIQueryWithOptions(ctx, abciReq.Path, abciReq.Data, opts)
+	result, err := ca.QueryWithProof(ctx, banktypes.StoreKey, prefixedAccountKey, 0)
 	if err != nil {
 		return nil, err
 	}
-	if !result.Response.IsOK() {
-		return nil, sdkErrorToGRPCError(result.Response)
-	}
-	// unmarshal balance information
-	value := result.Response.Value
 	// if the value returned is empty, the account balance does not yet exist
-	if len(value) == 0 {
-		log.Errorf("balance for account %s does not exist at block height %d", addr.String(), head.Height-1)
+	if len(result.Value) == 0 {
+		log.Errorf("balance for account %s does not exist at block height %d", addr.String(), result.Height)
 		return &Balance{
 			Denom:  app.BondDenom,
 			Amount: sdktypes.NewInt(0),
 		}, nil
 	}
-	coin, ok := sdktypes.NewIntFromString(string(value))
+	coin, ok := sdktypes.NewIntFromString(string(result.Value))
 	if !ok {
-		return nil, fmt.Errorf("cannot convert %s into sdktypes.Int", string(value))
+		return nil, fmt.Errorf("cannot convert %s into sdktypes.Int", string(result.Value))
 	}
-	// verify balance
-	path := fmt.Sprintf("/%s/%s", banktypes.StoreKey, string(prefixedAccountKey))
-	prt := rootmulti.DefaultProofRuntime()
-	err = prt.VerifyValue(result.Response.GetProofOps(), head.AppHash, path, value)
-	if err != nil {
-		return nil, err
-	}
-
 	return &Balance{
 		Denom:  app.BondDenom,
 		Amount: coin,
@@ -234,11 +362,7 @@ func (ca *CoreAccessor) BalanceForAddress(ctx context.Context, addr Address) (*B
 }
 
 func (ca *CoreAccessor) SubmitTx(ctx context.Context, tx Tx) (*TxResponse, error) {
-	txResp, err := apptypes.BroadcastTx(ctx, ca.coreConn, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK, tx)
-	if err != nil {
-		return nil, err
-	}
-	return txResp.TxResponse, nil
+	return ca.SubmitTxWithBroadcastMode(ctx, tx, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK)
 }
 
 func (ca *CoreAccessor) SubmitTxWithBroadcastMode(
@@ -246,10 +370,13 @@ func (ca *CoreAccessor) SubmitTxWithBroadcastMode(
 	tx Tx,
 	mode sdktx.BroadcastMode,
 ) (*TxResponse, error) {
+	hash := ca.txs.broadcast(tx)
 	txResp, err := apptypes.BroadcastTx(ctx, ca.coreConn, mode, tx)
 	if err != nil {
+		ca.txs.fail(hash, err)
 		return nil, err
 	}
+	ca.txs.track(txResp.TxResponse)
 	return txResp.TxResponse, nil
 }
 
@@ -259,7 +386,7 @@ func (ca *CoreAccessor) Transfer(
 	amount Int,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	if amount.IsNil() || amount.Int64() <= 0 {
+	if amount.IsNil() || !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -269,11 +396,7 @@ func (ca *CoreAccessor) Transfer(
 	}
 	coins := sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, amount))
 	msg := banktypes.NewMsgSend(from, addr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, apptypes.SetGasLimit(gasLim))
 }
 
 func (ca *CoreAccessor) CancelUnbondingDelegation(
@@ -283,7 +406,7 @@ func (ca *CoreAccessor) CancelUnbondingDelegation(
 	height Int,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	if amount.IsNil() || amount.Int64() <= 0 {
+	if amount.IsNil() || !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -293,11 +416,7 @@ func (ca *CoreAccessor) CancelUnbondingDelegation(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgCancelUnbondingDelegation(from, valAddr, height.Int64(), coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, apptypes.SetGasLimit(gasLim))
 }
 
 func (ca *CoreAccessor) BeginRedelegate(
@@ -307,7 +426,7 @@ func (ca *CoreAccessor) BeginRedelegate(
 	amount Int,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	if amount.IsNil() || amount.Int64() <= 0 {
+	if amount.IsNil() || !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -317,20 +436,16 @@ func (ca *CoreAccessor) BeginRedelegate(
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
 	msg := stakingtypes.NewMsgBeginRedelegate(from, srcValAddr, dstValAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	return ca.submitMsg(ctx, msg, apptypes.SetGasLimit(gasLim))
 }
 
 func (ca *CoreAccessor) Undelegate(
 	ctx context.Context,
-	delAddr ValAddress,
+	valAddr ValAddress,
 	amount Int,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	if amount.IsNil() || amount.Int64() <= 0 {
+	if amount.IsNil() || !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -339,21 +454,17 @@ func (ca *CoreAccessor) Undelegate(
 		return nil, err
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgUndelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	msg := stakingtypes.NewMsgUndelegate(from, valAddr, coins)
+	return ca.submitMsg(ctx, msg, apptypes.SetGasLimit(gasLim))
 }
 
 func (ca *CoreAccessor) Delegate(
 	ctx context.Context,
-	delAddr ValAddress,
+	valAddr ValAddress,
 	amount Int,
 	gasLim uint64,
 ) (*TxResponse, error) {
-	if amount.IsNil() || amount.Int64() <= 0 {
+	if amount.IsNil() || !amount.IsPositive() {
 		return nil, ErrInvalidAmount
 	}
 
@@ -362,12 +473,8 @@ func (ca *CoreAccessor) Delegate(
 		return nil, err
 	}
 	coins := sdktypes.NewCoin(app.BondDenom, amount)
-	msg := stakingtypes.NewMsgDelegate(from, delAddr, coins)
-	signedTx, err := ca.constructSignedTx(ctx, msg, apptypes.SetGasLimit(gasLim))
-	if err != nil {
-		return nil, err
-	}
-	return ca.SubmitTx(ctx, signedTx)
+	msg := stakingtypes.NewMsgDelegate(from, valAddr, coins)
+	return ca.submitMsg(ctx, msg, apptypes.SetGasLimit(gasLim))
 }
 
 func (ca *CoreAccessor) QueryDelegation(
@@ -413,6 +520,29 @@ func (ca *CoreAccessor) QueryRedelegations(
 	})
 }
 
-func (ca *CoreAccessor) IsStopped() bool {
+// Healthy reports whether the CoreAccessor is connected to celestia-core.
+func (ca *CoreAccessor) Healthy() error {
+	ca.lifecycleLk.Lock()
+	defer ca.lifecycleLk.Unlock()
+	if ca.coreConn == nil {
+		return ErrNotConnected
+	}
+	if ca.coreConn.GetState() == connectivity.TransientFailure {
+		return fmt.Errorf("core-access: connection to core endpoint is failing")
+	}
+	return nil
+}
+
+// block gets the block at the given height, or the latest one if the height is nil, from the
+// connected celestia-core node.
+func (ca *CoreAccessor) block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	cli := ca.rpcCli
+	if cli == nil {
+		return nil, ErrNotConnected
+	}
+	return cli.Block(ctx, height)
+}
+
+func (ca *CoreAccessor) IsStopped(context.Context) bool {
 	return ca.ctx.Err() != nil
 }
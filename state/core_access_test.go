@@ -4,30 +4,58 @@ import (
 	"context"
 	"testing"
 
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/core"
 )
 
 func TestLifecycle(t *testing.T) {
-	ca := NewCoreAccessor(nil, nil, "", "", "")
+	ca := NewCoreAccessor(nil, nil, nil, []core.Endpoint{{}})
 	ctx, cancel := context.WithCancel(context.Background())
 	// start the accessor
 	err := ca.Start(ctx)
 	require.NoError(t, err)
 	// ensure accessor isn't stopped
-	require.False(t, ca.IsStopped())
+	require.False(t, ca.IsStopped(ctx))
 	// cancel the top level context (this should not affect the lifecycle of the
 	// accessor as it should manage its own internal context)
 	cancel()
 	// ensure accessor was unaffected by top-level context cancellation
-	require.False(t, ca.IsStopped())
+	require.False(t, ca.IsStopped(ctx))
 	// stop the accessor
 	stopCtx, stopCancel := context.WithCancel(context.Background())
 	t.Cleanup(stopCancel)
 	err = ca.Stop(stopCtx)
 	require.NoError(t, err)
 	// ensure accessor is stopped
-	require.True(t, ca.IsStopped())
+	require.True(t, ca.IsStopped(ctx))
 	// ensure that stopping the accessor again does not return an error
 	err = ca.Stop(stopCtx)
 	require.NoError(t, err)
 }
+
+func TestStakingInvalidAmount(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, nil, []core.Endpoint{{}})
+	ctx := context.Background()
+
+	invalid := []Int{{}, sdktypes.NewInt(0), sdktypes.NewInt(-1)}
+	for _, amount := range invalid {
+		_, err := ca.Delegate(ctx, nil, amount, 0)
+		require.ErrorIs(t, err, ErrInvalidAmount)
+		_, err = ca.Undelegate(ctx, nil, amount, 0)
+		require.ErrorIs(t, err, ErrInvalidAmount)
+		_, err = ca.BeginRedelegate(ctx, nil, nil, amount, 0)
+		require.ErrorIs(t, err, ErrInvalidAmount)
+		_, err = ca.CancelUnbondingDelegation(ctx, nil, amount, sdktypes.NewInt(1), 0)
+		require.ErrorIs(t, err, ErrInvalidAmount)
+	}
+}
+
+func TestSubmitPayForData_NotStarted(t *testing.T) {
+	ca := NewCoreAccessor(nil, nil, nil, []core.Endpoint{{}})
+
+	// the fee estimation must not panic before the accessor is started
+	_, err := ca.SubmitPayForData(context.Background(), nil, nil, 1)
+	require.ErrorIs(t, err, ErrNotConnected)
+}
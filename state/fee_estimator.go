@@ -0,0 +1,130 @@
+package state
+
+import (
+	"context"
+	"sync"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+)
+
+var (
+	// DefaultFeeEstimationBlocks is the amount of the latest blocks the gas price is estimated over.
+	DefaultFeeEstimationBlocks = 10
+	// DefaultMinGasPrice is the gas price transactions pay at least, regardless of the estimation.
+	DefaultMinGasPrice = sdktypes.ZeroDec()
+)
+
+// blockGetter gets the block at the given height, or the latest one if the height is nil.
+type blockGetter interface {
+	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
+}
+
+// blockGetterFunc is an adapter allowing the use of an ordinary function as a blockGetter.
+type blockGetterFunc func(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
+
+func (f blockGetterFunc) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return f(ctx, height)
+}
+
+// feeEstimator estimates the effective minimum gas price out of the fees paid by the
+// transactions included into the latest blocks.
+type feeEstimator struct {
+	blocks      blockGetter
+	decoder     sdktypes.TxDecoder
+	numBlocks   int
+	minGasPrice sdktypes.Dec
+
+	// the estimation is cached until a new block is produced
+	lk         sync.Mutex
+	lastHeight int64
+	lastPrice  sdktypes.Dec
+}
+
+func newFeeEstimator(blocks blockGetter) *feeEstimator {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	return &feeEstimator{
+		blocks:      blocks,
+		decoder:     encCfg.TxConfig.TxDecoder(),
+		numBlocks:   DefaultFeeEstimationBlocks,
+		minGasPrice: DefaultMinGasPrice,
+	}
+}
+
+// GasPrice estimates the gas price a transaction has to pay to be included into a block.
+// Every latest block includes transactions up to some lowest gas price, and the estimation is the
+// highest of them, so that the price is accepted in congested blocks as well.
+func (fe *feeEstimator) GasPrice(ctx context.Context) (sdktypes.Dec, error) {
+	latest, err := fe.blocks.Block(ctx, nil)
+	if err != nil {
+		return sdktypes.Dec{}, err
+	}
+
+	fe.lk.Lock()
+	defer fe.lk.Unlock()
+	height := latest.Block.Height
+	if height == fe.lastHeight {
+		return fe.lastPrice, nil
+	}
+
+	price := fe.minGasPrice
+	for i := 0; i < fe.numBlocks && height-int64(i) > 0; i++ {
+		block := latest
+		if i > 0 {
+			h := height - int64(i)
+			block, err = fe.blocks.Block(ctx, &h)
+			if err != nil {
+				return sdktypes.Dec{}, err
+			}
+		}
+		if blockPrice, ok := fe.lowestGasPrice(block.Block.Txs); ok && blockPrice.GT(price) {
+			price = blockPrice
+		}
+	}
+
+	fe.lastHeight, fe.lastPrice = height, price
+	return price, nil
+}
+
+// Fee computes the fee for the given gas limit at the estimated gas price.
+func (fe *feeEstimator) Fee(ctx context.Context, gasLim uint64) (sdktypes.Coins, error) {
+	price, err := fe.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	amount := price.MulInt(sdktypes.NewIntFromUint64(gasLim)).Ceil().TruncateInt()
+	return sdktypes.NewCoins(sdktypes.NewCoin(app.BondDenom, amount)), nil
+}
+
+// lowestGasPrice finds the lowest gas price paid by the given transactions, if any.
+func (fe *feeEstimator) lowestGasPrice(txs types.Txs) (sdktypes.Dec, bool) {
+	var (
+		lowest sdktypes.Dec
+		found  bool
+	)
+	for _, raw := range txs {
+		// transactions paying for data are malleated by the block producer
+		if malleated, ok := types.UnwrapMalleatedTx(raw); ok {
+			raw = malleated.Tx
+		}
+		tx, err := fe.decoder(raw)
+		if err != nil {
+			log.Debugw("decoding tx for fee estimation", "err", err)
+			continue
+		}
+		feeTx, ok := tx.(sdktypes.FeeTx)
+		if !ok || feeTx.GetGas() == 0 {
+			continue
+		}
+		price := sdktypes.NewDecFromInt(feeTx.GetFee().AmountOf(app.BondDenom)).
+			QuoInt(sdktypes.NewIntFromUint64(feeTx.GetGas()))
+		if !found || price.LT(lowest) {
+			lowest, found = price, true
+		}
+	}
+	return lowest, found
+}
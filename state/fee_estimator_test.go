@@ -0,0 +1,76 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/celestiaorg/celestia-app/app"
+	"github.com/celestiaorg/celestia-app/app/encoding"
+)
+
+func TestFeeEstimator(t *testing.T) {
+	ctx := context.Background()
+
+	blocks := &testBlocks{}
+	// the lowest price of the first block is 0.5, and 2 of the second one
+	blocks.add(newTestTx(t, 100, 200), newTestTx(t, 100, 100))
+	blocks.add(newTestTx(t, 400, 200))
+	blocks.add()
+	fe := newFeeEstimator(blocks)
+
+	price, err := fe.GasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sdktypes.NewDec(2), price)
+
+	fee, err := fe.Fee(ctx, 1001)
+	require.NoError(t, err)
+	assert.Equal(t, sdktypes.NewCoins(sdktypes.NewInt64Coin(app.BondDenom, 2002)), fee)
+
+	// blocks out of the estimation window are not accounted
+	fe.numBlocks = 1
+	blocks.add(newTestTx(t, 0, 100))
+	price, err = fe.GasPrice(ctx)
+	require.NoError(t, err)
+	assert.True(t, price.IsZero())
+
+	// the price never goes below the minimum
+	fe.minGasPrice = sdktypes.NewDecWithPrec(1, 1)
+	blocks.add()
+	price, err = fe.GasPrice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sdktypes.NewDecWithPrec(1, 1), price)
+}
+
+// testBlocks serves the blocks added to it, the last one being the latest.
+type testBlocks struct {
+	blocks []*types.Block
+}
+
+func (tb *testBlocks) add(txs ...types.Tx) {
+	block := types.MakeBlock(int64(len(tb.blocks)+1), types.Data{Txs: txs}, nil)
+	tb.blocks = append(tb.blocks, block)
+}
+
+func (tb *testBlocks) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	block := tb.blocks[len(tb.blocks)-1]
+	if height != nil {
+		block = tb.blocks[*height-1]
+	}
+	return &coretypes.ResultBlock{Block: block}, nil
+}
+
+func newTestTx(t *testing.T, fee int64, gas uint64) types.Tx {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	builder := encCfg.TxConfig.NewTxBuilder()
+	builder.SetGasLimit(gas)
+	builder.SetFeeAmount(sdktypes.NewCoins(sdktypes.NewInt64Coin(app.BondDenom, fee)))
+	raw, err := encCfg.TxConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+	return raw
+}
@@ -0,0 +1,111 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+)
+
+// QueryResult is the value of a key in a store of the application state, verified against the
+// AppHash of a locally verified header.
+type QueryResult struct {
+	// Height is the height of the block the state is read after.
+	Height   int64  `json:"height"`
+	StoreKey string `json:"store_key"`
+	Key      []byte `json:"key"`
+	// Value is the value of the key, or nil if the key is proven to be absent from the store.
+	Value []byte `json:"value"`
+	// ProofOps prove the Value, or its absence, against the AppHash of the header at Height+1.
+	ProofOps *crypto.ProofOps `json:"proof_ops"`
+}
+
+// QueryWithProof reads the value of the key from the store of the application state with the
+// given key, e.g. "bank", as of the given height, or of the latest verifiable one if zero.
+//
+// NOTE: for block N, the block's `AppHash` is the result of applying the previous block's
+// transaction list, so the latest verifiable height is the one right before the node's current
+// head (head-1).
+func (ca *CoreAccessor) QueryWithProof(
+	ctx context.Context,
+	storeKey string,
+	key []byte,
+	height uint64,
+) (*QueryResult, error) {
+	head, err := ca.getter.Head(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if head.Height < 2 {
+		return nil, errors.New("state: no verifiable state yet")
+	}
+
+	// the header proving the state as of the height
+	proving := head
+	switch latest := uint64(head.Height) - 1; {
+	case height == 0:
+		height = latest
+	case height > latest:
+		return nil, fmt.Errorf("state: height %d is not verifiable yet, the latest verifiable is %d", height, latest)
+	case height < latest:
+		if ca.hgetter == nil {
+			return nil, errors.New("state: queries of past heights require a header getter")
+		}
+		proving, err = ca.hgetter.GetByHeight(ctx, height+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := rpcclient.ABCIQueryOptions{
+		Height: int64(height),
+		Prove:  true,
+	}
+	// TODO @renayay: once https://github.com/cosmos/cosmos-sdk/pull/12674 is merged, use const instead
+	cli := ca.rpcCli
+	if cli == nil {
+		return nil, ErrNotConnected
+	}
+	result, err := cli.ABCIQueryWithOptions(ctx, fmt.Sprintf("store/%s/key", storeKey), key, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Response.IsOK() {
+		return nil, sdkErrorToGRPCError(result.Response)
+	}
+
+	res := &QueryResult{
+		Height:   int64(height),
+		StoreKey: storeKey,
+		Key:      key,
+		Value:    result.Response.Value,
+		ProofOps: result.Response.ProofOps,
+	}
+	if len(res.Value) == 0 {
+		res.Value = nil
+	}
+	if err = res.Verify(proving.AppHash); err != nil {
+		return nil, fmt.Errorf("state: verifying query of %s at height %d: %w", storeKey, height, err)
+	}
+	return res, nil
+}
+
+// Verify verifies the QueryResult against the given AppHash of the header at Height+1.
+func (r *QueryResult) Verify(appHash []byte) error {
+	if r.ProofOps == nil {
+		return errors.New("no proof")
+	}
+	path := merkle.KeyPath{}.
+		AppendKey([]byte(r.StoreKey), merkle.KeyEncodingURL).
+		AppendKey(r.Key, merkle.KeyEncodingHex).
+		String()
+	prt := rootmulti.DefaultProofRuntime()
+	if len(r.Value) == 0 {
+		return prt.VerifyAbsence(r.ProofOps, appHash, path)
+	}
+	return prt.VerifyValue(r.ProofOps, appHash, path, r.Value)
+}
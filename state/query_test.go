@@ -0,0 +1,69 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestQueryResult_Verify(t *testing.T) {
+	// commit a key into the store of a multistore, as the application does
+	storeKey := storetypes.NewKVStoreKey("bank")
+	ms := rootmulti.NewStore(dbm.NewMemDB(), tmlog.NewNopLogger())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	// keys are not limited to the printable characters
+	key, value := []byte("balance/\x00\xff%/"), []byte("1000")
+	ms.GetCommitKVStore(storeKey).Set(key, value)
+	commit := ms.Commit()
+	appHash := commit.Hash
+
+	query := func(key []byte) *QueryResult {
+		resp := ms.Query(abci.RequestQuery{
+			Path:   fmt.Sprintf("/%s/key", storeKey.Name()),
+			Data:   key,
+			Height: commit.Version,
+			Prove:  true,
+		})
+		require.True(t, resp.IsOK(), resp.Log)
+		return &QueryResult{
+			Height:   commit.Version,
+			StoreKey: storeKey.Name(),
+			Key:      key,
+			Value:    resp.Value,
+			ProofOps: resp.ProofOps,
+		}
+	}
+
+	res := query(key)
+	require.Equal(t, value, res.Value)
+	require.NoError(t, res.Verify(appHash))
+
+	// the values are verified
+	forged := *res
+	forged.Value = []byte("1000000")
+	assert.Error(t, forged.Verify(appHash))
+	forged.Value = nil
+	assert.Error(t, forged.Verify(appHash))
+	// against the right AppHash only
+	assert.Error(t, res.Verify([]byte("wrong")))
+	// and with the proofs only
+	forged = *res
+	forged.ProofOps = nil
+	assert.Error(t, forged.Verify(appHash))
+
+	// the absence of keys is verified as well
+	res = query([]byte("absent"))
+	require.Empty(t, res.Value)
+	require.NoError(t, res.Verify(appHash))
+	forged = *res
+	forged.Value = []byte("1000")
+	assert.Error(t, forged.Verify(appHash))
+}
@@ -0,0 +1,162 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"google.golang.org/grpc"
+)
+
+// maxSequenceRetries is the amount of times a transaction is re-signed and resubmitted after being
+// rejected for the sequence mismatch.
+var maxSequenceRetries = 3
+
+// sequenceMismatchRe extracts the sequence the account expects from the error of the ante handler.
+var sequenceMismatchRe = regexp.MustCompile(`account sequence mismatch, expected (\d+), got \d+`)
+
+// accountSigner signs the transactions of an account with its sequence, as
+// apptypes.KeyringSigner does.
+type accountSigner interface {
+	// QueryAccountNumber sets the account number and the sequence to the ones of the chain.
+	QueryAccountNumber(ctx context.Context, conn *grpc.ClientConn) error
+	GetSignerData() (authsigning.SignerData, error)
+	SetSequence(n uint64)
+}
+
+// buildFunc builds and encodes a transaction signed with the current sequence of the account.
+type buildFunc func() ([]byte, error)
+
+// broadcastFunc broadcasts the encoded transaction.
+type broadcastFunc func(context.Context, Tx) (*TxResponse, error)
+
+// txSequencer serializes signing of the transactions of the node's account and tracks the
+// sequence of the account locally, so that transactions submitted concurrently are signed with
+// consecutive sequences instead of the same one known to the chain.
+type txSequencer struct {
+	signer accountSigner
+	conn   *grpc.ClientConn
+
+	lk sync.Mutex
+	// synced reports whether the local sequence is expected to match the one of the chain
+	synced bool
+}
+
+func newTxSequencer(signer accountSigner, conn *grpc.ClientConn) *txSequencer {
+	return &txSequencer{
+		signer: signer,
+		conn:   conn,
+	}
+}
+
+// build builds the transaction with the current sequence of the account without consuming it.
+func (ts *txSequencer) build(ctx context.Context, build buildFunc) ([]byte, error) {
+	ts.lk.Lock()
+	defer ts.lk.Unlock()
+	if err := ts.sync(ctx); err != nil {
+		return nil, err
+	}
+	return build()
+}
+
+// submit builds the transaction with the next sequence of the account and broadcasts it. If the
+// transaction is rejected for the sequence mismatch, it is rebuilt with the sequence the chain
+// expects and resubmitted.
+func (ts *txSequencer) submit(ctx context.Context, build buildFunc, broadcast broadcastFunc) (*TxResponse, error) {
+	for i := 0; ; i++ {
+		tx, err := ts.next(ctx, build)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := broadcast(ctx, tx)
+		switch {
+		case err != nil:
+			// the transaction may or may not have been accepted
+			ts.desync()
+			return nil, err
+		case resp.Code == 0:
+			return resp, nil
+		case resp.Codespace == sdkerrors.RootCodespace && resp.Code == sdkerrors.ErrWrongSequence.ABCICode():
+			if i < maxSequenceRetries {
+				log.Debugw("resubmitting tx after sequence mismatch", "attempt", i+1, "log", resp.RawLog)
+				ts.correct(resp.RawLog)
+				continue
+			}
+			ts.desync()
+		case resp.Height == 0:
+			// the transaction was rejected before the inclusion, so its sequence was not consumed
+			ts.desync()
+		}
+		return resp, nil
+	}
+}
+
+// next builds the transaction with the current sequence of the account and increments it.
+func (ts *txSequencer) next(ctx context.Context, build buildFunc) ([]byte, error) {
+	ts.lk.Lock()
+	defer ts.lk.Unlock()
+	if err := ts.sync(ctx); err != nil {
+		return nil, err
+	}
+
+	data, err := ts.signer.GetSignerData()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := build()
+	if err != nil {
+		return nil, err
+	}
+	ts.signer.SetSequence(data.Sequence + 1)
+	return tx, nil
+}
+
+// sync queries the sequence of the account from the chain, unless it is already tracked locally.
+func (ts *txSequencer) sync(ctx context.Context) error {
+	if ts.synced {
+		return nil
+	}
+	if err := ts.signer.QueryAccountNumber(ctx, ts.conn); err != nil {
+		return fmt.Errorf("querying account: %w", err)
+	}
+	ts.synced = true
+	return nil
+}
+
+// correct advances the local sequence to the one the chain expects according to the error log, or
+// makes it to be queried again, if the log does not tell. The local sequence is never decreased,
+// as the chain expecting a lower one only means the preceding transactions are still in flight.
+func (ts *txSequencer) correct(rawLog string) {
+	ts.lk.Lock()
+	defer ts.lk.Unlock()
+	match := sequenceMismatchRe.FindStringSubmatch(rawLog)
+	if match == nil {
+		ts.synced = false
+		return
+	}
+	expected, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		ts.synced = false
+		return
+	}
+	data, err := ts.signer.GetSignerData()
+	if err != nil {
+		ts.synced = false
+		return
+	}
+	if expected > data.Sequence {
+		ts.signer.SetSequence(expected)
+	}
+}
+
+// desync makes the sequence to be queried from the chain for the next transaction.
+func (ts *txSequencer) desync() {
+	ts.lk.Lock()
+	defer ts.lk.Unlock()
+	ts.synced = false
+}
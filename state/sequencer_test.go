@@ -0,0 +1,168 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTxSequencer_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	signer := &testSigner{chainSeq: 5}
+	ts := newTxSequencer(signer, nil)
+
+	var (
+		lk   sync.Mutex
+		seqs = make(map[string]struct{})
+	)
+	broadcast := func(_ context.Context, tx Tx) (*TxResponse, error) {
+		lk.Lock()
+		defer lk.Unlock()
+		seqs[string(tx)] = struct{}{}
+		return &TxResponse{Height: 1}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ts.submit(ctx, signer.build, broadcast)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// the account is queried once and every tx is signed with a new sequence
+	assert.Equal(t, 1, signer.queries)
+	assert.Len(t, seqs, 10)
+	for seq := 5; seq < 15; seq++ {
+		assert.Contains(t, seqs, strconv.Itoa(seq))
+	}
+}
+
+func TestTxSequencer_Mismatch(t *testing.T) {
+	ctx := context.Background()
+	signer := &testSigner{chainSeq: 5}
+	chain := &testChain{signer: signer}
+	ts := newTxSequencer(signer, nil)
+
+	_, err := ts.submit(ctx, signer.build, chain.broadcast)
+	require.NoError(t, err)
+
+	// the sequence is consumed by a tx submitted elsewhere, so the next one is resubmitted with the
+	// sequence the chain expects
+	signer.chainSeq++
+	resp, err := ts.submit(ctx, signer.build, chain.broadcast)
+	require.NoError(t, err)
+	assert.Zero(t, resp.Code)
+	assert.EqualValues(t, 8, signer.chainSeq)
+	assert.Equal(t, 1, signer.queries)
+
+	// the sequence is queried again, if the rejection does not tell the expected one
+	chain.vagueLog = true
+	signer.chainSeq++
+	resp, err = ts.submit(ctx, signer.build, chain.broadcast)
+	require.NoError(t, err)
+	assert.Zero(t, resp.Code)
+	assert.Equal(t, 2, signer.queries)
+
+	// failed broadcasts desynchronize the sequence as well
+	chain.err = errors.New("connection refused")
+	_, err = ts.submit(ctx, signer.build, chain.broadcast)
+	require.Error(t, err)
+	chain.err = nil
+	_, err = ts.submit(ctx, signer.build, chain.broadcast)
+	require.NoError(t, err)
+	assert.Equal(t, 3, signer.queries)
+}
+
+func TestTxSequencer_Build(t *testing.T) {
+	ctx := context.Background()
+	signer := &testSigner{chainSeq: 5}
+	ts := newTxSequencer(signer, nil)
+
+	// building does not consume the sequence
+	for i := 0; i < 2; i++ {
+		tx, err := ts.build(ctx, signer.build)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("5"), tx)
+	}
+}
+
+// testSigner signs the txs as their sequences.
+type testSigner struct {
+	lk       sync.Mutex
+	seq      uint64
+	chainSeq uint64
+	queries  int
+}
+
+func (s *testSigner) QueryAccountNumber(context.Context, *grpc.ClientConn) error {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.seq = s.chainSeq
+	s.queries++
+	return nil
+}
+
+func (s *testSigner) GetSignerData() (authsigning.SignerData, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return authsigning.SignerData{Sequence: s.seq}, nil
+}
+
+func (s *testSigner) SetSequence(n uint64) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.seq = n
+}
+
+func (s *testSigner) build() ([]byte, error) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return []byte(strconv.FormatUint(s.seq, 10)), nil
+}
+
+// testChain accepts the txs signed with the sequence of the testSigner's account on chain.
+type testChain struct {
+	signer   *testSigner
+	vagueLog bool
+	err      error
+}
+
+func (c *testChain) broadcast(_ context.Context, tx Tx) (*TxResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	seq, err := strconv.ParseUint(string(tx), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	c.signer.lk.Lock()
+	defer c.signer.lk.Unlock()
+	if seq != c.signer.chainSeq {
+		rawLog := fmt.Sprintf("account sequence mismatch, expected %d, got %d: incorrect account sequence",
+			c.signer.chainSeq, seq)
+		if c.vagueLog {
+			rawLog = "incorrect account sequence"
+		}
+		return &TxResponse{
+			Codespace: sdkerrors.RootCodespace,
+			Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+			RawLog:    rawLog,
+		}, nil
+	}
+	c.signer.chainSeq++
+	return &TxResponse{Height: 1}, nil
+}
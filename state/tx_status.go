@@ -0,0 +1,253 @@
+package state
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/celestia-node/header"
+)
+
+// txStatusRetention is the amount of blocks for which the status of a finalized
+// (committed or failed) transaction is kept after the block it was finalized in.
+var txStatusRetention int64 = 100
+
+// maxTrackedTxs bounds the amount of transactions tracked at once. Once reached, the finalized
+// transactions are evicted first, then the ones submitted the earliest.
+var maxTrackedTxs = 1000
+
+// ErrTxNotTracked is returned when the status of a transaction that was
+// not submitted through the node is requested.
+var ErrTxNotTracked = errors.New("state: transaction is not tracked")
+
+// TxStage represents a stage in the lifecycle of a transaction submitted through the node.
+type TxStage uint8
+
+const (
+	// TxBroadcast indicates the transaction was broadcast to celestia-core, but
+	// its admission to the mempool is not yet confirmed.
+	TxBroadcast TxStage = iota + 1
+	// TxPending indicates the transaction was admitted to the mempool and awaits
+	// inclusion into a block.
+	TxPending
+	// TxCommitted indicates the transaction was included into a block.
+	TxCommitted
+	// TxFailed indicates the transaction was rejected either on broadcast or on execution.
+	TxFailed
+)
+
+// String returns the human-readable representation of the TxStage.
+func (ts TxStage) String() string {
+	switch ts {
+	case TxBroadcast:
+		return "broadcast"
+	case TxPending:
+		return "pending"
+	case TxCommitted:
+		return "committed"
+	case TxFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TxStatus reports the latest known stage of a transaction submitted through the node.
+type TxStatus struct {
+	Hash  string  `json:"hash"`
+	Stage TxStage `json:"stage"`
+	// Height of the block the transaction was included in. Zero until committed.
+	Height int64 `json:"height"`
+	// Code and Log are the result of the transaction execution, if any.
+	Code uint32 `json:"code"`
+	Log  string `json:"log"`
+}
+
+// finalized reports whether the transaction reached its final stage.
+func (s *TxStatus) finalized() bool {
+	return s.Stage == TxCommitted || s.Stage == TxFailed
+}
+
+// txTracker keeps track of the stages of transactions submitted through the node.
+type txTracker struct {
+	lk  sync.RWMutex
+	txs map[string]*TxStatus
+	// added keeps the order the transactions started to be tracked in for eviction.
+	added map[string]uint64
+	seq   uint64
+}
+
+func newTxTracker() *txTracker {
+	return &txTracker{
+		txs:   make(map[string]*TxStatus),
+		added: make(map[string]uint64),
+	}
+}
+
+// set sets the status of the transaction, evicting another one, if the tracker is full.
+// It must be called under the lock.
+func (tt *txTracker) set(status *TxStatus) {
+	if _, ok := tt.txs[status.Hash]; !ok {
+		if len(tt.txs) >= maxTrackedTxs {
+			tt.evict()
+		}
+		tt.seq++
+		tt.added[status.Hash] = tt.seq
+	}
+	tt.txs[status.Hash] = status
+}
+
+// evict removes the earliest tracked finalized transaction or, if there is none, the earliest
+// tracked one. It must be called under the lock.
+func (tt *txTracker) evict() {
+	var (
+		evicted   string
+		finalized bool
+	)
+	for hash, status := range tt.txs {
+		switch {
+		case evicted == "",
+			status.finalized() && !finalized,
+			status.finalized() == finalized && tt.added[hash] < tt.added[evicted]:
+			evicted, finalized = hash, status.finalized()
+		}
+	}
+	log.Warnw("evicting tracked tx", "hash", evicted, "finalized", finalized)
+	tt.remove(evicted)
+}
+
+// remove stops tracking the transaction. It must be called under the lock.
+func (tt *txTracker) remove(hash string) {
+	delete(tt.txs, hash)
+	delete(tt.added, hash)
+}
+
+// broadcast marks the given raw transaction as broadcast.
+func (tt *txTracker) broadcast(tx Tx) string {
+	hash := strings.ToUpper(hex.EncodeToString(tx.Hash()))
+	tt.lk.Lock()
+	tt.set(&TxStatus{Hash: hash, Stage: TxBroadcast})
+	tt.lk.Unlock()
+	return hash
+}
+
+// fail marks the transaction with the given hash as failed due to the given error.
+func (tt *txTracker) fail(hash string, err error) {
+	tt.lk.Lock()
+	tt.set(&TxStatus{Hash: hash, Stage: TxFailed, Log: err.Error()})
+	tt.lk.Unlock()
+}
+
+// track updates the stage of the transaction from the given TxResponse.
+func (tt *txTracker) track(resp *TxResponse) {
+	if resp == nil || resp.TxHash == "" {
+		return
+	}
+
+	status := &TxStatus{
+		Hash:   strings.ToUpper(resp.TxHash),
+		Height: resp.Height,
+		Code:   resp.Code,
+		Log:    resp.RawLog,
+	}
+	switch {
+	case resp.Code != 0:
+		status.Stage = TxFailed
+	case resp.Height > 0:
+		status.Stage = TxCommitted
+	default:
+		status.Stage = TxPending
+	}
+
+	tt.lk.Lock()
+	tt.set(status)
+	tt.lk.Unlock()
+}
+
+// commit marks the transaction with the given hash as included in a block at the given height.
+func (tt *txTracker) commit(hash string, height int64, code uint32, rawLog string) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	status, ok := tt.txs[hash]
+	if !ok {
+		return
+	}
+	status.Height, status.Code, status.Log = height, code, rawLog
+	status.Stage = TxCommitted
+	if code != 0 {
+		status.Stage = TxFailed
+	}
+}
+
+// pending returns hashes of all the transactions awaiting inclusion into a block.
+func (tt *txTracker) pending() []string {
+	tt.lk.RLock()
+	defer tt.lk.RUnlock()
+	hashes := make([]string, 0, len(tt.txs))
+	for hash, status := range tt.txs {
+		if !status.finalized() {
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes
+}
+
+// prune removes finalized transactions that are older than txStatusRetention
+// relative to the given height.
+func (tt *txTracker) prune(height int64) {
+	tt.lk.Lock()
+	defer tt.lk.Unlock()
+	for hash, status := range tt.txs {
+		if status.finalized() && status.Height+txStatusRetention < height {
+			tt.remove(hash)
+		}
+	}
+}
+
+// status returns a copy of the latest known TxStatus of the transaction with the given hash.
+func (tt *txTracker) status(hash string) (*TxStatus, error) {
+	tt.lk.RLock()
+	defer tt.lk.RUnlock()
+	status, ok := tt.txs[strings.ToUpper(hash)]
+	if !ok {
+		return nil, ErrTxNotTracked
+	}
+	cp := *status
+	return &cp, nil
+}
+
+// trackTxs watches new headers to promote pending transactions to committed once
+// celestia-core reports their inclusion.
+func (ca *CoreAccessor) trackTxs(ctx context.Context, sub header.Subscription) {
+	defer close(ca.txsDone)
+	defer sub.Cancel()
+
+	for {
+		h, err := sub.NextHeader(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			log.Errorw("tracking txs: getting next header", "err", err)
+			continue
+		}
+
+		for _, hash := range ca.txs.pending() {
+			rawHash, err := hex.DecodeString(hash)
+			if err != nil {
+				continue
+			}
+			res, err := ca.rpcCli.Tx(ctx, rawHash, false)
+			if err != nil {
+				// the transaction is not yet included
+				log.Debugw("tracking txs: tx not found", "hash", hash, "height", h.Height, "err", err)
+				continue
+			}
+			ca.txs.commit(hash, res.Height, res.TxResult.Code, res.TxResult.Log)
+		}
+		ca.txs.prune(h.Height)
+	}
+}
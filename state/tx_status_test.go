@@ -0,0 +1,86 @@
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxTracker(t *testing.T) {
+	tt := newTxTracker()
+
+	_, err := tt.status("DEADBEEF")
+	require.ErrorIs(t, err, ErrTxNotTracked)
+
+	hash := tt.broadcast(Tx("tx"))
+	status, err := tt.status(hash)
+	require.NoError(t, err)
+	assert.Equal(t, TxBroadcast, status.Stage)
+	assert.Equal(t, []string{hash}, tt.pending())
+
+	tt.track(&TxResponse{TxHash: hash})
+	status, err = tt.status(hash)
+	require.NoError(t, err)
+	assert.Equal(t, TxPending, status.Stage)
+
+	tt.commit(hash, 10, 0, "")
+	status, err = tt.status(hash)
+	require.NoError(t, err)
+	assert.Equal(t, TxCommitted, status.Stage)
+	assert.EqualValues(t, 10, status.Height)
+	assert.Empty(t, tt.pending())
+
+	// finalized txs are kept until the retention window has passed
+	tt.prune(10 + txStatusRetention)
+	_, err = tt.status(hash)
+	require.NoError(t, err)
+	tt.prune(11 + txStatusRetention)
+	_, err = tt.status(hash)
+	require.ErrorIs(t, err, ErrTxNotTracked)
+}
+
+func TestTxTracker_Failed(t *testing.T) {
+	tt := newTxTracker()
+
+	hash := tt.broadcast(Tx("tx"))
+	tt.fail(hash, errors.New("connection refused"))
+	status, err := tt.status(hash)
+	require.NoError(t, err)
+	assert.Equal(t, TxFailed, status.Stage)
+
+	tt.track(&TxResponse{TxHash: "abcd", Code: 5, RawLog: "insufficient funds"})
+	status, err = tt.status("ABCD")
+	require.NoError(t, err)
+	assert.Equal(t, TxFailed, status.Stage)
+	assert.Equal(t, "insufficient funds", status.Log)
+}
+
+func TestTxTracker_Evict(t *testing.T) {
+	maxTracked := maxTrackedTxs
+	maxTrackedTxs = 3
+	t.Cleanup(func() {
+		maxTrackedTxs = maxTracked
+	})
+	tt := newTxTracker()
+
+	first := tt.broadcast(Tx("first"))
+	second := tt.broadcast(Tx("second"))
+	third := tt.broadcast(Tx("third"))
+	tt.commit(second, 10, 0, "")
+
+	// the finalized tx is evicted first
+	tt.broadcast(Tx("fourth"))
+	assert.Len(t, tt.txs, maxTrackedTxs)
+	_, err := tt.status(second)
+	require.ErrorIs(t, err, ErrTxNotTracked)
+
+	// then the earliest submitted one
+	tt.broadcast(Tx("fifth"))
+	assert.Len(t, tt.txs, maxTrackedTxs)
+	_, err = tt.status(first)
+	require.ErrorIs(t, err, ErrTxNotTracked)
+	_, err = tt.status(third)
+	require.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package watchdog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+var meter = global.MeterProvider().Meter("watchdog")
+
+// WithMetrics enables Otel metrics to monitor the lags of the chain heads behind the network head.
+func WithMetrics(w *Watchdog) error {
+	lagG, err := meter.AsyncInt64().Gauge(
+		"watchdog_lag",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("amount of blocks the chain head lags behind the network head"),
+	)
+	if err != nil {
+		return err
+	}
+
+	stalledG, err := meter.AsyncInt64().Gauge(
+		"watchdog_stalled",
+		instrument.WithUnit(unit.Dimensionless),
+		instrument.WithDescription("whether the chain head is stalled (1) or not (0)"),
+	)
+	if err != nil {
+		return err
+	}
+
+	return meter.RegisterCallback(
+		[]instrument.Asynchronous{
+			lagG, stalledG,
+		},
+		func(ctx context.Context) {
+			w.lk.Lock()
+			defer w.lk.Unlock()
+			for kind, l := range w.lags {
+				kindAttr := attribute.String("kind", string(kind))
+				lagG.Observe(ctx, int64(l.blocks()), kindAttr)
+
+				var stalled int64
+				if l.stalled {
+					stalled = 1
+				}
+				stalledG.Observe(ctx, stalled, kindAttr)
+			}
+		},
+	)
+}
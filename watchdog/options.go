@@ -0,0 +1,50 @@
+package watchdog
+
+import (
+	"fmt"
+	"time"
+)
+
+// Parameters is the set of parameters that must be configured for the Watchdog.
+type Parameters struct {
+	// CheckInterval is the period of time between the checks of the heights.
+	CheckInterval time.Duration
+	// SyncLagThreshold is the amount of blocks the synced chain head may lag behind the network
+	// head before it is considered stalled. Zero disables the check.
+	SyncLagThreshold uint64
+	// SampleLagThreshold is the amount of blocks the sampled chain head may lag behind the network
+	// head before it is considered stalled. Zero disables the check.
+	SampleLagThreshold uint64
+	// StallDuration is the period of time a chain head lagging beyond its threshold must not
+	// advance for to raise an alert.
+	StallDuration time.Duration
+}
+
+// DefaultParameters returns the default configuration values for the Watchdog.
+func DefaultParameters() Parameters {
+	return Parameters{
+		CheckInterval:      30 * time.Second,
+		SyncLagThreshold:   10,
+		SampleLagThreshold: 100,
+		StallDuration:      5 * time.Minute,
+	}
+}
+
+// Enabled reports whether any of the checks is enabled.
+func (p *Parameters) Enabled() bool {
+	return p.SyncLagThreshold > 0 || p.SampleLagThreshold > 0
+}
+
+// Validate validates the values in Parameters.
+func (p *Parameters) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	if p.CheckInterval <= 0 {
+		return fmt.Errorf("watchdog: CheckInterval must be positive")
+	}
+	if p.StallDuration < 0 {
+		return fmt.Errorf("watchdog: StallDuration can't be negative")
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/celestiaorg/celestia-node/das"
+	hsync "github.com/celestiaorg/celestia-node/header/sync"
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+var log = logging.Logger("watchdog")
+
+// ErrStalled is returned by Healthy while any of the chain heads is stalled.
+var ErrStalled = errors.New("watchdog: stalled")
+
+// Syncer reports the heights of the synced and the network chain heads.
+type Syncer interface {
+	SyncState(context.Context) (hsync.State, error)
+}
+
+// Sampler reports the height of the sampled chain head.
+type Sampler interface {
+	SamplingStats(context.Context) (das.SamplingStats, error)
+}
+
+// Kind identifies the chain head an Alert is about.
+type Kind string
+
+const (
+	// KindSync is the chain head synced by the header Syncer.
+	KindSync Kind = "sync"
+	// KindSample is the chain head sampled by the DASer.
+	KindSample Kind = "sample"
+)
+
+// Alert is raised once a chain head lagging behind the network head stops advancing for longer
+// than allowed, and once it advances again.
+type Alert struct {
+	Kind Kind `json:"kind"`
+	// Stalled is false for the alerts raised on recovery.
+	Stalled bool `json:"stalled"`
+	// Height is the height of the lagging chain head.
+	Height        uint64 `json:"height"`
+	NetworkHeight uint64 `json:"network_height"`
+	Lag           uint64 `json:"lag"`
+	// Since is the time the chain head stopped advancing at.
+	Since time.Time `json:"since"`
+	Time  time.Time `json:"time"`
+}
+
+func (a Alert) String() string {
+	if !a.Stalled {
+		return fmt.Sprintf("%s recovered at height %d after stalling for %s",
+			a.Kind, a.Height, a.Time.Sub(a.Since).Truncate(time.Second))
+	}
+	return fmt.Sprintf("%s stalled at height %d, %d blocks behind network head %d for %s",
+		a.Kind, a.Height, a.Lag, a.NetworkHeight, a.Time.Sub(a.Since).Truncate(time.Second))
+}
+
+// lag tracks the lag of a chain head behind the network head.
+type lag struct {
+	threshold     uint64
+	height        uint64
+	networkHeight uint64
+	// since is the time the lagging chain head stopped advancing at, zero while it doesn't lag
+	since   time.Time
+	stalled bool
+}
+
+func (l *lag) blocks() uint64 {
+	if l.networkHeight <= l.height {
+		return 0
+	}
+	return l.networkHeight - l.height
+}
+
+// Watchdog periodically compares the synced and the sampled chain heads with the network head and
+// raises an Alert once either lags behind without advancing for longer than allowed, so that the
+// nodes catching up are not alerted about. The alerts are published on the event Bus and exposed
+// as metrics.
+type Watchdog struct {
+	params  Parameters
+	syncer  Syncer
+	sampler Sampler
+	bus     *events.Bus
+
+	lk   sync.Mutex
+	lags map[Kind]*lag
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatchdog creates a new Watchdog. The 'sampler' may be nil for the nodes not sampling, in
+// which case only the synced chain head is watched.
+func NewWatchdog(params Parameters, syncer Syncer, sampler Sampler, bus *events.Bus) *Watchdog {
+	lags := map[Kind]*lag{
+		KindSync: {threshold: params.SyncLagThreshold},
+	}
+	if sampler != nil {
+		lags[KindSample] = &lag{threshold: params.SampleLagThreshold}
+	}
+	return &Watchdog{
+		params:  params,
+		syncer:  syncer,
+		sampler: sampler,
+		bus:     bus,
+		lags:    lags,
+	}
+}
+
+// Start starts the checks, if any is enabled.
+func (w *Watchdog) Start(context.Context) error {
+	if !w.params.Enabled() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel, w.done = cancel, make(chan struct{})
+	go w.loop(ctx)
+	return nil
+}
+
+// Stop stops the checks and waits for the ongoing one to finish.
+func (w *Watchdog) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+
+	w.cancel()
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Healthy returns ErrStalled while any of the chain heads is stalled.
+func (w *Watchdog) Healthy() error {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+	for kind, l := range w.lags {
+		if l.stalled {
+			return fmt.Errorf("%w: %s is %d blocks behind network head", ErrStalled, kind, l.blocks())
+		}
+	}
+	return nil
+}
+
+func (w *Watchdog) loop(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.params.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, alert := range w.check(ctx, time.Now()) {
+				w.publish(alert)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check updates the lags of the chain heads and returns the alerts to raise, if any.
+func (w *Watchdog) check(ctx context.Context, now time.Time) []Alert {
+	var alerts []Alert
+	state, err := w.syncer.SyncState(ctx)
+	if err != nil {
+		log.Warnw("getting sync state", "err", err)
+	} else if alert, ok := w.observe(KindSync, state.Height, state.NetworkHeight, now); ok {
+		alerts = append(alerts, alert)
+	}
+
+	if w.sampler == nil {
+		return alerts
+	}
+	stats, err := w.sampler.SamplingStats(ctx)
+	if err != nil {
+		log.Warnw("getting sampling stats", "err", err)
+	} else if alert, ok := w.observe(KindSample, stats.SampledChainHead, stats.NetworkHead, now); ok {
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// observe records the heights of the chain head of the given kind and returns the Alert to raise,
+// if the chain head has stalled or recovered.
+func (w *Watchdog) observe(kind Kind, height, networkHeight uint64, now time.Time) (Alert, bool) {
+	w.lk.Lock()
+	defer w.lk.Unlock()
+
+	l := w.lags[kind]
+	advanced := height > l.height
+	l.height, l.networkHeight = height, networkHeight
+	alert := Alert{
+		Kind:          kind,
+		Height:        height,
+		NetworkHeight: networkHeight,
+		Lag:           l.blocks(),
+		Since:         l.since,
+		Time:          now,
+	}
+
+	lagging := l.threshold != 0 && l.blocks() > l.threshold
+	if !lagging || advanced {
+		// the chain head caught up or is catching up
+		recovered := l.stalled
+		l.since, l.stalled = time.Time{}, false
+		if lagging {
+			l.since = now
+		}
+		return alert, recovered
+	}
+
+	if l.since.IsZero() {
+		l.since = now
+		alert.Since = now
+	}
+	if l.stalled || now.Sub(l.since) < w.params.StallDuration {
+		return alert, false
+	}
+	l.stalled = true
+	alert.Stalled = true
+	return alert, true
+}
+
+// publish publishes the Alert on the event Bus, which the notifications of the node are delivered
+// from.
+func (w *Watchdog) publish(alert Alert) {
+	tp := events.SyncRecovered
+	if alert.Stalled {
+		log.Warnw("chain head stalled", "kind", alert.Kind, "height", alert.Height,
+			"network_height", alert.NetworkHeight, "since", alert.Since)
+		tp = events.SyncStalled
+	} else {
+		log.Infow("chain head recovered", "kind", alert.Kind, "height", alert.Height)
+	}
+	w.bus.Publish(events.Event{
+		Type:    tp,
+		Time:    alert.Time,
+		Height:  alert.Height,
+		Message: alert.String(),
+	})
+}
@@ -0,0 +1,140 @@
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/celestiaorg/celestia-node/das"
+	hsync "github.com/celestiaorg/celestia-node/header/sync"
+	"github.com/celestiaorg/celestia-node/libs/events"
+)
+
+func TestWatchdog_Stall(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	syncer := &fakeSyncer{}
+	sampler := &fakeSampler{}
+	params := DefaultParameters()
+	w := NewWatchdog(params, syncer, sampler, nil)
+
+	now := time.Now()
+	syncer.state = hsync.State{Height: 100, NetworkHeight: 105}
+	sampler.stats = das.SamplingStats{SampledChainHead: 100, NetworkHead: 105}
+	assert.Empty(t, w.check(ctx, now))
+
+	// the lag exceeds the sync threshold, but not for long enough yet
+	syncer.state = hsync.State{Height: 100, NetworkHeight: 120}
+	assert.Empty(t, w.check(ctx, now.Add(time.Minute)))
+	assert.NoError(t, w.Healthy())
+
+	alerts := w.check(ctx, now.Add(time.Minute+params.StallDuration))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, KindSync, alerts[0].Kind)
+	assert.True(t, alerts[0].Stalled)
+	assert.EqualValues(t, 20, alerts[0].Lag)
+	assert.Equal(t, now.Add(time.Minute), alerts[0].Since)
+	assert.ErrorIs(t, w.Healthy(), ErrStalled)
+
+	// the stall is only alerted once
+	assert.Empty(t, w.check(ctx, now.Add(time.Hour)))
+
+	syncer.state = hsync.State{Height: 120, NetworkHeight: 120}
+	alerts = w.check(ctx, now.Add(2*time.Hour))
+	require.Len(t, alerts, 1)
+	assert.False(t, alerts[0].Stalled)
+	assert.NoError(t, w.Healthy())
+}
+
+// TestWatchdog_CatchingUp tests that the chain head lagging behind, but advancing, is not
+// considered stalled.
+func TestWatchdog_CatchingUp(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	syncer := &fakeSyncer{}
+	params := DefaultParameters()
+	w := NewWatchdog(params, syncer, nil, nil)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		syncer.state = hsync.State{Height: uint64(100 + i*10), NetworkHeight: 1000}
+		assert.Empty(t, w.check(ctx, now.Add(time.Duration(i)*params.StallDuration)))
+		assert.NoError(t, w.Healthy())
+	}
+
+	// until it stops advancing
+	alerts := w.check(ctx, now.Add(10*params.StallDuration))
+	require.Len(t, alerts, 1)
+	assert.True(t, alerts[0].Stalled)
+	assert.Equal(t, now.Add(9*params.StallDuration), alerts[0].Since)
+
+	// and advancing again recovers it
+	syncer.state = hsync.State{Height: 200, NetworkHeight: 1000}
+	alerts = w.check(ctx, now.Add(11*params.StallDuration))
+	require.Len(t, alerts, 1)
+	assert.False(t, alerts[0].Stalled)
+	assert.NoError(t, w.Healthy())
+}
+
+func TestWatchdog_Publish(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	t.Cleanup(cancel)
+
+	bus := events.NewBus()
+	sub := bus.Subscribe(events.SyncStalled)
+	t.Cleanup(sub.Cancel)
+
+	w := NewWatchdog(DefaultParameters(), &fakeSyncer{}, nil, bus)
+
+	alert := Alert{
+		Kind:          KindSample,
+		Stalled:       true,
+		Height:        10,
+		NetworkHeight: 200,
+		Lag:           190,
+		Since:         time.Now().Add(-time.Hour),
+		Time:          time.Now(),
+	}
+	w.publish(alert)
+
+	select {
+	case e := <-sub.Out():
+		assert.EqualValues(t, 10, e.Height)
+		assert.Equal(t, alert.String(), e.Message)
+	case <-ctx.Done():
+		t.Fatal(ctx.Err())
+	}
+}
+
+func TestParameters_Validate(t *testing.T) {
+	params := DefaultParameters()
+	require.NoError(t, params.Validate())
+
+	params.CheckInterval = 0
+	require.Error(t, params.Validate())
+
+	// disabled checks are not validated
+	params.SyncLagThreshold, params.SampleLagThreshold = 0, 0
+	require.NoError(t, params.Validate())
+}
+
+type fakeSyncer struct {
+	state hsync.State
+}
+
+func (s *fakeSyncer) SyncState(context.Context) (hsync.State, error) {
+	return s.state, nil
+}
+
+type fakeSampler struct {
+	stats das.SamplingStats
+}
+
+func (s *fakeSampler) SamplingStats(context.Context) (das.SamplingStats, error) {
+	return s.stats, nil
+}